@@ -79,8 +79,19 @@ function isMutating(argv: string[]): boolean {
   if (!verb) return false;
 
   // Task mutations
-  if (["add", "edit", "done", "mv", "move", "rm", "init"].includes(verb)) return true;
+  if (["edit", "done", "mv", "move"].includes(verb)) return !argv.includes("--dry-run");
+  if (["add", "rm", "delete", "init", "apply", "link", "start", "stop", "log"].includes(verb)) return true;
+  if (verb === "trash" && argv[1] === "restore") return true;
+  if (verb === "undo" && !argv.includes("--list") && !argv.includes("--dry-run")) return true;
   if (verb === "note" && argv[1] === "add") return true;
+  if ((verb === "subtask" || verb === "checklist") && !["ls", "list"].includes(argv[1] ?? "")) return true;
+  if ((verb === "dep" || verb === "deps") && ["add", "rm", "remove"].includes(argv[1] ?? "")) return true;
+  if (verb === "alias" && !["ls", "list"].includes(argv[1] ?? "")) return true;
+  if (verb === "import" || verb === "sync") return !argv.includes("--dry-run");
+
+  // Idea mutations (promote --dry-run only previews the task)
+  if ((verb === "idea" || verb === "ideas") && ["add", "capture", "note", "append"].includes(argv[1] ?? "")) return true;
+  if ((verb === "idea" || verb === "ideas") && argv[1] === "promote") return !argv.includes("--dry-run");
 
   // Project mutations
   if (verb === "project" && argv[1] === "add") return true;
@@ -3,6 +3,9 @@ module github.com/amirbrooks/tasker-docstore-framework
 go 1.22
 
 require (
+	github.com/fsnotify/fsnotify v1.9.0
 	github.com/oklog/ulid/v2 v2.1.0
 	gopkg.in/yaml.v3 v3.0.1
 )
+
+require golang.org/x/sys v0.13.0 // indirect
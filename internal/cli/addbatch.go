@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// batchTask is one JSON record of `add --batch`. Text uses the --text pipe
+// syntax; the other fields win over what it says.
+type batchTask struct {
+	Title      string   `json:"title"`
+	Text       string   `json:"text"`
+	Project    string   `json:"project"`
+	Column     string   `json:"column"`
+	Due        string   `json:"due"`
+	Start      string   `json:"start"`
+	Priority   string   `json:"priority"`
+	Tags       []string `json:"tags"`
+	Desc       string   `json:"desc"`
+	Details    string   `json:"details"`
+	Repeat     string   `json:"repeat"`
+	ExternalID string   `json:"external_id"`
+}
+
+// addBatch adds every task src ("-" for stdin) describes: a JSON array of
+// records, NDJSON records, or else one task per line in the --text pipe
+// syntax. base carries the command's flags (its Project only when --project
+// was given); they override a text line but only fill in what a JSON record
+// leaves out.
+func addBatch(ws *store.Workspace, gf GlobalFlags, src string, base store.AddTaskInput, resolveDue func(string) (string, string, error)) int {
+	text, err := readCaptureSource(src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
+	}
+	var results []captureLineResult
+	trimmed := strings.TrimSpace(strings.TrimPrefix(text, "\ufeff"))
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		var records []json.RawMessage
+		if err := json.Unmarshal([]byte(trimmed), &records); err != nil {
+			fmt.Fprintln(os.Stderr, "add: --batch:", err)
+			return ExitUsage
+		}
+		for i, raw := range records {
+			results = append(results, addBatchRecord(ws, gf, i+1, raw, base, resolveDue))
+		}
+	case strings.HasPrefix(trimmed, "{"):
+		for n, line := range strings.Split(strings.TrimPrefix(text, "\ufeff"), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				results = append(results, addBatchRecord(ws, gf, n+1, json.RawMessage(line), base, resolveDue))
+			}
+		}
+	default:
+		lines, numbers := splitCaptureLines(text)
+		for i, line := range lines {
+			results = append(results, captureTaskLine(ws, numbers[i], line, base, resolveDue))
+		}
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(os.Stderr, "add: --batch input has no tasks")
+		return ExitUsage
+	}
+	return emitCaptureLines(gf, "add", "added", results)
+}
+
+// addBatchRecord adds the task one JSON record describes; n is its line
+// (NDJSON) or position (array).
+func addBatchRecord(ws *store.Workspace, gf GlobalFlags, n int, raw json.RawMessage, base store.AddTaskInput, resolveDue func(string) (string, string, error)) captureLineResult {
+	res := captureLineResult{Line: n, Text: string(raw)}
+	fail := func(code int, err error) captureLineResult {
+		res.Error, res.code = err.Error(), code
+		return res
+	}
+	var rec batchTask
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rec); err != nil {
+		return fail(ExitUsage, err)
+	}
+	title, details, textDue, textPriority, textTags, textProject := parseTextParts(rec.Text, strings.TrimSpace(rec.Project) == "")
+	if strings.TrimSpace(rec.Title) != "" {
+		title = rec.Title
+	}
+	res.Text = strings.TrimSpace(title)
+
+	input := base
+	input.Title = strings.TrimSpace(title)
+	input.Tags = append(append(append([]string{}, base.Tags...), rec.Tags...), textTags...)
+	input.ExternalID = strings.TrimSpace(rec.ExternalID)
+	if input.Title == "" {
+		return fail(ExitUsage, fmt.Errorf("empty title"))
+	}
+	if desc := firstNonEmpty(rec.Desc, rec.Details, details); desc != "" {
+		input.Description = desc
+	}
+	if p := firstNonEmpty(rec.Priority, textPriority); p != "" {
+		input.Priority = p
+	}
+	if input.Priority == "" {
+		input.Priority = "normal"
+	}
+	if c := strings.TrimSpace(rec.Column); c != "" {
+		if err := checkColumn(ws, c); err != nil {
+			return fail(ExitUsage, err)
+		}
+		input.Column = c
+	}
+	if p := firstNonEmpty(rec.Project, textProject); p != "" {
+		if err := checkAddProject(ws, p, base.CreateProject); err != nil {
+			return fail(ExitNotFound, err)
+		}
+		input.Project = p
+	} else {
+		input.Project = resolveProject(ws, base.Project)
+	}
+	var err error
+	if strings.TrimSpace(rec.Due) != "" {
+		input.Due, input.DueTime, err = resolveDueTimeArg(gf, rec.Due)
+	} else {
+		input.Due, input.DueTime, err = resolveDue(textDue)
+	}
+	if err != nil {
+		return fail(ExitUsage, err)
+	}
+	if strings.TrimSpace(rec.Start) != "" {
+		if input.Start, err = resolveDateArg(gf, "start", rec.Start); err != nil {
+			return fail(ExitUsage, err)
+		}
+	}
+	if strings.TrimSpace(rec.Repeat) != "" {
+		input.Repeat = rec.Repeat
+	}
+	addCaptureTask(ws, &res, input)
+	return res
+}
+
+// firstNonEmpty returns the first of values that is not blank, trimmed.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func TestAddBatch(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+		code  int
+		want  []string // titles added, sorted
+		check func(t *testing.T, tasks map[string]store.Task)
+	}{
+		{
+			name:  "text lines",
+			input: "Buy milk #errand\n\nCall the bank | about the card\n",
+			want:  []string{"Buy milk", "Call the bank"},
+			check: func(t *testing.T, tasks map[string]store.Task) {
+				if tags := tasks["Buy milk"].Tags; len(tags) != 1 || tags[0] != "errand" {
+					t.Fatalf("expected the #errand tag, got %v", tags)
+				}
+				if !strings.Contains(tasks["Call the bank"].Body, "about the card") {
+					t.Fatalf("expected the details in the body, got %q", tasks["Call the bank"].Body)
+				}
+			},
+		},
+		{
+			name:  "json array",
+			input: `[{"title": "Ship", "priority": "high", "tags": ["release"], "column": "Doing"}, {"text": "Review #code"}]`,
+			want:  []string{"Review", "Ship"},
+			check: func(t *testing.T, tasks map[string]store.Task) {
+				ship := tasks["Ship"]
+				if ship.Priority != "high" || ship.Column != "doing" || len(ship.Tags) != 1 || ship.Tags[0] != "release" {
+					t.Fatalf("unexpected Ship: %+v", ship.TaskMeta)
+				}
+				if tags := tasks["Review"].Tags; len(tags) != 1 || tags[0] != "code" {
+					t.Fatalf("expected the text's tag, got %v", tags)
+				}
+			},
+		},
+		{
+			name:  "ndjson with a bad record partway through",
+			input: "{\"title\": \"First\"}\n{\"title\": \"Second\", \"colour\": \"red\"}\n{\"title\": \"Third\"}\n",
+			code:  ExitUsage,
+			want:  []string{"First", "Third"},
+		},
+		{
+			name:  "unknown column",
+			input: "{\"title\": \"Odd\", \"column\": \"icebox\"}\n{\"title\": \"Fine\", \"column\": \"blocked\"}\n",
+			code:  ExitUsage,
+			want:  []string{"Fine"},
+		},
+		{
+			name:  "unknown project",
+			input: `[{"title": "Lost", "project": "Wokr"}, {"title": "Found", "project": "Work"}]`,
+			code:  ExitNotFound,
+			want:  []string{"Found"},
+		},
+		{
+			name:  "malformed array",
+			input: `[{"title": "Half"`,
+			code:  ExitUsage,
+		},
+		{
+			name:  "empty input",
+			input: "\n  \n",
+			code:  ExitUsage,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ws := newTestWorkspace(t)
+			src := filepath.Join(t.TempDir(), "batch")
+			if err := os.WriteFile(src, []byte(c.input), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if code := Run([]string{"--root", ws.Root, "--quiet", "add", "--batch", src, "--project", "Work"}); code != c.code {
+				t.Fatalf("expected exit %d, got %d", c.code, code)
+			}
+			tasks, err := ws.ListTasks(store.ListFilter{All: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			byTitle := map[string]store.Task{}
+			var titles []string
+			for _, task := range tasks {
+				byTitle[task.Title] = task
+				titles = append(titles, task.Title)
+			}
+			sort.Strings(titles)
+			if strings.Join(titles, ",") != strings.Join(c.want, ",") {
+				t.Fatalf("expected tasks %v, got %v", c.want, titles)
+			}
+			if c.check != nil {
+				c.check(t, byTitle)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const aliasUsage = "Usage: tasker alias add <name> \"<command...>\" | alias ls | alias rm <name>"
+
+// maxAliasDepth bounds alias-to-alias expansion so a cycle cannot loop.
+const maxAliasDepth = 8
+
+func isCommandName(name string) bool {
+	for _, c := range commandNames {
+		if c == name {
+			return true
+		}
+	}
+	return name == "--help" || name == "-h"
+}
+
+// commandIndex is the position of the command in args, skipping global flags
+// the same way extractGlobalFlags does.
+func commandIndex(args []string) int {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--root", "--format", "--export-dir", "--lock-timeout", "--now":
+			i++
+		case "--json", "--ndjson", "--stdout-json", "--stdout-ndjson", "--plain", "--ascii", "--quiet", "--silent", "--verbose", "--no-lock":
+		default:
+			return i
+		}
+	}
+	return -1
+}
+
+// expandAliases replaces a leading alias in args with its expansion (again
+// while the result starts with another alias) and reports whether anything
+// was expanded. Commands always win over aliases of the same name.
+func expandAliases(cfg store.Config, args []string) ([]string, bool, error) {
+	expanded := false
+	seen := map[string]bool{}
+	for depth := 0; ; depth++ {
+		i := commandIndex(args)
+		if i < 0 || isCommandName(args[i]) {
+			return args, expanded, nil
+		}
+		expansion, ok := cfg.Alias(args[i])
+		if !ok {
+			return args, expanded, nil
+		}
+		if seen[args[i]] || depth == maxAliasDepth {
+			return nil, false, fmt.Errorf("alias %q expands to itself", args[i])
+		}
+		seen[args[i]] = true
+		tokens, err := splitCommandLine(expansion)
+		if err != nil {
+			return nil, false, fmt.Errorf("alias %q: %v", args[i], err)
+		}
+		out := make([]string, 0, len(args)+len(tokens))
+		out = append(out, args[:i]...)
+		out = append(out, tokens...)
+		out = append(out, args[i+1:]...)
+		args = out
+		expanded = true
+	}
+}
+
+// splitCommandLine splits s into words like a POSIX shell: whitespace
+// separates words, single quotes are literal, and double quotes allow
+// backslash escapes.
+func splitCommandLine(s string) ([]string, error) {
+	var words []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("unterminated quote or escape")
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+	return words, nil
+}
+
+// quoteCommandLine joins words so splitCommandLine returns them unchanged.
+func quoteCommandLine(words []string) string {
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if w != "" && !strings.ContainsAny(w, " \t\n'\"\\") {
+			out = append(out, w)
+			continue
+		}
+		out = append(out, "'"+strings.ReplaceAll(w, "'", `'\''`)+"'")
+	}
+	return strings.Join(out, " ")
+}
+
+// defineAlias validates and stores an alias for `alias add` and
+// `config set alias.<name>`. One word is a command line as typed; several are
+// words the shell already split.
+func defineAlias(ws *store.Workspace, cmd string, name string, words []string) (string, string, int) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if isCommandName(name) {
+		fmt.Fprintf(os.Stderr, "%s: %q is a tasker command\n", cmd, name)
+		return "", "", ExitConflict
+	}
+	expansion := strings.Join(words, " ")
+	if len(words) > 1 {
+		expansion = quoteCommandLine(words)
+	}
+	if _, err := splitCommandLine(expansion); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		return "", "", ExitUsage
+	}
+	if err := ws.SetAlias(name, expansion); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		if errors.Is(err, store.ErrInvalid) {
+			return "", "", ExitUsage
+		}
+		if errors.Is(err, store.ErrConflict) {
+			return "", "", ExitConflict
+		}
+		return "", "", ExitInternal
+	}
+	return name, strings.TrimSpace(expansion), ExitOK
+}
+
+func cmdAlias(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, aliasUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "add", "set":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, aliasUsage)
+			return ExitUsage
+		}
+		name, expansion, code := defineAlias(ws, "alias", args[1], args[2:])
+		if code != ExitOK {
+			return code
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "alias", "alias", map[string]any{"name": name, "command": expansion})
+		}
+		if !gf.Quiet {
+			fmt.Printf("Alias %s = %s\n", name, expansion)
+		}
+		return ExitOK
+	case "rm", "remove", "delete":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, aliasUsage)
+			return ExitUsage
+		}
+		if err := ws.RemoveAlias(args[1]); err != nil {
+			fmt.Fprintln(os.Stderr, "alias:", err)
+			if errors.Is(err, store.ErrNotFound) {
+				return ExitNotFound
+			}
+			if errors.Is(err, store.ErrConflict) {
+				return ExitConflict
+			}
+			return ExitInternal
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "alias", "alias", map[string]any{"name": strings.ToLower(strings.TrimSpace(args[1])), "removed": true})
+		}
+		if !gf.Quiet {
+			fmt.Println("Removed alias", strings.ToLower(strings.TrimSpace(args[1])))
+		}
+		return ExitOK
+	case "ls", "list":
+		cfg := ws.Config()
+		names := cfg.AliasNames()
+		if gf.Plain {
+			for _, name := range names {
+				fmt.Fprintf(os.Stdout, "%s\t%s\n", name, cfg.Aliases[name])
+			}
+			return ExitOK
+		}
+		if gf.JSON {
+			aliases := cfg.Aliases
+			if aliases == nil {
+				aliases = map[string]string{}
+			}
+			return emitJSONPayload(gf, "alias", "aliases", map[string]any{"aliases": aliases})
+		}
+		if len(names) == 0 {
+			fmt.Println("No aliases.")
+			return ExitOK
+		}
+		for _, name := range names {
+			fmt.Printf("%s = %s\n", name, cfg.Aliases[name])
+		}
+		return ExitOK
+	default:
+		fmt.Fprintln(os.Stderr, aliasUsage)
+		return ExitUsage
+	}
+}
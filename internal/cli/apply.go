@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// applyOp is one entry in an ops file. Selector fields may reference an
+// earlier op's task with "$<ref>".
+type applyOp struct {
+	Op       string   `json:"op"`
+	Ref      string   `json:"ref,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+	Project  string   `json:"project,omitempty"`
+	Column   string   `json:"column,omitempty"`
+	Match    string   `json:"match,omitempty"`
+	To       string   `json:"to,omitempty"`
+	Title    string   `json:"title,omitempty"`
+	Due      *string  `json:"due,omitempty"`
+	Priority *string  `json:"priority,omitempty"`
+	Repeat   *string  `json:"repeat,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	AddTags  []string `json:"add_tags,omitempty"`
+	RmTags   []string `json:"remove_tags,omitempty"`
+	Desc     string   `json:"desc,omitempty"`
+	Text     string   `json:"text,omitempty"`
+	NewTitle *string  `json:"new_title,omitempty"`
+	// CreateProject mirrors add --create-project.
+	CreateProject bool `json:"create_project,omitempty"`
+	// ExternalID mirrors add --external-id.
+	ExternalID string `json:"external_id,omitempty"`
+	// Force mirrors mv/done --force for blocked tasks.
+	Force bool `json:"force,omitempty"`
+}
+
+type applyResult struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	Ref     string `json:"ref,omitempty"`
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Project string `json:"project"`
+	Column  string `json:"column"`
+}
+
+// applyOpError carries the failing op index through the transaction.
+type applyOpError struct {
+	Index int
+	Op    string
+	Err   error
+}
+
+func (e *applyOpError) Error() string {
+	return fmt.Sprintf("op #%d (%s): %v", e.Index+1, e.Op, e.Err)
+}
+
+func (e *applyOpError) Unwrap() error { return e.Err }
+
+var errApplyDryRun = errors.New("dry run")
+
+func parseApplyOps(b []byte) ([]applyOp, error) {
+	trimmed := strings.TrimSpace(string(b))
+	var ops []applyOp
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &ops); err != nil {
+			return nil, err
+		}
+		return ops, nil
+	}
+	var wrapper struct {
+		Ops []applyOp `json:"ops"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &wrapper); err != nil {
+		return nil, err
+	}
+	return wrapper.Ops, nil
+}
+
+func cmdApply(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--dry-run": false,
+		"--timeout": true,
+	})
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	dryRun := fs.Bool("dry-run", false, "Validate and run the ops, then roll everything back")
+	timeout := fs.Duration("timeout", ws.WriteLockTimeout(), "How long to wait for the workspace lock")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker apply [--dry-run] [--timeout <dur>] <ops.json|->")
+		return ExitUsage
+	}
+	var b []byte
+	var err error
+	if rest[0] == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(rest[0])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "apply:", err)
+		return ExitUsage
+	}
+	ops, err := parseApplyOps(b)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "apply: invalid ops file:", err)
+		return ExitUsage
+	}
+	if len(ops) == 0 {
+		fmt.Fprintln(os.Stderr, "apply: no ops")
+		return ExitUsage
+	}
+
+	var results []applyResult
+	err = ws.Transaction("apply", *timeout, func() error {
+		refs := map[string]string{}
+		for i, op := range ops {
+			res, err := runApplyOp(ws, op, refs)
+			if err != nil {
+				return &applyOpError{Index: i, Op: op.Op, Err: err}
+			}
+			res.Index = i
+			if op.Ref != "" {
+				refs[op.Ref] = res.ID
+			}
+			results = append(results, res)
+		}
+		if *dryRun {
+			return errApplyDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errApplyDryRun) {
+		fmt.Fprintln(os.Stderr, "apply:", err)
+		fmt.Fprintln(os.Stderr, "apply: no changes were made (rolled back)")
+		var conflict *store.MatchConflictError
+		switch {
+		case errors.As(err, &conflict):
+			handleMatchConflict(gf, "apply", err)
+			return ExitConflict
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+
+	payload := map[string]any{
+		"applied": !*dryRun,
+		"count":   len(results),
+		"results": results,
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "apply", "apply", payload)
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "#\tOP\tID\tPROJECT/COL\tTITLE")
+		for _, r := range results {
+			fmt.Fprintf(os.Stdout, "%d\t%s\t%s\t%s/%s\t%s\n", r.Index+1, r.Op, r.ID, r.Project, r.Column, r.Title)
+		}
+		return ExitOK
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	verb := "Applied"
+	if *dryRun {
+		verb = "Dry run OK (rolled back)"
+	}
+	fmt.Printf("%s: %d ops\n", verb, len(results))
+	for _, r := range results {
+		fmt.Printf("  %d. %s %s (%s/%s)\n", r.Index+1, r.Op, r.Title, r.Project, r.Column)
+	}
+	return ExitOK
+}
+
+func runApplyOp(ws *store.Workspace, op applyOp, refs map[string]string) (applyResult, error) {
+	kind := strings.ToLower(strings.TrimSpace(op.Op))
+	res := applyResult{Op: kind, Ref: op.Ref}
+	var task *store.Task
+	var err error
+	switch kind {
+	case "add":
+		if err := checkColumn(ws, op.Column); err != nil {
+			return res, fmt.Errorf("%w: %v", store.ErrInvalid, err)
+		}
+		due := ""
+		if op.Due != nil {
+			due = parseDueToken(*op.Due)
+		}
+		priority := "normal"
+		if op.Priority != nil {
+			priority = *op.Priority
+		}
+		repeat := ""
+		if op.Repeat != nil {
+			repeat = *op.Repeat
+		}
+		task, err = ws.AddTask(store.AddTaskInput{
+			Title:         op.Title,
+			Project:       resolveProject(ws, op.Project),
+			Column:        op.Column,
+			Due:           due,
+			Priority:      priority,
+			Tags:          op.Tags,
+			Description:   op.Desc,
+			Repeat:        repeat,
+			CreateProject: op.CreateProject,
+			ExternalID:    op.ExternalID,
+		})
+	case "done":
+		var ref *store.Task
+		if ref, err = resolveApplyTarget(ws, op, refs); err == nil {
+			task, err = ws.CompleteTask(ref.ID, store.MoveOptions{Force: op.Force})
+		}
+	case "mv", "move":
+		to := op.To
+		if strings.TrimSpace(to) == "" {
+			return res, fmt.Errorf("%w: \"to\" column is required", store.ErrInvalid)
+		}
+		if err := checkColumn(ws, to); err != nil {
+			return res, fmt.Errorf("%w: %v", store.ErrInvalid, err)
+		}
+		var ref *store.Task
+		if ref, err = resolveApplyTarget(ws, op, refs); err == nil {
+			task, err = ws.MoveTaskWith(ref.ID, to, store.MoveOptions{Force: op.Force})
+		}
+	case "note":
+		if strings.TrimSpace(op.Text) == "" {
+			return res, fmt.Errorf("%w: \"text\" is required", store.ErrInvalid)
+		}
+		var ref *store.Task
+		if ref, err = resolveApplyTarget(ws, op, refs); err == nil {
+			task, err = ws.AddNote(ref.ID, op.Text)
+		}
+	case "edit":
+		patch := store.TaskPatch{
+			Title:      op.NewTitle,
+			Priority:   op.Priority,
+			Repeat:     op.Repeat,
+			AddTags:    op.AddTags,
+			RemoveTags: op.RmTags,
+		}
+		if op.Due != nil {
+			due := parseDueToken(*op.Due)
+			patch.Due = &due
+		}
+		if op.Tags != nil {
+			tags := op.Tags
+			patch.Tags = &tags
+		}
+		var ref *store.Task
+		if ref, err = resolveApplyTarget(ws, op, refs); err == nil {
+			task, err = ws.EditTask(ref.ID, patch)
+		}
+	default:
+		return res, fmt.Errorf("%w: unknown op %q (use add|mv|done|note|edit)", store.ErrInvalid, op.Op)
+	}
+	if err != nil {
+		return res, err
+	}
+	res.ID = task.ID
+	res.Title = task.Title
+	res.Project = task.Project
+	res.Column = task.Column
+	return res, nil
+}
+
+func resolveApplyTarget(ws *store.Workspace, op applyOp, refs map[string]string) (*store.Task, error) {
+	selector := strings.TrimSpace(op.Selector)
+	if selector == "" {
+		return nil, fmt.Errorf("%w: \"selector\" is required", store.ErrInvalid)
+	}
+	if strings.HasPrefix(selector, "$") {
+		id, ok := refs[strings.TrimPrefix(selector, "$")]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown ref %s", store.ErrInvalid, selector)
+		}
+		return ws.GetTaskByPrefix(id)
+	}
+	filter, err := selectorFilter(ws, op.Project, op.Column, "", false, op.Match)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", store.ErrInvalid, err)
+	}
+	return ws.GetTaskBySelectorFiltered(selector, filter)
+}
@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const archiveUsage = "Usage: tasker archive [--project <name>] [--done-older-than <age>] [--dry-run] | archive compact [--project <name>] [--dry-run]"
+
+// cmdArchive moves done tasks that have sat in done longer than
+// --done-older-than (agent.auto_archive_days, else 30d) into each project's
+// archived column, all under one journal entry.
+func cmdArchive(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) > 0 && args[0] == "compact" {
+		return cmdArchiveCompact(ws, gf, args[1:])
+	}
+	args = reorderFlags(args, map[string]bool{
+		"--project":         true,
+		"--done-older-than": true,
+		"--dry-run":         false,
+	})
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug, glob or comma list (default: every project)")
+	olderThan := fs.String("done-older-than", "", "Archive tasks done longer ago than this (e.g. 30d, 72h)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be archived without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, archiveUsage)
+		return ExitUsage
+	}
+	age, err := archiveAge(ws, *olderThan)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "archive:", err)
+		return ExitUsage
+	}
+	if strings.EqualFold(strings.TrimSpace(*project), "all") || strings.EqualFold(strings.TrimSpace(*project), "none") {
+		*project = ""
+	}
+	if err := checkProjectSpec(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "archive:", err)
+		return ExitNotFound
+	}
+	tasks, err := ws.ArchiveDone("archive", *project, age, *dryRun)
+	if err != nil {
+		return bulkError("archive", err)
+	}
+	if tasks == nil {
+		tasks = []store.Task{}
+	}
+	if len(tasks) == 0 && !gf.Plain && !gf.JSON {
+		if !gf.Quiet {
+			fmt.Printf("Nothing to archive (no done tasks older than %s)\n", formatArchiveAge(age))
+		}
+		return ExitOK
+	}
+	verb := "Archived"
+	if *dryRun {
+		verb = "Archive"
+	}
+	return emitBulkResult(gf, "archive", tasks, *dryRun, fmt.Sprintf("%s %d task(s)", verb, len(tasks)))
+}
+
+// cmdArchiveCompact rolls archived task files into archive/<year>.ndjson
+// bundles; ls --archive-bundles still finds them.
+func cmdArchiveCompact(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--dry-run": false,
+	})
+	fs := flag.NewFlagSet("archive compact", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug, glob or comma list (default: every project)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be compacted without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, archiveUsage)
+		return ExitUsage
+	}
+	if err := checkProjectSpec(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "archive compact:", err)
+		return ExitNotFound
+	}
+	tasks, err := ws.CompactArchive(*project, *dryRun)
+	if err != nil {
+		return bulkError("archive compact", err)
+	}
+	if tasks == nil {
+		tasks = []store.Task{}
+	}
+	if gf.Plain {
+		for _, t := range tasks {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s/%s\t%s\n", t.ID, t.Bundle, t.Project, t.Column, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "archive compact", "tasks", map[string]any{"tasks": tasks, "count": len(tasks), "dry_run": *dryRun})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	if len(tasks) == 0 {
+		fmt.Println("Nothing to compact (no archived task files)")
+		return ExitOK
+	}
+	counts := map[string]int{}
+	var bundles []string
+	for _, t := range tasks {
+		if counts[t.Bundle] == 0 {
+			bundles = append(bundles, t.Bundle)
+		}
+		counts[t.Bundle]++
+	}
+	sort.Strings(bundles)
+	verb := "Compacted"
+	if *dryRun {
+		verb = "Would compact"
+	}
+	fmt.Printf("%s %d archived task(s)\n", verb, len(tasks))
+	for _, b := range bundles {
+		fmt.Printf("  - %s: %d\n", b, counts[b])
+	}
+	return ExitOK
+}
+
+// archiveAge parses --done-older-than, falling back to
+// agent.auto_archive_days and then 30 days.
+func archiveAge(ws *store.Workspace, value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		if days := ws.Config().AutoArchiveDays(); days > 0 {
+			return time.Duration(days) * 24 * time.Hour, nil
+		}
+		return 30 * 24 * time.Hour, nil
+	}
+	d, err := store.ParseHorizon(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --done-older-than %q (use e.g. 30d or 72h)", value)
+	}
+	return d, nil
+}
+
+func formatArchiveAge(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
+
+// runAutoArchive applies agent.auto_archive_days after a successful
+// mutating command. It stays quiet unless something was archived, and never
+// runs after undo (which would re-archive what was just restored) or after
+// archive itself.
+func runAutoArchive(ws *store.Workspace, gf GlobalFlags, cmd string) {
+	if cmd == "undo" || cmd == "archive" || ws.Config().AutoArchiveDays() <= 0 {
+		return
+	}
+	tasks, err := ws.AutoArchive()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "auto-archive:", err)
+		return
+	}
+	if len(tasks) > 0 && !gf.Quiet && !gf.JSON && !gf.Plain {
+		fmt.Fprintf(os.Stderr, "auto-archive: archived %d done task(s) older than %dd\n", len(tasks), ws.Config().AutoArchiveDays())
+	}
+}
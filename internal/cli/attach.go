@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const attachUsage = "Usage: tasker attach <selector...> <file> [--project <name>] [--match <m>]"
+
+// cmdAttach copies a file into the task's attachments directory. The last
+// argument is the file; the ones before it select the task.
+func cmdAttach(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("attach", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) < 2 {
+		fmt.Fprintln(os.Stderr, attachUsage)
+		return ExitUsage
+	}
+	file := rest[len(rest)-1]
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "attach:", err)
+		return ExitUsage
+	}
+	task, code := resolveTaskSelector(ws, gf, "attach", strings.Join(rest[:len(rest)-1], " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	task, rel, err := ws.AttachFile(task.ID, file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "attach:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", task.ID, rel)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "attach", "attach", map[string]any{"task": task, "attachment": rel})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Attached %s to %s\n", rel, taskTitleOrUntitled(task.Title))
+	}
+	return ExitOK
+}
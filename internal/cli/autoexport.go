@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// autoExport is one parsed exports.auto entry.
+type autoExport struct {
+	View    string
+	Project string
+}
+
+// parseAutoExport parses "<view>[:<project>]".
+func parseAutoExport(spec string) (autoExport, error) {
+	view, project, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	e := autoExport{View: strings.ToLower(strings.TrimSpace(view)), Project: strings.TrimSpace(project)}
+	switch e.View {
+	case "today", "week", "board", "ical", "obsidian":
+	case "metrics":
+		if e.Project != "" {
+			return e, fmt.Errorf("metrics covers the whole workspace; drop %q", ":"+e.Project)
+		}
+	default:
+		return e, fmt.Errorf("unknown view %q (use today|week|board|ical|obsidian[:<project>]|metrics)", e.View)
+	}
+	return e, nil
+}
+
+func normalizeExportFormat(format string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "human", "text":
+		return "human", true
+	case "telegram":
+		return "telegram", true
+	case "json":
+		return "json", true
+	}
+	return "", false
+}
+
+// fileName is "<view>[-<project>].<ext>"; names are stable so readers can
+// watch one path.
+func (e autoExport) fileName(project string, format string) string {
+	name := e.View
+	if project != "" {
+		name += "-" + strings.ToLower(strings.ReplaceAll(project, " ", "-"))
+	}
+	if format == "json" {
+		return name + ".json"
+	}
+	return name + ".txt"
+}
+
+// refreshAutoExports re-renders the exports.auto views. Failures are
+// reported on stderr but never change the command's exit code.
+func refreshAutoExports(ws *store.Workspace, gf GlobalFlags) {
+	cfg := ws.Config()
+	if cfg.Exports == nil || len(cfg.Exports.Auto) == 0 {
+		return
+	}
+	format, ok := normalizeExportFormat(cfg.Exports.Format)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "exports.auto: unknown exports.format %q\n", cfg.Exports.Format)
+		return
+	}
+	for _, spec := range cfg.Exports.Auto {
+		e, err := parseAutoExport(spec)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "exports.auto:", err)
+			continue
+		}
+		if e.View == "metrics" {
+			// Always metrics.json, whatever exports.format says.
+			data, err := metricsExport(ws)
+			if err == nil {
+				err = writeStableExport(gf.ExportDir, "metrics.json", data)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "exports.auto: %s: %v\n", spec, err)
+			}
+			continue
+		}
+		if e.View == "ical" {
+			// Always an .ics file, covering every project unless one is named.
+			var data []byte
+			err := checkProject(ws, e.Project)
+			if err == nil {
+				data, err = ws.RenderICal(store.ICalOptions{Project: e.Project, Events: true, Todos: true})
+			}
+			if err == nil {
+				err = writeStableExport(gf.ExportDir, icalFileName(e.Project), data)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "exports.auto: %s: %v\n", spec, err)
+			}
+			continue
+		}
+		if e.View == "obsidian" {
+			// Always a Markdown note, covering every project unless one is named.
+			var tasks []store.Task
+			err := checkProject(ws, e.Project)
+			if err == nil {
+				tasks, err = ws.ListTasks(store.ListFilter{Project: e.Project})
+			}
+			if err == nil {
+				err = writeStableExport(gf.ExportDir, obsidianFileName(e.Project), ws.RenderObsidian(tasks))
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "exports.auto: %s: %v\n", spec, err)
+			}
+			continue
+		}
+		project := resolveProject(ws, e.Project)
+		data, err := renderAutoExport(ws, gf, e.View, project, format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "exports.auto: %s: %v\n", spec, err)
+			continue
+		}
+		if err := writeStableExport(gf.ExportDir, e.fileName(project, format), data); err != nil {
+			fmt.Fprintf(os.Stderr, "exports.auto: %s: %v\n", spec, err)
+		}
+	}
+}
+
+func renderAutoExport(ws *store.Workspace, gf GlobalFlags, view string, project string, format string) ([]byte, error) {
+	if project != "" {
+		if err := checkProject(ws, project); err != nil {
+			return nil, err
+		}
+	}
+	open := resolveOpenOnly(ws, project, false, false) || format == "telegram"
+	groupBy := resolveGroupBy(ws, project, "")
+	if groupBy == "none" {
+		groupBy = ""
+	}
+	showTotals := resolveShowTotals(ws, false)
+	var payload any
+	var out string
+	var err error
+	switch view {
+	case "today":
+		if format == "json" {
+			payload, err = ws.TodayView(project, open, groupBy)
+		} else {
+			out, err = ws.RenderToday(project, open, groupBy, showTotals, format)
+		}
+	case "week":
+		days := resolveWeekDays(ws, project, 0)
+		if format == "json" {
+			payload, err = ws.WeekView(project, days, open, groupBy)
+		} else {
+			out, err = ws.RenderAgenda(project, days, open, groupBy, showTotals, format)
+		}
+	case "board":
+		if project == "" {
+			return nil, fmt.Errorf("board needs a project (board:<project>)")
+		}
+		if format == "json" {
+			payload, err = boardPayload(ws, project, false)
+		} else {
+			out, err = ws.RenderBoard(project, gf.ASCII, format, format == "telegram", 0, false)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "json" {
+		b, err := json.MarshalIndent(payload, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+	return []byte(strings.TrimRight(out, "\n") + "\n"), nil
+}
+
+// writeStableExport replaces dir/name atomically.
+func writeStableExport(dir string, name string, data []byte) error {
+	if strings.TrimSpace(dir) == "" {
+		return fmt.Errorf("export directory is empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return replaceFile(dir, filepath.Join(dir, name), data)
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const briefUsage = "Usage: tasker brief [--project <name|glob>...] [--max-chars N]"
+
+// cmdBrief prints a status small enough to paste into an agent prompt.
+func cmdBrief(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project":   true,
+		"--max-chars": true,
+	})
+	fs := flag.NewFlagSet("brief", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable; default: TASKER_PROJECT or agent.default_project, else all)")
+	maxChars := fs.Int("max-chars", store.DefaultBriefMaxChars, "Longest output in characters (0: no limit)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 || *maxChars < 0 {
+		fmt.Fprintln(os.Stderr, briefUsage)
+		return ExitUsage
+	}
+	project := resolveProject(ws, store.JoinProjectSpec(projects.Values))
+	if err := checkProjectSpec(ws, project); err != nil {
+		fmt.Fprintln(os.Stderr, "brief:", err)
+		return ExitNotFound
+	}
+	brief, err := ws.Brief(project)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "brief:", err)
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "brief", "brief", map[string]any{"brief": brief, "text": brief.Render(*maxChars)})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	fmt.Print(brief.Render(*maxChars))
+	return ExitOK
+}
+
+type mcpBriefArgs struct {
+	Project  string `json:"project"`
+	MaxChars *int   `json:"max_chars"`
+}
+
+func mcpBrief(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in mcpBriefArgs
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	maxChars := store.DefaultBriefMaxChars
+	if in.MaxChars != nil {
+		if *in.MaxChars < 0 {
+			return nil, fmt.Errorf("%w: max_chars must not be negative", errBadRequest)
+		}
+		maxChars = *in.MaxChars
+	}
+	project := resolveProject(ws, in.Project)
+	if err := checkProject(ws, project); err != nil {
+		return nil, fmt.Errorf("%w: %v", store.ErrNotFound, err)
+	}
+	brief, err := ws.Brief(project)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"brief": brief, "text": brief.Render(maxChars)}, nil
+}
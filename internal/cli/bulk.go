@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const editUsage = "Usage: tasker edit [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--all-matches] [--dry-run] [--set <key>=<value>]... [--add-tag <t>]... [--remove-tag <t>]... [<selector>]"
+
+// bulkTargets resolves every task an --all-matches command acts on. Without
+// a selector at least one filter must be given, so a bare --all-matches
+// never touches the whole workspace.
+func bulkTargets(ws *store.Workspace, cmd string, selector string, filter store.SelectorFilter, filtered bool) ([]store.Task, int) {
+	if strings.TrimSpace(selector) == "" && !filtered {
+		fmt.Fprintf(os.Stderr, "%s: --all-matches needs a selector or a filter (--project, --column, --status, --tag)\n", cmd)
+		return nil, ExitUsage
+	}
+	tasks, err := ws.MatchTasks(selector, filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		return nil, ExitInternal
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintf(os.Stderr, "%s: not found\n", cmd)
+		return nil, ExitNotFound
+	}
+	return tasks, ExitOK
+}
+
+func taskIDs(tasks []store.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// bulkError reports a failed batch; nothing was written.
+func bulkError(cmd string, err error) int {
+	var blocked *store.BlockedError
+	switch {
+	case errors.As(err, &blocked):
+		fmt.Fprintf(os.Stderr, "%s: %v (use --force to complete anyway)\n", cmd, err)
+	default:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	}
+	fmt.Fprintf(os.Stderr, "%s: no changes were made\n", cmd)
+	switch {
+	case blocked != nil, errors.Is(err, store.ErrConflict):
+		return ExitConflict
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	}
+	return ExitInternal
+}
+
+// emitBulkResult prints the tasks a batch changed (or would change).
+func emitBulkResult(gf GlobalFlags, cmd string, tasks []store.Task, dryRun bool, summary string) int {
+	if gf.Plain {
+		for _, t := range tasks {
+			fmt.Fprintf(os.Stdout, "%s\t%s/%s\t%s\n", t.ID, t.Project, t.Column, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, cmd, "tasks", map[string]any{"tasks": tasks, "count": len(tasks), "dry_run": dryRun})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	if dryRun {
+		summary = "Would " + strings.ToLower(summary[:1]) + summary[1:]
+	}
+	fmt.Println(summary)
+	for _, t := range tasks {
+		fmt.Printf("  - %s (%s/%s, %s)\n", taskTitleOrUntitled(t.Title), t.Project, t.Column, t.ID)
+	}
+	return ExitOK
+}
+
+// cmdBulkMove moves every match to dest; an empty dest completes them into
+// each project's done column.
+func cmdBulkMove(ws *store.Workspace, gf GlobalFlags, cmd string, selector string, filter store.SelectorFilter, filtered bool, dest string, force bool, dryRun bool) int {
+	targets, code := bulkTargets(ws, cmd, selector, filter, filtered)
+	if code != ExitOK {
+		return code
+	}
+	opts := store.MoveOptions{Force: force}
+	if dest == "" {
+		tasks, err := ws.CompleteTasks(taskIDs(targets), opts, dryRun)
+		if err != nil {
+			return bulkError(cmd, err)
+		}
+		verb := "Completed"
+		if dryRun {
+			verb = "Complete"
+		}
+		return emitBulkResult(gf, cmd, tasks, dryRun, fmt.Sprintf("%s %d task(s)", verb, len(tasks)))
+	}
+	tasks, err := ws.MoveTasks(taskIDs(targets), dest, opts, dryRun)
+	if err != nil {
+		return bulkError(cmd, err)
+	}
+	verb := "Moved"
+	if dryRun {
+		verb = "Move"
+	}
+	return emitBulkResult(gf, cmd, tasks, dryRun, fmt.Sprintf("%s %d task(s) -> %s", verb, len(tasks), dest))
+}
+
+func cmdEdit(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project":     true,
+		"--column":      true,
+		"--status":      true,
+		"--tag":         true,
+		"--any-tag":     true,
+		"--not-tag":     true,
+		"--all":         false,
+		"--match":       true,
+		"--all-matches": false,
+		"--dry-run":     false,
+		"--set":         true,
+		"--add-tag":     true,
+		"--remove-tag":  true,
+	})
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	column := fs.String("column", "", "Column id (filter)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
+	tags := addTagFlags(fs)
+	all := fs.Bool("all", false, "Include archived")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	allMatches := fs.Bool("all-matches", false, "Edit every matching task instead of exactly one")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without writing (with --all-matches)")
+	var sets, addTags, removeTags stringList
+	fs.Var(&sets, "set", "Field to change as key=value: title|due|due_time|all_day|start|priority|repeat|tags (repeatable)")
+	fs.Var(&addTags, "add-tag", "Tag to add (repeatable)")
+	fs.Var(&removeTags, "remove-tag", "Tag to remove (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if selector == "" && !*allMatches {
+		fmt.Fprintln(os.Stderr, editUsage)
+		return ExitUsage
+	}
+	if *dryRun && !*allMatches {
+		fmt.Fprintln(os.Stderr, "edit: --dry-run needs --all-matches")
+		return ExitUsage
+	}
+	patch, err := parseEditSets(gf, sets)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "edit:", err)
+		return ExitUsage
+	}
+	patch.AddTags = addTags
+	patch.RemoveTags = removeTags
+	if patchEmpty(patch) {
+		fmt.Fprintln(os.Stderr, "edit: nothing to change (use --set, --add-tag or --remove-tag)")
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, *column, *status, *all, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "edit:", err)
+		return ExitUsage
+	}
+	filter.Tags = tags.filter()
+	if *allMatches {
+		filtered := *project != "" || *column != "" || *status != "" || filter.Tags.Active()
+		targets, code := bulkTargets(ws, "edit", selector, filter, filtered)
+		if code != ExitOK {
+			return code
+		}
+		tasks, err := ws.EditTasks(taskIDs(targets), patch, *dryRun)
+		if err != nil {
+			return bulkError("edit", err)
+		}
+		return emitBulkResult(gf, "edit", tasks, *dryRun, fmt.Sprintf("Edited %d task(s)", len(tasks)))
+	}
+	taskRef, err := ws.GetTaskBySelectorFiltered(selector, filter)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "edit: not found")
+			return ExitNotFound
+		}
+		if errors.Is(err, store.ErrConflict) {
+			if handleMatchConflict(gf, "edit", err) {
+				return ExitConflict
+			}
+			fmt.Fprintln(os.Stderr, "edit: ambiguous selector (use --all-matches to edit every match)")
+			return ExitConflict
+		}
+		fmt.Fprintln(os.Stderr, "edit:", err)
+		return ExitInternal
+	}
+	task, err := ws.EditTask(taskRef.ID, patch)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "edit:", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s/%s\t%s\n", task.ID, task.Project, task.Column, task.Title)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "edit", "task", map[string]any{"task": task})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Edited %s\n", taskTitleOrUntitled(task.Title))
+	}
+	return ExitOK
+}
+
+// parseEditSets turns --set key=value pairs into a patch.
+func parseEditSets(gf GlobalFlags, sets []string) (store.TaskPatch, error) {
+	var patch store.TaskPatch
+	for _, raw := range sets {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok {
+			return patch, fmt.Errorf("invalid --set %q (use key=value)", raw)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title":
+			patch.Title = &value
+		case "due":
+			// A time in the text sets due_time; without one the task keeps
+			// its time of day.
+			due, clock, err := resolveDueTimeArg(gf, value)
+			if err != nil {
+				return patch, err
+			}
+			patch.Due = &due
+			if clock != "" {
+				patch.DueTime = &clock
+			}
+		case "due_time", "due-time":
+			patch.DueTime = &value
+		case "all_day", "all-day":
+			allDay, err := strconv.ParseBool(value)
+			if err != nil || !allDay {
+				return patch, fmt.Errorf("invalid --set all_day=%s (use all_day=true, or due_time=HH:MM for a timed task)", value)
+			}
+			none := ""
+			patch.DueTime = &none
+		case "start":
+			start, err := resolveDateArg(gf, "start", value)
+			if err != nil {
+				return patch, err
+			}
+			patch.Start = &start
+		case "priority":
+			patch.Priority = &value
+		case "repeat":
+			patch.Repeat = &value
+		case "tags":
+			tags := []string{}
+			for _, t := range strings.Split(value, ",") {
+				if t = strings.TrimSpace(t); t != "" {
+					tags = append(tags, t)
+				}
+			}
+			patch.Tags = &tags
+		default:
+			return patch, fmt.Errorf("unknown --set key %q (use title|due|due_time|all_day|start|priority|repeat|tags)", key)
+		}
+	}
+	return patch, nil
+}
+
+func patchEmpty(p store.TaskPatch) bool {
+	return p.Title == nil && p.Due == nil && p.DueTime == nil && p.Start == nil && p.Priority == nil && p.Repeat == nil && p.Tags == nil &&
+		len(p.AddTags) == 0 && len(p.RemoveTags) == 0
+}
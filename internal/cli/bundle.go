@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdProjectExport(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--out": true,
+	})
+	fs := flag.NewFlagSet("project export", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	out := fs.String("out", "", "Bundle path (default: export dir; - for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker project export <name> [--out <file.tgz|->]")
+		return ExitUsage
+	}
+	name := strings.Join(rest, " ")
+	if err := checkProject(ws, name); err != nil {
+		fmt.Fprintln(os.Stderr, "project export:", err)
+		return ExitNotFound
+	}
+	var buf bytes.Buffer
+	manifest, err := ws.ExportProject(name, &buf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "project export:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
+		return ExitInternal
+	}
+	target := strings.TrimSpace(*out)
+	if target == "-" {
+		_, _ = os.Stdout.Write(buf.Bytes())
+		return ExitOK
+	}
+	if target == "" {
+		target, err = writeExportFile(gf.ExportDir, "project-"+manifest.Project.Slug, "tgz", buf.Bytes())
+	} else {
+		target = store.ExpandHome(target)
+		err = os.WriteFile(target, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "project export:", err)
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "project export", "project-export", map[string]any{"path": target, "manifest": manifest})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Exported %s (%d tasks, %d ideas) to: %s\n", manifest.Project.Name, manifest.Tasks, manifest.Ideas, target)
+	}
+	return ExitOK
+}
+
+func cmdProjectImport(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker project import <bundle.tgz|->")
+		return ExitUsage
+	}
+	var in io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(store.ExpandHome(args[0]))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "project import:", err)
+			return ExitNotFound
+		}
+		defer f.Close()
+		in = f
+	}
+	manifest, err := ws.ImportProject(in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "project import:", err)
+		switch {
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "project import", "project-import", map[string]any{"manifest": manifest})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Imported %s (%s): %d tasks, %d ideas\n", manifest.Project.Name, manifest.Project.Slug, manifest.Tasks, manifest.Ideas)
+	}
+	return ExitOK
+}
@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// captureLineResult is one NDJSON record of `capture --lines`.
+type captureLineResult struct {
+	Line  int         `json:"line"`
+	OK    bool        `json:"ok"`
+	Text  string      `json:"text"`
+	Task  *store.Task `json:"task,omitempty"`
+	Idea  *store.Idea `json:"idea,omitempty"`
+	Error string      `json:"error,omitempty"`
+	code  int
+}
+
+// readCaptureSource reads src, or stdin when src is "-".
+func readCaptureSource(src string) (string, error) {
+	var r io.Reader = os.Stdin
+	if src != "-" {
+		f, err := os.Open(src)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		r = f
+	}
+	b, err := io.ReadAll(r)
+	return string(b), err
+}
+
+// captureLines returns the non-empty lines of src ("-" for stdin) with their
+// 1-based line numbers.
+func captureLines(src string) ([]string, []int, error) {
+	text, err := readCaptureSource(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	lines, numbers := splitCaptureLines(text)
+	return lines, numbers, nil
+}
+
+// splitCaptureLines returns the non-empty lines of text with their 1-based
+// line numbers. Markdown bullets and checkboxes are dropped so notes can be
+// pasted as-is.
+func splitCaptureLines(text string) ([]string, []int) {
+	var lines []string
+	var numbers []int
+	for n, raw := range strings.Split(strings.TrimPrefix(text, "\ufeff"), "\n") {
+		line := strings.TrimSpace(raw)
+		for _, prefix := range []string{"- ", "* ", "• "} {
+			line = strings.TrimPrefix(line, prefix)
+		}
+		for _, prefix := range []string{"[ ] ", "[x] ", "[X] "} {
+			line = strings.TrimPrefix(line, prefix)
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+			numbers = append(numbers, n+1)
+		}
+	}
+	return lines, numbers
+}
+
+func captureTaskLines(ws *store.Workspace, gf GlobalFlags, src string, base store.AddTaskInput, resolveDue func(string) (string, string, error)) int {
+	lines, numbers, err := captureLines(src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	results := make([]captureLineResult, 0, len(lines))
+	for i, line := range lines {
+		results = append(results, captureTaskLine(ws, numbers[i], line, base, resolveDue))
+	}
+	return emitCaptureLines(gf, "capture", "captured", results)
+}
+
+// captureTaskLine adds the task one line of pipe syntax describes; base
+// (the command's flags) overrides what the line says.
+func captureTaskLine(ws *store.Workspace, n int, line string, base store.AddTaskInput, resolveDue func(string) (string, string, error)) captureLineResult {
+	res := captureLineResult{Line: n, Text: line}
+	title, details, textDue, textPriority, textTags, textProject := parseTextParts(line, base.Project == "")
+	input := base
+	input.Title = strings.TrimSpace(title)
+	input.Tags = append(append([]string{}, base.Tags...), textTags...)
+	if input.Description == "" {
+		input.Description = details
+	}
+	if input.Priority == "" {
+		input.Priority = textPriority
+	}
+	if input.Priority == "" {
+		input.Priority = "normal"
+	}
+	due, dueTime, err := resolveDue(textDue)
+	project, projectErr := lineProject(ws, base, textProject)
+	switch {
+	case input.Title == "":
+		res.Error, res.code = "empty title", ExitUsage
+	case err != nil:
+		res.Error, res.code = err.Error(), ExitUsage
+	case projectErr != nil:
+		res.Error, res.code = projectErr.Error(), ExitNotFound
+	default:
+		input.Project = project
+		input.Due = due
+		input.DueTime = dueTime
+		addCaptureTask(ws, &res, input)
+	}
+	return res
+}
+
+// lineProject picks the project of one line of input: --project (base), else
+// the line's +project, else the default project.
+func lineProject(ws *store.Workspace, base store.AddTaskInput, textProject string) (string, error) {
+	if base.Project != "" || textProject == "" {
+		return resolveProject(ws, base.Project), nil
+	}
+	return textProject, checkAddProject(ws, textProject, base.CreateProject)
+}
+
+// addCaptureTask adds input and records the outcome in res.
+func addCaptureTask(ws *store.Workspace, res *captureLineResult, input store.AddTaskInput) {
+	task, err := ws.AddTask(input)
+	if err != nil {
+		res.Error, res.code = err.Error(), ExitInternal
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			res.code = ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			res.code = ExitUsage
+		}
+		return
+	}
+	res.OK, res.Task = true, task
+}
+
+func captureIdeaLines(ws *store.Workspace, gf GlobalFlags, src string, project string, tags []string) int {
+	lines, numbers, err := captureLines(src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	results := make([]captureLineResult, 0, len(lines))
+	for i, line := range lines {
+		res := captureLineResult{Line: numbers[i], Text: line}
+		title, body, textTags, textProject := parseIdeaTextParts(line)
+		input := store.AddIdeaInput{
+			Title:   strings.TrimSpace(title),
+			Project: project,
+			Body:    body,
+			Tags:    append(textTags, tags...),
+		}
+		if input.Project == "" {
+			input.Project = strings.TrimSpace(textProject)
+		}
+		if input.Title == "" {
+			res.Error, res.code = "empty title", ExitUsage
+		} else if idea, err := ws.AddIdea(input); err != nil {
+			res.Error, res.code = err.Error(), ExitInternal
+		} else {
+			res.OK, res.Idea = true, idea
+		}
+		results = append(results, res)
+	}
+	return emitCaptureLines(gf, "capture", "captured", results)
+}
+
+// emitCaptureLines prints one NDJSON record per line (a single JSON payload
+// with --json, counting successes under countKey) and exits with the code of
+// the first failed line.
+func emitCaptureLines(gf GlobalFlags, cmd, countKey string, results []captureLineResult) int {
+	code, captured := ExitOK, 0
+	for _, r := range results {
+		if r.OK {
+			captured++
+		} else if code == ExitOK {
+			code = r.code
+		}
+	}
+	if gf.JSON {
+		if c := emitJSONPayload(gf, cmd, "captures", map[string]any{"results": results, countKey: captured, "failed": len(results) - captured}); c != ExitOK {
+			return c
+		}
+		return code
+	}
+	if !gf.Quiet {
+		for _, r := range results {
+			b, _ := json.Marshal(r)
+			fmt.Println(string(b))
+		}
+	}
+	if captured < len(results) {
+		fmt.Fprintf(os.Stderr, "%s: %d of %d line(s) failed\n", cmd, len(results)-captured, len(results))
+	}
+	return code
+}
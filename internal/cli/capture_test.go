@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// withStdin points os.Stdin at a file holding input for the rest of the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = orig
+		_ = f.Close()
+	})
+}
+
+func TestCaptureParseEmail(t *testing.T) {
+	ws := newTestWorkspace(t)
+	root := []string{"--root", ws.Root, "--quiet"}
+
+	withStdin(t, "From: Bob <bob@example.com>\r\n"+
+		"Subject: Re: Renew the domain\r\n"+
+		"Message-ID: <renew@example.com>\r\n"+
+		"\r\n"+
+		"It expires on Friday.\r\n"+
+		"> earlier thread\r\n")
+	if code := Run(append(root, "capture", "--parse", "email", "--project", "Work")); code != ExitOK {
+		t.Fatalf("expected ExitOK, got %d", code)
+	}
+	tasks, err := ws.ListTasks(store.ListFilter{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected one task, got %d", len(tasks))
+	}
+	task := tasks[0]
+	if task.Title != "Renew the domain" || task.ExternalID != "renew@example.com" || !strings.Contains(strings.Join(task.Tags, ","), "from:bob@example.com") {
+		t.Fatalf("unexpected task: %+v", task.TaskMeta)
+	}
+	if full, err := ws.GetTaskByPrefix(task.ID); err != nil || full.Body == "" || strings.Contains(full.Body, "earlier thread") {
+		t.Fatalf("expected the body without the quote, got %q (%v)", full.Body, err)
+	}
+
+	// The same message again is the same task.
+	withStdin(t, "Subject: Renew the domain\nMessage-ID: <renew@example.com>\n\nAgain\n")
+	if code := Run(append(root, "capture", "--parse", "email", "--project", "Work")); code != ExitOK {
+		t.Fatalf("expected ExitOK for a repeated message, got %d", code)
+	}
+
+	for name, input := range map[string]string{"empty": "", "not an email": "just a line\n"} {
+		withStdin(t, input)
+		if code := Run(append(root, "capture", "--parse", "email", "--project", "Work")); code != ExitUsage {
+			t.Fatalf("%s: expected ExitUsage, got %d", name, code)
+		}
+	}
+	withStdin(t, "Subject: Title\n\nbody\n")
+	if code := Run(append(root, "capture", "--parse", "email", "Also a title")); code != ExitUsage {
+		t.Fatalf("expected ExitUsage with capture text, got %d", code)
+	}
+	if tasks, _ := ws.ListTasks(store.ListFilter{All: true}); len(tasks) != 1 {
+		t.Fatalf("expected no further tasks, got %d", len(tasks))
+	}
+}
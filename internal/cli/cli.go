@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -18,11 +19,15 @@ import (
 
 // Exit codes
 const (
-	ExitOK       = 0
-	ExitUsage    = 2
-	ExitNotFound = 3
-	ExitConflict = 4
-	ExitInternal = 10
+	ExitOK          = 0
+	ExitUsage       = 2
+	ExitNotFound    = 3
+	ExitConflict    = 4
+	ExitLocked      = 5
+	ExitReadOnly    = 6
+	ExitRateLimited = 7
+	ExitInvalid     = 8
+	ExitInternal    = 10
 )
 
 type GlobalFlags struct {
@@ -32,12 +37,22 @@ type GlobalFlags struct {
 	Plain         bool
 	ASCII         bool
 	Quiet         bool
+	Silent        bool
 	Verbose       bool
 	StdoutJSON    bool
 	StdoutNDJSON  bool
 	ExportDir     string
 	ExportBaseTag string
 	Format        string
+	// NoLock disables the workspace lock (--no-lock).
+	NoLock bool
+	// LockTimeout is how long to wait for the workspace lock (--lock-timeout).
+	LockTimeout time.Duration
+	// Now pins the clock for this invocation (--now or TASKER_NOW); zero
+	// means the system clock.
+	Now time.Time
+	// RootSource says where Root came from (see defaultRoot).
+	RootSource string
 }
 
 func reorderFlags(args []string, takesValue map[string]bool) []string {
@@ -114,7 +129,11 @@ func resolveProject(ws *store.Workspace, project string) string {
 	return ""
 }
 
-func resolveOpenOnly(ws *store.Workspace, openFlag bool, allFlag bool) bool {
+// The resolve* helpers below pick a setting from the flag, then the
+// environment, then the defaults of the project the command is scoped to
+// (see store.ProjectDefaults), then the agent config.
+
+func resolveOpenOnly(ws *store.Workspace, project string, openFlag bool, allFlag bool) bool {
 	if allFlag {
 		return false
 	}
@@ -124,26 +143,32 @@ func resolveOpenOnly(ws *store.Workspace, openFlag bool, allFlag bool) bool {
 	if v, ok := envBool("TASKER_OPEN_ONLY"); ok {
 		return v
 	}
+	if d := ws.DefaultsFor(project); d.OpenOnly != nil {
+		return *d.OpenOnly
+	}
 	if ac := agentConfig(ws); ac != nil && ac.OpenOnly {
 		return true
 	}
 	return false
 }
 
-func resolveWeekDays(ws *store.Workspace, daysFlag int) int {
+func resolveWeekDays(ws *store.Workspace, project string, daysFlag int) int {
 	if daysFlag > 0 {
 		return daysFlag
 	}
 	if v, ok := envInt("TASKER_WEEK_DAYS"); ok {
 		return v
 	}
+	if d := ws.DefaultsFor(project); d.WeekDays > 0 {
+		return d.WeekDays
+	}
 	if ac := agentConfig(ws); ac != nil && ac.WeekDays > 0 {
 		return ac.WeekDays
 	}
 	return 7
 }
 
-func resolveGroupBy(ws *store.Workspace, groupFlag string) string {
+func resolveGroupBy(ws *store.Workspace, project string, groupFlag string) string {
 	group := strings.ToLower(strings.TrimSpace(groupFlag))
 	if group != "" {
 		return group
@@ -151,6 +176,9 @@ func resolveGroupBy(ws *store.Workspace, groupFlag string) string {
 	if v := envString("TASKER_GROUP"); v != "" {
 		return strings.ToLower(v)
 	}
+	if d := ws.DefaultsFor(project); d.SummaryGroup != "" {
+		return d.SummaryGroup
+	}
 	if ac := agentConfig(ws); ac != nil && strings.TrimSpace(ac.SummaryGroup) != "" {
 		return strings.ToLower(strings.TrimSpace(ac.SummaryGroup))
 	}
@@ -206,6 +234,12 @@ func selectorFilter(ws *store.Workspace, project string, column string, status s
 	if err != nil {
 		return store.SelectorFilter{}, err
 	}
+	if err := checkProject(ws, project); err != nil {
+		return store.SelectorFilter{}, err
+	}
+	if err := checkColumn(ws, column); err != nil {
+		return store.SelectorFilter{}, err
+	}
 	return store.SelectorFilter{
 		Project:         resolveSelectorProject(ws, project),
 		Column:          strings.TrimSpace(column),
@@ -253,11 +287,36 @@ func ideaSelectorFilter(project string, scope string, match string) (store.IdeaS
 	}, nil
 }
 
-func handleMatchConflict(cmd string, err error) bool {
+// resolveTaskSelector resolves a task selector for cmd, reporting errors on
+// stderr and returning the exit code to use.
+func resolveTaskSelector(ws *store.Workspace, gf GlobalFlags, cmd string, selector string, filter store.SelectorFilter) (*store.Task, int) {
+	task, err := ws.GetTaskBySelectorFiltered(selector, filter)
+	if err == nil {
+		return task, ExitOK
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "%s: not found: %s\n", cmd, selector)
+		return nil, ExitNotFound
+	}
+	if errors.Is(err, store.ErrConflict) {
+		if handleMatchConflict(gf, cmd, err) {
+			return nil, ExitConflict
+		}
+		fmt.Fprintf(os.Stderr, "%s: ambiguous selector\n", cmd)
+		return nil, ExitConflict
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	return nil, ExitInternal
+}
+
+func handleMatchConflict(gf GlobalFlags, cmd string, err error) bool {
 	var mc *store.MatchConflictError
 	if !errors.As(err, &mc) {
 		return false
 	}
+	if gf.JSON || gf.NDJSON {
+		emitMatchConflict(gf, cmd, mc)
+	}
 	if len(mc.Matches) == 0 {
 		fmt.Fprintf(os.Stderr, "%s: ambiguous selector\n", cmd)
 		return true
@@ -282,6 +341,26 @@ func handleMatchConflict(cmd string, err error) bool {
 	return true
 }
 
+// emitMatchConflict writes the conflict as a structured error on stdout so
+// machine callers can pick a candidate (e.g. the only open one) directly.
+func emitMatchConflict(gf GlobalFlags, cmd string, mc *store.MatchConflictError) {
+	payload := map[string]any{
+		"error":      "conflict",
+		"command":    cmd,
+		"reason":     mc.Reason,
+		"exit_code":  ExitConflict,
+		"candidates": mc.Candidates(),
+	}
+	if gf.NDJSON {
+		b, _ := json.Marshal(payload)
+		fmt.Println(string(b))
+		return
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(payload)
+}
+
 func handleIdeaMatchConflict(cmd string, err error) bool {
 	var mc *store.IdeaMatchConflictError
 	if !errors.As(err, &mc) {
@@ -306,8 +385,36 @@ func handleIdeaMatchConflict(cmd string, err error) bool {
 	fmt.Fprintln(os.Stderr, "Tip: use a more specific selector, --scope/--project, or --match.")
 	return true
 }
+
+// silenceOutput points os.Stdout/os.Stderr at the null device until the
+// returned func is called. Used by --silent so only the exit code remains.
+func silenceOutput() func() {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return func() {}
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devNull, devNull
+	return func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		_ = devNull.Close()
+	}
+}
+
 func Run(args []string) int {
+	var restore func()
+	defer func() {
+		if restore != nil {
+			restore()
+		}
+	}()
+	// --silent comes from the global flag parser, so "--root --silent" is a
+	// root, not silence. The parser returns what it read before an error, so
+	// a --silent ahead of a bad flag still hides the error.
 	gf, rest, err := extractGlobalFlags(args)
+	if gf.Silent {
+		restore = silenceOutput()
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err.Error())
 		return ExitUsage
@@ -318,15 +425,131 @@ func Run(args []string) int {
 		return ExitUsage
 	}
 
-	cmd := rest[0]
-	cmdArgs := rest[1:]
-
 	ws, err := store.Open(gf.Root)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "tasker:", err)
 		return ExitInternal
 	}
+	if expanded, ok, err := expandAliases(ws.Config(), args); err != nil {
+		fmt.Fprintln(os.Stderr, "tasker:", err)
+		return ExitUsage
+	} else if ok {
+		// The expansion may carry global flags of its own (--format, --json).
+		args = expanded
+		root := gf.Root
+		gf, rest, err = extractGlobalFlags(args)
+		if gf.Silent && restore == nil {
+			restore = silenceOutput()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			return ExitUsage
+		}
+		if len(rest) == 0 {
+			printHelp()
+			return ExitUsage
+		}
+		if gf.Root != root {
+			if ws, err = store.Open(gf.Root); err != nil {
+				fmt.Fprintln(os.Stderr, "tasker:", err)
+				return ExitInternal
+			}
+		}
+	}
+	ws.ASCII = gf.ASCII
+	ws.NoLock = gf.NoLock
+	ws.LockTimeout = gf.LockTimeout
+	ws.Actor = invocationActor()
+	if !gf.Now.IsZero() {
+		store.SetNow(gf.Now)
+	}
+	setDueLocale(ws.Config())
+
+	cmd := rest[0]
+	cmdArgs := rest[1:]
 
+	started := time.Now()
+	mutating := isMutatingInvocation(cmd, cmdArgs)
+	code := runLocked(ws, gf, cmd, cmdArgs, mutating)
+	logInvocation(ws, gf, cmd, args, mutating, started, code)
+	return code
+}
+
+// runLocked dispatches cmd. A mutating invocation holds the workspace lock
+// from its first read to its last write (auto-exports and auto-commit
+// included), so two agents running tasker at once cannot interleave their
+// read-modify-write cycles; the second waits up to the lock timeout and then
+// fails with exit code 5.
+func runLocked(ws *store.Workspace, gf GlobalFlags, cmd string, cmdArgs []string, mutating bool) int {
+	if mutating && !opensEditor(cmd, cmdArgs) {
+		unlock, err := ws.Lock(ws.WriteLockTimeout())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "tasker:", err)
+			return lockExitCode(ws, ExitInternal)
+		}
+		defer unlock()
+	}
+	code := dispatch(ws, gf, cmd, cmdArgs)
+	code = lockExitCode(ws, code)
+	if mutating && code == ExitOK {
+		afterMutation(ws, gf, cmd, cmdArgs)
+	}
+	return code
+}
+
+// afterMutation runs the post-write hooks of a successful write: auto-archive,
+// auto-exports and auto-commit. The caller holds the workspace lock.
+func afterMutation(ws *store.Workspace, gf GlobalFlags, cmd string, cmdArgs []string) {
+	runAutoArchive(ws, gf, cmd)
+	refreshAutoExports(ws, gf)
+	autoCommit(ws, cmd, cmdArgs)
+}
+
+// runWrite runs a write made on behalf of command (e.g. "idea", "add") by a
+// long-running server such as serve or mcp the way Run runs the command
+// itself: under the workspace lock, followed by the post-write hooks, and
+// recorded in the operations log. via names the request in the log entry.
+func runWrite(ws *store.Workspace, gf GlobalFlags, command []string, via string, write func() error) error {
+	started := time.Now()
+	cmd, cmdArgs := command[0], command[1:]
+	err := func() error {
+		unlock, err := ws.Lock(ws.WriteLockTimeout())
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if err := write(); err != nil {
+			return err
+		}
+		afterMutation(ws, gf, cmd, cmdArgs)
+		return nil
+	}()
+	logArgs := append(append([]string{}, cmdArgs...), via)
+	logInvocation(ws, gf, cmd, logArgs, true, started, writeExitCode(err))
+	return err
+}
+
+// writeExitCode is the exit code the CLI would have given a write failing
+// with err.
+func writeExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, store.ErrLocked):
+		return ExitLocked
+	case errors.Is(err, store.ErrReadOnly):
+		return ExitReadOnly
+	case errors.Is(err, errBadRequest), errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrConflict):
+		return ExitConflict
+	}
+	return ExitInternal
+}
+
+func dispatch(ws *store.Workspace, gf GlobalFlags, cmd string, cmdArgs []string) int {
 	switch cmd {
 	case "help", "--help", "-h":
 		printHelp()
@@ -359,6 +582,58 @@ func Run(args []string) int {
 		return cmdDone(ws, gf, cmdArgs)
 	case "note":
 		return cmdNote(ws, gf, cmdArgs)
+	case "subtask", "checklist":
+		return cmdSubtask(ws, gf, cmdArgs)
+	case "dep", "deps":
+		return cmdDep(ws, gf, cmdArgs)
+	case "link":
+		return cmdLink(ws, gf, cmdArgs)
+	case "unlink":
+		return cmdUnlink(ws, gf, cmdArgs)
+	case "attach":
+		return cmdAttach(ws, gf, cmdArgs)
+	case "clone":
+		return cmdClone(ws, gf, cmdArgs)
+	case "links":
+		return cmdLinks(ws, gf, cmdArgs)
+	case "alias":
+		return cmdAlias(ws, gf, cmdArgs)
+	case "start":
+		return cmdStart(ws, gf, cmdArgs)
+	case "stop":
+		return cmdStop(ws, gf, cmdArgs)
+	case "log":
+		return cmdLog(ws, gf, cmdArgs)
+	case "timesheet":
+		return cmdTimesheet(ws, gf, cmdArgs)
+	case "rm", "delete":
+		return cmdRm(ws, gf, cmdArgs)
+	case "archive":
+		return cmdArchive(ws, gf, cmdArgs)
+	case "escalate":
+		return cmdEscalate(ws, gf, cmdArgs)
+	case "find":
+		return cmdFind(ws, gf, cmdArgs)
+	case "tag", "tags":
+		return cmdTag(ws, gf, cmdArgs)
+	case "validate":
+		return cmdValidate(ws, gf, cmdArgs)
+	case "trash":
+		return cmdTrash(ws, gf, cmdArgs)
+	case "undo":
+		return cmdUndo(ws, gf, cmdArgs)
+	case "open":
+		return cmdOpen(ws, gf, cmdArgs)
+	case "edit":
+		return cmdEdit(ws, gf, cmdArgs)
+	case "env":
+		return cmdEnv(ws, gf, cmdArgs)
+	case "_complete":
+		return cmdComplete(ws, gf, cmdArgs)
+	case "exitcodes", "exit-codes":
+		return cmdExitCodes(gf, cmdArgs)
+	case "index":
+		return cmdIndex(ws, gf, cmdArgs)
 	case "board":
 		return cmdBoard(ws, gf, cmdArgs)
 	case "today":
@@ -367,8 +642,46 @@ func Run(args []string) int {
 		return cmdTasks(ws, gf, cmdArgs)
 	case "week", "agenda", "upcoming":
 		return cmdAgenda(ws, gf, cmdArgs)
+	case "scheduled":
+		return cmdScheduled(ws, gf, cmdArgs)
+	case "export":
+		return cmdExport(ws, gf, cmdArgs)
+	case "import":
+		return cmdImport(ws, gf, cmdArgs)
+	case "sync":
+		return cmdSync(ws, gf, cmdArgs)
+	case "brief":
+		return cmdBrief(ws, gf, cmdArgs)
+	case "health":
+		return cmdHealth(ws, gf, cmdArgs)
+	case "du":
+		return cmdDu(ws, gf, cmdArgs)
+	case "report":
+		return cmdReport(ws, gf, cmdArgs)
+	case "history":
+		return cmdHistory(ws, gf, cmdArgs)
+	case "doctor":
+		return cmdDoctor(ws, gf, cmdArgs)
+	case "apply":
+		return cmdApply(ws, gf, cmdArgs)
+	case "diff":
+		return cmdDiff(ws, gf, cmdArgs)
+	case "snapshot":
+		return cmdSnapshot(ws, gf, cmdArgs)
+	case "metrics":
+		return cmdMetrics(ws, gf, cmdArgs)
+	case "serve":
+		return cmdServe(ws, gf, cmdArgs)
+	case "mcp":
+		return cmdMCP(ws, gf, cmdArgs)
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown command: %s\n\n", cmd)
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", cmd)
+		if hint := didYouMean(cmd, commandNames); hint != "" {
+			fmt.Fprintln(os.Stderr, hint)
+			fmt.Fprintln(os.Stderr, "Run `tasker help` for usage.")
+			return ExitUsage
+		}
+		fmt.Fprintln(os.Stderr)
 		printHelp()
 		return ExitUsage
 	}
@@ -381,7 +694,7 @@ Usage:
   tasker [global flags] <command> [args]
 
 Global flags:
-  --root <path>    Store root (default: ~/.tasker or TASKER_ROOT)
+  --root <path>    Store root (default: TASKER_ROOT, ~/.tasker, or $XDG_DATA_HOME/tasker)
   --format <f>     Output format: human|telegram (default: human)
   --json           Write JSON output to <root>/exports (no stdout JSON)
   --ndjson         Write NDJSON output to <root>/exports (no stdout NDJSON)
@@ -389,9 +702,13 @@ Global flags:
   --stdout-ndjson  Allow NDJSON to stdout (debug only)
   --export-dir     Override export directory (default: <root>/exports)
   --plain          TSV output
-  --ascii          ASCII rendering for board output
+  --ascii          ASCII rendering for board output and note separators
   --quiet
+  --silent         No output at all; communicate via exit code only
   --verbose
+  --no-lock        Skip the workspace lock (only safe with a single writer)
+  --lock-timeout   How long writes wait for the workspace lock (default: 10s; 0 fails at once)
+  --now <time>     Pin the clock (RFC3339 or YYYY-MM-DD; default: TASKER_NOW, else system time)
 
 Commands:
   init [--project <name>]
@@ -399,35 +716,113 @@ Commands:
   workflow init [--workspace <path>] [--file <name>] [--runs-dir <path>] [--templates-dir <path>]
   workflow prompts init [--prompts-dir <path>] [--night-shift <path>] [--proactive <path>]
   workflow schedule init [--window <dur>] [--heartbeat-every <dur>] [--heartbeat-prompt <path>]
+  alias add <name> "<command...>" | alias ls | alias rm <name>
   config show
   config set <key> <value>
+  config edit
+  config columns [ls|add|rm|rename|reorder] [--project <name>] ...
   project add "<name>"
-  project ls
+  project ls [--sort name|activity] [--all]
+  project set <name> <column|open_only|week_days|summary_group> <value|unset>
+  project rename <name> "<new name>"
+  project archive <name> | project unarchive <name>
+  project rm <name> [--force]
+  project export <name> [--out <file.tgz|->]
+  project import <bundle.tgz|->
   idea add "<title>" [--project <name>] [--body <text>] [--tag <t>...] [--stdin]
   idea add --text "<title | details | #tag>" [--project <name>] [--stdin]
   idea capture "<title | details | #tag>" [--project <name>] [--stdin]
-  idea ls [--scope root|project|all] [--project <name>] [--tag <t>] [--search <q>]
-  idea show [--scope root|project|all] [--project <name>] [--match <m>] <selector...>
+  idea ls [--scope root|project|all] [--project <name>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--search <q>] [--unscored] [--sort updated|score|title] [--limit N] [--offset N] [--deleted|--archived]
+  idea show [--scope root|project|all] [--project <name>] [--match <m>] [--full] [--page N] <selector...>
   idea resolve [--scope root|project|all] [--project <name>] [--match <m>] <selector...>
   idea note add [--scope root|project|all] [--project <name>] [--match <m>] <selector...> -- <text...>
   idea append [--scope root|project|all] [--project <name>] [--match <m>] <selector...> -- <text...>
-  idea promote [--scope root|project|all] [--project <name>] [--to-project <name>] [--column <col>] [--due <date>] [--priority <p>] [--tag <t>...] [--link] [--delete] <selector...>
-  add "<title>" --project <name> [--column <col>] [--due <date>] [--priority <p>] [--tag <t>...] [--desc <text>|--details <text>]
+  idea edit [--scope root|project|all] [--project <name>] [--match <m>] [--set title|body|tags=<value>...] [--add-tag <t>...] [--remove-tag <t>...] <selector...>
+  idea tag add|rm [--scope root|project|all] [--project <name>] [--match <m>] <selector> <tag>...
+  idea archive [--scope root|project|all] [--project <name>] [--match <m>] <selector...>
+  idea unarchive [--scope root|project|all] [--project <name>] [--match <m>] <selector...>
+  idea rm [--scope root|project|all] [--project <name>] [--match <m>] <selector...>
+  idea score [--scope root|project|all] [--project <name>] [--match <m>] --impact 1-5 --effort 1-5 <selector...>
+  idea triage [--scope root|project|all] [--project <name>] [--tag <t>...] [--limit N]
+  idea promote [--scope root|project|all] [--project <name>] [--to-project <name>] [--column <col>] [--due <date>] [--priority <p>] [--tag <t>...] [--link] [--delete] [--dry-run] <selector...>
+  add "<title>" --project <name> [--column <col>] [--due <date>] [--start <date>] [--priority <p>] [--tag <t>...] [--desc <text>|--details <text>] [--repeat <rule>] [--ack full|minimal] [--create-project] [--external-id <key>]
+  add --file <draft.md|-> [--project <name>] [--column <col>] ...
   add --text "<title | details | due 2026-01-23>" --project <name> [--column <col>] [--priority <p>] [--tag <t>...]
-  capture "<title | details | due 2026-01-23>" [--project <name>] [--column <col>] [--priority <p>] [--tag <t>...]
-  ls [--project <name>] [--column <col>] [--status <s>] [--tag <t>] [--search <q>] [--all]
-  show [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...>
-  resolve [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...>
-  mv [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...> <column>
-  done [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...>
+  add --batch <file|-> [--project <name>] [--column <col>] [--priority <p>] [--tag <t>...] ...
+  capture "<title | details | due 2026-01-23>" [--project <name>] [--column <col>] [--priority <p>] [--tag <t>...] [--repeat <rule>] [--ack full|minimal] [--create-project] [--external-id <key>]
+  capture --parse email [--project <name>] [--column <col>] [--priority <p>] [--tag <t>...] < message.eml
+  capture --lines <file|-> [--as task|idea] [--project <name>] [--tag <t>...]
+  ls [--project <name|glob>...] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--search <q>] [--query <expr>] [--all] [--due-before <date>] [--due-after <date>] [--overdue] [--due-today] [--sort <keys> [--reverse]] [--limit N] [--offset N] [--archive-bundles] [--deleted]
+  find [--project <name>] [--kind task|idea] [--all] [--match <m>] <query...>
+  tag ls [--project <name>]
+  tag rename <old> <new> [--dry-run]
+  tag rm <tag> [--from-done-only] [--dry-run]
+  show [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] [--with-checklist] <selector...>
+  resolve [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] [--overdue|--due-today|--due-before <date>|--due-after <date>] <selector...>
+  mv [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--force] [--all-matches [--dry-run]] <selector...> <column>
+  done [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--force] [--all-matches [--dry-run]] <selector...>
+  open [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...>
+  edit [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--all-matches [--dry-run]] [--set <key>=<value>...] [--add-tag <t>...] [--remove-tag <t>...] <selector...>
   note add [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...> -- <text...>
-  board --project <name> [--open|--all]
-  today [--project <name>] [--open|--all] [--group project|column|none] [--totals]
-  tasks [today|week] [--project <name>] [--days N] [--open|--all] [--group project|column|none] [--totals]
+  rm [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...>
+  clone [--project <name>] [--column <col>] [--title <title>] [--reset-due] [--no-notes] [--match <m>] <selector...>
+  trash ls | trash restore <task-or-idea-id>
+  archive [--project <name|glob>] [--done-older-than <age>] [--dry-run]
+  escalate [--project <name|glob>] [--dry-run]
+  archive compact [--project <name|glob>] [--dry-run]
+  history [--since <age|date>] [--project <name>] [--limit <n>] [<selector...>]
+  undo [--dry-run] [--force] | undo --list
+  subtask add [--project <name>|none|all] [--match <m>] <selector...> -- <text...>
+  subtask done|undo [--project <name>|none|all] [--match <m>] <selector...> <n>
+  subtask ls [--project <name>|none|all] [--match <m>] <selector...>
+  dep add|rm <selector...> --blocks <selector> | --blocked-by <selector>
+  dep ls <selector...>
+  dep graph [--project <name>]
+  link <selector-a> <selector-b> [--type relates|duplicates|follows] [--idea]
+  unlink <selector-a> <selector-b> [--idea]
+  links <selector...>
+  attach <selector...> <file> [--project <name>]
+  start <selector...> | stop [<selector...>]
+  log <selector...> --hours <n> [--date <date>] [-- <text...>]
+  timesheet [--week|--days N] [--project <name>]
+  index rebuild|status
+  board --project <name> [--open|--all] [--per-column <n>] [--hide-scheduled] [--watch [--interval <d>]]
+  today [--project <name|glob>...] [--open|--all] [--group project|column|none] [--totals] [--date <day>|--yesterday] [--sort <keys> [--reverse]] [--limit N] [--offset N] [--max-per-group N] [--watch [--interval <d>]]
+  tasks [today|week] [--project <name|glob>...] [--days N] [--open|--all] [--group project|column|none] [--totals] [--date <day>|--yesterday] [--sort <keys> [--reverse]] [--limit N] [--offset N] [--max-per-group N]
   summary [today|week] [--project <name>] [--days N] [--open|--all] [--group project|column|none] [--totals]
-  week [--project <name>] [--days N] [--open|--all] [--group project|column|none] [--totals]
+  week [--project <name|glob>...] [--days N] [--open|--all] [--group project|column|none|project,day|column,day] [--totals] [--date <day>] [--sort <keys> [--reverse]] [--limit N] [--offset N] [--max-per-group N] [--watch [--interval <d>]]
   agenda [--project <name>] [--days N] [--open|--all] [--group project|column|none] [--totals]
   upcoming [--project <name>] [--days N] [--open|--all] [--group project|column|none] [--totals]
+  scheduled [--project <name>] [--days N]
+  export metrics [--out <file>|-]
+  export ical [--project <name>] [--days N] [--all] [--as event|todo|both] [--out <file>|-]
+  export todotxt [--project <name>] [--all] [--out <file>|-]
+  import todotxt <file|-> [--project <name>] [--dry-run]
+  export csv [--project <name>] [--all] [--map field=Header,...] [--out <file>|-]
+  export obsidian [--project <name>] [--all] [--out <file>|-]
+  export --using <template> [--project <name>] [--all] [--out <file>|-]
+  export --list
+  import csv <file|-> [--project <name>] [--map field=Header,...] [--dry-run]
+  sync github --repo <owner/name> [--project <name>] [--create-project] [--dry-run]
+  sync git init [--remote <url>] [--no-auto-commit]
+  sync git [--remote <name>] [--no-push] [--dry-run]
+  snapshot create "<name>"
+  snapshot ls
+  snapshot restore [--no-backup] <name>
+  snapshot rm <name>
+  diff [--tasks|--ideas] <other-root|export.json>
+  apply [--dry-run] [--timeout <dur>] <ops.json|->
+  brief [--project <name|glob>...] [--max-chars N]
+  health
+  validate [--strict]
+  du [--large <size>]
+  report burndown|cfd [--project <name|glob>...] [--days N]
+  doctor [--rollback|--replay] [--fix]
+  metrics
+  serve [--addr <host:port>]
+  mcp
+  env
+  exitcodes
 
 Columns:
   inbox|todo|doing|blocked|done|archive
@@ -439,16 +834,13 @@ func extractGlobalFlags(args []string) (GlobalFlags, []string, error) {
 	gf := GlobalFlags{}
 	gf.Format = "human"
 
-	// Default root from env or home.
-	if env := os.Getenv("TASKER_ROOT"); env != "" {
-		gf.Root = env
-	} else {
-		home, _ := os.UserHomeDir()
-		if home != "" {
-			gf.Root = filepath.Join(home, ".tasker")
-		} else {
-			gf.Root = ".tasker"
+	gf.Root, gf.RootSource = defaultRoot()
+	if env := strings.TrimSpace(os.Getenv("TASKER_NOW")); env != "" {
+		now, err := parseNow(env)
+		if err != nil {
+			return gf, nil, fmt.Errorf("invalid TASKER_NOW %q (use RFC3339 like 2026-01-23T09:00:00Z, or YYYY-MM-DD)", env)
 		}
+		gf.Now = now
 	}
 
 	out := make([]string, 0, len(args))
@@ -466,6 +858,7 @@ func extractGlobalFlags(args []string) (GlobalFlags, []string, error) {
 				return gf, nil, errors.New("--root requires a value")
 			}
 			gf.Root = args[i+1]
+			gf.RootSource = "--root"
 			skip = 1
 		case "--format":
 			if i+1 >= len(args) {
@@ -493,8 +886,37 @@ func extractGlobalFlags(args []string) (GlobalFlags, []string, error) {
 			gf.ASCII = true
 		case "--quiet":
 			gf.Quiet = true
+		case "--silent":
+			gf.Silent = true
+			gf.Quiet = true
 		case "--verbose":
 			gf.Verbose = true
+		case "--no-lock":
+			gf.NoLock = true
+		case "--lock-timeout":
+			if i+1 >= len(args) {
+				return gf, nil, errors.New("--lock-timeout requires a value")
+			}
+			d, err := time.ParseDuration(args[i+1])
+			if err != nil || d < 0 {
+				return gf, nil, fmt.Errorf("invalid --lock-timeout %q (use a duration like 30s)", args[i+1])
+			}
+			if d == 0 {
+				// The workspace reads zero as the default.
+				d = store.LockNoWait
+			}
+			gf.LockTimeout = d
+			skip = 1
+		case "--now":
+			if i+1 >= len(args) {
+				return gf, nil, errors.New("--now requires a value")
+			}
+			now, err := parseNow(args[i+1])
+			if err != nil {
+				return gf, nil, fmt.Errorf("invalid --now %q (use RFC3339 like 2026-01-23T09:00:00Z, or YYYY-MM-DD)", args[i+1])
+			}
+			gf.Now = now
+			skip = 1
 		default:
 			out = append(out, a)
 		}
@@ -520,6 +942,21 @@ func extractGlobalFlags(args []string) (GlobalFlags, []string, error) {
 	return gf, out, nil
 }
 
+// parseNow reads a --now/TASKER_NOW value: an RFC3339 time, a local
+// "YYYY-MM-DDTHH:MM[:SS]" time, or a date (midnight UTC).
+func parseNow(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Parse("2006-01-02", value)
+}
+
 func normalizeFormat(format string) (string, error) {
 	format = strings.ToLower(strings.TrimSpace(format))
 	switch format {
@@ -565,7 +1002,7 @@ func cmdOnboarding(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker config <show|set> ...")
+		fmt.Fprintln(os.Stderr, "Usage: tasker config <show|set|columns|edit> ...")
 		return ExitUsage
 	}
 	sub := args[0]
@@ -574,8 +1011,12 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		// handled below
 	case "set":
 		return cmdConfigSet(ws, gf, args[1:])
+	case "edit":
+		return cmdConfigEdit(ws, gf, args[1:])
+	case "columns", "column":
+		return cmdConfigColumns(ws, gf, args[1:])
 	default:
-		fmt.Fprintln(os.Stderr, "Usage: tasker config <show|set> ...")
+		fmt.Fprintln(os.Stderr, "Usage: tasker config <show|set|columns|edit> ...")
 		return ExitUsage
 	}
 
@@ -588,6 +1029,7 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		"root":        ws.Root,
 		"config_path": cfgPath,
 		"exists":      exists,
+		"etag":        ws.ConfigETag(),
 		"config":      cfg,
 	}
 
@@ -632,6 +1074,8 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintf(w, "root\t%s\n", ws.Root)
 		fmt.Fprintf(w, "config_path\t%s\n", cfgPath)
 		fmt.Fprintf(w, "exists\t%t\n", exists)
+		fmt.Fprintf(w, "version\t%d\n", cfg.Version)
+		fmt.Fprintf(w, "etag\t%s\n", ws.ConfigETag())
 		if cfg.Agent != nil {
 			fmt.Fprintf(w, "agent.require_explicit\t%t\n", cfg.Agent.RequireExplicit)
 			fmt.Fprintf(w, "agent.default_project\t%s\n", cfg.Agent.DefaultProject)
@@ -640,9 +1084,45 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			fmt.Fprintf(w, "agent.open_only\t%t\n", cfg.Agent.OpenOnly)
 			fmt.Fprintf(w, "agent.summary_group\t%s\n", cfg.Agent.SummaryGroup)
 			fmt.Fprintf(w, "agent.summary_totals\t%t\n", cfg.Agent.SummaryTotals)
+			fmt.Fprintf(w, "agent.auto_archive_days\t%d\n", cfg.Agent.AutoArchiveDays)
 		} else {
 			fmt.Fprintf(w, "agent\t(none)\n")
 		}
+		if cfg.Log != nil {
+			fmt.Fprintf(w, "log.enabled\t%t\n", cfg.Log.Enabled)
+			fmt.Fprintf(w, "log.max_bytes\t%d\n", cfg.Log.MaxBytes)
+			fmt.Fprintf(w, "log.max_files\t%d\n", cfg.Log.MaxFiles)
+		}
+		fmt.Fprintf(w, "projects.auto_create\t%t\n", cfg.AutoCreateProjects())
+		fmt.Fprintf(w, "notes.separator\t%s\n", cfg.NoteSeparator())
+		fmt.Fprintf(w, "ideas.journal\t%s\n", ideaJournalValue(cfg))
+		fmt.Fprintf(w, "sync.auto_commit\t%t\n", cfg.SyncAutoCommit())
+		fmt.Fprintf(w, "formats.telegram.max_chars\t%d\n", cfg.TelegramMaxChars())
+		fmt.Fprintf(w, "formats.telegram.detail_width\t%d\n", cfg.TelegramDetailWidth())
+		fmt.Fprintf(w, "formats.human.title_width\t%d\n", cfg.HumanTitleWidth())
+		fmt.Fprintf(w, "formats.human.snippet_width\t%d\n", cfg.HumanSnippetWidth())
+		fmt.Fprintf(w, "aging.enabled\t%t\n", cfg.AgingEnabled())
+		fmt.Fprintf(w, "aging.stale_days\t%d\n", cfg.StaleDays())
+		fmt.Fprintf(w, "escalation.after_days_overdue\t%s\n", store.FormatEscalationSteps(cfg.EscalationSteps()))
+		fmt.Fprintf(w, "agenda.due_soon\t%s\n", cfg.DueSoonHorizon())
+		fmt.Fprintf(w, "locale\t%s\n", cfg.LocaleID())
+		fmt.Fprintf(w, "ids.style\t%s\n", cfg.IDStyle())
+		for _, name := range cfg.AliasNames() {
+			fmt.Fprintf(w, "alias.%s\t%s\n", name, cfg.Aliases[name])
+		}
+		if cfg.Theme != nil {
+			fmt.Fprintf(w, "theme.icons\t%s\n", themeIconsValue(cfg))
+			for _, line := range themeOverrides(cfg.Theme) {
+				fmt.Fprintf(w, "%s\t%s\n", line[0], line[1])
+			}
+		}
+		if cfg.Exports != nil {
+			fmt.Fprintf(w, "exports.auto\t%s\n", strings.Join(cfg.Exports.Auto, ","))
+			fmt.Fprintf(w, "exports.format\t%s\n", cfg.Exports.Format)
+		}
+		for _, st := range cfg.Statuses {
+			fmt.Fprintf(w, "status.%s\topen_like=%t abbrev=%s\n", st.ID, st.OpenLike, cfg.StatusAbbrev(st.ID))
+		}
 		for _, c := range cfg.Columns {
 			fmt.Fprintf(w, "column.%s\tname=%s dir=%s status=%s\n", c.ID, c.Name, c.Dir, c.Status)
 		}
@@ -653,7 +1133,7 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	fmt.Println("Config")
 	fmt.Println("  Root:", ws.Root)
 	if exists {
-		fmt.Println("  Config file:", cfgPath)
+		fmt.Printf("  Config file: %s (version %d)\n", cfgPath, cfg.Version)
 	} else {
 		fmt.Println("  Config file:", cfgPath, "(not found; defaults shown)")
 	}
@@ -670,8 +1150,70 @@ func cmdConfig(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Printf("  open_only: %t\n", cfg.Agent.OpenOnly)
 		fmt.Printf("  summary_group: %s\n", cfg.Agent.SummaryGroup)
 		fmt.Printf("  summary_totals: %t\n", cfg.Agent.SummaryTotals)
+		fmt.Printf("  auto_archive_days: %d\n", cfg.Agent.AutoArchiveDays)
 	}
+	if cfg.Log != nil {
+		fmt.Println()
+		fmt.Println("Operations log:")
+		fmt.Printf("  enabled: %t\n", cfg.Log.Enabled)
+		fmt.Printf("  max_bytes: %d\n", cfg.Log.MaxBytes)
+		fmt.Printf("  max_files: %d\n", cfg.Log.MaxFiles)
+	}
+	fmt.Println()
+	fmt.Println("Projects:")
+	fmt.Printf("  auto_create: %t\n", cfg.AutoCreateProjects())
+	fmt.Println()
+	fmt.Println("Notes:")
+	fmt.Printf("  separator: %s\n", cfg.NoteSeparator())
 	fmt.Println()
+	fmt.Println("Formats:")
+	fmt.Printf("  telegram.max_chars: %d\n", cfg.TelegramMaxChars())
+	fmt.Printf("  telegram.detail_width: %d\n", cfg.TelegramDetailWidth())
+	fmt.Printf("  human.title_width: %d\n", cfg.HumanTitleWidth())
+	fmt.Printf("  human.snippet_width: %d\n", cfg.HumanSnippetWidth())
+	fmt.Println()
+	fmt.Println("Aging:")
+	fmt.Printf("  enabled: %t\n", cfg.AgingEnabled())
+	fmt.Printf("  stale_days: %d\n", cfg.StaleDays())
+	fmt.Println()
+	fmt.Println("Escalation:")
+	fmt.Printf("  after_days_overdue: %s\n", store.FormatEscalationSteps(cfg.EscalationSteps()))
+	fmt.Println()
+	fmt.Println("Agenda:")
+	fmt.Printf("  due_soon: %s\n", cfg.DueSoonHorizon())
+	fmt.Println()
+	fmt.Println("Locale:", cfg.LocaleID())
+	fmt.Println("ID style:", cfg.IDStyle())
+	fmt.Println()
+	if names := cfg.AliasNames(); len(names) > 0 {
+		fmt.Println("Aliases:")
+		for _, name := range names {
+			fmt.Printf("  %s = %s\n", name, cfg.Aliases[name])
+		}
+		fmt.Println()
+	}
+	if cfg.Theme != nil {
+		fmt.Println("Theme:")
+		fmt.Printf("  icons: %s\n", themeIconsValue(cfg))
+		for _, line := range themeOverrides(cfg.Theme) {
+			fmt.Printf("  %s: %s\n", strings.TrimPrefix(line[0], "theme."), line[1])
+		}
+		fmt.Println()
+	}
+	if cfg.Exports != nil && len(cfg.Exports.Auto) > 0 {
+		format, _ := normalizeExportFormat(cfg.Exports.Format)
+		fmt.Println("Auto exports:")
+		fmt.Printf("  auto: %s\n", strings.Join(cfg.Exports.Auto, ", "))
+		fmt.Printf("  format: %s\n", format)
+		fmt.Println()
+	}
+	if len(cfg.Statuses) > 0 {
+		fmt.Println("Statuses:")
+		for _, st := range cfg.Statuses {
+			fmt.Printf("  %s: open_like=%t abbrev=%s\n", st.ID, st.OpenLike, cfg.StatusAbbrev(st.ID))
+		}
+		fmt.Println()
+	}
 	fmt.Println("Columns:")
 	for _, c := range cfg.Columns {
 		fmt.Printf("  %s: %s (dir=%s, status=%s)\n", c.ID, c.Name, c.Dir, c.Status)
@@ -684,12 +1226,68 @@ func cmdConfigSet(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "Usage: tasker config set <key> <value>")
 		return ExitUsage
 	}
+	// Hold the lock from the read to the save so parallel sets of different
+	// keys all land instead of the last one winning.
+	unlock, err := ws.LockConfig(ws.WriteLockTimeout())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config set:", err)
+		return ExitInternal
+	}
+	defer unlock()
 	key := strings.ToLower(strings.TrimSpace(args[0]))
 	value := strings.TrimSpace(strings.Join(args[1:], " "))
+	if name, ok := strings.CutPrefix(key, "alias."); ok {
+		return configSetAlias(ws, gf, name, args[1:])
+	}
 	cfg := ws.Config()
-	if cfg.Agent == nil {
+	if cfg.Agent == nil && strings.HasPrefix(key, "agent.") {
 		cfg.Agent = &store.AgentConfig{}
 	}
+	if cfg.Log == nil && strings.HasPrefix(key, "log.") {
+		cfg.Log = &store.LogConfig{}
+	}
+	if cfg.Projects == nil && strings.HasPrefix(key, "projects.") {
+		cfg.Projects = &store.ProjectsConfig{}
+	}
+	if cfg.Notes == nil && strings.HasPrefix(key, "notes.") {
+		cfg.Notes = &store.NotesConfig{}
+	}
+	if cfg.Sync == nil && strings.HasPrefix(key, "sync.") {
+		cfg.Sync = &store.SyncConfig{}
+	}
+	if cfg.Ideas == nil && strings.HasPrefix(key, "ideas.") {
+		cfg.Ideas = &store.IdeasConfig{}
+	}
+	if cfg.Exports == nil && strings.HasPrefix(key, "exports.") {
+		cfg.Exports = &store.ExportsConfig{}
+	}
+	if cfg.Aging == nil && strings.HasPrefix(key, "aging.") {
+		cfg.Aging = &store.AgingConfig{}
+	}
+	if cfg.Agenda == nil && strings.HasPrefix(key, "agenda.") {
+		cfg.Agenda = &store.AgendaConfig{}
+	}
+	if cfg.IDs == nil && strings.HasPrefix(key, "ids.") {
+		cfg.IDs = &store.IDsConfig{}
+	}
+	if strings.HasPrefix(key, "formats.") {
+		if cfg.Formats == nil {
+			cfg.Formats = &store.FormatsConfig{}
+		}
+		if cfg.Formats.Telegram == nil && strings.HasPrefix(key, "formats.telegram.") {
+			cfg.Formats.Telegram = &store.TelegramFormatConfig{}
+		}
+		if cfg.Formats.Human == nil && strings.HasPrefix(key, "formats.human.") {
+			cfg.Formats.Human = &store.HumanFormatConfig{}
+		}
+	}
+
+	if id, ok := strings.CutPrefix(key, "status."); ok {
+		return configSetStatus(ws, gf, id, value)
+	}
+	if strings.HasPrefix(key, "theme.") {
+		return configSetTheme(ws, gf, key, value)
+	}
 
 	switch key {
 	case "agent.require_explicit":
@@ -740,14 +1338,327 @@ func cmdConfigSet(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return configSetInvalid("agent.summary_totals", value)
 		}
 		cfg.Agent.SummaryTotals = v
+	case "agent.auto_archive_days":
+		switch strings.ToLower(value) {
+		case "off", "none", "null", "":
+			cfg.Agent.AutoArchiveDays = 0
+		default:
+			n, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(value), "d"))
+			if err != nil || n < 0 {
+				return configSetInvalid("agent.auto_archive_days", value)
+			}
+			cfg.Agent.AutoArchiveDays = n
+		}
+	case "log.enabled":
+		v, ok := parseBool(value)
+		if !ok {
+			return configSetInvalid("log.enabled", value)
+		}
+		cfg.Log.Enabled = v
+	case "log.max_bytes":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 1 {
+			return configSetInvalid("log.max_bytes", value)
+		}
+		cfg.Log.MaxBytes = n
+	case "log.max_files":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return configSetInvalid("log.max_files", value)
+		}
+		cfg.Log.MaxFiles = n
+	case "projects.auto_create":
+		v, ok := parseBool(value)
+		if !ok {
+			return configSetInvalid("projects.auto_create", value)
+		}
+		cfg.Projects.AutoCreate = &v
+	case "notes.separator":
+		switch strings.ToLower(value) {
+		case "", "none", "null", "default":
+			cfg.Notes.Separator = ""
+		default:
+			cfg.Notes.Separator = value
+		}
+	case "sync.auto_commit":
+		v, ok := parseBool(value)
+		if !ok {
+			return configSetInvalid("sync.auto_commit", value)
+		}
+		cfg.Sync.AutoCommit = v
+	case "ideas.journal":
+		switch strings.ToLower(value) {
+		case "daily":
+			cfg.Ideas.Journal = "daily"
+		case "", "none", "off", "false":
+			cfg.Ideas.Journal = ""
+		default:
+			return configSetInvalid("ideas.journal", value)
+		}
+	case "formats.telegram.max_chars":
+		n, ok := parseFormatWidth(value, 4096)
+		if !ok {
+			return configSetInvalid("formats.telegram.max_chars", value)
+		}
+		cfg.Formats.Telegram.MaxChars = n
+	case "formats.telegram.detail_width":
+		n, ok := parseFormatWidth(value, 0)
+		if !ok {
+			return configSetInvalid("formats.telegram.detail_width", value)
+		}
+		cfg.Formats.Telegram.DetailWidth = n
+	case "formats.human.title_width":
+		n, ok := parseFormatWidth(value, 0)
+		if !ok {
+			return configSetInvalid("formats.human.title_width", value)
+		}
+		cfg.Formats.Human.TitleWidth = n
+	case "formats.human.snippet_width":
+		n, ok := parseFormatWidth(value, 0)
+		if !ok {
+			return configSetInvalid("formats.human.snippet_width", value)
+		}
+		cfg.Formats.Human.SnippetWidth = n
+	case "aging.enabled":
+		v, ok := parseBool(value)
+		if !ok {
+			return configSetInvalid("aging.enabled", value)
+		}
+		cfg.Aging.Enabled = &v
+	case "agenda.due_soon":
+		switch strings.ToLower(value) {
+		case "default", "":
+			cfg.Agenda.DueSoon = ""
+		default:
+			if _, err := store.ParseHorizon(value); err != nil {
+				return configSetInvalid("agenda.due_soon", value)
+			}
+			cfg.Agenda.DueSoon = strings.ToLower(value)
+		}
+	case "aging.stale_days":
+		switch strings.ToLower(value) {
+		case "default":
+			cfg.Aging.StaleDays = 0
+		case "off", "none", "never":
+			cfg.Aging.StaleDays = -1
+		default:
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return configSetInvalid("aging.stale_days", value)
+			}
+			if n == 0 {
+				n = -1
+			}
+			cfg.Aging.StaleDays = n
+		}
+	case "escalation.after_days_overdue":
+		switch strings.ToLower(value) {
+		case "", "off", "none", "null":
+			cfg.Escalation = nil
+		default:
+			steps, err := store.ParseEscalationSteps(value)
+			if err != nil || len(steps) == 0 {
+				return configSetInvalid("escalation.after_days_overdue", value)
+			}
+			cfg.Escalation = &store.EscalationConfig{AfterDaysOverdue: steps}
+		}
+	case "exports.auto":
+		var specs []string
+		switch strings.ToLower(value) {
+		case "", "none", "null", "off":
+		default:
+			for _, spec := range strings.Split(value, ",") {
+				if strings.TrimSpace(spec) == "" {
+					continue
+				}
+				e, err := parseAutoExport(spec)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "config set:", err)
+					return ExitUsage
+				}
+				if e.Project != "" {
+					specs = append(specs, e.View+":"+e.Project)
+				} else {
+					specs = append(specs, e.View)
+				}
+			}
+		}
+		cfg.Exports.Auto = specs
+	case "locale":
+		switch strings.ToLower(value) {
+		case "", "default", "none", "null":
+			cfg.Locale = ""
+		default:
+			id, err := store.NormalizeLocale(value)
+			if err != nil {
+				return configSetInvalid("locale", value)
+			}
+			cfg.Locale = id
+		}
+	case "ids.style":
+		if strings.EqualFold(value, "default") {
+			value = ""
+		}
+		style, err := store.NormalizeIDStyle(value)
+		if err != nil {
+			return configSetInvalid("ids.style", value)
+		}
+		if style == store.IDStyleULID {
+			style = ""
+		}
+		cfg.IDs.Style = style
+	case "exports.format":
+		format, ok := normalizeExportFormat(value)
+		if !ok {
+			return configSetInvalid("exports.format", value)
+		}
+		if format == "human" {
+			format = ""
+		}
+		cfg.Exports.Format = format
 	default:
 		fmt.Fprintln(os.Stderr, "Unknown config key:", key)
-		fmt.Fprintln(os.Stderr, "Allowed keys: agent.require_explicit, agent.default_project, agent.default_view, agent.week_days, agent.open_only, agent.summary_group, agent.summary_totals")
+		fmt.Fprintln(os.Stderr, "Allowed keys: agent.require_explicit, agent.default_project, agent.default_view, agent.week_days, agent.open_only, agent.summary_group, agent.summary_totals, agent.auto_archive_days, log.enabled, log.max_bytes, log.max_files, projects.auto_create, notes.separator, ideas.journal, formats.telegram.max_chars, formats.telegram.detail_width, formats.human.title_width, formats.human.snippet_width, aging.enabled, aging.stale_days, escalation.after_days_overdue, agenda.due_soon, locale, ids.style, exports.auto, exports.format, sync.auto_commit, status.<id>, theme.icons, theme.column.<id>, theme.priority.<level>, theme.section.<key>, alias.<name>")
 		return ExitUsage
 	}
 
 	if err := ws.SaveConfig(cfg); err != nil {
 		fmt.Fprintln(os.Stderr, "config set:", err)
+		if errors.Is(err, store.ErrConflict) {
+			return ExitConflict
+		}
+		return ExitInternal
+	}
+	if !gf.Quiet {
+		fmt.Printf("Updated %s\n", key)
+	}
+	return ExitOK
+}
+
+// configSetStatus handles `config set status.<id> open|closed|none`, where
+// open/closed set open_like and none drops the status.
+func configSetStatus(ws *store.Workspace, gf GlobalFlags, id string, value string) int {
+	def := store.StatusDef{ID: id}
+	remove := false
+	switch strings.ToLower(value) {
+	case "open", "open_like", "true":
+		def.OpenLike = true
+	case "closed", "false":
+	case "none", "null", "off", "remove":
+		remove = true
+	default:
+		return configSetInvalid("status."+id, value)
+	}
+	if err := ws.SetStatus(def, remove); err != nil {
+		fmt.Fprintln(os.Stderr, "config set:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if !gf.Quiet {
+		fmt.Printf("Updated status.%s\n", id)
+	}
+	return ExitOK
+}
+
+// configSetAlias handles `config set alias.<name> "<command...>"`, the same
+// as `alias add`; the value none removes the alias.
+func configSetAlias(ws *store.Workspace, gf GlobalFlags, name string, words []string) int {
+	if len(words) == 1 && strings.EqualFold(strings.TrimSpace(words[0]), "none") {
+		if err := ws.RemoveAlias(name); err != nil {
+			fmt.Fprintln(os.Stderr, "config set:", err)
+			if errors.Is(err, store.ErrNotFound) {
+				return ExitNotFound
+			}
+			if errors.Is(err, store.ErrConflict) {
+				return ExitConflict
+			}
+			return ExitInternal
+		}
+	} else if _, _, code := defineAlias(ws, "config set", name, words); code != ExitOK {
+		return code
+	}
+	if !gf.Quiet {
+		fmt.Printf("Updated alias.%s\n", strings.ToLower(strings.TrimSpace(name)))
+	}
+	return ExitOK
+}
+
+// configSetTheme handles theme.icons (none|default) and
+// theme.column.<id>, theme.priority.<level>, theme.section.<key>, whose value
+// is an icon, "none" to drop it, or "default" to restore the built-in one.
+func configSetTheme(ws *store.Workspace, gf GlobalFlags, key string, value string) int {
+	cfg := ws.Config()
+	theme := store.ThemeConfig{}
+	if cfg.Theme != nil {
+		theme = *cfg.Theme
+	}
+	if key == "theme.icons" {
+		switch strings.ToLower(value) {
+		case store.IconsNone, "off", "false":
+			theme.Icons = store.IconsNone
+		case "", "default", "on", "true":
+			theme.Icons = ""
+		default:
+			return configSetInvalid(key, value)
+		}
+	} else {
+		kind, name, ok := strings.Cut(strings.TrimPrefix(key, "theme."), ".")
+		if !ok || name == "" {
+			return configSetInvalid(key, value)
+		}
+		var icons *map[string]string
+		switch kind {
+		case "column", "columns":
+			icons = &theme.Columns
+		case "priority", "priorities":
+			switch name {
+			case "low", "normal", "high", "urgent":
+			default:
+				return configSetInvalid(key, value)
+			}
+			icons = &theme.Priorities
+		case "section", "sections":
+			if !isThemeSection(name) {
+				fmt.Fprintf(os.Stderr, "config set: unknown theme section %q (use %s)\n", name, strings.Join(store.SectionIconKeys(), "|"))
+				return ExitUsage
+			}
+			icons = &theme.Sections
+		default:
+			return configSetInvalid(key, value)
+		}
+		m := map[string]string{}
+		for k, v := range *icons {
+			m[k] = v
+		}
+		switch strings.ToLower(value) {
+		case "default":
+			delete(m, name)
+		case "none", "off", "":
+			m[name] = ""
+		default:
+			m[name] = value
+		}
+		if len(m) == 0 {
+			m = nil
+		}
+		*icons = m
+	}
+	cfg.Theme = &theme
+	if theme.Icons == "" && theme.Columns == nil && theme.Priorities == nil && theme.Sections == nil {
+		cfg.Theme = nil
+	}
+	if err := ws.SaveConfig(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, "config set:", err)
+		if errors.Is(err, store.ErrConflict) {
+			return ExitConflict
+		}
 		return ExitInternal
 	}
 	if !gf.Quiet {
@@ -756,6 +1667,60 @@ func cmdConfigSet(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	return ExitOK
 }
 
+// parseFormatWidth parses a formats.* size. "default" (or 0) resets to the
+// built-in value; max > 0 caps the accepted range.
+func parseFormatWidth(s string, max int) (int, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "default", "none", "null":
+		return 0, true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 || (max > 0 && n > max) {
+		return 0, false
+	}
+	return n, true
+}
+
+func isThemeSection(name string) bool {
+	for _, k := range store.SectionIconKeys() {
+		if k == name {
+			return true
+		}
+	}
+	return false
+}
+
+func themeIconsValue(cfg store.Config) string {
+	if cfg.IconsOff() {
+		return store.IconsNone
+	}
+	return "default"
+}
+
+// themeOverrides lists the theme's icon overrides as sorted key/value pairs,
+// "none" for a removed icon.
+func themeOverrides(t *store.ThemeConfig) [][2]string {
+	var out [][2]string
+	for _, group := range []struct {
+		prefix string
+		icons  map[string]string
+	}{{"theme.column.", t.Columns}, {"theme.priority.", t.Priorities}, {"theme.section.", t.Sections}} {
+		keys := make([]string, 0, len(group.icons))
+		for k := range group.icons {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v := group.icons[k]
+			if v == "" {
+				v = "none"
+			}
+			out = append(out, [2]string{group.prefix + k, v})
+		}
+	}
+	return out
+}
+
 func parseBool(s string) (bool, bool) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "1", "true", "yes", "y", "on":
@@ -767,6 +1732,14 @@ func parseBool(s string) (bool, bool) {
 	}
 }
 
+// ideaJournalValue is ideas.journal as shown by config show.
+func ideaJournalValue(cfg store.Config) string {
+	if j := cfg.IdeaJournal(); j != "" {
+		return j
+	}
+	return "off"
+}
+
 func configSetInvalid(key, value string) int {
 	fmt.Fprintf(os.Stderr, "Invalid value for %s: %q\n", key, value)
 	return ExitUsage
@@ -791,7 +1764,7 @@ func cmdInit(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdProject(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker project <add|ls> ...")
+		fmt.Fprintln(os.Stderr, projectUsage)
 		return ExitUsage
 	}
 	sub := args[0]
@@ -827,51 +1800,88 @@ func cmdProject(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		}
 		return ExitOK
 	case "ls", "list":
-		projects, err := ws.ListProjects()
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "project ls:", err)
-			return ExitInternal
+		return cmdProjectList(ws, gf, args[1:])
+	case "set":
+		return cmdProjectSet(ws, gf, args[1:])
+	case "rename":
+		return cmdProjectRename(ws, gf, args[1:])
+	case "archive":
+		return cmdProjectArchive(ws, gf, args[1:], true)
+	case "unarchive":
+		return cmdProjectArchive(ws, gf, args[1:], false)
+	case "rm", "remove":
+		return cmdProjectRemove(ws, gf, args[1:])
+	case "export":
+		return cmdProjectExport(ws, gf, args[1:])
+	case "import":
+		return cmdProjectImport(ws, gf, args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, projectUsage)
+		return ExitUsage
+	}
+}
+
+func cmdProjectList(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("project ls", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sortBy := fs.String("sort", "name", "Order by "+strings.Join(store.ProjectSorts, "|")+" (activity: most recently active first)")
+	all := fs.Bool("all", false, "Include archived projects")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker project ls [--sort name|activity] [--all]")
+		return ExitUsage
+	}
+	projects, err := ws.ProjectOverviews(*sortBy, *all)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "project ls:", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
 		}
-		if gf.Plain {
-			fmt.Fprintln(os.Stdout, "SLUG\tNAME\tUPDATED")
-			for _, p := range projects {
-				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", p.Slug, p.Name, p.UpdatedAt.Format(time.RFC3339))
-			}
-			return ExitOK
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "SLUG\tNAME\tUPDATED\tOPEN\tDOING\tBLOCKED\tDONE\tACTIVITY")
+		for _, p := range projects {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%d\t%d\t%d\t%d\t%s\n", p.Slug, p.Name, p.UpdatedAt.Format(time.RFC3339),
+				p.Open, p.Doing, p.Blocked, p.Done, p.LastActivity.Format(time.RFC3339))
 		}
-		if gf.JSON {
-			if gf.StdoutJSON {
-				enc := json.NewEncoder(os.Stdout)
-				enc.SetIndent("", "  ")
-				_ = enc.Encode(map[string]any{"projects": projects})
-			} else {
-				path, err := writeJSONExport(gf, "projects", map[string]any{"projects": projects})
-				if err != nil {
-					fmt.Fprintln(os.Stderr, "project ls:", err)
-					return ExitInternal
-				}
-				if !gf.Quiet {
-					fmt.Println("Wrote JSON to:", path)
-				}
+		return ExitOK
+	}
+	if gf.JSON {
+		if gf.StdoutJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(map[string]any{"projects": projects})
+		} else {
+			path, err := writeJSONExport(gf, "projects", map[string]any{"projects": projects})
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "project ls:", err)
+				return ExitInternal
+			}
+			if !gf.Quiet {
+				fmt.Println("Wrote JSON to:", path)
 			}
-			return ExitOK
-		}
-		w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
-		fmt.Fprintln(w, "SLUG	NAME	UPDATED")
-		for _, p := range projects {
-			fmt.Fprintf(w, "%s	%s	%s\n", p.Slug, p.Name, p.UpdatedAt.Format(time.RFC3339))
 		}
-		_ = w.Flush()
 		return ExitOK
-	default:
-		fmt.Fprintln(os.Stderr, "Usage: tasker project <add|ls> ...")
-		return ExitUsage
 	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "SLUG	NAME	OPEN	DOING	BLOCKED	DONE	LAST ACTIVITY")
+	for _, p := range projects {
+		name := p.Name
+		if p.IsArchived() {
+			name += " (archived)"
+		}
+		fmt.Fprintf(w, "%s	%s	%d	%d	%d	%d	%s\n", p.Slug, name, p.Open, p.Doing, p.Blocked, p.Done, p.LastActivity.Format("2006-01-02 15:04"))
+	}
+	_ = w.Flush()
+	return ExitOK
 }
 
 func cmdIdea(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker idea <add|capture|ls|show|resolve|note|append|promote> ...")
+		fmt.Fprintln(os.Stderr, ideaUsage)
 		return ExitUsage
 	}
 	sub := args[0]
@@ -892,8 +1902,22 @@ func cmdIdea(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		return cmdIdeaAppend(ws, gf, args[1:])
 	case "promote":
 		return cmdIdeaPromote(ws, gf, args[1:])
+	case "edit":
+		return cmdIdeaEdit(ws, gf, args[1:])
+	case "tag", "tags":
+		return cmdIdeaTag(ws, gf, args[1:])
+	case "archive":
+		return cmdIdeaArchive(ws, gf, args[1:], true)
+	case "unarchive":
+		return cmdIdeaArchive(ws, gf, args[1:], false)
+	case "rm", "remove", "delete":
+		return cmdIdeaRemove(ws, gf, args[1:])
+	case "score":
+		return cmdIdeaScore(ws, gf, args[1:])
+	case "triage":
+		return cmdIdeaTriage(ws, gf, args[1:])
 	default:
-		fmt.Fprintln(os.Stderr, "Usage: tasker idea <add|capture|ls|show|resolve|note|append|promote> ...")
+		fmt.Fprintln(os.Stderr, ideaUsage)
 		return ExitUsage
 	}
 }
@@ -1122,17 +2146,30 @@ func cmdIdeaCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdIdeaList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--scope":   true,
-		"--project": true,
-		"--tag":     true,
-		"--search":  true,
+		"--scope":    true,
+		"--project":  true,
+		"--tag":      true,
+		"--any-tag":  true,
+		"--not-tag":  true,
+		"--search":   true,
+		"--deleted":  false,
+		"--archived": false,
+		"--unscored": false,
+		"--sort":     true,
+		"--limit":    true,
+		"--offset":   true,
 	})
 	fs := flag.NewFlagSet("idea ls", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	scope := fs.String("scope", "", "Scope (root|project|all)")
 	project := fs.String("project", "", "Project name/slug")
-	tag := fs.String("tag", "", "Filter by tag (single)")
+	tags := addTagFlags(fs)
 	search := fs.String("search", "", "Search query (title/body)")
+	deleted := fs.Bool("deleted", false, "List ideas in the trash instead")
+	archived := fs.Bool("archived", false, "List archived ideas instead")
+	unscored := fs.Bool("unscored", false, "Only ideas not yet triaged")
+	sortBy := fs.String("sort", "", "Order by "+strings.Join(store.IdeaSortFields, "|")+" (default updated)")
+	paging := addPageFlags(fs, false)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
@@ -1142,16 +2179,43 @@ func cmdIdeaList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		return ExitUsage
 	}
 	filter := store.IdeaListFilter{
-		Project: *project,
-		Scope:   scopeValue,
-		Tag:     *tag,
-		Search:  *search,
+		Project:  *project,
+		Scope:    scopeValue,
+		Tags:     tags.filter(),
+		Search:   *search,
+		Archived: *archived,
+		Unscored: *unscored,
+		Sort:     *sortBy,
+	}
+	page, err := paging.page()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea ls:", err)
+		return ExitUsage
+	}
+	if *deleted && *archived {
+		fmt.Fprintln(os.Stderr, "idea ls: --deleted and --archived cannot be combined")
+		return ExitUsage
+	}
+	if *deleted {
+		if paging.active() {
+			fmt.Fprintln(os.Stderr, "idea ls: --limit and --offset cannot be combined with --deleted")
+			return ExitUsage
+		}
+		return listDeletedIdeas(ws, gf, filter)
 	}
 	ideas, err := ws.ListIdeas(filter)
+	if errors.Is(err, store.ErrInvalid) {
+		fmt.Fprintln(os.Stderr, "idea ls:", err)
+		return ExitUsage
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "idea ls:", err)
 		return ExitInternal
 	}
+	total := len(ideas)
+	start, end := store.PageBounds(total, page.Offset, page.Limit)
+	ideas = ideas[start:end]
+	more := total - end
 	if gf.NDJSON {
 		if gf.StdoutNDJSON {
 			for _, idea := range ideas {
@@ -1172,10 +2236,11 @@ func cmdIdeaList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 				fmt.Println("Wrote NDJSON to:", path)
 			}
 		}
+		reportMore(gf, more, end)
 		return ExitOK
 	}
 	if gf.Plain {
-		fmt.Fprintln(os.Stdout, "ID\tSCOPE\tPROJECT\tTITLE\tTAGS")
+		fmt.Fprintln(os.Stdout, "ID\tSCOPE\tPROJECT\tTITLE\tTAGS\tSCORE")
 		for _, idea := range ideas {
 			scopeLabel := "root"
 			projectLabel := "-"
@@ -1183,18 +2248,24 @@ func cmdIdeaList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 				scopeLabel = "project"
 				projectLabel = idea.Project
 			}
-			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n",
-				idea.ID, scopeLabel, projectLabel, idea.Title, strings.Join(idea.Tags, ","))
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				idea.ID, scopeLabel, projectLabel, idea.Title, strings.Join(idea.Tags, ","), ideaScoreLabel(idea.Score))
 		}
+		reportMore(gf, more, end)
 		return ExitOK
 	}
 	if gf.JSON {
+		payload := map[string]any{"ideas": ideas}
+		if paging.active() {
+			payload["total"] = total
+			payload["more"] = more
+		}
 		if gf.StdoutJSON {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			_ = enc.Encode(map[string]any{"ideas": ideas})
+			_ = enc.Encode(payload)
 		} else {
-			path, err := writeJSONExport(gf, "ideas", map[string]any{"ideas": ideas})
+			path, err := writeJSONExport(gf, "ideas", payload)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, "idea ls:", err)
 				return ExitInternal
@@ -1206,8 +2277,9 @@ func cmdIdeaList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		return ExitOK
 	}
 	for _, idea := range ideas {
-		fmt.Fprintln(os.Stdout, formatIdeaListBullet(idea))
+		fmt.Fprintln(os.Stdout, formatIdeaListBullet(idea, ws.Config().HumanSnippetWidth()))
 	}
+	reportMore(gf, more, end)
 	return ExitOK
 }
 
@@ -1216,18 +2288,26 @@ func cmdIdeaShow(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		"--scope":   true,
 		"--project": true,
 		"--match":   true,
+		"--full":    false,
+		"--page":    true,
 	})
 	fs := flag.NewFlagSet("idea show", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	scope := fs.String("scope", "", "Scope (root|project|all)")
 	project := fs.String("project", "", "Project name/slug")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	full := fs.Bool("full", false, "Show the whole body, split into pages (telegram format)")
+	page := fs.Int("page", 0, "Page of the full body to show (telegram format; implies --full)")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 	rest := fs.Args()
-	if len(rest) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker idea show [--scope root|project|all] [--project <name>] [--match <m>] <selector>")
+	if len(rest) < 1 || *page < 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker idea show [--scope root|project|all] [--project <name>] [--match <m>] [--full] [--page N] <selector>")
+		return ExitUsage
+	}
+	if *page > 0 && gf.Format != "telegram" {
+		fmt.Fprintln(os.Stderr, "idea show: --page requires --format telegram")
 		return ExitUsage
 	}
 	selector := strings.Join(rest, " ")
@@ -1269,6 +2349,23 @@ func cmdIdeaShow(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		}
 		return ExitOK
 	}
+	if gf.Format == "telegram" {
+		if !*full && *page == 0 {
+			fmt.Println(ws.RenderIdeaTelegram(idea))
+			return ExitOK
+		}
+		pages := ws.IdeaTelegramPages(idea)
+		n := *page
+		if n == 0 {
+			n = 1
+		}
+		if n > len(pages) {
+			fmt.Fprintf(os.Stderr, "idea show: page %d out of range (1-%d)\n", n, len(pages))
+			return ExitNotFound
+		}
+		fmt.Println(pages[n-1])
+		return ExitOK
+	}
 	fmt.Println(idea.RenderHuman())
 	return ExitOK
 }
@@ -1326,20 +2423,89 @@ func cmdIdeaResolve(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			Tags:    idea.Tags,
 		})
 	}
-	payload := map[string]any{
-		"selector": selector,
-		"count":    len(out),
-		"matches":  out,
+	code := emitResolveResult(gf, "idea resolve", "idea-resolve", selector, out, func() {
+		fmt.Fprintln(os.Stdout, "ID\tSCOPE\tPROJECT\tTITLE\tTAGS")
+		for _, idea := range matches {
+			scopeLabel := "root"
+			projectLabel := "-"
+			if idea.Project != "" {
+				scopeLabel = "project"
+				projectLabel = idea.Project
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n",
+				idea.ID, scopeLabel, projectLabel, idea.Title, strings.Join(idea.Tags, ","))
+		}
+	})
+	if code != ExitOK {
+		return code
 	}
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	_ = enc.Encode(payload)
 	if len(out) == 0 {
 		return ExitNotFound
 	}
 	return ExitOK
 }
 
+// promoteTargetProject picks the project an idea is promoted into:
+// --to-project, else the idea's project, else the default project. With
+// neither, or a --to-project that does not exist but resembles existing
+// projects, an interactive session offers a picker; otherwise the target
+// falls back to Personal with a notice on stderr.
+func promoteTargetProject(ws *store.Workspace, gf GlobalFlags, idea *store.Idea, toProject string) (string, int) {
+	target := strings.TrimSpace(toProject)
+	if target == "" {
+		target = strings.TrimSpace(idea.Project)
+	}
+	if target == "" {
+		target = resolveProject(ws, "")
+	}
+	projects, err := ws.ListProjects()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		return "", ExitInternal
+	}
+	slugs := make([]string, 0, len(projects))
+	for _, p := range projects {
+		slugs = append(slugs, p.Slug)
+	}
+	var options []string
+	def := 0
+	question := ""
+	switch {
+	case target == "":
+		options = slugs
+		for i, slug := range slugs {
+			if slug == "personal" {
+				def = i
+			}
+		}
+		question = "No --to-project or default project. Promote into:"
+	case checkProject(ws, target) != nil:
+		options = suggest(store.Slugify(target), slugs)
+		if len(options) == 0 {
+			return target, ExitOK
+		}
+		options = append(options, target+" (new project)")
+		question = fmt.Sprintf("Project %q does not exist. Promote into:", target)
+	default:
+		return target, ExitOK
+	}
+	if len(options) == 0 || !isInteractive(gf) {
+		if target == "" {
+			target = "Personal"
+			if !gf.Quiet {
+				fmt.Fprintln(os.Stderr, "idea promote: no --to-project or default project; using Personal")
+			}
+		}
+		return target, ExitOK
+	}
+	choice, err := pickOption(question, options, def)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		return "", ExitUsage
+	}
+	return strings.TrimSuffix(choice, " (new project)"), ExitOK
+}
+
 func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
 		"--scope":      true,
@@ -1355,6 +2521,7 @@ func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		"--next-week":  false,
 		"--link":       false,
 		"--delete":     false,
+		"--dry-run":    false,
 	})
 	fs := flag.NewFlagSet("idea promote", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
@@ -1362,7 +2529,7 @@ func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	project := fs.String("project", "", "Idea project name/slug")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
 	toProject := fs.String("to-project", "", "Target task project name/slug")
-	column := fs.String("column", "inbox", "Target column id (inbox|todo|doing|blocked|done|archive)")
+	column := fs.String("column", "", "Target column id (default: inbox, or the project's first open column)")
 	due := fs.String("due", "", "Due date (YYYY-MM-DD) or RFC3339")
 	dueToday := fs.Bool("today", false, "Shortcut: due today")
 	dueTomorrow := fs.Bool("tomorrow", false, "Shortcut: due tomorrow")
@@ -1372,12 +2539,13 @@ func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	fs.Var(&searchTag, "tag", "Tag (repeatable)")
 	link := fs.Bool("link", false, "Add a backlink to the idea in task notes")
 	deleteIdea := fs.Bool("delete", false, "Delete idea after promoting")
+	dryRun := fs.Bool("dry-run", false, "Print the task that would be created without writing")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 	rest := fs.Args()
 	if len(rest) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker idea promote [--scope root|project|all] [--project <name>] [--to-project <name>] [--column <col>] [--due <date>] [--priority <p>] [--tag <t>...] [--link] [--delete] <selector>")
+		fmt.Fprintln(os.Stderr, "Usage: tasker idea promote [--scope root|project|all] [--project <name>] [--to-project <name>] [--column <col>] [--due <date>] [--priority <p>] [--tag <t>...] [--link] [--delete] [--dry-run] <selector>")
 		return ExitUsage
 	}
 	if strings.TrimSpace(*due) != "" && (*dueToday || *dueTomorrow || *dueNextWeek) {
@@ -1414,16 +2582,20 @@ func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "idea promote:", err)
 		return ExitInternal
 	}
-	targetProject := strings.TrimSpace(*toProject)
-	if targetProject == "" {
-		if idea.Project != "" {
-			targetProject = idea.Project
-		} else {
-			targetProject = resolveProject(ws, "")
-		}
+	targetProject, code := promoteTargetProject(ws, gf, idea, *toProject)
+	if code != ExitOK {
+		return code
 	}
-	dueValue := strings.TrimSpace(*due)
-	now := time.Now().UTC()
+	if err := checkProjectColumn(ws, targetProject, *column); err != nil {
+		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		return ExitUsage
+	}
+	dueValue, dueTime, err := resolveDueTimeArg(gf, *due)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		return ExitUsage
+	}
+	now := store.Now()
 	if *dueToday {
 		dueValue = now.Format("2006-01-02")
 	}
@@ -1453,28 +2625,80 @@ func cmdIdeaPromote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		Project:     strings.TrimSpace(targetProject),
 		Column:      strings.TrimSpace(*column),
 		Due:         strings.TrimSpace(dueValue),
+		DueTime:     dueTime,
 		Priority:    strings.TrimSpace(*priority),
 		Tags:        tags,
 		Description: desc,
 	}
+	if *dryRun {
+		return previewPromote(ws, gf, idea, input, *deleteIdea)
+	}
 	task, err := ws.AddTask(input)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
 		return ExitInternal
 	}
-	code := emitAddResult(ws, gf, task, desc)
+	code = emitAddResult(ws, gf, task, desc, "")
 	if *deleteIdea {
 		if err := ws.DeleteIdea(idea); err != nil {
 			fmt.Fprintln(os.Stderr, "idea promote:", err)
 			return ExitInternal
 		}
 		if !gf.Quiet && gf.Format != "telegram" && !gf.JSON && !gf.NDJSON {
-			fmt.Println("Removed idea:", title)
+			fmt.Printf("Removed idea: %s (restore with: tasker trash restore %s)\n", title, idea.ID)
 		}
 	}
 	return code
 }
 
+// previewPromote prints the task idea promote would create, and whether the
+// idea would be removed, without writing anything.
+func previewPromote(ws *store.Workspace, gf GlobalFlags, idea *store.Idea, input store.AddTaskInput, deleteIdea bool) int {
+	task, err := ws.PreviewTask(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea promote:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	payload := map[string]any{
+		"dry_run":     true,
+		"task":        task,
+		"body":        task.Body,
+		"idea":        idea,
+		"delete_idea": deleteIdea,
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "idea promote", "promote-preview", payload); rc != ExitOK {
+			return rc
+		}
+	}
+	if gf.NDJSON {
+		if rc := emitNDJSONItems(gf, "idea promote", "promote-preview", []any{payload}); rc != ExitOK {
+			return rc
+		}
+	}
+	if gf.Quiet || gf.JSON || gf.NDJSON {
+		return ExitOK
+	}
+	fmt.Println("Would create (dry run, nothing written):")
+	fmt.Println(task.RenderHuman())
+	if deleteIdea {
+		fmt.Printf("Would remove idea: %s (%s)\n", taskTitleOrUntitled(idea.Title), idea.ID)
+	}
+	return ExitOK
+}
+
 func ideaNoteUsage(label string) string {
 	return fmt.Sprintf("Usage: tasker %s [--scope root|project|all] [--project <name>] [--match <m>] <selector...> -- <text...>", label)
 }
@@ -1641,16 +2865,60 @@ func emitIdeaAddResult(gf GlobalFlags, idea *store.Idea) int {
 		}
 		return ExitOK
 	}
-	titleText := strings.TrimSpace(idea.Title)
-	if titleText == "" {
-		titleText = "(untitled)"
-	}
-	loc := ideaLocationLabel(idea.Project)
-	fmt.Printf("Added idea (%s): %s\n", loc, titleText)
-	return ExitOK
-}
-
-func emitAddResult(ws *store.Workspace, gf GlobalFlags, task *store.Task, descText string) int {
+	titleText := strings.TrimSpace(idea.Title)
+	if titleText == "" {
+		titleText = "(untitled)"
+	}
+	loc := ideaLocationLabel(idea.Project)
+	fmt.Printf("Added idea (%s): %s\n", loc, titleText)
+	return ExitOK
+}
+
+// parseAck validates --ack (full|minimal).
+func parseAck(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "full":
+		return "", nil
+	case "minimal", "min":
+		return "minimal", nil
+	default:
+		return "", fmt.Errorf("invalid --ack %q (use full|minimal)", value)
+	}
+}
+
+// addAck is the --ack minimal payload: just enough to reference the task.
+type addAck struct {
+	OK       bool   `json:"ok"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Project  string `json:"project"`
+	Column   string `json:"column"`
+	Existing bool   `json:"existing,omitempty"`
+}
+
+func emitAddResult(ws *store.Workspace, gf GlobalFlags, task *store.Task, descText string, ack string) int {
+	if ack == "minimal" {
+		payload := addAck{OK: true, ID: task.ID, Title: task.Title, Project: task.Project, Column: task.Column, Existing: task.Existing}
+		if gf.NDJSON {
+			return emitNDJSONItems(gf, "add", "task", []any{payload})
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "add", "task", payload)
+		}
+		if gf.Quiet {
+			return ExitOK
+		}
+		mark := ws.Config().SectionIcon("added")
+		if gf.ASCII || mark == "" {
+			mark = "OK"
+		}
+		verb := "Added"
+		if task.Existing {
+			verb = "Exists"
+		}
+		fmt.Printf("%s %s (%s)\n", verb, mark, task.ID)
+		return ExitOK
+	}
 	if gf.NDJSON {
 		if gf.StdoutNDJSON {
 			b, _ := json.Marshal(task)
@@ -1689,33 +2957,48 @@ func emitAddResult(ws *store.Workspace, gf GlobalFlags, task *store.Task, descTe
 		titleText = "(untitled)"
 	}
 	if gf.Format == "telegram" {
-		colLabel := columnLabel(ws, task.Column)
-		line := formatChatAddLine(titleText, descText, task.Due)
+		colLabel := columnLabel(ws, task.Project, task.Column)
+		line := formatChatAddLine(ws.Config(), titleText, descText, task.Due)
+		if task.Existing {
+			fmt.Printf("Already in %s:\n%s\n", colLabel, line)
+			return ExitOK
+		}
 		fmt.Printf("Added to %s:\n%s\n", colLabel, line)
 		return ExitOK
 	}
+	if task.Existing {
+		fmt.Printf("Exists %s (%s/%s)\n", titleText, task.Project, task.Column)
+		return ExitOK
+	}
 	fmt.Printf("Added %s (%s/%s)\n", titleText, task.Project, task.Column)
 	return ExitOK
 }
 
 func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project":   true,
-		"--column":    true,
-		"--due":       true,
-		"--priority":  true,
-		"--tag":       true,
-		"--desc":      true,
-		"--details":   true,
-		"--text":      true,
-		"--today":     false,
-		"--tomorrow":  false,
-		"--next-week": false,
+		"--project":        true,
+		"--column":         true,
+		"--due":            true,
+		"--priority":       true,
+		"--tag":            true,
+		"--desc":           true,
+		"--details":        true,
+		"--text":           true,
+		"--today":          false,
+		"--tomorrow":       false,
+		"--next-week":      false,
+		"--create-project": false,
+		"--file":           true,
+		"--repeat":         true,
+		"--ack":            true,
+		"--external-id":    true,
+		"--start":          true,
+		"--batch":          true,
 	})
 	fs := flag.NewFlagSet("add", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug")
-	column := fs.String("column", "inbox", "Column id (inbox|todo|doing|blocked|done|archive)")
+	column := fs.String("column", "", "Column id (default: inbox, or the project's first open column)")
 	due := fs.String("due", "", "Due date (YYYY-MM-DD) or RFC3339")
 	dueToday := fs.Bool("today", false, "Shortcut: due today")
 	dueTomorrow := fs.Bool("tomorrow", false, "Shortcut: due tomorrow")
@@ -1726,6 +3009,13 @@ func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	desc := fs.String("desc", "", "Description (short)")
 	details := fs.String("details", "", "Details (alias for --desc)")
 	text := fs.String("text", "", "Raw input using \" | \" separators")
+	createProject := fs.Bool("create-project", false, "Create the project if it does not exist")
+	repeat := fs.String("repeat", "", "Recurrence (daily|weekly|monthly|yearly|weekdays|\"every 2 weeks\"|\"every mon,thu\"|FREQ=...)")
+	ackMode := fs.String("ack", "full", "Confirmation style (full|minimal)")
+	externalID := fs.String("external-id", "", "Client key; re-adding with the same key returns the existing task")
+	start := fs.String("start", "", "Start date; the task stays out of today/week until then (same forms as --due)")
+	file := fs.String("file", "", "Markdown draft (# heading = title, frontmatter = metadata, rest = body; - for stdin)")
+	batch := fs.String("batch", "", "Add many tasks from a file (- for stdin): one --text line each, or JSON/NDJSON records")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
@@ -1735,7 +3025,41 @@ func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "Usage: provide either --text or a title, not both")
 		return ExitUsage
 	}
-	if textValue == "" && len(rest) == 0 {
+	batchSrc := strings.TrimSpace(*batch)
+	if batchSrc != "" && (textValue != "" || len(rest) > 0 || strings.TrimSpace(*file) != "" || strings.TrimSpace(*externalID) != "") {
+		fmt.Fprintln(os.Stderr, "Usage: --batch reads every task from its input; drop the title, --text, --file and --external-id")
+		return ExitUsage
+	}
+	var draft *store.TaskDraft
+	if strings.TrimSpace(*file) != "" {
+		if textValue != "" || len(rest) > 0 || strings.TrimSpace(*desc) != "" || strings.TrimSpace(*details) != "" {
+			fmt.Fprintln(os.Stderr, "Usage: --file cannot be combined with a title, --text or --desc")
+			return ExitUsage
+		}
+		var err error
+		if draft, err = readTaskDraft(*file); err != nil {
+			fmt.Fprintln(os.Stderr, "add:", err)
+			return ExitUsage
+		}
+		set := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+		if !set["project"] && draft.Project != "" {
+			*project = draft.Project
+		}
+		if !set["column"] && draft.Column != "" {
+			*column = draft.Column
+		}
+		if !set["priority"] && draft.Priority != "" {
+			*priority = draft.Priority
+		}
+		if !set["due"] && !*dueToday && !*dueTomorrow && !*dueNextWeek && draft.Due != "" {
+			*due = parseDueToken(draft.Due)
+		}
+		if !set["repeat"] && draft.Repeat != "" {
+			*repeat = draft.Repeat
+		}
+	}
+	if textValue == "" && len(rest) == 0 && draft == nil && batchSrc == "" {
 		fmt.Fprintln(os.Stderr, "Usage: tasker add \"<title>\" --project <name> [--column todo] ...")
 		return ExitUsage
 	}
@@ -1760,23 +3084,35 @@ func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if detailsText != "" {
 		descText = detailsText
 	}
-	title := strings.Join(rest, " ")
-	textTitle, textDetails, textDue, textPriority, textTags := parseTextParts(textValue)
+	explicitProject := strings.TrimSpace(*project) != ""
+	title, textProject, textTags := extractTaskInlineTokens(strings.Join(rest, " "), !explicitProject)
+	textTitle, textDetails, textDue, textPriority, pipeTags, pipeProject := parseTextParts(textValue, !explicitProject)
 	if textValue != "" {
-		title = textTitle
+		title, textTags, textProject = textTitle, pipeTags, pipeProject
 	}
-	if strings.TrimSpace(title) == "" {
+	if textProject != "" {
+		*project = textProject
+	}
+	if draft != nil {
+		title = draft.Title
+	}
+	if strings.TrimSpace(title) == "" && batchSrc == "" {
 		fmt.Fprintln(os.Stderr, "Usage: tasker add \"<title>\" --project <name> [--column todo] ...")
 		return ExitUsage
 	}
 	if descText == "" {
 		descText = textDetails
 	}
-	dueValue := strings.TrimSpace(*due)
-	if dueValue == "" {
-		dueValue = textDue
+	dueText := *due
+	if strings.TrimSpace(dueText) == "" {
+		dueText = textDue
+	}
+	dueValue, dueTime, err := resolveDueTimeArg(gf, dueText)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
 	}
-	now := time.Now().UTC()
+	now := store.Now()
 	if *dueToday {
 		dueValue = now.Format("2006-01-02")
 	}
@@ -1786,6 +3122,11 @@ func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if *dueNextWeek {
 		dueValue = now.AddDate(0, 0, 7).Format("2006-01-02")
 	}
+	startValue, err := resolveDateArg(gf, "start", *start)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
+	}
 	priorityValue := strings.TrimSpace(*priority)
 	if priorityValue == "" {
 		priorityValue = textPriority
@@ -1797,42 +3138,96 @@ func cmdAdd(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if len(textTags) > 0 {
 		tags = append(tags, textTags...)
 	}
+	body := ""
+	if draft != nil {
+		tags = append(tags, draft.Tags...)
+		body = draft.Body
+	}
+	if err := checkColumn(ws, *column); err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
+	}
+	repeatValue, err := store.NormalizeRepeat(*repeat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
+	}
+	ack, err := parseAck(*ackMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitUsage
+	}
 	projectName := resolveProject(ws, *project)
+	if err := checkAddProject(ws, projectName, *createProject); err != nil {
+		fmt.Fprintln(os.Stderr, "add:", err)
+		return ExitNotFound
+	}
+	if batchSrc != "" {
+		base := store.AddTaskInput{
+			Project:       strings.TrimSpace(*project),
+			Column:        strings.TrimSpace(*column),
+			Start:         startValue,
+			Priority:      strings.TrimSpace(*priority),
+			Tags:          searchTag.Values,
+			Description:   descText,
+			Repeat:        repeatValue,
+			CreateProject: *createProject,
+		}
+		return addBatch(ws, gf, batchSrc, base, dueFlagResolver(gf, *due, *dueToday, *dueTomorrow, *dueNextWeek))
+	}
 	input := store.AddTaskInput{
-		Title:       strings.TrimSpace(title),
-		Project:     strings.TrimSpace(projectName),
-		Column:      strings.TrimSpace(*column),
-		Due:         strings.TrimSpace(dueValue),
-		Priority:    strings.TrimSpace(priorityValue),
-		Tags:        tags,
-		Description: descText,
+		Title:         strings.TrimSpace(title),
+		Project:       strings.TrimSpace(projectName),
+		Column:        strings.TrimSpace(*column),
+		Due:           strings.TrimSpace(dueValue),
+		DueTime:       dueTime,
+		Start:         startValue,
+		Priority:      strings.TrimSpace(priorityValue),
+		Tags:          tags,
+		Description:   descText,
+		Body:          body,
+		Repeat:        repeatValue,
+		CreateProject: *createProject,
+		ExternalID:    strings.TrimSpace(*externalID),
 	}
 	task, err := ws.AddTask(input)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "add:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
 		return ExitInternal
 	}
-	return emitAddResult(ws, gf, task, descText)
+	return emitAddResult(ws, gf, task, descText, ack)
 }
 
 func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project":   true,
-		"--column":    true,
-		"--due":       true,
-		"--priority":  true,
-		"--tag":       true,
-		"--desc":      true,
-		"--details":   true,
-		"--text":      true,
-		"--today":     false,
-		"--tomorrow":  false,
-		"--next-week": false,
+		"--project":        true,
+		"--column":         true,
+		"--due":            true,
+		"--priority":       true,
+		"--tag":            true,
+		"--desc":           true,
+		"--details":        true,
+		"--text":           true,
+		"--today":          false,
+		"--tomorrow":       false,
+		"--next-week":      false,
+		"--create-project": false,
+		"--repeat":         true,
+		"--ack":            true,
+		"--external-id":    true,
+		"--start":          true,
+		"--from-email":     false,
+		"--parse":          true,
+		"--lines":          true,
+		"--as":             true,
 	})
 	fs := flag.NewFlagSet("capture", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug")
-	column := fs.String("column", "inbox", "Column id (inbox|todo|doing|blocked|done|archive)")
+	column := fs.String("column", "", "Column id (default: inbox, or the project's first open column)")
 	due := fs.String("due", "", "Due date (YYYY-MM-DD) or RFC3339")
 	dueToday := fs.Bool("today", false, "Shortcut: due today")
 	dueTomorrow := fs.Bool("tomorrow", false, "Shortcut: due tomorrow")
@@ -1843,6 +3238,15 @@ func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	desc := fs.String("desc", "", "Description (short)")
 	details := fs.String("details", "", "Details (alias for --desc)")
 	text := fs.String("text", "", "Raw input using \" | \" separators")
+	createProject := fs.Bool("create-project", false, "Create the project if it does not exist")
+	repeat := fs.String("repeat", "", "Recurrence (daily|weekly|monthly|yearly|weekdays|\"every 2 weeks\"|\"every mon,thu\"|FREQ=...)")
+	ackMode := fs.String("ack", "full", "Confirmation style (full|minimal)")
+	externalID := fs.String("external-id", "", "Client key; re-adding with the same key returns the existing task")
+	start := fs.String("start", "", "Start date; the task stays out of today/week until then (same forms as --due)")
+	fromEmail := fs.Bool("from-email", false, "Read a raw RFC 822 email from stdin (subject as title, body as details)")
+	parse := fs.String("parse", "", "Parse stdin as a message: email (same as --from-email)")
+	linesSrc := fs.String("lines", "", "Capture each non-empty line of a file (- for stdin) separately")
+	as := fs.String("as", "task", "With --lines: capture lines as task or idea")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
@@ -1855,7 +3259,61 @@ func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if textValue == "" {
 		textValue = strings.TrimSpace(strings.Join(rest, " "))
 	}
-	if textValue == "" {
+	switch strings.ToLower(strings.TrimSpace(*parse)) {
+	case "":
+	case "email":
+		*fromEmail = true
+	default:
+		fmt.Fprintf(os.Stderr, "capture: unknown --parse %q (use email)\n", *parse)
+		return ExitUsage
+	}
+	if *linesSrc != "" {
+		if textValue != "" || *fromEmail || strings.TrimSpace(*externalID) != "" {
+			fmt.Fprintln(os.Stderr, "Usage: --lines reads every capture from its input; drop the capture text, --parse email and --external-id")
+			return ExitUsage
+		}
+		if *as == "idea" {
+			var taskOnly []string
+			fs.Visit(func(f *flag.Flag) {
+				switch f.Name {
+				case "project", "tag", "lines", "as":
+				default:
+					taskOnly = append(taskOnly, "--"+f.Name)
+				}
+			})
+			if len(taskOnly) > 0 {
+				fmt.Fprintf(os.Stderr, "Usage: --as idea takes only --project and --tag (got %s)\n", strings.Join(taskOnly, ", "))
+				return ExitUsage
+			}
+			return captureIdeaLines(ws, gf, *linesSrc, strings.TrimSpace(*project), searchTag.Values)
+		}
+		if *as != "task" {
+			fmt.Fprintf(os.Stderr, "capture: unknown --as %q (use task or idea)\n", *as)
+			return ExitUsage
+		}
+	} else if *as != "task" {
+		fmt.Fprintln(os.Stderr, "Usage: --as requires --lines (use idea capture for a single idea)")
+		return ExitUsage
+	}
+	var email *store.EmailDraft
+	if *fromEmail {
+		if textValue != "" {
+			fmt.Fprintln(os.Stderr, "Usage: --parse email reads the title from the email; drop the capture text")
+			return ExitUsage
+		}
+		var err error
+		email, err = store.ParseEmail(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "capture:", err)
+			return ExitUsage
+		}
+		// The subject is the whole title; " | " in it is not a separator.
+		textValue = email.Title
+		if strings.TrimSpace(*externalID) == "" {
+			*externalID = email.MessageID
+		}
+	}
+	if textValue == "" && *linesSrc == "" {
 		fmt.Fprintln(os.Stderr, "Usage: tasker capture \"<title | details | due 2026-01-23>\" [--project <name>] ...")
 		return ExitUsage
 	}
@@ -1880,7 +3338,61 @@ func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if detailsText != "" {
 		descText = detailsText
 	}
-	title, textDetails, textDue, textPriority, textTags := parseTextParts(textValue)
+	startValue, err := resolveDateArg(gf, "start", *start)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	if err := checkColumn(ws, *column); err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	repeatValue, err := store.NormalizeRepeat(*repeat)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	ack, err := parseAck(*ackMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
+	}
+	projectName := resolveProject(ws, *project)
+	if err := checkAddProject(ws, projectName, *createProject); err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitNotFound
+	}
+	// resolveCaptureDue applies --due and the shortcuts over a due date
+	// parsed from the capture text.
+	resolveCaptureDue := dueFlagResolver(gf, *due, *dueToday, *dueTomorrow, *dueNextWeek)
+	if *linesSrc != "" {
+		if _, _, err := resolveCaptureDue(""); err != nil {
+			fmt.Fprintln(os.Stderr, "capture:", err)
+			return ExitUsage
+		}
+		base := store.AddTaskInput{
+			Project:       strings.TrimSpace(*project),
+			Column:        strings.TrimSpace(*column),
+			Start:         startValue,
+			Priority:      strings.TrimSpace(*priority),
+			Tags:          searchTag.Values,
+			Description:   descText,
+			Repeat:        repeatValue,
+			CreateProject: *createProject,
+		}
+		return captureTaskLines(ws, gf, *linesSrc, base, resolveCaptureDue)
+	}
+	title, textDetails, textDue, textPriority, textTags, textProject := parseTextParts(textValue, strings.TrimSpace(*project) == "")
+	if email != nil {
+		title, textDetails, textDue, textPriority, textTags, textProject = email.Title, email.Body, "", "", email.Tags(), ""
+	}
+	if textProject != "" {
+		projectName = textProject
+		if err := checkAddProject(ws, projectName, *createProject); err != nil {
+			fmt.Fprintln(os.Stderr, "capture:", err)
+			return ExitNotFound
+		}
+	}
 	if strings.TrimSpace(title) == "" {
 		fmt.Fprintln(os.Stderr, "Usage: tasker capture \"<title | details | due 2026-01-23>\" [--project <name>] ...")
 		return ExitUsage
@@ -1888,19 +3400,10 @@ func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if descText == "" {
 		descText = textDetails
 	}
-	dueValue := strings.TrimSpace(*due)
-	if dueValue == "" {
-		dueValue = textDue
-	}
-	now := time.Now().UTC()
-	if *dueToday {
-		dueValue = now.Format("2006-01-02")
-	}
-	if *dueTomorrow {
-		dueValue = now.AddDate(0, 0, 1).Format("2006-01-02")
-	}
-	if *dueNextWeek {
-		dueValue = now.AddDate(0, 0, 7).Format("2006-01-02")
+	dueValue, dueTime, err := resolveCaptureDue(textDue)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		return ExitUsage
 	}
 	priorityValue := strings.TrimSpace(*priority)
 	if priorityValue == "" {
@@ -1913,52 +3416,117 @@ func cmdCapture(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if len(textTags) > 0 {
 		tags = append(tags, textTags...)
 	}
-	projectName := resolveProject(ws, *project)
 	input := store.AddTaskInput{
-		Title:       strings.TrimSpace(title),
-		Project:     strings.TrimSpace(projectName),
-		Column:      strings.TrimSpace(*column),
-		Due:         strings.TrimSpace(dueValue),
-		Priority:    strings.TrimSpace(priorityValue),
-		Tags:        tags,
-		Description: descText,
+		Title:         strings.TrimSpace(title),
+		Project:       strings.TrimSpace(projectName),
+		Column:        strings.TrimSpace(*column),
+		Due:           strings.TrimSpace(dueValue),
+		DueTime:       dueTime,
+		Start:         startValue,
+		Priority:      strings.TrimSpace(priorityValue),
+		Tags:          tags,
+		Description:   descText,
+		Repeat:        repeatValue,
+		CreateProject: *createProject,
+		ExternalID:    strings.TrimSpace(*externalID),
 	}
 	task, err := ws.AddTask(input)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "capture:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
 		return ExitInternal
 	}
-	return emitAddResult(ws, gf, task, descText)
+	return emitAddResult(ws, gf, task, descText, ack)
 }
 
 func cmdList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--column":  true,
-		"--status":  true,
-		"--tag":     true,
-		"--search":  true,
-		"--all":     false,
+		"--project":         true,
+		"--column":          true,
+		"--status":          true,
+		"--tag":             true,
+		"--any-tag":         true,
+		"--not-tag":         true,
+		"--search":          true,
+		"--query":           true,
+		"--all":             false,
+		"--due-before":      true,
+		"--due-after":       true,
+		"--overdue":         false,
+		"--due-today":       false,
+		"--deleted":         false,
+		"--sort":            true,
+		"--reverse":         false,
+		"--limit":           true,
+		"--offset":          true,
+		"--archive-bundles": false,
 	})
 	fs := flag.NewFlagSet("ls", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
-	project := fs.String("project", "", "Project name/slug")
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable)")
 	column := fs.String("column", "", "Column id")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
-	tag := fs.String("tag", "", "Filter by tag (single)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
+	tags := addTagFlags(fs)
 	search := fs.String("search", "", "Search query (title/description)")
+	query := fs.String("query", "", "Filter expression, e.g. 'project=work and (priority>=high or tag=client)'")
 	all := fs.Bool("all", false, "Include archive column")
+	deleted := fs.Bool("deleted", false, "List tasks in the trash instead")
+	bundles := fs.Bool("archive-bundles", false, "Also list tasks compacted into archive bundles")
+	due := addDueFlags(fs)
+	order := addSortFlags(fs)
+	paging := addPageFlags(fs, false)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 
+	project := store.JoinProjectSpec(projects.Values)
+	if !*deleted {
+		if err := checkProjectSpec(ws, project); err != nil {
+			fmt.Fprintln(os.Stderr, "ls:", err)
+			return ExitNotFound
+		}
+	}
+	if err := checkColumn(ws, *column); err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		return ExitUsage
+	}
+	dueFilter, err := due.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		return ExitUsage
+	}
 	filter := store.ListFilter{
-		Project: *project,
-		Column:  *column,
-		Status:  *status,
-		Tag:     *tag,
-		Search:  *search,
-		All:     *all,
+		Project:        project,
+		Column:         *column,
+		Status:         *status,
+		Tags:           tags.filter(),
+		Search:         *search,
+		All:            *all,
+		Due:            dueFilter,
+		ArchiveBundles: *bundles,
+	}
+	if filter.Query, err = parseQuery(*query); err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		return ExitUsage
+	}
+	if filter.Sort, err = order.sort(); err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		return ExitUsage
+	}
+	page, err := paging.page()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		return ExitUsage
+	}
+	if *deleted {
+		if filter.Sort.Active() || paging.active() || *bundles {
+			fmt.Fprintln(os.Stderr, "ls: --sort, --limit, --offset and --archive-bundles cannot be combined with --deleted")
+			return ExitUsage
+		}
+		return listDeletedTasks(ws, gf, filter)
 	}
 
 	tasks, err := ws.ListTasks(filter)
@@ -1966,6 +3534,10 @@ func cmdList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "ls:", err)
 		return ExitInternal
 	}
+	total := len(tasks)
+	start, end := store.PageBounds(total, page.Offset, page.Limit)
+	tasks = tasks[start:end]
+	more := total - end
 
 	if gf.NDJSON {
 		if gf.StdoutNDJSON {
@@ -1987,6 +3559,7 @@ func cmdList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 				fmt.Println("Wrote NDJSON to:", path)
 			}
 		}
+		reportMore(gf, more, end)
 		return ExitOK
 	}
 
@@ -1998,18 +3571,24 @@ func cmdList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 				dueStr = t.Due
 			}
 			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s/%s\t%s\n",
-				t.ID, t.StatusAbbrev(), t.PriorityAbbrev(), dueStr, t.Project, t.Column, t.Title)
+				t.ID, ws.Config().StatusAbbrev(t.Status), t.PriorityAbbrev(), dueStr, t.Project, t.Column, t.Title)
 		}
+		reportMore(gf, more, end)
 		return ExitOK
 	}
 
 	if gf.JSON {
+		payload := map[string]any{"tasks": tasks}
+		if paging.active() {
+			payload["total"] = total
+			payload["more"] = more
+		}
 		if gf.StdoutJSON {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			_ = enc.Encode(map[string]any{"tasks": tasks})
+			_ = enc.Encode(payload)
 		} else {
-			path, err := writeJSONExport(gf, "tasks", map[string]any{"tasks": tasks})
+			path, err := writeJSONExport(gf, "tasks", payload)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, "ls:", err)
 				return ExitInternal
@@ -2022,12 +3601,13 @@ func cmdList(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	}
 
 	for _, t := range tasks {
-		fmt.Fprintln(os.Stdout, formatListBullet(t))
+		fmt.Fprintln(os.Stdout, formatListBullet(ws, t, ws.AgingSuffix(t, gf.ASCII)))
 	}
+	reportMore(gf, more, end)
 	return ExitOK
 }
 
-func formatListBullet(t store.Task) string {
+func formatListBullet(ws *store.Workspace, t store.Task, aging string) string {
 	title := strings.TrimSpace(t.Title)
 	if title == "" {
 		title = "(untitled)"
@@ -2038,9 +3618,9 @@ func formatListBullet(t store.Task) string {
 	}
 	due := ""
 	if strings.TrimSpace(t.Due) != "" {
-		due = fmt.Sprintf(" (due %s)", strings.TrimSpace(t.Due))
+		due = fmt.Sprintf(" (due %s)", t.DueLabel())
 	}
-	status := strings.TrimSpace(t.StatusAbbrev())
+	status := strings.TrimSpace(ws.Config().StatusAbbrev(t.Status))
 	label := status
 	pri := strings.TrimSpace(t.PriorityAbbrev())
 	if pri != "" && pri != "N" {
@@ -2053,7 +3633,10 @@ func formatListBullet(t store.Task) string {
 	if label != "" {
 		label = "[" + label + "] "
 	}
-	return fmt.Sprintf("- %s%s: %s%s", label, loc, title, due)
+	if progress := t.ChecklistProgress(); progress != "" {
+		title = title + " [" + progress + "]"
+	}
+	return fmt.Sprintf("- %s%s: %s%s%s", label, loc, title, due, aging)
 }
 
 func ideaLocationLabel(project string) string {
@@ -2064,13 +3647,16 @@ func ideaLocationLabel(project string) string {
 	return project
 }
 
-func formatIdeaListBullet(idea store.Idea) string {
+func formatIdeaListBullet(idea store.Idea, snippetWidth int) string {
 	title := strings.TrimSpace(idea.Title)
 	if title == "" {
 		title = "(untitled)"
 	}
+	if idea.Score != nil {
+		title += " [score " + ideaScoreLabel(idea.Score) + "]"
+	}
 	loc := ideaLocationLabel(idea.Project)
-	snippet := cleanSummary(idea.Body, 140)
+	snippet := cleanSummary(idea.Body, snippetWidth)
 	if snippet != "" {
 		return fmt.Sprintf("- %s: %s — %s", loc, title, snippet)
 	}
@@ -2094,13 +3680,12 @@ func ideaBacklinkLine(ws *store.Workspace, idea *store.Idea) string {
 	return fmt.Sprintf("Source idea: %s (%s)", idea.ID, scope)
 }
 
-func columnLabel(ws *store.Workspace, colID string) string {
+func columnLabel(ws *store.Workspace, project string, colID string) string {
 	colID = strings.TrimSpace(colID)
 	if colID == "" {
 		return "inbox"
 	}
-	cfg := ws.Config()
-	for _, col := range cfg.Columns {
+	for _, col := range ws.Columns(project) {
 		if col.ID == colID {
 			name := strings.TrimSpace(col.Name)
 			if name != "" {
@@ -2111,14 +3696,14 @@ func columnLabel(ws *store.Workspace, colID string) string {
 	return colID
 }
 
-func formatChatAddLine(title string, details string, due string) string {
+func formatChatAddLine(cfg store.Config, title string, details string, due string) string {
 	line := title
-	detailText := cleanSummary(details, 160)
+	detailText := cleanSummary(details, cfg.TelegramDetailWidth())
 	if detailText != "" {
 		line = line + " — " + detailText
 	}
 	if strings.TrimSpace(due) != "" {
-		if dueShort := formatDueShort(due); dueShort != "" {
+		if dueShort := cfg.FormatDueShort(due); dueShort != "" {
 			line = line + " (due " + dueShort + ")"
 		}
 	}
@@ -2147,31 +3732,6 @@ func cleanSummary(s string, max int) string {
 	return string(r[:max-3]) + "..."
 }
 
-func formatDueShort(due string) string {
-	due = strings.TrimSpace(due)
-	if due == "" {
-		return ""
-	}
-	if len(due) >= 10 {
-		datePart := due[:10]
-		if t, err := time.Parse("2006-01-02", datePart); err == nil {
-			now := time.Now().UTC()
-			if t.Year() == now.Year() {
-				return t.Format("Jan 02")
-			}
-			return t.Format("Jan 02 2006")
-		}
-	}
-	if t, err := time.Parse(time.RFC3339, due); err == nil {
-		now := time.Now().UTC()
-		if t.Year() == now.Year() {
-			return t.Format("Jan 02")
-		}
-		return t.Format("Jan 02 2006")
-	}
-	return due
-}
-
 func readStdinText() (string, error) {
 	b, err := io.ReadAll(os.Stdin)
 	if err != nil {
@@ -2272,6 +3832,52 @@ func extractIdeaInlineTokens(text string, strip bool, allowProject bool) (string
 	return text, project, tags
 }
 
+// extractTaskInlineTokens reads the inline tokens of a task title. The first
+// "+name" that is a project slug becomes the project when allowProject and
+// leaves the title; any other "+word" stays text ("Give +1 to proposal").
+// "#tag" becomes a tag and leaves the title, while "@name" tags the task but
+// stays put, since it is usually a mention ("Reply to @alice"). Tokens must
+// have a letter, so "#42" is text too.
+func extractTaskInlineTokens(text string, allowProject bool) (string, string, []string) {
+	var kept []string
+	project := ""
+	var tags []string
+	for _, field := range strings.Fields(text) {
+		token := trimIdeaTokenPunct(field)
+		switch {
+		case strings.HasPrefix(token, "+") && allowProject && project == "" && isTaskProjectToken(token[1:]):
+			project = token[1:]
+			continue
+		case strings.HasPrefix(token, "#") && isTaskToken(token[1:]):
+			tags = append(tags, token[1:])
+			continue
+		case strings.HasPrefix(token, "@") && isTaskToken(token[1:]):
+			tags = append(tags, token[1:])
+		}
+		kept = append(kept, field)
+	}
+	return strings.Join(kept, " "), project, tags
+}
+
+// isTaskToken is isIdeaToken with at least one letter.
+func isTaskToken(s string) bool {
+	if !isIdeaToken(s) {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' {
+			return true
+		}
+	}
+	return false
+}
+
+// isTaskProjectToken reports whether s reads as a project slug ("webapp",
+// "q3-launch"), not a count or an underscored word.
+func isTaskProjectToken(s string) bool {
+	return isTaskToken(s) && store.Slugify(s) == strings.ToLower(s)
+}
+
 func trimIdeaTokenPunct(token string) string {
 	return strings.TrimRightFunc(token, func(r rune) bool {
 		switch r {
@@ -2326,35 +3932,160 @@ func parsePriorityToken(text string) string {
 	}
 }
 
-func parseDueToken(text string) string {
-	text = strings.TrimSpace(text)
-	if text == "" {
-		return ""
+// readTaskDraft parses a Markdown draft from path, or stdin for "-".
+func readTaskDraft(path string) (*store.TaskDraft, error) {
+	var b []byte
+	var err error
+	if strings.TrimSpace(path) == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(store.ExpandHome(path))
 	}
-	lower := strings.ToLower(text)
-	now := time.Now().UTC()
-	switch lower {
-	case "today":
-		return now.Format("2006-01-02")
-	case "tomorrow":
-		return now.AddDate(0, 0, 1).Format("2006-01-02")
-	case "next week", "next-week", "nextweek":
-		return now.AddDate(0, 0, 7).Format("2006-01-02")
-	default:
-		return text
+	if err != nil {
+		return nil, err
+	}
+	return store.ParseTaskDraft(string(b))
+}
+
+// parseQuery parses a --query expression, with dates read like --due; nil
+// for an empty one.
+func parseQuery(text string) (*store.Query, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	return store.ParseQuery(text, func(value string) (string, error) {
+		return resolveDue(value, store.Now())
+	})
+}
+
+// tagFlags are the tag filters shared by ls, idea ls and the selector flags
+// of mv, done and edit.
+type tagFlags struct {
+	all *multiFlag
+	any *multiFlag
+	not *multiFlag
+}
+
+func addTagFlags(fs *flag.FlagSet) tagFlags {
+	t := tagFlags{all: &multiFlag{}, any: &multiFlag{}, not: &multiFlag{}}
+	fs.Var(t.all, "tag", "Only items with this tag (repeatable: all of them)")
+	fs.Var(t.any, "any-tag", "Only items with at least one of these tags (repeatable)")
+	fs.Var(t.not, "not-tag", "Skip items with this tag (repeatable)")
+	return t
+}
+
+func (t tagFlags) filter() store.TagFilter {
+	return store.TagFilter{All: t.all.Values, Any: t.any.Values, Not: t.not.Values}
+}
+
+// dueFlags are the due-date filters shared by ls and resolve.
+type dueFlags struct {
+	before  *string
+	after   *string
+	overdue *bool
+	today   *bool
+}
+
+func addDueFlags(fs *flag.FlagSet) dueFlags {
+	return dueFlags{
+		before:  fs.String("due-before", "", "Only tasks due before this date (YYYY-MM-DD|today|tomorrow)"),
+		after:   fs.String("due-after", "", "Only tasks due after this date (YYYY-MM-DD|today|tomorrow)"),
+		overdue: fs.Bool("overdue", false, "Only open tasks past their due date"),
+		today:   fs.Bool("due-today", false, "Only tasks due today"),
+	}
+}
+
+func (d dueFlags) filter() (store.DueFilter, error) {
+	f := store.DueFilter{
+		Before:  parseDueToken(*d.before),
+		After:   parseDueToken(*d.after),
+		Overdue: *d.overdue,
+		Today:   *d.today,
+	}
+	return f, f.Validate()
+}
+
+// sortFlags are --sort and --reverse, shared by ls, today and week.
+type sortFlags struct {
+	keys    *string
+	reverse *bool
+}
+
+func addSortFlags(fs *flag.FlagSet) sortFlags {
+	return sortFlags{
+		keys:    fs.String("sort", "", "Order by "+strings.Join(store.SortFields, "|")+"; comma-separated for several keys (priority,due)"),
+		reverse: fs.Bool("reverse", false, "Reverse the --sort order"),
+	}
+}
+
+func (s sortFlags) sort() (store.TaskSort, error) {
+	return store.ParseSort(*s.keys, *s.reverse)
+}
+
+// pageFlags are --limit and --offset, plus --max-per-group for the grouped
+// views.
+type pageFlags struct {
+	limit       *int
+	offset      *int
+	maxPerGroup *int
+}
+
+func addPageFlags(fs *flag.FlagSet, grouped bool) pageFlags {
+	if !grouped {
+		return pageFlags{
+			limit:       fs.Int("limit", 0, "List at most N (0: no limit)"),
+			offset:      fs.Int("offset", 0, "Skip the first N"),
+			maxPerGroup: new(int),
+		}
+	}
+	return pageFlags{
+		limit:       fs.Int("limit", 0, "List at most N tasks per section (0: no limit)"),
+		offset:      fs.Int("offset", 0, "Skip the first N tasks of each section"),
+		maxPerGroup: fs.Int("max-per-group", 0, "List at most N tasks per project/column group (0: no limit)"),
+	}
+}
+
+func (p pageFlags) page() (store.ViewPage, error) {
+	page := store.ViewPage{Limit: *p.limit, Offset: *p.offset, MaxPerGroup: *p.maxPerGroup}
+	if page.Limit < 0 || page.Offset < 0 || page.MaxPerGroup < 0 {
+		return page, fmt.Errorf("--limit, --offset and --max-per-group must be >= 0")
 	}
+	return page, nil
 }
 
-func parseTextParts(text string) (string, string, string, string, []string) {
+func (p pageFlags) active() bool {
+	return *p.limit > 0 || *p.offset > 0
+}
+
+// reportMore notes that more items follow a --limit page: on stdout after
+// human output, on stderr when stdout is plain rows or NDJSON.
+func reportMore(gf GlobalFlags, more int, next int) {
+	if more <= 0 || gf.Quiet {
+		return
+	}
+	line := fmt.Sprintf("%s (next page: --offset %d)", store.MoreLine(more, gf.ASCII), next)
+	if gf.Plain || gf.NDJSON {
+		fmt.Fprintln(os.Stderr, line)
+		return
+	}
+	fmt.Println(line)
+}
+
+// parseTextParts splits task text in the " | " syntax into its title,
+// details, due, priority, tags and project. The title's inline tokens are
+// read by extractTaskInlineTokens (a +project only when allowProject, i.e.
+// no --project was given), and a trailing "due <date>" is cut off when no
+// due part is given ("Fix login +webapp @backend due tomorrow").
+func parseTextParts(text string, allowProject bool) (string, string, string, string, []string, string) {
 	parts := splitPipeParts(text)
 	if len(parts) == 0 {
-		return "", "", "", "", nil
+		return "", "", "", "", nil, ""
 	}
-	title := strings.TrimSpace(parts[0])
+	title, project, tags := extractTaskInlineTokens(parts[0], allowProject)
+	title = strings.TrimSpace(title)
 	var details []string
 	var due string
 	var priority string
-	var tags []string
 	for _, part := range parts[1:] {
 		if part == "" {
 			continue
@@ -2447,22 +4178,50 @@ func parseTextParts(text string) (string, string, string, string, []string) {
 			tags = append(tags, parseTagsPart(value)...)
 			continue
 		}
-		if value, ok := cutPrefixFold(part, "tags "); ok {
-			tags = append(tags, parseTagsPart(value)...)
-			continue
+		if value, ok := cutPrefixFold(part, "tags "); ok {
+			tags = append(tags, parseTagsPart(value)...)
+			continue
+		}
+		if value, ok := cutPrefixFold(part, "tags:"); ok {
+			tags = append(tags, parseTagsPart(value)...)
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(part), "#") {
+			tags = append(tags, parseTagsPart(part)...)
+			continue
+		}
+		details = append(details, part)
+	}
+	if due == "" {
+		title, due = cutTitleDue(title)
+	}
+	detailText := strings.TrimSpace(strings.Join(details, " — "))
+	return title, detailText, due, priority, tags, project
+}
+
+// cutTitleDue cuts a trailing "due <date>" or "by <date>" off a title when
+// the date is one tasker understands, so "Finish due diligence" stays whole.
+func cutTitleDue(title string) (string, string) {
+	lower := strings.ToLower(title)
+	for _, keyword := range []string{" due ", " by "} {
+		i := strings.LastIndex(lower, keyword)
+		if i <= 0 {
+			continue
+		}
+		text := strings.TrimSpace(title[i+len(keyword):])
+		dateText, clock := splitDueClock(text)
+		if clock != "" && strings.TrimSpace(dateText) == "" {
+			dateText = "today"
 		}
-		if value, ok := cutPrefixFold(part, "tags:"); ok {
-			tags = append(tags, parseTagsPart(value)...)
+		if strings.TrimSpace(dateText) == "" {
 			continue
 		}
-		if strings.HasPrefix(strings.TrimSpace(part), "#") {
-			tags = append(tags, parseTagsPart(part)...)
+		if _, err := resolveDue(dateText, store.Now()); err != nil {
 			continue
 		}
-		details = append(details, part)
+		return strings.TrimSpace(title[:i]), text
 	}
-	detailText := strings.TrimSpace(strings.Join(details, " — "))
-	return title, detailText, due, priority, tags
+	return title, ""
 }
 
 func parseIdeaTextParts(text string) (string, string, []string, string) {
@@ -2573,25 +4332,31 @@ func splitIdeaNoteInput(ws *store.Workspace, filter store.IdeaSelectorFilter, to
 
 func cmdShow(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--column":  true,
-		"--status":  true,
-		"--all":     false,
-		"--match":   true,
+		"--project":        true,
+		"--column":         true,
+		"--status":         true,
+		"--all":            false,
+		"--match":          true,
+		"--with-checklist": false,
 	})
 	fs := flag.NewFlagSet("show", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
 	column := fs.String("column", "", "Column id")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
 	all := fs.Bool("all", false, "Include archived")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	withChecklist := fs.Bool("with-checklist", false, "List unchecked checklist items (telegram format)")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 	rest := fs.Args()
 	if len(rest) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker show [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector>")
+		fmt.Fprintln(os.Stderr, "Usage: tasker show [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] [--with-checklist] <selector>")
+		return ExitUsage
+	}
+	if *withChecklist && gf.Format != "telegram" {
+		fmt.Fprintln(os.Stderr, "show: --with-checklist requires --format telegram")
 		return ExitUsage
 	}
 	selector := strings.Join(rest, " ")
@@ -2607,7 +4372,7 @@ func cmdShow(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitNotFound
 		}
 		if errors.Is(err, store.ErrConflict) {
-			if handleMatchConflict("show", err) {
+			if handleMatchConflict(gf, "show", err) {
 				return ExitConflict
 			}
 			fmt.Fprintln(os.Stderr, "show: ambiguous selector")
@@ -2633,31 +4398,40 @@ func cmdShow(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		}
 		return ExitOK
 	}
+	if gf.Format == "telegram" {
+		fmt.Println(ws.RenderTaskTelegram(task, *withChecklist))
+		return ExitOK
+	}
 	fmt.Println(task.RenderHuman())
 	return ExitOK
 }
 
 func cmdResolve(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--column":  true,
-		"--status":  true,
-		"--all":     false,
-		"--match":   true,
+		"--project":    true,
+		"--column":     true,
+		"--status":     true,
+		"--all":        false,
+		"--match":      true,
+		"--due-before": true,
+		"--due-after":  true,
+		"--overdue":    false,
+		"--due-today":  false,
 	})
 	fs := flag.NewFlagSet("resolve", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
 	column := fs.String("column", "", "Column id")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
 	all := fs.Bool("all", false, "Include archived")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	due := addDueFlags(fs)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 	rest := fs.Args()
 	if len(rest) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker resolve [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector>")
+		fmt.Fprintln(os.Stderr, "Usage: tasker resolve [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] [--due-before <date>] [--due-after <date>] [--overdue] [--due-today] <selector>")
 		return ExitUsage
 	}
 	selector := strings.Join(rest, " ")
@@ -2666,6 +4440,10 @@ func cmdResolve(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "resolve:", err)
 		return ExitUsage
 	}
+	if filter.Due, err = due.filter(); err != nil {
+		fmt.Fprintln(os.Stderr, "resolve:", err)
+		return ExitUsage
+	}
 	matches, err := ws.ResolveTasks(selector, filter)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
@@ -2702,50 +4480,108 @@ func cmdResolve(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			Tags:     t.Tags,
 		})
 	}
+	code := emitResolveResult(gf, "resolve", "resolve", selector, out, func() {
+		fmt.Fprintln(os.Stdout, "ID\tST\tPRI\tDUE\tPROJECT/COL\tTITLE")
+		for _, t := range matches {
+			dueStr := "-"
+			if t.Due != "" {
+				dueStr = t.Due
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s/%s\t%s\n",
+				t.ID, ws.Config().StatusAbbrev(t.Status), t.PriorityAbbrev(), dueStr, t.Project, t.Column, t.Title)
+		}
+	})
+	if code != ExitOK {
+		return code
+	}
+	if len(out) == 0 {
+		return ExitNotFound
+	}
+	return ExitOK
+}
+
+// emitResolveResult writes resolve matches. Without --json/--ndjson/--plain the
+// payload goes to stdout as JSON (the agent contract); --json and --ndjson follow
+// the export-dir convention used by every other command.
+func emitResolveResult[T any](gf GlobalFlags, label string, base string, selector string, matches []T, plain func()) int {
+	if gf.NDJSON {
+		items := make([]any, 0, len(matches))
+		for i := range matches {
+			items = append(items, matches[i])
+		}
+		return emitNDJSONItems(gf, label, base, items)
+	}
+	if gf.Plain {
+		plain()
+		return ExitOK
+	}
 	payload := map[string]any{
 		"selector": selector,
-		"count":    len(out),
-		"matches":  out,
+		"count":    len(matches),
+		"matches":  matches,
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, label, base, payload)
 	}
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	_ = enc.Encode(payload)
-	if len(out) == 0 {
-		return ExitNotFound
-	}
 	return ExitOK
 }
 
 func cmdMove(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--column":  true,
-		"--status":  true,
-		"--all":     false,
-		"--match":   true,
+		"--project":     true,
+		"--column":      true,
+		"--status":      true,
+		"--all":         false,
+		"--tag":         true,
+		"--any-tag":     true,
+		"--not-tag":     true,
+		"--match":       true,
+		"--force":       false,
+		"--all-matches": false,
+		"--dry-run":     false,
 	})
 	fs := flag.NewFlagSet("mv", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
 	column := fs.String("column", "", "Column id (filter)")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
 	all := fs.Bool("all", false, "Include archived")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	force := fs.Bool("force", false, "Complete even if open tasks still block it")
+	tags := addTagFlags(fs)
+	allMatches := fs.Bool("all-matches", false, "Act on every matching task instead of exactly one")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without writing (with --all-matches)")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
+	if *dryRun && !*allMatches {
+		fmt.Fprintln(os.Stderr, "mv: --dry-run needs --all-matches")
+		return ExitUsage
+	}
 	rest := fs.Args()
-	if len(rest) < 2 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker mv [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector> <column>")
+	if len(rest) < 2 && !(*allMatches && len(rest) == 1) {
+		fmt.Fprintln(os.Stderr, "Usage: tasker mv [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--force] [--all-matches [--dry-run]] <selector> <column>")
 		return ExitUsage
 	}
 	destColumn := rest[len(rest)-1]
+	if err := checkColumn(ws, destColumn); err != nil {
+		fmt.Fprintln(os.Stderr, "mv:", err)
+		return ExitUsage
+	}
 	selector := strings.Join(rest[:len(rest)-1], " ")
 	filter, err := selectorFilter(ws, *project, *column, *status, *all, *match)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "mv:", err)
 		return ExitUsage
 	}
+	filter.Tags = tags.filter()
+	if *allMatches {
+		filtered := *project != "" || *column != "" || *status != "" || filter.Tags.Active()
+		return cmdBulkMove(ws, gf, "mv", selector, filter, filtered, destColumn, *force, *dryRun)
+	}
 	taskRef, err := ws.GetTaskBySelectorFiltered(selector, filter)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
@@ -2753,7 +4589,7 @@ func cmdMove(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitNotFound
 		}
 		if errors.Is(err, store.ErrConflict) {
-			if handleMatchConflict("mv", err) {
+			if handleMatchConflict(gf, "mv", err) {
 				return ExitConflict
 			}
 			fmt.Fprintln(os.Stderr, "mv: ambiguous selector")
@@ -2762,12 +4598,17 @@ func cmdMove(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "mv:", err)
 		return ExitInternal
 	}
-	task, err := ws.MoveTask(taskRef.ID, destColumn)
+	task, err := ws.MoveTaskWith(taskRef.ID, destColumn, store.MoveOptions{Force: *force})
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			fmt.Fprintln(os.Stderr, "mv: not found")
 			return ExitNotFound
 		}
+		var blocked *store.BlockedError
+		if errors.As(err, &blocked) {
+			fmt.Fprintf(os.Stderr, "mv: %v (use --force to complete anyway)\n", err)
+			return ExitConflict
+		}
 		if errors.Is(err, store.ErrConflict) {
 			fmt.Fprintln(os.Stderr, "mv: ambiguous id prefix")
 			return ExitConflict
@@ -2797,30 +4638,54 @@ func cmdMove(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		title = "(untitled)"
 	}
 	fmt.Printf("Moved %s -> %s\n", title, task.Column)
+	printNextOccurrence(task)
 	return ExitOK
 }
 
+// printNextOccurrence reports the task spawned by completing a recurring task.
+func printNextOccurrence(task *store.Task) {
+	next := task.NextOccurrence
+	if next == nil {
+		return
+	}
+	fmt.Printf("Next: %s (%s) due %s\n", next.Title, next.Column, next.Due)
+}
+
 func cmdDone(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--column":  true,
-		"--status":  true,
-		"--all":     false,
-		"--match":   true,
+		"--project":     true,
+		"--column":      true,
+		"--status":      true,
+		"--all":         false,
+		"--tag":         true,
+		"--any-tag":     true,
+		"--not-tag":     true,
+		"--match":       true,
+		"--force":       false,
+		"--all-matches": false,
+		"--dry-run":     false,
 	})
 	fs := flag.NewFlagSet("done", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
 	column := fs.String("column", "", "Column id (filter)")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
 	all := fs.Bool("all", false, "Include archived")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	force := fs.Bool("force", false, "Complete even if open tasks still block it")
+	tags := addTagFlags(fs)
+	allMatches := fs.Bool("all-matches", false, "Act on every matching task instead of exactly one")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without writing (with --all-matches)")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
+	if *dryRun && !*allMatches {
+		fmt.Fprintln(os.Stderr, "done: --dry-run needs --all-matches")
+		return ExitUsage
+	}
 	rest := fs.Args()
-	if len(rest) < 1 {
-		fmt.Fprintln(os.Stderr, "Usage: tasker done [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector>")
+	if len(rest) < 1 && !*allMatches {
+		fmt.Fprintln(os.Stderr, "Usage: tasker done [--project <name>|none|all] [--column <col>] [--status <s>] [--tag <t>...] [--any-tag <t>...] [--not-tag <t>...] [--all] [--match <m>] [--force] [--all-matches [--dry-run]] <selector>")
 		return ExitUsage
 	}
 	selector := strings.Join(rest, " ")
@@ -2829,6 +4694,11 @@ func cmdDone(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "done:", err)
 		return ExitUsage
 	}
+	filter.Tags = tags.filter()
+	if *allMatches {
+		filtered := *project != "" || *column != "" || *status != "" || filter.Tags.Active()
+		return cmdBulkMove(ws, gf, "done", selector, filter, filtered, "", *force, *dryRun)
+	}
 	taskRef, err := ws.GetTaskBySelectorFiltered(selector, filter)
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
@@ -2836,7 +4706,7 @@ func cmdDone(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitNotFound
 		}
 		if errors.Is(err, store.ErrConflict) {
-			if handleMatchConflict("done", err) {
+			if handleMatchConflict(gf, "done", err) {
 				return ExitConflict
 			}
 			fmt.Fprintln(os.Stderr, "done: ambiguous selector")
@@ -2845,12 +4715,17 @@ func cmdDone(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		fmt.Fprintln(os.Stderr, "done:", err)
 		return ExitInternal
 	}
-	task, err := ws.MoveTask(taskRef.ID, "done")
+	task, err := ws.CompleteTask(taskRef.ID, store.MoveOptions{Force: *force})
 	if err != nil {
 		if errors.Is(err, store.ErrNotFound) {
 			fmt.Fprintln(os.Stderr, "done: not found")
 			return ExitNotFound
 		}
+		var blocked *store.BlockedError
+		if errors.As(err, &blocked) {
+			fmt.Fprintf(os.Stderr, "done: %v (use --force to complete anyway)\n", err)
+			return ExitConflict
+		}
 		if errors.Is(err, store.ErrConflict) {
 			fmt.Fprintln(os.Stderr, "done: ambiguous id prefix")
 			return ExitConflict
@@ -2880,6 +4755,7 @@ func cmdDone(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		title = "(untitled)"
 	}
 	fmt.Printf("Done %s\n", title)
+	printNextOccurrence(task)
 	return ExitOK
 }
 
@@ -2917,7 +4793,7 @@ func cmdNote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
 	column := fs.String("column", "", "Column id (filter)")
-	status := fs.String("status", "", "Status (open|doing|blocked|done|archived)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
 	all := fs.Bool("all", false, "Include archived")
 	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
 	if err := fs.Parse(args); err != nil {
@@ -2946,7 +4822,7 @@ func cmdNote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 				return ExitNotFound
 			}
 			if errors.Is(err, store.ErrConflict) {
-				if handleMatchConflict("note", err) {
+				if handleMatchConflict(gf, "note", err) {
 					return ExitConflict
 				}
 				fmt.Fprintln(os.Stderr, "note: ambiguous selector")
@@ -2986,7 +4862,7 @@ func cmdNote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitNotFound
 		}
 		if errors.Is(err, store.ErrConflict) {
-			if handleMatchConflict("note", err) {
+			if handleMatchConflict(gf, "note", err) {
 				return ExitConflict
 			}
 			fmt.Fprintln(os.Stderr, "note: ambiguous selector")
@@ -3022,22 +4898,42 @@ func cmdNote(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdBoard(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--open":    false,
-		"--all":     false,
+		"--project":        true,
+		"--open":           false,
+		"--all":            false,
+		"--per-column":     true,
+		"--watch":          false,
+		"--interval":       true,
+		"--hide-scheduled": false,
 	})
 	fs := flag.NewFlagSet("board", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	project := fs.String("project", "", "Project name/slug")
-	openOnly := fs.Bool("open", false, "Only open/doing/blocked")
+	openOnly := fs.Bool("open", false, "Only open-like statuses (open/doing/blocked and configured open_like ones)")
 	all := fs.Bool("all", false, "Include done/archived")
+	perColumn := fs.Int("per-column", 0, "Max tasks per column (telegram format)")
+	watch := fs.Bool("watch", false, "Redraw the board whenever the workspace changes")
+	interval := fs.Duration("interval", defaultWatchInterval, "How often --watch checks the date (and polls, without file notifications)")
+	hideScheduled := fs.Bool("hide-scheduled", false, "Hide tasks whose start date is still ahead")
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
 	if strings.TrimSpace(*project) == "" {
-		fmt.Fprintln(os.Stderr, "Usage: tasker board --project <name> [--open|--all]")
+		fmt.Fprintln(os.Stderr, "Usage: tasker board --project <name> [--open|--all] [--per-column <n>] [--hide-scheduled] [--watch [--interval <d>]]")
+		return ExitUsage
+	}
+	if *perColumn < 0 {
+		fmt.Fprintln(os.Stderr, "board: --per-column must be >= 0")
+		return ExitUsage
+	}
+	if *perColumn > 0 && gf.Format != "telegram" {
+		fmt.Fprintln(os.Stderr, "board: --per-column requires --format telegram")
 		return ExitUsage
 	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "board:", err)
+		return ExitNotFound
+	}
 	open := *openOnly
 	if *all {
 		open = false
@@ -3045,7 +4941,12 @@ func cmdBoard(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	if gf.Format == "telegram" && !*all && !*openOnly {
 		open = true
 	}
-	out, err := ws.RenderBoard(strings.TrimSpace(*project), gf.ASCII, gf.Format, open)
+	if *watch {
+		return watchRender(ws, "board", *interval, func() (string, error) {
+			return ws.RenderBoard(strings.TrimSpace(*project), gf.ASCII, gf.Format, open, *perColumn, *hideScheduled)
+		})
+	}
+	out, err := ws.RenderBoard(strings.TrimSpace(*project), gf.ASCII, gf.Format, open, *perColumn, *hideScheduled)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "board:", err)
 		return ExitInternal
@@ -3056,22 +4957,51 @@ func cmdBoard(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdToday(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--open":    false,
-		"--all":     false,
-		"--group":   true,
-		"--totals":  false,
+		"--project":       true,
+		"--open":          false,
+		"--all":           false,
+		"--group":         true,
+		"--totals":        false,
+		"--watch":         false,
+		"--interval":      true,
+		"--date":          true,
+		"--yesterday":     false,
+		"--sort":          true,
+		"--reverse":       false,
+		"--limit":         true,
+		"--offset":        true,
+		"--max-per-group": true,
 	})
 	fs := flag.NewFlagSet("today", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
-	project := fs.String("project", "", "Project name/slug")
-	openOnly := fs.Bool("open", false, "Only open/doing/blocked")
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable)")
+	openOnly := fs.Bool("open", false, "Only open-like statuses (open/doing/blocked and configured open_like ones)")
 	all := fs.Bool("all", false, "Include done/archived")
 	group := fs.String("group", "", "Group by project|column|none")
 	totals := fs.Bool("totals", false, "Show per-group totals")
+	watch := fs.Bool("watch", false, "Redraw the view whenever the workspace changes")
+	interval := fs.Duration("interval", defaultWatchInterval, "How often --watch checks the date (and polls, without file notifications)")
+	date := fs.String("date", "", "Render the view for another day (YYYY-MM-DD, yesterday, mon, ...)")
+	yesterday := fs.Bool("yesterday", false, "Same as --date yesterday")
+	order := addSortFlags(fs)
+	paging := addPageFlags(fs, true)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
+	viewSort, err := order.sort()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "today:", err)
+		return ExitUsage
+	}
+	ws.ViewSort = viewSort
+	if ws.ViewPage, err = paging.page(); err != nil {
+		fmt.Fprintln(os.Stderr, "today:", err)
+		return ExitUsage
+	}
+	if code := applyViewDate("today", *date, *yesterday); code != ExitOK {
+		return code
+	}
 	rest := fs.Args()
 	if len(rest) > 0 {
 		if len(rest) == 1 && (rest[0] == "today" || rest[0] == "now") {
@@ -3081,12 +5011,17 @@ func cmdToday(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitUsage
 		}
 	}
-	projectName := resolveProject(ws, *project)
-	open := resolveOpenOnly(ws, *openOnly, *all)
+	project := store.JoinProjectSpec(projects.Values)
+	if err := checkProjectSpec(ws, project); err != nil {
+		fmt.Fprintln(os.Stderr, "today:", err)
+		return ExitNotFound
+	}
+	projectName := resolveProject(ws, project)
+	open := resolveOpenOnly(ws, projectName, *openOnly, *all)
 	if gf.Format == "telegram" && !*all {
 		open = true
 	}
-	groupBy := resolveGroupBy(ws, *group)
+	groupBy := resolveGroupBy(ws, projectName, *group)
 	if groupBy == "none" {
 		groupBy = ""
 	}
@@ -3095,6 +5030,23 @@ func cmdToday(ws *store.Workspace, gf GlobalFlags, args []string) int {
 		return ExitUsage
 	}
 	showTotals := resolveShowTotals(ws, *totals)
+	if *watch {
+		if gf.JSON || gf.NDJSON {
+			fmt.Fprintln(os.Stderr, "today: --watch cannot be combined with --json/--ndjson")
+			return ExitUsage
+		}
+		return watchRender(ws, "today", *interval, func() (string, error) {
+			return ws.RenderToday(projectName, open, groupBy, showTotals, gf.Format)
+		})
+	}
+	if gf.JSON || gf.NDJSON {
+		view, err := ws.TodayView(projectName, open, groupBy)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "today:", err)
+			return ExitInternal
+		}
+		return emitAgendaView(gf, "today", view)
+	}
 	out, err := ws.RenderToday(projectName, open, groupBy, showTotals, gf.Format)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "today:", err)
@@ -3106,24 +5058,53 @@ func cmdToday(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdAgenda(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--days":    true,
-		"--open":    false,
-		"--all":     false,
-		"--group":   true,
-		"--totals":  false,
+		"--project":       true,
+		"--days":          true,
+		"--open":          false,
+		"--all":           false,
+		"--group":         true,
+		"--totals":        false,
+		"--watch":         false,
+		"--interval":      true,
+		"--date":          true,
+		"--yesterday":     false,
+		"--sort":          true,
+		"--reverse":       false,
+		"--limit":         true,
+		"--offset":        true,
+		"--max-per-group": true,
 	})
 	fs := flag.NewFlagSet("week", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
-	project := fs.String("project", "", "Project name/slug")
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable)")
 	days := fs.Int("days", 0, "Days ahead (default 7)")
-	openOnly := fs.Bool("open", false, "Only open/doing/blocked")
+	openOnly := fs.Bool("open", false, "Only open-like statuses (open/doing/blocked and configured open_like ones)")
 	all := fs.Bool("all", false, "Include done/archived")
 	group := fs.String("group", "", "Group by project|column|none")
 	totals := fs.Bool("totals", false, "Show per-group totals")
+	watch := fs.Bool("watch", false, "Redraw the view whenever the workspace changes")
+	interval := fs.Duration("interval", defaultWatchInterval, "How often --watch checks the date (and polls, without file notifications)")
+	date := fs.String("date", "", "Start the view on another day (YYYY-MM-DD, yesterday, mon, ...)")
+	yesterday := fs.Bool("yesterday", false, "Same as --date yesterday")
+	order := addSortFlags(fs)
+	paging := addPageFlags(fs, true)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
+	viewSort, err := order.sort()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "week:", err)
+		return ExitUsage
+	}
+	ws.ViewSort = viewSort
+	if ws.ViewPage, err = paging.page(); err != nil {
+		fmt.Fprintln(os.Stderr, "week:", err)
+		return ExitUsage
+	}
+	if code := applyViewDate("week", *date, *yesterday); code != ExitOK {
+		return code
+	}
 	rest := fs.Args()
 	if len(rest) > 0 {
 		if len(rest) == 1 && (rest[0] == "week" || rest[0] == "this-week" || rest[0] == "next") {
@@ -3133,21 +5114,43 @@ func cmdAgenda(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			return ExitUsage
 		}
 	}
-	projectName := resolveProject(ws, *project)
-	open := resolveOpenOnly(ws, *openOnly, *all)
+	project := store.JoinProjectSpec(projects.Values)
+	if err := checkProjectSpec(ws, project); err != nil {
+		fmt.Fprintln(os.Stderr, "week:", err)
+		return ExitNotFound
+	}
+	projectName := resolveProject(ws, project)
+	open := resolveOpenOnly(ws, projectName, *openOnly, *all)
 	if gf.Format == "telegram" && !*all {
 		open = true
 	}
-	window := resolveWeekDays(ws, *days)
-	groupBy := resolveGroupBy(ws, *group)
+	window := resolveWeekDays(ws, projectName, *days)
+	groupBy := resolveGroupBy(ws, projectName, *group)
 	if groupBy == "none" {
 		groupBy = ""
 	}
-	if groupBy != "" && groupBy != "project" && groupBy != "column" {
-		fmt.Fprintln(os.Stderr, "week: invalid --group (use project|column|none)")
+	if _, _, err := store.SplitAgendaGroup(groupBy); err != nil {
+		fmt.Fprintln(os.Stderr, "week: invalid --group (use project|column|none|project,day|column,day)")
 		return ExitUsage
 	}
 	showTotals := resolveShowTotals(ws, *totals)
+	if *watch {
+		if gf.JSON || gf.NDJSON {
+			fmt.Fprintln(os.Stderr, "week: --watch cannot be combined with --json/--ndjson")
+			return ExitUsage
+		}
+		return watchRender(ws, "week", *interval, func() (string, error) {
+			return ws.RenderAgenda(projectName, window, open, groupBy, showTotals, gf.Format)
+		})
+	}
+	if gf.JSON || gf.NDJSON {
+		view, err := ws.WeekView(projectName, window, open, groupBy)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "week:", err)
+			return ExitInternal
+		}
+		return emitAgendaView(gf, "week", view)
+	}
 	out, err := ws.RenderAgenda(projectName, window, open, groupBy, showTotals, gf.Format)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "week:", err)
@@ -3159,24 +5162,49 @@ func cmdAgenda(ws *store.Workspace, gf GlobalFlags, args []string) int {
 
 func cmdTasks(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	args = reorderFlags(args, map[string]bool{
-		"--project": true,
-		"--days":    true,
-		"--open":    false,
-		"--all":     false,
-		"--group":   true,
-		"--totals":  false,
+		"--project":       true,
+		"--days":          true,
+		"--open":          false,
+		"--all":           false,
+		"--group":         true,
+		"--totals":        false,
+		"--date":          true,
+		"--yesterday":     false,
+		"--sort":          true,
+		"--reverse":       false,
+		"--limit":         true,
+		"--offset":        true,
+		"--max-per-group": true,
 	})
 	fs := flag.NewFlagSet("tasks", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
-	project := fs.String("project", "", "Project name/slug")
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable)")
 	days := fs.Int("days", 0, "Days ahead (for week/agenda)")
-	openOnly := fs.Bool("open", false, "Only open/doing/blocked")
+	openOnly := fs.Bool("open", false, "Only open-like statuses (open/doing/blocked and configured open_like ones)")
 	all := fs.Bool("all", false, "Include done/archived")
 	group := fs.String("group", "", "Group by project|column|none")
 	totals := fs.Bool("totals", false, "Show per-group totals")
+	date := fs.String("date", "", "Render the view for another day (YYYY-MM-DD, yesterday, mon, ...)")
+	yesterday := fs.Bool("yesterday", false, "Same as --date yesterday")
+	order := addSortFlags(fs)
+	paging := addPageFlags(fs, true)
 	if err := fs.Parse(args); err != nil {
 		return ExitUsage
 	}
+	viewSort, err := order.sort()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tasks:", err)
+		return ExitUsage
+	}
+	ws.ViewSort = viewSort
+	if ws.ViewPage, err = paging.page(); err != nil {
+		fmt.Fprintln(os.Stderr, "tasks:", err)
+		return ExitUsage
+	}
+	if code := applyViewDate("tasks", *date, *yesterday); code != ExitOK {
+		return code
+	}
 	rest := fs.Args()
 	mode := ""
 	if len(rest) > 0 {
@@ -3200,22 +5228,46 @@ func cmdTasks(ws *store.Workspace, gf GlobalFlags, args []string) int {
 			mode = "today"
 		}
 	}
-	projectName := resolveProject(ws, *project)
-	open := resolveOpenOnly(ws, *openOnly, *all)
+	project := store.JoinProjectSpec(projects.Values)
+	if err := checkProjectSpec(ws, project); err != nil {
+		fmt.Fprintln(os.Stderr, "tasks:", err)
+		return ExitNotFound
+	}
+	projectName := resolveProject(ws, project)
+	open := resolveOpenOnly(ws, projectName, *openOnly, *all)
 	if gf.Format == "telegram" && !*all {
 		open = true
 	}
-	groupBy := resolveGroupBy(ws, *group)
+	groupBy := resolveGroupBy(ws, projectName, *group)
 	if groupBy == "none" {
 		groupBy = ""
 	}
-	if groupBy != "" && groupBy != "project" && groupBy != "column" {
+	if mode == "week" {
+		if _, _, err := store.SplitAgendaGroup(groupBy); err != nil {
+			fmt.Fprintln(os.Stderr, "tasks: invalid --group (use project|column|none|project,day|column,day)")
+			return ExitUsage
+		}
+	} else if groupBy != "" && groupBy != "project" && groupBy != "column" {
 		fmt.Fprintln(os.Stderr, "tasks: invalid --group (use project|column|none)")
 		return ExitUsage
 	}
 	showTotals := resolveShowTotals(ws, *totals)
+	if gf.JSON || gf.NDJSON {
+		var view *store.AgendaView
+		var err error
+		if mode == "week" {
+			view, err = ws.WeekView(projectName, resolveWeekDays(ws, projectName, *days), open, groupBy)
+		} else {
+			view, err = ws.TodayView(projectName, open, groupBy)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "tasks:", err)
+			return ExitInternal
+		}
+		return emitAgendaView(gf, mode, view)
+	}
 	if mode == "week" {
-		window := resolveWeekDays(ws, *days)
+		window := resolveWeekDays(ws, projectName, *days)
 		out, err := ws.RenderAgenda(projectName, window, open, groupBy, showTotals, gf.Format)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "tasks:", err)
@@ -3233,6 +5285,48 @@ func cmdTasks(ws *store.Workspace, gf GlobalFlags, args []string) int {
 	return ExitOK
 }
 
+// applyViewDate pins the store clock to the same time of day on the date
+// given with --date/--yesterday, so today/week render that day.
+func applyViewDate(cmd string, date string, yesterday bool) int {
+	if yesterday {
+		if strings.TrimSpace(date) != "" {
+			fmt.Fprintf(os.Stderr, "%s: use --date or --yesterday, not both\n", cmd)
+			return ExitUsage
+		}
+		date = "yesterday"
+	}
+	if strings.TrimSpace(date) == "" {
+		return ExitOK
+	}
+	now := store.Now()
+	resolved, err := resolveDue(date, now)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: --date: %v\n", cmd, err)
+		return ExitUsage
+	}
+	day, err := time.Parse("2006-01-02", resolved[:min(len(resolved), 10)])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: --date: %v\n", cmd, err)
+		return ExitUsage
+	}
+	today := now.Truncate(24 * time.Hour)
+	store.SetNow(now.Add(day.Sub(today)))
+	return ExitOK
+}
+
+// emitAgendaView writes a today/week view: the whole view for --json, one
+// section per line for --ndjson.
+func emitAgendaView(gf GlobalFlags, label string, view *store.AgendaView) int {
+	if gf.NDJSON {
+		items := make([]any, 0, len(view.Sections))
+		for _, sec := range view.Sections {
+			items = append(items, sec)
+		}
+		return emitNDJSONItems(gf, label, label, items)
+	}
+	return emitJSONPayload(gf, label, label, view)
+}
+
 // multiFlag supports repeated --tag flags.
 type multiFlag struct{ Values []string }
 
@@ -3242,6 +5336,45 @@ func (m *multiFlag) Set(v string) error {
 	return nil
 }
 
+// emitJSONPayload writes payload to stdout with --stdout-json, otherwise to an export file.
+func emitJSONPayload(gf GlobalFlags, label string, base string, payload any) int {
+	if gf.StdoutJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(payload)
+		return ExitOK
+	}
+	path, err := writeJSONExport(gf, base, payload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		return ExitInternal
+	}
+	if !gf.Quiet {
+		fmt.Println("Wrote JSON to:", path)
+	}
+	return ExitOK
+}
+
+// emitNDJSONItems writes items to stdout with --stdout-ndjson, otherwise to an export file.
+func emitNDJSONItems(gf GlobalFlags, label string, base string, items []any) int {
+	if gf.StdoutNDJSON {
+		for _, item := range items {
+			b, _ := json.Marshal(item)
+			fmt.Println(string(b))
+		}
+		return ExitOK
+	}
+	path, err := writeNDJSONExport(gf, base, items)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		return ExitInternal
+	}
+	if !gf.Quiet {
+		fmt.Println("Wrote NDJSON to:", path)
+	}
+	return ExitOK
+}
+
 func writeJSONExport(gf GlobalFlags, base string, payload any) (string, error) {
 	data, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
@@ -3274,21 +5407,94 @@ func writeExportFile(dir, base, ext string, data []byte) (string, error) {
 	ts := t.Format("20060102-150405")
 	name := fmt.Sprintf("%s-%s.%s", base, ts, ext)
 	path := filepath.Join(dir, name)
+	// Reserve the name with O_EXCL so two processes exporting in the same
+	// second never pick the same file.
 	for i := 1; ; i++ {
-		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_ = f.Close()
 			break
 		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", err
+		}
 		name = fmt.Sprintf("%s-%s-%d.%s", base, ts, i, ext)
 		path = filepath.Join(dir, name)
 	}
-	tmp := filepath.Join(dir, fmt.Sprintf(".tmp-%d", time.Now().UTC().UnixNano()))
-	if err := os.WriteFile(tmp, data, 0o644); err != nil {
-		_ = os.Remove(tmp)
-		return "", err
-	}
-	if err := os.Rename(tmp, path); err != nil {
-		_ = os.Remove(tmp)
+	if err := replaceFile(dir, path, data); err != nil {
+		_ = os.Remove(path)
 		return "", err
 	}
 	return path, nil
 }
+
+// replaceFile writes data to a uniquely named temp file in dir and renames it
+// onto path, so readers never see a partial file and concurrent writers never
+// share a temp file.
+func replaceFile(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func cmdScheduled(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--days":    true,
+	})
+	fs := flag.NewFlagSet("scheduled", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug")
+	days := fs.Int("days", 0, "Only tasks starting within N days (default: all)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 || *days < 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker scheduled [--project <name>] [--days N]")
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "scheduled:", err)
+		return ExitNotFound
+	}
+	projectName := resolveProject(ws, *project)
+	if gf.Plain || gf.JSON {
+		tasks, err := ws.ScheduledTasks(projectName, *days)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "scheduled:", err)
+			return ExitInternal
+		}
+		if gf.Plain {
+			for _, t := range tasks {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s/%s\t%s\n", t.ID, t.Start, t.Due, t.Project, t.Column, t.Title)
+			}
+			return ExitOK
+		}
+		if tasks == nil {
+			tasks = []store.Task{}
+		}
+		return emitJSONPayload(gf, "scheduled", "scheduled", map[string]any{"project": projectName, "tasks": tasks})
+	}
+	out, err := ws.RenderScheduled(projectName, *days, gf.Format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "scheduled:", err)
+		return ExitInternal
+	}
+	fmt.Println(out)
+	return ExitOK
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const cloneUsage = "Usage: tasker clone <selector...> [--project <name>] [--column <col>] [--title <title>] [--reset-due] [--no-notes]"
+
+// cmdClone copies a task under a new ID, as a fresh open task.
+func cmdClone(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project":   true,
+		"--column":    true,
+		"--title":     true,
+		"--match":     true,
+		"--reset-due": false,
+		"--no-notes":  false,
+	})
+	fs := flag.NewFlagSet("clone", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project for the copy (default: the source's)")
+	column := fs.String("column", "", "Column for the copy (default: the source's if open, else the project's default)")
+	title := fs.String("title", "", "Title for the copy (default: the source's)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	resetDue := fs.Bool("reset-due", false, "Drop the due date, due time and start date")
+	noNotes := fs.Bool("no-notes", false, "Drop the timestamped note entries")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, cloneUsage)
+		return ExitUsage
+	}
+	// --project names the copy's project, so the source is looked up in all.
+	filter, err := selectorFilter(ws, "all", "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clone:", err)
+		return ExitUsage
+	}
+	src, code := resolveTaskSelector(ws, gf, "clone", strings.Join(fs.Args(), " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	task, err := ws.CloneTask(src.ID, store.CloneOptions{
+		Project:   *project,
+		Column:    *column,
+		Title:     *title,
+		ResetDue:  *resetDue,
+		DropNotes: *noNotes,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "clone:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", task.ID, task.Project, task.Column, task.Title)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "clone", "task", map[string]any{"task": task, "source": src.ID})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Cloned %s as %s (%s/%s)\n", src.ID, task.ID, task.Project, task.Column)
+	}
+	return ExitOK
+}
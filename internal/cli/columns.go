@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const columnsUsage = `Usage: tasker config columns [ls] [--project <name>]
+       tasker config columns add <id> [--name <name>] [--status <status>] [--after <id>] [--project <name>]
+       tasker config columns rm <id> [--project <name>]
+       tasker config columns rename <id> <name> [--id <new-id>] [--project <name>]
+       tasker config columns reorder <id>... [--project <name>]`
+
+func cmdConfigColumns(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	sub := "ls"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		sub, args = args[0], args[1:]
+	}
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--name":    true,
+		"--status":  true,
+		"--after":   true,
+		"--id":      true,
+	})
+	fs := flag.NewFlagSet("config columns", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Edit this project's columns instead of the workspace columns")
+	name := fs.String("name", "", "Display name")
+	status := fs.String("status", "open", "Status of tasks in the column (open|doing|blocked|done|archived or a configured status)")
+	after := fs.String("after", "", "Insert after this column id (default: last)")
+	newID := fs.String("id", "", "New column id (rename)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "config columns:", err)
+		return ExitUsage
+	}
+	change := store.ColumnChange{Project: *project}
+	label := "config columns " + sub
+	var err error
+	switch sub {
+	case "ls", "list":
+		if len(rest) != 0 {
+			fmt.Fprintln(os.Stderr, columnsUsage)
+			return ExitUsage
+		}
+	case "add":
+		if len(rest) != 1 {
+			fmt.Fprintln(os.Stderr, columnsUsage)
+			return ExitUsage
+		}
+		_, err = ws.AddColumn(change, store.ColumnDef{ID: rest[0], Name: *name, Status: strings.ToLower(strings.TrimSpace(*status))}, *after)
+	case "rm", "remove":
+		if len(rest) != 1 {
+			fmt.Fprintln(os.Stderr, columnsUsage)
+			return ExitUsage
+		}
+		err = ws.RemoveColumn(change, rest[0])
+	case "rename":
+		if len(rest) < 1 || (len(rest) < 2 && *newID == "") {
+			fmt.Fprintln(os.Stderr, columnsUsage)
+			return ExitUsage
+		}
+		_, err = ws.RenameColumn(change, rest[0], strings.Join(rest[1:], " "), *newID)
+	case "reorder":
+		if len(rest) == 0 {
+			fmt.Fprintln(os.Stderr, columnsUsage)
+			return ExitUsage
+		}
+		_, err = ws.ReorderColumns(change, rest)
+	default:
+		fmt.Fprintln(os.Stderr, columnsUsage)
+		return ExitUsage
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	return printColumns(ws, gf, *project)
+}
+
+func printColumns(ws *store.Workspace, gf GlobalFlags, project string) int {
+	cols := ws.Columns(project)
+	if gf.Plain {
+		for _, c := range cols {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Dir, c.Status)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "config columns", "columns", map[string]any{"project": project, "columns": cols})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	scope := "Workspace columns:"
+	if strings.TrimSpace(project) != "" {
+		scope = fmt.Sprintf("Columns for %s:", project)
+	}
+	fmt.Println(scope)
+	for _, c := range cols {
+		fmt.Printf("  %s: %s (dir=%s, status=%s)\n", c.ID, c.Name, c.Dir, c.Status)
+	}
+	return ExitOK
+}
@@ -0,0 +1,173 @@
+package cli
+
+import "strings"
+
+// command is one top-level command (or another spelling of one) as Run
+// dispatches it.
+type command struct {
+	name string
+	// mutates reports whether an invocation with these arguments writes to
+	// the workspace; such invocations hold the workspace lock and run the
+	// post-write hooks. nil means the command only reads.
+	mutates func(args []string) bool
+}
+
+// commands lists every public command in help order; completion and
+// did-you-mean suggestions offer them in this order.
+var commands = []command{
+	{name: "help"},
+	{name: "init", mutates: always},
+	{name: "onboarding"},
+	{name: "workflow", mutates: always},
+	{name: "alias", mutates: exceptSubcommand("ls", "list")},
+	{name: "config", mutates: configMutates},
+	{name: "cfg", mutates: configMutates},
+	{name: "project", mutates: onSubcommand("add", "import", "set", "rename", "archive", "unarchive", "rm", "remove")},
+	{name: "idea", mutates: ideaMutates},
+	{name: "ideas", mutates: ideaMutates},
+	{name: "add", mutates: always},
+	{name: "capture", mutates: always},
+	{name: "ls"},
+	{name: "list"},
+	{name: "find"},
+	{name: "tag", mutates: both(onSubcommand("rename", "mv", "rm", "remove"), unlessFlag("--dry-run"))},
+	{name: "tags", mutates: both(onSubcommand("rename", "mv", "rm", "remove"), unlessFlag("--dry-run"))},
+	{name: "show"},
+	{name: "resolve"},
+	{name: "mv", mutates: unlessFlag("--dry-run")},
+	{name: "move", mutates: unlessFlag("--dry-run")},
+	{name: "done", mutates: unlessFlag("--dry-run")},
+	{name: "open", mutates: unlessFlag("--dry-run")},
+	{name: "edit", mutates: unlessFlag("--dry-run")},
+	{name: "note", mutates: always},
+	{name: "rm", mutates: always},
+	{name: "delete", mutates: always},
+	{name: "trash", mutates: onSubcommand("restore")},
+	{name: "archive", mutates: unlessFlag("--dry-run")},
+	{name: "escalate", mutates: unlessFlag("--dry-run")},
+	{name: "undo", mutates: unlessFlag("--list", "--dry-run")},
+	{name: "subtask", mutates: exceptSubcommand("ls", "list")},
+	{name: "checklist", mutates: exceptSubcommand("ls", "list")},
+	{name: "dep", mutates: onSubcommand("add", "rm", "remove")},
+	{name: "deps", mutates: onSubcommand("add", "rm", "remove")},
+	{name: "link", mutates: always},
+	{name: "unlink", mutates: always},
+	{name: "links"},
+	{name: "attach", mutates: always},
+	{name: "clone", mutates: always},
+	{name: "start", mutates: always},
+	{name: "stop", mutates: always},
+	{name: "log", mutates: always},
+	{name: "timesheet"},
+	// index rebuild and export write only derived files (the task index
+	// cache, export files), each replaced atomically, as any listing already
+	// does for the index without the lock. Treating them as writes would run
+	// auto-archive and auto-commit after a read.
+	{name: "index"},
+	{name: "board"},
+	{name: "today"},
+	{name: "tasks"},
+	{name: "summary"},
+	{name: "week"},
+	{name: "agenda"},
+	{name: "upcoming"},
+	{name: "scheduled"},
+	{name: "export"}, // see index
+	{name: "import", mutates: unlessFlag("--dry-run")},
+	{name: "sync", mutates: unlessFlag("--dry-run")},
+	{name: "apply", mutates: always},
+	{name: "diff"},
+	{name: "snapshot", mutates: onSubcommand("create", "new", "restore", "rm", "delete")},
+	{name: "brief"},
+	{name: "health"},
+	{name: "validate"},
+	{name: "du"},
+	{name: "report"},
+	{name: "history"},
+	{name: "doctor", mutates: withFlag("--rollback", "--replay", "--fix")},
+	{name: "metrics"},
+	// serve and mcp lock per request; holding the lock while they run
+	// would shut every other tasker out.
+	{name: "serve"},
+	{name: "mcp"},
+	{name: "env"},
+	{name: "exitcodes"},
+	{name: "exit-codes"},
+}
+
+var commandNames = func() []string {
+	names := make([]string, len(commands))
+	for i, c := range commands {
+		names[i] = c.name
+	}
+	return names
+}()
+
+// isMutatingInvocation reports whether a command writes to the workspace.
+func isMutatingInvocation(cmd string, cmdArgs []string) bool {
+	for _, c := range commands {
+		if c.name == cmd {
+			return c.mutates != nil && c.mutates(cmdArgs)
+		}
+	}
+	return false
+}
+
+func always([]string) bool { return true }
+
+func hasArg(args []string, names ...string) bool {
+	for _, a := range args {
+		for _, name := range names {
+			if a == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// withFlag mutates only when one of flags is given.
+func withFlag(flags ...string) func([]string) bool {
+	return func(args []string) bool { return hasArg(args, flags...) }
+}
+
+// unlessFlag mutates unless one of flags (e.g. --dry-run) is given.
+func unlessFlag(flags ...string) func([]string) bool {
+	return func(args []string) bool { return !hasArg(args, flags...) }
+}
+
+// onSubcommand mutates for the listed subcommands only.
+func onSubcommand(subs ...string) func([]string) bool {
+	return func(args []string) bool { return len(args) > 0 && hasArg(args[:1], subs...) }
+}
+
+// exceptSubcommand mutates for every subcommand but the listed ones.
+func exceptSubcommand(subs ...string) func([]string) bool {
+	return func(args []string) bool { return len(args) == 0 || !hasArg(args[:1], subs...) }
+}
+
+func both(a, b func([]string) bool) func([]string) bool {
+	return func(args []string) bool { return a(args) && b(args) }
+}
+
+// configMutates: set and edit write, and so does `config columns` with an
+// action other than listing.
+func configMutates(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] == "columns" || args[0] == "column" {
+		return len(args) > 1 && !strings.HasPrefix(args[1], "-") && args[1] != "ls" && args[1] != "list"
+	}
+	return args[0] == "set" || args[0] == "edit"
+}
+
+var ideaWrites = onSubcommand("add", "capture", "note", "append", "edit", "tag", "tags", "archive", "unarchive", "rm", "remove", "delete", "score", "triage")
+
+// ideaMutates: idea writes, and promote unless it is a dry run.
+func ideaMutates(args []string) bool {
+	if len(args) > 0 && args[0] == "promote" {
+		return !hasArg(args, "--dry-run")
+	}
+	return ideaWrites(args)
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsMutatingInvocation(t *testing.T) {
+	cases := []struct {
+		line string
+		want bool
+	}{
+		{"add Buy milk", true},
+		{"ls --project work", false},
+		{"mv milk done", true},
+		{"mv milk done --dry-run", false},
+		{"undo", true},
+		{"undo --list", false},
+		{"tag rename a b", true},
+		{"tag rename a b --dry-run", false},
+		{"tag ls", false},
+		{"project add Home", true},
+		{"project ls", false},
+		{"subtask", true},
+		{"subtask ls milk", false},
+		{"config set agenda.due_soon 3d", true},
+		{"config show", false},
+		{"config columns add review", true},
+		{"config columns ls", false},
+		{"config columns --project work", false},
+		{"idea promote x", true},
+		{"idea promote x --dry-run", false},
+		{"idea ls", false},
+		{"doctor", false},
+		{"doctor --fix", true},
+		{"trash restore x", true},
+		{"trash ls", false},
+		{"sync git", true},
+		{"sync git --dry-run", false},
+		{"serve", false},
+		{"mcp", false},
+		{"nosuchcommand", false},
+	}
+	for _, c := range cases {
+		words := strings.Fields(c.line)
+		if got := isMutatingInvocation(words[0], words[1:]); got != c.want {
+			t.Fatalf("%q: mutating = %t, want %t", c.line, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const completeUsage = "Usage: tasker _complete --line <command line> [--point <n>]"
+
+// maxCompletions caps the candidates returned for one word.
+const maxCompletions = 50
+
+// completion is one candidate for the word being completed.
+type completion struct {
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// subcommands are the completable second words of commands that take one.
+var subcommands = map[string][]string{
+	"alias":     {"add", "ls", "rm"},
+	"config":    {"show", "set", "columns", "edit"},
+	"cfg":       {"show", "set", "columns", "edit"},
+	"project":   {"add", "ls", "set", "rename", "archive", "unarchive", "rm", "export", "import"},
+	"idea":      {"add", "capture", "ls", "show", "resolve", "note", "append", "edit", "tag", "archive", "unarchive", "rm", "score", "triage", "promote"},
+	"ideas":     {"add", "capture", "ls", "show", "resolve", "note", "append", "edit", "tag", "archive", "unarchive", "rm", "score", "triage", "promote"},
+	"workflow":  {"init", "prompts", "schedule"},
+	"trash":     {"ls", "restore"},
+	"archive":   {"compact"},
+	"tag":       {"ls", "rename", "rm"},
+	"tags":      {"ls", "rename", "rm"},
+	"subtask":   {"add", "done", "undo", "ls"},
+	"checklist": {"add", "done", "undo", "ls"},
+	"dep":       {"add", "rm", "ls", "graph"},
+	"deps":      {"add", "rm", "ls", "graph"},
+	"index":     {"rebuild", "status"},
+	"tasks":     {"today", "week"},
+	"summary":   {"today", "week"},
+	"export":    {"metrics", "ical", "todotxt", "csv", "obsidian"},
+	"import":    {"todotxt", "csv"},
+	"sync":      {"github", "git"},
+	"snapshot":  {"create", "ls", "restore", "rm"},
+	"report":    {"burndown", "cfd"},
+}
+
+// taskCommands take a task selector as their positional words.
+var taskCommands = map[string]bool{
+	"show": true, "resolve": true, "mv": true, "move": true, "done": true, "edit": true, "open": true,
+	"note": true, "rm": true, "delete": true, "start": true, "stop": true, "log": true,
+	"links": true, "link": true, "unlink": true, "attach": true, "clone": true,
+}
+
+// completeValueFlags lists the flags whose value can be completed, by kind.
+var completeValueFlags = map[string]string{
+	"--project":    "project",
+	"--to-project": "project",
+	"--column":     "column",
+	"--status":     "status",
+	"--tag":        "tag",
+	"--any-tag":    "tag",
+	"--not-tag":    "tag",
+	"--add-tag":    "tag",
+	"--remove-tag": "tag",
+	"--priority":   "priority",
+	"--sort":       "sort",
+	"--format":     "format",
+	"--root":       "file",
+	"--export-dir": "file",
+	"--out":        "file",
+	"--file":       "file",
+	"--batch":      "file",
+}
+
+var globalFlagNames = []string{
+	"--root", "--format", "--json", "--ndjson", "--stdout-json", "--stdout-ndjson",
+	"--export-dir", "--plain", "--ascii", "--quiet", "--silent", "--verbose",
+	"--no-lock", "--lock-timeout", "--now",
+}
+
+// cmdComplete is the hidden completion endpoint behind the shell scripts and
+// chat autocomplete. It takes the whole command line as one argument (bash
+// passes $COMP_LINE and $COMP_POINT) so global flags in it are not parsed,
+// completes the word that ends at --point, and prints JSON, or one
+// value<TAB>description line per candidate with --plain.
+func cmdComplete(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--line":  true,
+		"--point": true,
+	})
+	fs := flag.NewFlagSet("_complete", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	line := fs.String("line", "", "Command line being completed")
+	point := fs.Int("point", -1, "Cursor offset in bytes (default: end of line)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, completeUsage)
+		return ExitUsage
+	}
+	text := *line
+	if *point >= 0 && *point < len(text) {
+		text = text[:*point]
+	}
+	words, word := completionWords(text)
+	kind, candidates := completeWord(ws, words, word)
+
+	if gf.Plain {
+		for _, c := range candidates {
+			fmt.Fprintf(os.Stdout, "%s\t%s\n", c.Value, c.Description)
+		}
+		return ExitOK
+	}
+	if candidates == nil {
+		candidates = []completion{}
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(map[string]any{
+		"word":       word,
+		"kind":       kind,
+		"candidates": candidates,
+		"truncated":  len(candidates) == maxCompletions,
+	})
+	return ExitOK
+}
+
+// completionWords splits text into the finished words and the partial word
+// at the end ("" after trailing whitespace). An unterminated quote counts as
+// closed, so its contents are the partial word, and a leading "tasker" is
+// dropped.
+func completionWords(text string) ([]string, string) {
+	open := false
+	words, err := splitCommandLine(text)
+	for _, q := range []string{`"`, `'`} {
+		if err == nil {
+			break
+		}
+		words, err = splitCommandLine(text + q)
+		open = err == nil
+	}
+	if err != nil {
+		words = strings.Fields(text)
+	}
+	word := ""
+	if n := len(words); n > 0 && (open || strings.TrimRight(text, " \t") == text) {
+		words, word = words[:n-1], words[n-1]
+	}
+	if len(words) > 0 && filepath.Base(words[0]) == "tasker" {
+		words = words[1:]
+	}
+	return words, word
+}
+
+// completeWord picks the candidates for word given the words before it.
+func completeWord(ws *store.Workspace, words []string, word string) (string, []completion) {
+	if n := len(words); n > 0 {
+		if kind, ok := completeValueFlags[words[n-1]]; ok {
+			return kind, completeValues(ws, kind, words, word)
+		}
+	}
+	if strings.HasPrefix(word, "-") {
+		return "flag", matchCompletions(word, globalFlagNames, nil)
+	}
+	i := commandIndex(words)
+	if i < 0 {
+		names := append(append([]string{}, commandNames...), ws.Config().AliasNames()...)
+		return "command", matchCompletions(word, names, nil)
+	}
+	cmd := words[i]
+	positional := positionalWords(words[i+1:])
+	if subs, ok := subcommands[cmd]; ok && len(positional) == 0 {
+		return "subcommand", matchCompletions(word, subs, nil)
+	}
+	if taskCommands[cmd] {
+		if (cmd == "mv" || cmd == "move") && len(positional) > 0 {
+			return "column", completeValues(ws, "column", words, word)
+		}
+		if cmd == "attach" && len(positional) > 0 {
+			// The file: left to the shell.
+			return "file", nil
+		}
+		return "task", completeTasks(ws, flagValue(words, "--project"), word)
+	}
+	return "", nil
+}
+
+// positionalWords drops flags, and the values of flags known to take one.
+func positionalWords(words []string) []string {
+	var out []string
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		if _, ok := completeValueFlags[w]; ok {
+			i++
+			continue
+		}
+		if strings.HasPrefix(w, "-") {
+			continue
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// flagValue is the last value given to name in words, or "".
+func flagValue(words []string, name string) string {
+	value := ""
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == name {
+			value = words[i+1]
+		}
+	}
+	return value
+}
+
+func completeValues(ws *store.Workspace, kind string, words []string, word string) []completion {
+	switch kind {
+	case "project":
+		projects, err := ws.ActiveProjects()
+		if err != nil {
+			return nil
+		}
+		values := make([]string, 0, len(projects))
+		desc := map[string]string{}
+		for _, p := range projects {
+			values = append(values, p.Slug)
+			desc[p.Slug] = p.Name
+		}
+		return matchCompletions(word, values, desc)
+	case "column":
+		var values []string
+		desc := map[string]string{}
+		for _, c := range ws.Columns(flagValue(words, "--project")) {
+			values = append(values, c.ID)
+			desc[c.ID] = c.Name
+		}
+		return matchCompletions(word, values, desc)
+	case "status":
+		var values []string
+		for _, s := range ws.Config().StatusDefs() {
+			values = append(values, s.ID)
+		}
+		return matchCompletions(word, values, nil)
+	case "tag":
+		return matchCompletions(word, workspaceTags(ws), nil)
+	case "priority":
+		return matchCompletions(word, []string{"low", "normal", "high", "urgent"}, nil)
+	case "sort":
+		if i := commandIndex(words); i >= 0 && (words[i] == "idea" || words[i] == "ideas") {
+			return matchCompletions(word, store.IdeaSortFields, nil)
+		}
+		return matchCompletions(word, store.SortFields, nil)
+	case "format":
+		return matchCompletions(word, []string{"human", "telegram"}, nil)
+	}
+	// file: left to the shell.
+	return nil
+}
+
+// workspaceTags lists every tag used by a task or idea, sorted.
+func workspaceTags(ws *store.Workspace) []string {
+	counts, err := ws.TagCounts("")
+	if err != nil {
+		return nil
+	}
+	tags := make([]string, 0, len(counts))
+	for _, c := range counts {
+		tags = append(tags, c.Tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// completeTasks offers the titles of open tasks whose title or id starts
+// with word (case-insensitive), most recently updated first.
+func completeTasks(ws *store.Workspace, project string, word string) []completion {
+	tasks, err := ws.ListTasks(store.ListFilter{Project: project})
+	if err != nil {
+		return nil
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i].UpdatedAt, tasks[j].UpdatedAt
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return a.After(*b)
+	})
+	prefix := strings.ToLower(word)
+	var out []completion
+	for _, t := range tasks {
+		title := taskTitleOrUntitled(t.Title)
+		if !strings.HasPrefix(strings.ToLower(title), prefix) && !strings.HasPrefix(strings.ToLower(t.ID), prefix) {
+			continue
+		}
+		out = append(out, completion{Value: title, Description: t.ID + " " + t.Project + "/" + t.Column})
+		if len(out) == maxCompletions {
+			break
+		}
+	}
+	return out
+}
+
+// matchCompletions keeps the values starting with word (case-insensitive),
+// in order and without duplicates.
+func matchCompletions(word string, values []string, desc map[string]string) []completion {
+	prefix := strings.ToLower(word)
+	seen := map[string]bool{}
+	var out []completion
+	for _, v := range values {
+		if seen[v] || !strings.HasPrefix(strings.ToLower(v), prefix) {
+			continue
+		}
+		seen[v] = true
+		out = append(out, completion{Value: v, Description: desc[v]})
+		if len(out) == maxCompletions {
+			break
+		}
+	}
+	return out
+}
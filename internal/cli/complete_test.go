@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// caseLiterals collects the string literals of the case clauses of every
+// switch in the function name of cli.go whose tag is the identifier tag.
+func caseLiterals(t *testing.T, name string, tag string) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "cli.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []string
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != name {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok {
+				return true
+			}
+			if id, ok := sw.Tag.(*ast.Ident); !ok || id.Name != tag {
+				return true
+			}
+			for _, stmt := range sw.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						s, _ := strconv.Unquote(lit.Value)
+						out = append(out, s)
+					}
+				}
+			}
+			return true
+		})
+	}
+	if len(out) == 0 {
+		t.Fatalf("no switch on %s in %s", tag, name)
+	}
+	return out
+}
+
+// sameSet fails unless got and want hold the same strings.
+func sameSet(t *testing.T, what string, got, want []string) {
+	t.Helper()
+	index := func(list []string) map[string]bool {
+		m := map[string]bool{}
+		for _, s := range list {
+			m[s] = true
+		}
+		return m
+	}
+	g, w := index(got), index(want)
+	var missing, extra []string
+	for s := range w {
+		if !g[s] {
+			missing = append(missing, s)
+		}
+	}
+	for s := range g {
+		if !w[s] {
+			extra = append(extra, s)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	if len(missing) > 0 || len(extra) > 0 {
+		t.Fatalf("%s: missing %v, stale %v", what, missing, extra)
+	}
+}
+
+func TestCompletionListsCurrentCommandsAndFlags(t *testing.T) {
+	// Every command dispatch handles is completable, hidden "_" commands
+	// and the -h spellings of help aside, and nothing else is.
+	var dispatched []string
+	for _, name := range caseLiterals(t, "dispatch", "cmd") {
+		if !strings.HasPrefix(name, "_") && !strings.HasPrefix(name, "-") {
+			dispatched = append(dispatched, name)
+		}
+	}
+	sameSet(t, "command names", commandNames, dispatched)
+
+	var parsed []string
+	for _, name := range caseLiterals(t, "extractGlobalFlags", "a") {
+		if strings.HasPrefix(name, "--") {
+			parsed = append(parsed, name)
+		}
+	}
+	sameSet(t, "global flags", globalFlagNames, parsed)
+}
+
+func TestCompleteWord(t *testing.T) {
+	ws := newTestWorkspace(t)
+	if _, err := ws.AddTask(store.AddTaskInput{Title: "Buy milk", Project: "Work", Tags: []string{"errand"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.SetAlias("tdy", "today --json"); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		line string
+		kind string
+		want []string
+	}{
+		{"tasker ag", "command", []string{"agenda"}},
+		{"tasker td", "command", []string{"tdy"}},
+		{"tasker --json ex", "command", []string{"export", "exitcodes", "exit-codes"}},
+		{"tasker export ", "subcommand", []string{"metrics", "ical", "todotxt", "csv", "obsidian"}},
+		{"tasker sync g", "subcommand", []string{"github", "git"}},
+		{"tasker ls --lock", "flag", []string{"--lock-timeout"}},
+		{"tasker ls --project ", "project", []string{"work"}},
+		{"tasker ls --tag e", "tag", []string{"errand"}},
+		{"tasker ls --priority u", "priority", []string{"urgent"}},
+		{"tasker ls --project work --column do", "column", []string{"doing", "done"}},
+		{`tasker done "buy m`, "task", []string{"Buy milk"}},
+		{`tasker mv "Buy milk" bl`, "column", []string{"blocked"}},
+		{"tasker attach milk ", "file", nil},
+		{"tasker board ", "", nil},
+	}
+	for _, c := range cases {
+		words, word := completionWords(c.line)
+		kind, got := completeWord(ws, words, word)
+		var values []string
+		for _, cand := range got {
+			values = append(values, cand.Value)
+		}
+		if kind != c.kind || strings.Join(values, ",") != strings.Join(c.want, ",") {
+			t.Fatalf("%q: got %s %v, want %s %v", c.line, kind, values, c.kind, c.want)
+		}
+	}
+}
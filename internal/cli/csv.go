@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdExportCSV(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--all":     false,
+		"--map":     true,
+		"--out":     true,
+	})
+	fs := flag.NewFlagSet("export csv", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (default: all projects)")
+	all := fs.Bool("all", false, "Include archived tasks")
+	mapSpec := fs.String("map", "", "Rename headers: field=Header pairs, comma-separated")
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/tasks[-<project>].csv)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	mapping, err := store.ParseCSVMapping(*mapSpec)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export csv:", err)
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "export csv:", err)
+		return ExitNotFound
+	}
+	name := strings.TrimSpace(*project)
+	tasks, err := ws.ListTasks(store.ListFilter{Project: name, All: *all})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export csv:", err)
+		return ExitInternal
+	}
+	data, err := ws.RenderCSV(tasks, mapping)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export csv:", err)
+		return ExitInternal
+	}
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		file := "tasks.csv"
+		if name != "" {
+			file = "tasks-" + store.Slugify(name) + ".csv"
+		}
+		path = filepath.Join(gf.ExportDir, file)
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export csv:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Printf("Wrote %d task(s) to: %s\n", len(tasks), path)
+	}
+	return ExitOK
+}
@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const depUsage = "Usage: tasker dep <add|rm> <selector...> (--blocks <selector>|--blocked-by <selector>) | dep ls <selector...> | dep graph [--project <name>]"
+
+func cmdDep(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, depUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "add", "rm", "remove":
+		return cmdDepEdit(ws, gf, args[0], args[1:])
+	case "ls", "list":
+		return cmdDepList(ws, gf, args[1:])
+	case "graph":
+		return cmdDepGraph(ws, gf, args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, depUsage)
+		return ExitUsage
+	}
+}
+
+// resolveDepTask resolves a selector for the dep commands, reporting errors.
+func resolveDepTask(ws *store.Workspace, gf GlobalFlags, selector string, filter store.SelectorFilter) (*store.Task, int) {
+	task, err := ws.GetTaskBySelectorFiltered(selector, filter)
+	if err == nil {
+		return task, ExitOK
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "dep: not found: %s\n", selector)
+		return nil, ExitNotFound
+	}
+	if errors.Is(err, store.ErrConflict) {
+		if handleMatchConflict(gf, "dep", err) {
+			return nil, ExitConflict
+		}
+		fmt.Fprintln(os.Stderr, "dep: ambiguous selector")
+		return nil, ExitConflict
+	}
+	fmt.Fprintln(os.Stderr, "dep:", err)
+	return nil, ExitInternal
+}
+
+func cmdDepEdit(ws *store.Workspace, gf GlobalFlags, sub string, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--blocks":     true,
+		"--blocked-by": true,
+		"--project":    true,
+		"--match":      true,
+	})
+	fs := flag.NewFlagSet("dep "+sub, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	blocks := fs.String("blocks", "", "Task this one blocks")
+	blockedBy := fs.String("blocked-by", "", "Task that blocks this one")
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	other := strings.TrimSpace(*blocks)
+	if strings.TrimSpace(*blockedBy) != "" {
+		other = strings.TrimSpace(*blockedBy)
+	}
+	if len(rest) == 0 || other == "" || (strings.TrimSpace(*blocks) != "" && strings.TrimSpace(*blockedBy) != "") {
+		fmt.Fprintln(os.Stderr, depUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dep:", err)
+		return ExitUsage
+	}
+	self, code := resolveDepTask(ws, gf, strings.Join(rest, " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	target, code := resolveDepTask(ws, gf, other, filter)
+	if code != ExitOK {
+		return code
+	}
+	blocker, blocked := self, target
+	if strings.TrimSpace(*blockedBy) != "" {
+		blocker, blocked = target, self
+	}
+	var task *store.Task
+	if sub == "add" {
+		task, err = ws.AddDependency(blocker.ID, blocked.ID)
+	} else {
+		task, err = ws.RemoveDependency(blocker.ID, blocked.ID)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dep:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "dep", "task", map[string]any{"task": task})
+	}
+	verb := "now blocks"
+	if sub != "add" {
+		verb = "no longer blocks"
+	}
+	fmt.Printf("%s %s %s\n", taskTitleOrUntitled(blocker.Title), verb, taskTitleOrUntitled(blocked.Title))
+	return ExitOK
+}
+
+func cmdDepList(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("dep ls", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) == 0 {
+		fmt.Fprintln(os.Stderr, depUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dep:", err)
+		return ExitUsage
+	}
+	task, code := resolveDepTask(ws, gf, strings.Join(fs.Args(), " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	all, err := ws.ListTasks(store.ListFilter{All: true})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dep:", err)
+		return ExitInternal
+	}
+	var blockedBy, blocks []store.Task
+	wanted := map[string]bool{}
+	for _, id := range task.BlockedBy {
+		wanted[id] = true
+	}
+	for _, t := range all {
+		if wanted[t.ID] {
+			blockedBy = append(blockedBy, t)
+		}
+		for _, id := range t.BlockedBy {
+			if id == task.ID {
+				blocks = append(blocks, t)
+			}
+		}
+	}
+	if gf.Plain {
+		for _, t := range blockedBy {
+			fmt.Fprintf(os.Stdout, "blocked_by\t%s\t%s\t%s\n", t.ID, t.Status, t.Title)
+		}
+		for _, t := range blocks {
+			fmt.Fprintf(os.Stdout, "blocks\t%s\t%s\t%s\n", t.ID, t.Status, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "dep", "deps", map[string]any{"task": task, "blocked_by": blockedBy, "blocks": blocks})
+	}
+	fmt.Println(taskTitleOrUntitled(task.Title))
+	if len(blockedBy)+len(blocks) == 0 {
+		fmt.Println("  (no dependencies)")
+		return ExitOK
+	}
+	for _, t := range blockedBy {
+		fmt.Printf("  blocked by: %s (%s/%s)\n", taskTitleOrUntitled(t.Title), t.Project, t.Column)
+	}
+	for _, t := range blocks {
+		fmt.Printf("  blocks: %s (%s/%s)\n", taskTitleOrUntitled(t.Title), t.Project, t.Column)
+	}
+	return ExitOK
+}
+
+func cmdDepGraph(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+	})
+	fs := flag.NewFlagSet("dep graph", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if strings.TrimSpace(*project) != "" {
+		if err := checkProject(ws, *project); err != nil {
+			fmt.Fprintln(os.Stderr, "dep graph:", err)
+			return ExitNotFound
+		}
+	}
+	g, err := ws.DependencyGraph(*project)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "dep graph:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, e := range g.Edges {
+			fmt.Fprintf(os.Stdout, "%s\tblocks\t%s\n", e.From, e.To)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "dep graph", "deps", g)
+	}
+	fmt.Print(g.Render(gf.ASCII))
+	return ExitOK
+}
+
+func taskTitleOrUntitled(title string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "(untitled)"
+	}
+	return title
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// loadDiffSide reads tasks and ideas from a workspace root or a JSON export
+// (`{"tasks": [...]}` and/or `{"ideas": [...]}`). The bool reports whether
+// bodies are available (exports omit them).
+func loadDiffSide(path string) ([]store.Task, []store.Idea, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if info.IsDir() {
+		other, err := store.Open(path)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return loadWorkspaceItems(other)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	var export struct {
+		Tasks []store.Task `json:"tasks"`
+		Ideas []store.Idea `json:"ideas"`
+	}
+	if err := json.Unmarshal(b, &export); err != nil {
+		return nil, nil, false, fmt.Errorf("%s: not a workspace or JSON export: %v", path, err)
+	}
+	return export.Tasks, export.Ideas, false, nil
+}
+
+func loadWorkspaceItems(ws *store.Workspace) ([]store.Task, []store.Idea, bool, error) {
+	tasks, err := ws.ListTasks(store.ListFilter{All: true})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	ideas, err := ws.ListIdeas(store.IdeaListFilter{Scope: store.IdeaScopeAll})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return tasks, ideas, true, nil
+}
+
+func cmdDiff(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--tasks": false,
+		"--ideas": false,
+	})
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	tasksOnly := fs.Bool("tasks", false, "Only compare tasks")
+	ideasOnly := fs.Bool("ideas", false, "Only compare ideas")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker diff [--tasks|--ideas] <other-root|export.json>")
+		return ExitUsage
+	}
+	other := store.ExpandHome(rest[0])
+
+	baseTasks, baseIdeas, baseBodies, err := loadDiffSide(other)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "diff:", err)
+		return ExitNotFound
+	}
+	curTasks, curIdeas, _, err := loadWorkspaceItems(ws)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "diff:", err)
+		return ExitInternal
+	}
+
+	var taskDiffs, ideaDiffs []store.ItemDiff
+	if !*ideasOnly {
+		taskDiffs = store.DiffTasks(baseTasks, curTasks, baseBodies)
+	}
+	if !*tasksOnly {
+		ideaDiffs = store.DiffIdeas(baseIdeas, curIdeas, baseBodies)
+	}
+
+	if gf.JSON || gf.NDJSON {
+		all := append(append([]store.ItemDiff{}, taskDiffs...), ideaDiffs...)
+		if gf.NDJSON {
+			items := make([]any, 0, len(all))
+			for _, d := range all {
+				items = append(items, d)
+			}
+			return emitNDJSONItems(gf, "diff", "diff", items)
+		}
+		return emitJSONPayload(gf, "diff", "diff", map[string]any{
+			"base":    other,
+			"target":  ws.Root,
+			"tasks":   taskDiffs,
+			"ideas":   ideaDiffs,
+			"summary": map[string]any{"tasks": diffCounts(taskDiffs), "ideas": diffCounts(ideaDiffs)},
+		})
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "KIND\tCHANGE\tID\tFIELD\tFROM\tTO\tTITLE")
+		for _, d := range append(append([]store.ItemDiff{}, taskDiffs...), ideaDiffs...) {
+			if len(d.Fields) == 0 {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t-\t-\t-\t%s\n", d.Kind, d.Change, d.ID, d.Title)
+				continue
+			}
+			for _, f := range d.Fields {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", d.Kind, d.Change, d.ID, f.Field, cleanSummary(f.From, 80), cleanSummary(f.To, 80), d.Title)
+			}
+		}
+		return ExitOK
+	}
+
+	fmt.Printf("Diff: %s -> %s\n", other, ws.Root)
+	if len(taskDiffs) == 0 && len(ideaDiffs) == 0 {
+		fmt.Println("No differences.")
+		return ExitOK
+	}
+	if !*ideasOnly {
+		printItemDiffs("Tasks", taskDiffs)
+	}
+	if !*tasksOnly {
+		printItemDiffs("Ideas", ideaDiffs)
+	}
+	return ExitOK
+}
+
+func diffCounts(diffs []store.ItemDiff) map[string]int {
+	out := map[string]int{store.DiffAdded: 0, store.DiffRemoved: 0, store.DiffChanged: 0}
+	for _, d := range diffs {
+		out[d.Change]++
+	}
+	return out
+}
+
+func printItemDiffs(label string, diffs []store.ItemDiff) {
+	c := diffCounts(diffs)
+	fmt.Printf("\n%s: +%d -%d ~%d\n", label, c[store.DiffAdded], c[store.DiffRemoved], c[store.DiffChanged])
+	for _, d := range diffs {
+		mark := "~"
+		switch d.Change {
+		case store.DiffAdded:
+			mark = "+"
+		case store.DiffRemoved:
+			mark = "-"
+		}
+		fmt.Printf("  %s %s %s\n", mark, d.ID, d.Title)
+		for _, f := range d.Fields {
+			if f.Field == "notes" || f.Field == "body" {
+				from, to := lineCount(f.From), lineCount(f.To)
+				fmt.Printf("      %s: changed (%d -> %d lines)\n", f.Field, from, to)
+				continue
+			}
+			fmt.Printf("      %s: %s -> %s\n", f.Field, diffValue(f.From), diffValue(f.To))
+		}
+	}
+}
+
+func lineCount(s string) int {
+	if strings.TrimSpace(s) == "" {
+		return 0
+	}
+	return strings.Count(strings.TrimRight(s, "\n"), "\n") + 1
+}
+
+func diffValue(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+	return cleanSummary(s, 80)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdDoctor(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	rollback := fs.Bool("rollback", false, "Roll back interrupted operations (restore previous files)")
+	replay := fs.Bool("replay", false, "Replay interrupted operations (apply intended files)")
+	fix := fs.Bool("fix", false, "Rewrite frontmatter of tasks moved by hand to match their location")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if *rollback && *replay {
+		fmt.Fprintln(os.Stderr, "Usage: choose only one of --rollback/--replay")
+		return ExitUsage
+	}
+
+	mode := ""
+	if *rollback {
+		mode = store.JournalRollback
+	} else if *replay {
+		mode = store.JournalReplay
+	}
+	var resolved []store.JournalEntry
+	if mode != "" {
+		var err error
+		resolved, err = ws.RecoverJournal(mode, 0)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "doctor:", err)
+			return ExitInternal
+		}
+	}
+	pending, err := ws.PendingJournal()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "doctor:", err)
+		return ExitInternal
+	}
+
+	var fixed []store.LocationFix
+	if *fix {
+		if len(pending) > 0 {
+			fmt.Fprintln(os.Stderr, "doctor: finish the interrupted operations (--rollback or --replay) before --fix")
+			return ExitConflict
+		}
+		fixed, err = ws.FixLocations()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "doctor:", err)
+			return ExitInternal
+		}
+	}
+	stale, err := ws.LocationMismatches()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "doctor:", err)
+		return ExitInternal
+	}
+
+	code := ExitOK
+	if len(pending) > 0 {
+		code = ExitInternal
+	}
+	payload := map[string]any{
+		"pending":  journalSummaries(pending),
+		"resolved": journalSummaries(resolved),
+		"mode":     mode,
+		"fixed":    locationSummaries(ws, fixed),
+		"stale":    locationSummaries(ws, stale),
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "doctor", "doctor", payload); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "STATE\tID\tOP\tAT\tPATHS")
+		for _, e := range resolved {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n", mode, e.ID, e.Op, e.At.Format("2006-01-02T15:04:05Z07:00"), strings.Join(journalPaths(e), ","))
+		}
+		for _, e := range pending {
+			fmt.Fprintf(os.Stdout, "pending\t%s\t%s\t%s\t%s\n", e.ID, e.Op, e.At.Format("2006-01-02T15:04:05Z07:00"), strings.Join(journalPaths(e), ","))
+		}
+		for _, f := range fixed {
+			fmt.Fprintf(os.Stdout, "fixed\t%s\tlocation\t\t%s\n", f.ID, rootRel(ws, f.Path))
+		}
+		for _, f := range stale {
+			fmt.Fprintf(os.Stdout, "stale\t%s\tlocation\t\t%s\n", f.ID, rootRel(ws, f.Path))
+		}
+		return code
+	}
+
+	for _, f := range fixed {
+		fmt.Printf("Fixed %s (%s): %s\n", f.ID, f.Title, fieldChanges(f.Fields))
+	}
+	if len(stale) > 0 {
+		fmt.Printf("Stale frontmatter: %d task(s) moved by hand\n", len(stale))
+		for _, f := range stale {
+			fmt.Printf("  - %s %s: %s\n", f.ID, rootRel(ws, f.Path), fieldChanges(f.Fields))
+		}
+		fmt.Println("Tip: run `tasker doctor --fix` to rewrite their frontmatter to match where they are.")
+	}
+
+	for _, e := range resolved {
+		fmt.Printf("%s %s (%s): %s\n", pastTense(mode), e.Op, e.ID, strings.Join(journalPaths(e), ", "))
+	}
+	if len(pending) == 0 {
+		fmt.Println("Journal clean: no interrupted operations.")
+		return code
+	}
+	fmt.Printf("Interrupted operations: %d\n", len(pending))
+	for _, e := range pending {
+		fmt.Printf("  - %s %s at %s: %s\n", e.ID, e.Op, e.At.Format("2006-01-02 15:04:05"), strings.Join(journalPaths(e), ", "))
+	}
+	fmt.Println("Tip: run `tasker doctor --rollback` to restore the previous files, or `--replay` to finish the operations.")
+	return code
+}
+
+func pastTense(mode string) string {
+	if mode == store.JournalReplay {
+		return "Replayed"
+	}
+	return "Rolled back"
+}
+
+func journalPaths(e store.JournalEntry) []string {
+	out := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		out = append(out, c.Path)
+	}
+	return out
+}
+
+func fieldChanges(fields []store.FieldChange) string {
+	parts := make([]string, 0, len(fields))
+	for _, c := range fields {
+		from := c.From
+		if from == "" {
+			from = "(none)"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s -> %s", c.Field, from, c.To))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func rootRel(ws *store.Workspace, path string) string {
+	if rel, err := filepath.Rel(ws.Root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+func locationSummaries(ws *store.Workspace, fixes []store.LocationFix) []map[string]any {
+	out := make([]map[string]any, 0, len(fixes))
+	for _, f := range fixes {
+		out = append(out, map[string]any{
+			"id":     f.ID,
+			"title":  f.Title,
+			"path":   rootRel(ws, f.Path),
+			"fields": f.Fields,
+		})
+	}
+	return out
+}
+
+func journalSummaries(entries []store.JournalEntry) []map[string]any {
+	out := make([]map[string]any, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, map[string]any{
+			"id":    e.ID,
+			"op":    e.Op,
+			"at":    e.At,
+			"paths": journalPaths(e),
+		})
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const duUsage = "Usage: tasker du [--large <size>]"
+
+// cmdDu reports file counts and sizes per project column, ideas, exports and
+// the store's bookkeeping dirs, and flags files over --large, so it is clear
+// what bloats a synced workspace.
+func cmdDu(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--large": true,
+	})
+	fs := flag.NewFlagSet("du", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	large := fs.String("large", "64k", "Flag files bigger than this (bytes, or with a k/m/g suffix)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, duUsage)
+		return ExitUsage
+	}
+	threshold, err := parseByteSize(*large)
+	if err != nil || threshold <= 0 {
+		fmt.Fprintf(os.Stderr, "du: invalid --large %q (use e.g. 512k or 2m)\n", *large)
+		return ExitUsage
+	}
+	report, err := ws.DiskUsage(threshold, gf.ExportDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "du:", err)
+		return ExitInternal
+	}
+
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "KIND\tNAME\tFILES\tBYTES")
+		for _, a := range report.Areas {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%d\t%d\n", a.Kind, a.Name, a.Files, a.Bytes)
+		}
+		for _, f := range report.Large {
+			fmt.Fprintf(os.Stdout, "large\t%s\t1\t%d\n", f.Path, f.Bytes)
+		}
+		fmt.Fprintf(os.Stdout, "total\t%s\t%d\t%d\n", report.Root, report.Files, report.Bytes)
+		return ExitOK
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "du", "du", report); rc != ExitOK {
+			return rc
+		}
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+
+	width := len("total")
+	for _, a := range report.Areas {
+		width = max(width, len(a.Name))
+	}
+	fmt.Printf("%-*s  %6s  %9s\n", width, "AREA", "FILES", "SIZE")
+	for _, a := range report.Areas {
+		fmt.Printf("%-*s  %6d  %9s\n", width, a.Name, a.Files, formatByteSize(a.Bytes))
+	}
+	fmt.Printf("%-*s  %6d  %9s\n", width, "total", report.Files, formatByteSize(report.Bytes))
+	if len(report.Large) == 0 {
+		return ExitOK
+	}
+	fmt.Printf("\nLarge files (over %s):\n", formatByteSize(report.LargeAfter))
+	for _, f := range report.Large {
+		fmt.Printf("  %9s  %s (%s)\n", formatByteSize(f.Bytes), f.Path, f.Kind)
+	}
+	return ExitOK
+}
+
+// parseByteSize reads a size like "65536", "64k", "1.5m" or "2g" (binary
+// units, case-insensitive, optional trailing "b").
+func parseByteSize(value string) (int64, error) {
+	s := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "b")
+	mult := float64(1)
+	if n := len(s); n > 0 {
+		switch s[n-1] {
+		case 'k':
+			mult, s = 1<<10, s[:n-1]
+		case 'm':
+			mult, s = 1<<20, s[:n-1]
+		case 'g':
+			mult, s = 1<<30, s[:n-1]
+		}
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return int64(f * mult), nil
+}
+
+// formatByteSize renders n bytes as "512 B", "64.0 KiB", "1.2 MiB".
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit && exp < 3; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
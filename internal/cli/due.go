@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// The configured locale's weekday names and words for "next". Run sets them
+// from config so every due parser accepts them alongside English.
+var (
+	localeWeekdays   map[string]time.Weekday
+	localeNextBefore []string
+	localeNextAfter  []string
+)
+
+func setDueLocale(cfg store.Config) {
+	localeWeekdays = map[string]time.Weekday{}
+	for name, wd := range cfg.WeekdayNames() {
+		localeWeekdays[strings.ReplaceAll(name, "-", " ")] = wd
+	}
+	localeNextBefore, localeNextAfter = cfg.NextWords()
+}
+
+// lookupWeekday matches an English or localized weekday name ("fri.",
+// "freitag", "sábado").
+func lookupWeekday(s string) (time.Weekday, bool) {
+	s = strings.TrimSuffix(s, ".")
+	if wd, ok := store.ParseWeekday(s); ok {
+		return wd, true
+	}
+	wd, ok := localeWeekdays[s]
+	return wd, ok
+}
+
+// nextWeekday matches "next <weekday>" and its localized forms.
+func nextWeekday(s string) (time.Weekday, bool) {
+	for _, word := range append([]string{"next"}, localeNextBefore...) {
+		if rest, ok := strings.CutPrefix(s, word+" "); ok {
+			if wd, ok := lookupWeekday(rest); ok {
+				return wd, true
+			}
+		}
+	}
+	for _, word := range localeNextAfter {
+		if rest, ok := strings.CutSuffix(s, " "+word); ok {
+			if wd, ok := lookupWeekday(rest); ok {
+				return wd, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// parseDueToken resolves a due date for filters and text parts, returning
+// the input unchanged when it is not a date tasker understands.
+func parseDueToken(text string) string {
+	due, err := resolveDue(text, store.Now())
+	if err != nil {
+		return strings.TrimSpace(text)
+	}
+	return due
+}
+
+// resolveDueArg resolves a due date typed on the command line and, with
+// --verbose, echoes what a relative date resolved to on stderr.
+func resolveDueArg(gf GlobalFlags, text string) (string, error) {
+	return resolveDateArg(gf, "due", text)
+}
+
+// resolveDueTimeArg is resolveDueArg for text that may end in a time of day
+// ("fri 15:00", "tomorrow at 3pm"; a time alone means today). The time
+// comes back as HH:MM, "" when there is none.
+func resolveDueTimeArg(gf GlobalFlags, text string) (string, string, error) {
+	dateText, clock := splitDueClock(text)
+	if clock != "" && strings.TrimSpace(dateText) == "" {
+		dateText = "today"
+	}
+	date, err := resolveDueArg(gf, dateText)
+	return date, clock, err
+}
+
+// dueFlagResolver returns a resolver that applies --due and the
+// --today/--tomorrow/--next-week shortcuts over a due date parsed from a
+// line of input, for commands that add many tasks at once.
+func dueFlagResolver(gf GlobalFlags, due string, today, tomorrow, nextWeek bool) func(string) (string, string, error) {
+	return func(textDue string) (string, string, error) {
+		dueText := due
+		if strings.TrimSpace(dueText) == "" {
+			dueText = textDue
+		}
+		dueValue, dueTime, err := resolveDueTimeArg(gf, dueText)
+		if err != nil {
+			return "", "", err
+		}
+		now := store.Now()
+		switch {
+		case today:
+			dueValue = now.Format("2006-01-02")
+		case tomorrow:
+			dueValue = now.AddDate(0, 0, 1).Format("2006-01-02")
+		case nextWeek:
+			dueValue = now.AddDate(0, 0, 7).Format("2006-01-02")
+		}
+		return dueValue, dueTime, nil
+	}
+}
+
+// splitDueClock cuts a trailing time of day ("15:00", "3pm", "3 pm",
+// optionally after "at") off a due text.
+func splitDueClock(text string) (string, string) {
+	fields := strings.Fields(text)
+	for _, n := range []int{2, 1} {
+		if len(fields) < n {
+			continue
+		}
+		tail := strings.Join(fields[len(fields)-n:], "")
+		if n == 2 && !strings.HasSuffix(strings.ToLower(tail), "m") {
+			continue
+		}
+		clock, err := store.NormalizeDueTime(tail)
+		if err != nil {
+			continue
+		}
+		rest := fields[:len(fields)-n]
+		if len(rest) > 0 && strings.EqualFold(rest[len(rest)-1], "at") {
+			rest = rest[:len(rest)-1]
+		}
+		return strings.Join(rest, " "), clock
+	}
+	return text, ""
+}
+
+// resolveDateArg is resolveDueArg for any date field; label names it in the
+// --verbose echo.
+func resolveDateArg(gf GlobalFlags, label string, text string) (string, error) {
+	date, err := resolveDue(text, store.Now())
+	if err != nil {
+		return "", err
+	}
+	if gf.Verbose && date != strings.TrimSpace(text) {
+		fmt.Fprintf(os.Stderr, "%s: %q -> %s\n", label, strings.TrimSpace(text), date)
+	}
+	return date, nil
+}
+
+// resolveDue turns a due date into YYYY-MM-DD relative to now. It accepts
+// YYYY-MM-DD and RFC3339 as-is, plus:
+//
+//	today, tomorrow, yesterday
+//	mon..sun / monday..sunday   today if it is that day, else the next one
+//	next <weekday>              the next one strictly after today
+//	next week|month|year        one week/month/year from today
+//	in N days|weeks|months|years (also "in a week", "in 2w")
+//	end of week|month|year      Sunday, last day of month, Dec 31 (eow/eom/eoy)
+//
+// Weekday names and "next" are also accepted in the configured locale
+// ("freitag", "nächsten Freitag", "vendredi prochain"). Months are clamped,
+// so "in 1 month" from Jan 31 is Feb 28/29.
+func resolveDue(text string, now time.Time) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", nil
+	}
+	if _, err := time.Parse("2006-01-02", text); err == nil {
+		return text, nil
+	}
+	if _, err := time.Parse(time.RFC3339, text); err == nil {
+		return text, nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	d, ok := relativeDue(strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(text, "-", " "))), " "), today)
+	if !ok {
+		return "", fmt.Errorf("unrecognized due date %q (use YYYY-MM-DD, today, fri, next friday, in 3 days, end of month)", text)
+	}
+	return d.Format("2006-01-02"), nil
+}
+
+func relativeDue(s string, today time.Time) (time.Time, bool) {
+	switch s {
+	case "today", "tod":
+		return today, true
+	case "tomorrow", "tmr", "tom":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "next week", "nextweek":
+		return today.AddDate(0, 0, 7), true
+	case "next month":
+		return store.AddMonthsClamped(today, 1), true
+	case "next year":
+		return store.AddMonthsClamped(today, 12), true
+	case "end of week", "eow":
+		return today.AddDate(0, 0, (7-int(today.Weekday()))%7), true
+	case "end of month", "eom":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, time.UTC), true
+	case "end of year", "eoy":
+		return time.Date(today.Year(), 12, 31, 0, 0, 0, 0, time.UTC), true
+	}
+	if wd, ok := lookupWeekday(s); ok {
+		return today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7), true
+	}
+	if wd, ok := nextWeekday(s); ok {
+		days := (int(wd) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return today.AddDate(0, 0, days), true
+	}
+	if rest, ok := strings.CutPrefix(s, "in "); ok {
+		return offsetDue(rest, today)
+	}
+	return time.Time{}, false
+}
+
+// offsetDue parses "3 days", "a week", "2w" and similar.
+func offsetDue(s string, today time.Time) (time.Time, bool) {
+	num, unit, found := strings.Cut(s, " ")
+	if !found {
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return time.Time{}, false
+		}
+		num, unit = s[:i], s[i:]
+	}
+	n := 1
+	if num != "a" && num != "an" && num != "one" {
+		v, err := strconv.Atoi(num)
+		if err != nil || v < 0 {
+			return time.Time{}, false
+		}
+		n = v
+	}
+	switch strings.TrimSuffix(unit, "s") {
+	case "d", "day":
+		return today.AddDate(0, 0, n), true
+	case "w", "wk", "week":
+		return today.AddDate(0, 0, 7*n), true
+	case "m", "mo", "month":
+		return store.AddMonthsClamped(today, n), true
+	case "y", "yr", "year":
+		return store.AddMonthsClamped(today, 12*n), true
+	}
+	return time.Time{}, false
+}
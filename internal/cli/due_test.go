@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveDue(t *testing.T) {
+	// A Friday; January has 31 days, so month arithmetic clamps.
+	fri := time.Date(2026, 1, 30, 18, 0, 0, 0, time.UTC)
+	leap := time.Date(2028, 1, 31, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		now  time.Time
+		text string
+		want string
+	}{
+		{fri, "", ""},
+		{fri, "2026-02-03", "2026-02-03"},
+		{fri, "2026-02-03T10:00:00Z", "2026-02-03T10:00:00Z"},
+		{fri, "today", "2026-01-30"},
+		{fri, "Tomorrow", "2026-01-31"},
+		{fri, "yesterday", "2026-01-29"},
+		{fri, "fri", "2026-01-30"},
+		{fri, "Fri.", "2026-01-30"},
+		{fri, "mon", "2026-02-02"},
+		{fri, "weds", "2026-02-04"},
+		{fri, "thursday", "2026-02-05"},
+		{fri, "next fri", "2026-02-06"},
+		{fri, "next monday", "2026-02-02"},
+		{fri, "next week", "2026-02-06"},
+		{fri, "next month", "2026-02-28"},
+		{fri, "next year", "2027-01-30"},
+		{fri, "in 3 days", "2026-02-02"},
+		{fri, "in 2w", "2026-02-13"},
+		{fri, "in a month", "2026-02-28"},
+		{fri, "in 13 months", "2027-02-28"},
+		{fri, "end of week", "2026-02-01"},
+		{fri, "eom", "2026-01-31"},
+		{fri, "end-of-year", "2026-12-31"},
+		{leap, "next month", "2028-02-29"},
+		{leap, "eom", "2028-01-31"},
+		{leap, "in 1 year", "2029-01-31"},
+	}
+	for _, c := range cases {
+		got, err := resolveDue(c.text, c.now)
+		if err != nil || got != c.want {
+			t.Fatalf("resolveDue(%q, %s) = %q, %v; want %q", c.text, c.now.Format("2006-01-02"), got, err, c.want)
+		}
+	}
+
+	for _, text := range []string{"someday", "2026-02-30", "next blursday", "in 3 fortnights", "next"} {
+		if got, err := resolveDue(text, fri); err == nil {
+			t.Fatalf("resolveDue(%q) = %q; want an error", text, got)
+		}
+	}
+}
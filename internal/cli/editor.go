@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const openUsage = "Usage: tasker open [--project <name>] [--column <col>] [--status <s>] [--all] [--match <m>] <selector>"
+
+// opensEditor reports whether an invocation hands a file to $EDITOR. Those
+// take the workspace lock only to save the result, not while the editor
+// is open.
+func opensEditor(cmd string, cmdArgs []string) bool {
+	switch cmd {
+	case "open":
+		return true
+	case "config", "cfg":
+		return len(cmdArgs) > 0 && cmdArgs[0] == "edit"
+	}
+	return false
+}
+
+// editorCommand is $VISUAL, then $EDITOR, then vi, split into words so
+// values like "code --wait" work.
+func editorCommand() []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// editInEditor writes content to a temp file named like name, runs the
+// editor on it and returns what was saved.
+func editInEditor(name string, content []byte) ([]byte, error) {
+	f, err := os.CreateTemp("", "tasker-*-"+name)
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	argv := append(editorCommand(), path)
+	cmd := exec.Command(argv[0], argv[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("editor %s: %w", argv[0], err)
+	}
+	return os.ReadFile(path)
+}
+
+// editStart is what the editor opens: a rejected earlier edit when one was
+// kept, else the file itself.
+func editStart(path string, current []byte, gf GlobalFlags) []byte {
+	rej, err := os.ReadFile(store.RejectPath(path))
+	if err != nil {
+		return current
+	}
+	if !gf.Quiet {
+		fmt.Fprintf(os.Stderr, "Resuming the rejected edit in %s\n", store.RejectPath(path))
+	}
+	return rej
+}
+
+// reportEditError prints where an edit went wrong, one file:line:col per
+// problem, so editors and terminals can jump to it.
+func reportEditError(label string, err error) int {
+	var editErr *store.EditError
+	if !errors.As(err, &editErr) {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		switch {
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		}
+		return ExitInternal
+	}
+	fmt.Fprintf(os.Stderr, "%s: %s is unchanged; your edit is kept in %s\n", label, editErr.Path, editErr.Rejected)
+	for _, issue := range editErr.Issues {
+		fmt.Fprintf(os.Stderr, "%s:%s\n", editErr.Rejected, issue)
+	}
+	fmt.Fprintln(os.Stderr, "Run the command again to fix it.")
+	return ExitUsage
+}
+
+// cmdOpen edits a task file in $EDITOR. The result is only saved when it
+// still parses (see store.ApplyTaskEdit).
+func cmdOpen(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--column":  true,
+		"--status":  true,
+		"--all":     false,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	column := fs.String("column", "", "Column id (filter)")
+	status := fs.String("status", "", "Status (filter)")
+	all := fs.Bool("all", false, "Include archived")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(selector) == "" {
+		fmt.Fprintln(os.Stderr, openUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, *column, *status, *all, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "open:", err)
+		return ExitUsage
+	}
+	task, err := ws.GetTaskBySelectorFiltered(selector, filter)
+	if err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			if !handleMatchConflict(gf, "open", err) {
+				fmt.Fprintln(os.Stderr, "open: ambiguous selector")
+			}
+			return ExitConflict
+		}
+		if errors.Is(err, store.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "open: not found")
+			return ExitNotFound
+		}
+		fmt.Fprintln(os.Stderr, "open:", err)
+		return ExitInternal
+	}
+	base, err := os.ReadFile(task.Path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "open:", err)
+		return ExitInternal
+	}
+	start := editStart(task.Path, base, gf)
+	edited, err := editInEditor(filepath.Base(task.Path), start)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "open:", err)
+		return ExitInternal
+	}
+	if bytes.Equal(edited, base) {
+		_ = os.Remove(store.RejectPath(task.Path))
+		if !gf.Quiet {
+			fmt.Println("No changes")
+		}
+		return ExitOK
+	}
+	saved, err := ws.ApplyTaskEdit(task.ID, base, edited)
+	if err != nil {
+		return reportEditError("open", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "open", "task", map[string]any{"task": saved})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Saved %s\n", taskTitleOrUntitled(saved.Title))
+	}
+	return ExitOK
+}
+
+// cmdConfigEdit edits config.json in $EDITOR, saved only when it still
+// parses and its columns and statuses check out.
+func cmdConfigEdit(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker config edit")
+		return ExitUsage
+	}
+	path := filepath.Join(ws.Root, "config.json")
+	base, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config edit:", err)
+		if errors.Is(err, os.ErrNotExist) {
+			return ExitNotFound
+		}
+		return ExitInternal
+	}
+	etag := ws.ConfigETag()
+	edited, err := editInEditor("config.json", editStart(path, base, gf))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config edit:", err)
+		return ExitInternal
+	}
+	if bytes.Equal(edited, base) {
+		_ = os.Remove(store.RejectPath(path))
+		if !gf.Quiet {
+			fmt.Println("No changes")
+		}
+		return ExitOK
+	}
+	if err := ws.ApplyConfigEdit(etag, edited); err != nil {
+		return reportEditError("config edit", err)
+	}
+	if !gf.Quiet {
+		fmt.Println("Saved config.json")
+	}
+	return ExitOK
+}
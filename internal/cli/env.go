@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// envVars are the environment variables tasker reads, in the order
+// `tasker env` prints them.
+var envVars = []string{
+	"TASKER_ROOT",
+	"TASKER_PROJECT",
+	"TASKER_VIEW",
+	"TASKER_WEEK_DAYS",
+	"TASKER_OPEN_ONLY",
+	"TASKER_GROUP",
+	"TASKER_TOTALS",
+	"TASKER_LOG",
+	"TASKER_NOW",
+	"TASKER_ACTOR",
+	"TASKER_GITHUB_API",
+	"XDG_DATA_HOME",
+	"HOME",
+}
+
+// defaultRoot picks the store root when --root is not given: TASKER_ROOT,
+// then an existing ~/.tasker, then an existing XDG data dir, then
+// $XDG_DATA_HOME/tasker when that variable is set, and finally ~/.tasker.
+// An existing ~/.tasker always wins so setting XDG_DATA_HOME never hides a
+// store that is already in use.
+func defaultRoot() (string, string) {
+	if env := os.Getenv("TASKER_ROOT"); env != "" {
+		return env, "TASKER_ROOT"
+	}
+	home, _ := os.UserHomeDir()
+	if home == "" {
+		return ".tasker", "cwd"
+	}
+	legacy := filepath.Join(home, ".tasker")
+	if isDir(legacy) {
+		return legacy, "home"
+	}
+	xdgHome := os.Getenv("XDG_DATA_HOME")
+	if !filepath.IsAbs(xdgHome) {
+		// The XDG spec says relative values are invalid and must be ignored.
+		xdgHome = ""
+	}
+	xdg := filepath.Join(home, ".local", "share", "tasker")
+	if xdgHome != "" {
+		xdg = filepath.Join(xdgHome, "tasker")
+	}
+	if isDir(xdg) {
+		return xdg, "xdg"
+	}
+	if xdgHome != "" {
+		return xdg, "xdg"
+	}
+	return legacy, "home"
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func cmdEnv(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker env")
+		return ExitUsage
+	}
+	configPath := filepath.Join(ws.Root, "config.json")
+	_, err := os.Stat(configPath)
+	configExists := err == nil
+	env := make(map[string]string, len(envVars))
+	for _, key := range envVars {
+		if v, ok := os.LookupEnv(key); ok {
+			env[key] = v
+		}
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "root\t%s\n", ws.Root)
+		fmt.Fprintf(os.Stdout, "root_source\t%s\n", gf.RootSource)
+		fmt.Fprintf(os.Stdout, "config\t%s\n", configPath)
+		fmt.Fprintf(os.Stdout, "export_dir\t%s\n", gf.ExportDir)
+		for _, key := range envVars {
+			if v, ok := env[key]; ok {
+				fmt.Fprintf(os.Stdout, "%s\t%s\n", key, v)
+			}
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "env", "env", map[string]any{
+			"root":          ws.Root,
+			"root_source":   gf.RootSource,
+			"config_path":   configPath,
+			"config_exists": configExists,
+			"export_dir":    gf.ExportDir,
+			"env":           env,
+		})
+	}
+	config := configPath
+	if !configExists {
+		config += " (missing; run tasker init)"
+	}
+	fmt.Printf("Root:       %s (from %s)\n", ws.Root, gf.RootSource)
+	fmt.Printf("Config:     %s\n", config)
+	fmt.Printf("Export dir: %s\n", gf.ExportDir)
+	fmt.Println("Environment:")
+	for _, key := range envVars {
+		v, ok := env[key]
+		if !ok {
+			v = "(unset)"
+		}
+		fmt.Printf("  %s=%s\n", key, v)
+	}
+	return ExitOK
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const escalateUsage = "Usage: tasker escalate [--project <name|glob>] [--dry-run]"
+
+// cmdEscalate writes the priorities the escalation policy
+// (escalation.after_days_overdue) gives overdue tasks into their files;
+// views such as today and week already show them without it.
+func cmdEscalate(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--dry-run": false,
+	})
+	fs := flag.NewFlagSet("escalate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug, glob or comma list (default: every project)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be escalated without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, escalateUsage)
+		return ExitUsage
+	}
+	if len(ws.Config().EscalationSteps()) == 0 {
+		fmt.Fprintln(os.Stderr, "escalate: no escalation policy (e.g. tasker config set escalation.after_days_overdue 3:high,7:urgent)")
+		return ExitUsage
+	}
+	if err := checkProjectSpec(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "escalate:", err)
+		return ExitNotFound
+	}
+	var escalations []store.Escalation
+	var err error
+	if *dryRun {
+		escalations, err = ws.Escalations(*project)
+	} else {
+		escalations, err = ws.Escalate(*project)
+	}
+	if err != nil {
+		return bulkError("escalate", err)
+	}
+	if escalations == nil {
+		escalations = []store.Escalation{}
+	}
+	if gf.Plain {
+		for _, e := range escalations {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%d\t%s\n", e.Task.ID, e.From, e.To, e.DaysOverdue, e.Task.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "escalate", "escalate", map[string]any{"escalations": escalations, "count": len(escalations), "dry_run": *dryRun})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	if len(escalations) == 0 {
+		fmt.Println("Nothing to escalate")
+		return ExitOK
+	}
+	verb := "Escalated"
+	if *dryRun {
+		verb = "Would escalate"
+	}
+	fmt.Printf("%s %d task(s) (%s)\n", verb, len(escalations), store.FormatEscalationSteps(ws.Config().EscalationSteps()))
+	for _, e := range escalations {
+		fmt.Printf("- %s: %s -> %s (overdue %dd) (%s)\n", taskTitleOrUntitled(e.Task.Title), e.From, e.To, e.DaysOverdue, e.Task.ID)
+	}
+	return ExitOK
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// exitCode documents one process exit code for `tasker exitcodes`.
+type exitCode struct {
+	Code        int    `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Reserved codes are assigned but not returned by any command yet.
+	Reserved bool `json:"reserved,omitempty"`
+}
+
+// exitCodes is the exit code contract, in code order. Codes are never
+// reused; new failure classes get the next free number below 10.
+var exitCodes = []exitCode{
+	{ExitOK, "ok", "Success.", false},
+	{ExitUsage, "usage", "Usage or validation error: bad flags, arguments or values.", false},
+	{ExitNotFound, "not_found", "The task, idea, project or file was not found (also: nothing matched).", false},
+	{ExitConflict, "conflict", "Ambiguous selector, duplicate or conflicting change.", false},
+	{ExitLocked, "locked", "Another tasker process held the workspace lock past the timeout; retry later.", false},
+	{ExitReadOnly, "read_only", "The workspace cannot be written (read-only filesystem or permissions).", false},
+	{ExitRateLimited, "rate_limited", "A remote service (e.g. the GitHub API) rate-limited the request; retry later.", false},
+	{ExitInvalid, "invalid", "The workspace failed tasker validate (unparseable files, duplicate IDs, or drift with --strict).", false},
+	{ExitInternal, "internal", "Internal or I/O error, or a failed health check.", false},
+}
+
+// lockExitCode narrows a failing command's code when the failure came from
+// the workspace lock, so callers can tell "retry later" from other errors.
+func lockExitCode(ws *store.Workspace, code int) int {
+	if code == ExitOK {
+		return code
+	}
+	switch err := ws.LockFailure(); {
+	case errors.Is(err, store.ErrLocked):
+		return ExitLocked
+	case errors.Is(err, store.ErrReadOnly):
+		return ExitReadOnly
+	}
+	return code
+}
+
+func cmdExitCodes(gf GlobalFlags, args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker exitcodes")
+		return ExitUsage
+	}
+	if gf.Plain {
+		for _, c := range exitCodes {
+			fmt.Fprintf(os.Stdout, "%d\t%s\t%s\n", c.Code, c.Name, c.Description)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "exitcodes", "exitcodes", map[string]any{"exit_codes": exitCodes})
+	}
+	for _, c := range exitCodes {
+		note := ""
+		if c.Reserved {
+			note = " (reserved)"
+		}
+		fmt.Printf("%3d  %-12s %s%s\n", c.Code, c.Name, c.Description, note)
+	}
+	return ExitOK
+}
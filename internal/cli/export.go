@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const exportUsage = "Usage: tasker export metrics [--out <file>|-] | export ical [--project <name>] [--days N] [--all] [--as event|todo|both] [--out <file>|-] | export todotxt [--project <name>] [--all] [--out <file>|-] | export csv [--project <name>] [--all] [--map field=Header,...] [--out <file>|-] | export obsidian [--project <name>] [--all] [--out <file>|-] | export --using <template> [--project <name>] [--all] [--out <file>|-] | export --list"
+
+func cmdExport(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	if strings.HasPrefix(args[0], "-") {
+		return cmdExportTemplate(ws, gf, args)
+	}
+	switch args[0] {
+	case "metrics":
+		return cmdExportMetrics(ws, gf, args[1:])
+	case "ical", "ics":
+		return cmdExportICal(ws, gf, args[1:])
+	case "todotxt", "todo.txt":
+		return cmdExportTodoTxt(ws, gf, args[1:])
+	case "csv":
+		return cmdExportCSV(ws, gf, args[1:])
+	case "obsidian", "md":
+		return cmdExportObsidian(ws, gf, args[1:])
+	}
+	fmt.Fprintln(os.Stderr, exportUsage)
+	return ExitUsage
+}
+
+func cmdExportMetrics(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--out": true})
+	fs := flag.NewFlagSet("export metrics", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/metrics.json)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	data, err := metricsExport(ws)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export metrics:", err)
+		return ExitInternal
+	}
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		path = filepath.Join(gf.ExportDir, "metrics.json")
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export metrics:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Println("Wrote metrics to:", path)
+	}
+	return ExitOK
+}
+
+// metricsExport is the metrics.json content shared by `export metrics` and
+// the exports.auto "metrics" view.
+func metricsExport(ws *store.Workspace) ([]byte, error) {
+	report, err := ws.MetricsReport()
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+func cmdExportICal(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--days":    true,
+		"--all":     false,
+		"--as":      true,
+		"--out":     true,
+	})
+	fs := flag.NewFlagSet("export ical", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (default: all projects)")
+	days := fs.Int("days", 0, "Only tasks due within N days (overdue ones stay)")
+	all := fs.Bool("all", false, "Include done tasks as completed to-dos")
+	as := fs.String("as", "both", "Components to emit (event|todo|both)")
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/tasks[-<project>].ics)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 || *days < 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	opts, err := icalOptions(*as)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export ical:", err)
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "export ical:", err)
+		return ExitNotFound
+	}
+	opts.Project = strings.TrimSpace(*project)
+	opts.Days = *days
+	opts.All = *all
+	data, err := ws.RenderICal(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export ical:", err)
+		return ExitInternal
+	}
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		path = filepath.Join(gf.ExportDir, icalFileName(opts.Project))
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export ical:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Println("Wrote calendar to:", path)
+	}
+	return ExitOK
+}
+
+// icalOptions maps --as to the components to emit.
+func icalOptions(as string) (store.ICalOptions, error) {
+	switch strings.ToLower(strings.TrimSpace(as)) {
+	case "", "both":
+		return store.ICalOptions{Events: true, Todos: true}, nil
+	case "event", "events", "vevent":
+		return store.ICalOptions{Events: true}, nil
+	case "todo", "todos", "vtodo":
+		return store.ICalOptions{Todos: true}, nil
+	}
+	return store.ICalOptions{}, fmt.Errorf("invalid --as %q (use event|todo|both)", as)
+}
+
+// icalFileName is tasks.ics, or tasks-<project>.ics for one project.
+func icalFileName(project string) string {
+	if project == "" {
+		return "tasks.ics"
+	}
+	return "tasks-" + store.Slugify(project) + ".ics"
+}
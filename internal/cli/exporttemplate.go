@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// cmdExportTemplate renders a user template from export-templates/ with the
+// workspace payload: `export --using <name>`.
+func cmdExportTemplate(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--using":   true,
+		"--project": true,
+		"--all":     false,
+		"--out":     true,
+		"--list":    false,
+	})
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	using := fs.String("using", "", "Template file in <root>/export-templates/ (.tmpl may be left off)")
+	project := fs.String("project", "", "Project name/slug (default: all projects)")
+	all := fs.Bool("all", false, "Include archived tasks")
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/<template name without .tmpl>)")
+	list := fs.Bool("list", false, "List the available templates")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 || (*using == "") == !*list {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	if *list {
+		names, err := ws.ExportTemplates()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "export:", err)
+			return ExitInternal
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "export", "export-templates", map[string]any{"templates": names})
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		if len(names) == 0 && !gf.Quiet && !gf.Plain {
+			fmt.Printf("No templates in %s\n", filepath.Join(ws.Root, store.ExportTemplatesDir))
+		}
+		return ExitOK
+	}
+	tmplPath, err := ws.ExportTemplatePath(*using)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		return ExitNotFound
+	}
+	name := strings.TrimSpace(*project)
+	payload, err := ws.BuildExportPayload(name, *all)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		return ExitInternal
+	}
+	data, err := store.RenderExportTemplate(tmplPath, payload)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		path = filepath.Join(gf.ExportDir, templateFileName(filepath.Base(tmplPath), name))
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Printf("Wrote %s to: %s\n", filepath.Base(tmplPath), path)
+	}
+	return ExitOK
+}
+
+// templateFileName drops .tmpl from the template name, and adds -<project>
+// before the extension for one project: report.md.tmpl -> report-work.md.
+func templateFileName(tmpl string, project string) string {
+	name := strings.TrimSuffix(tmpl, ".tmpl")
+	if project == "" {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "-" + store.Slugify(project) + ext
+}
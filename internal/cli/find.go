@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const findUsage = "Usage: tasker find [--project <name>] [--kind task|idea] [--all] [--match <m>] <query...>"
+
+// cmdFind searches tasks and ideas at once and labels each hit with its kind
+// and where it lives.
+func cmdFind(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--kind":    true,
+		"--all":     false,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("find", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (root ideas are left out)")
+	kind := fs.String("kind", "", "Only tasks or only ideas (task|idea)")
+	all := fs.Bool("all", false, "Include archived tasks")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" {
+		fmt.Fprintln(os.Stderr, findUsage)
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "find:", err)
+		return ExitNotFound
+	}
+	hits, err := ws.Find(query, store.FindOptions{Project: *project, Match: *match, Kind: *kind, All: *all})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "find:", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if len(hits) == 0 {
+		fmt.Fprintln(os.Stderr, "find: no matches")
+		return ExitNotFound
+	}
+	if gf.NDJSON {
+		items := make([]any, 0, len(hits))
+		for i := range hits {
+			items = append(items, hits[i])
+		}
+		return emitNDJSONItems(gf, "find", "find", items)
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "KIND\tID\tPROJECT\tWHERE\tTITLE")
+		for _, h := range hits {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n", h.Kind, h.ID, orDash(h.Project), findWhere(h), h.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "find", "find", map[string]any{"query": query, "hits": hits, "count": len(hits)})
+	}
+	for _, h := range hits {
+		if h.Kind == store.FindKindTask {
+			fmt.Printf("- task %s/%s: %s (%s)\n", h.Project, h.Column, taskTitleOrUntitled(h.Title), h.ID)
+			continue
+		}
+		fmt.Printf("- idea %s: %s (%s)\n", ideaLocationLabel(h.Project), taskTitleOrUntitled(h.Title), h.ID)
+	}
+	return ExitOK
+}
+
+// findWhere is a hit's column (tasks) or scope (ideas).
+func findWhere(h store.FindHit) string {
+	if h.Kind == store.FindKindTask {
+		return h.Column
+	}
+	return h.Scope
+}
+
+func orDash(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return "-"
+	}
+	return s
+}
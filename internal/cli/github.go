@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// errRateLimited marks a GitHub API answer that hit the rate limit.
+var errRateLimited = errors.New("rate limited")
+
+// githubClient is a minimal GitHub REST client for issue sync.
+type githubClient struct {
+	base  string
+	token string
+	http  *http.Client
+}
+
+// newGitHubClient uses TASKER_GITHUB_API (for GitHub Enterprise) or
+// api.github.com, authenticated with GITHUB_TOKEN or GH_TOKEN when set.
+func newGitHubClient() *githubClient {
+	base := envString("TASKER_GITHUB_API")
+	if base == "" {
+		base = "https://api.github.com"
+	}
+	token := envString("GITHUB_TOKEN")
+	if token == "" {
+		token = envString("GH_TOKEN")
+	}
+	return &githubClient{
+		base:  strings.TrimRight(base, "/"),
+		token: token,
+		http:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (c *githubClient) do(method string, path string, body any, out any) error {
+	var rd io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		rd = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.base+path, rd)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "tasker")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		msg := strings.TrimSpace(apiErr.Message)
+		if msg == "" {
+			msg = resp.Status
+		}
+		switch {
+		case resp.StatusCode == http.StatusTooManyRequests,
+			resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+			if reset := resp.Header.Get("Retry-After"); reset != "" {
+				msg += " (retry after " + reset + "s)"
+			}
+			return fmt.Errorf("%w: %s", errRateLimited, msg)
+		case resp.StatusCode == http.StatusNotFound:
+			return fmt.Errorf("%w: %s %s: %s", store.ErrNotFound, method, path, msg)
+		}
+		return fmt.Errorf("%s %s: %s", method, path, msg)
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// openIssues fetches every open issue of repo (pull requests excluded).
+func (c *githubClient) openIssues(repo string) ([]store.RemoteIssue, error) {
+	const perPage = 100
+	var out []store.RemoteIssue
+	for page := 1; ; page++ {
+		var batch []struct {
+			Number  int    `json:"number"`
+			Title   string `json:"title"`
+			Body    string `json:"body"`
+			HTMLURL string `json:"html_url"`
+			Labels  []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+			PullRequest json.RawMessage `json:"pull_request"`
+		}
+		path := fmt.Sprintf("/repos/%s/issues?state=open&per_page=%d&page=%d", repo, perPage, page)
+		if err := c.do(http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+		for _, is := range batch {
+			if len(is.PullRequest) > 0 {
+				continue
+			}
+			labels := make([]string, len(is.Labels))
+			for i, l := range is.Labels {
+				labels[i] = l.Name
+			}
+			out = append(out, store.RemoteIssue{
+				Provider: "github",
+				Repo:     repo,
+				Number:   is.Number,
+				Title:    is.Title,
+				Body:     is.Body,
+				URL:      is.HTMLURL,
+				Labels:   labels,
+			})
+		}
+		if len(batch) < perPage {
+			return out, nil
+		}
+	}
+}
+
+// closeIssue closes issue number of repo as completed.
+func (c *githubClient) closeIssue(repo string, number int) error {
+	path := fmt.Sprintf("/repos/%s/issues/%d", repo, number)
+	return c.do(http.MethodPatch, path, map[string]string{"state": "closed", "state_reason": "completed"}, nil)
+}
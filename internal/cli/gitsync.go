@@ -0,0 +1,365 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const gitSyncUsage = "Usage: tasker sync git init [--remote <url>] [--no-auto-commit] | sync git [--remote <name>] [--no-push] [--dry-run]"
+
+// gitIgnore keeps machine-local state out of the repository: locks, the
+// write-ahead journal and undo history, caches, snapshots, trash and logs.
+const gitIgnore = `# tasker: machine-local state
+.lock
+.journal/
+.index/
+.snapshots/
+.trash/
+logs/
+exports/
+`
+
+// gitAttributes lets git merge the append-only audit log by keeping the
+// lines of both sides, so two machines logging in the same month never
+// conflict.
+const gitAttributes = `events/*.ndjson merge=union
+`
+
+// errNotGitRepo marks a root that `sync git init` has not set up.
+var errNotGitRepo = errors.New("not a git repository; run `tasker sync git init` first")
+
+// gitSyncResult is what one `sync git` run did (or would do).
+type gitSyncResult struct {
+	Remote    string   `json:"remote"`
+	Branch    string   `json:"branch"`
+	DryRun    bool     `json:"dry_run"`
+	Committed bool     `json:"committed"`
+	Pending   int      `json:"pending,omitempty"`
+	Pulled    int      `json:"pulled"`
+	Pushed    int      `json:"pushed"`
+	Conflicts []string `json:"conflicts,omitempty"`
+}
+
+// runGit runs git in root and returns its trimmed stdout. Failures carry
+// git's own error text.
+func runGit(root string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+	// Never stop for a password prompt; fail instead.
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return strings.TrimSpace(stdout.String()), fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func isGitRepo(root string) bool {
+	_, err := os.Stat(filepath.Join(root, ".git"))
+	return err == nil
+}
+
+// gitIdentity prefixes args for commands that record a commit, so they run
+// as "tasker" when no git identity is configured.
+func gitIdentity(root string, args ...string) []string {
+	if email, _ := runGit(root, "config", "user.email"); email == "" {
+		return append([]string{"-c", "user.name=tasker", "-c", "user.email=tasker@localhost"}, args...)
+	}
+	return args
+}
+
+// gitCommitAll commits every change in root with message and reports
+// whether there was anything to commit.
+func gitCommitAll(root string, message string) (bool, error) {
+	status, err := runGit(root, "status", "--porcelain")
+	if err != nil || status == "" {
+		return false, err
+	}
+	if _, err := runGit(root, "add", "-A"); err != nil {
+		return false, err
+	}
+	if _, err := runGit(root, gitIdentity(root, "commit", "-q", "-m", message)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// autoCommit commits the root after a successful mutating command when
+// sync.auto_commit is on and the root is a git repository.
+func autoCommit(ws *store.Workspace, cmd string, cmdArgs []string) {
+	if !ws.Config().SyncAutoCommit() || !isGitRepo(ws.Root) {
+		return
+	}
+	message := "tasker " + cmd
+	if len(cmdArgs) > 0 {
+		for _, sub := range subcommands[cmd] {
+			if cmdArgs[0] == sub {
+				message += " " + sub
+				break
+			}
+		}
+	}
+	if _, err := gitCommitAll(ws.Root, message); err != nil {
+		fmt.Fprintln(os.Stderr, "tasker: auto-commit:", err)
+	}
+}
+
+func gitRevCount(root string, rng string) int {
+	out, err := runGit(root, "rev-list", "--count", rng)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(out)
+	return n
+}
+
+func cmdSyncGit(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) > 0 && args[0] == "init" {
+		return cmdSyncGitInit(ws, gf, args[1:])
+	}
+	args = reorderFlags(args, map[string]bool{
+		"--remote":  true,
+		"--no-push": false,
+		"--dry-run": false,
+	})
+	fs := flag.NewFlagSet("sync git", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	remote := fs.String("remote", "origin", "Remote to pull from and push to")
+	noPush := fs.Bool("no-push", false, "Pull only")
+	dryRun := fs.Bool("dry-run", false, "Fetch and report without committing, merging or pushing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, gitSyncUsage)
+		return ExitUsage
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Fprintln(os.Stderr, "sync git: git is not installed")
+		return ExitInternal
+	}
+	if !isGitRepo(ws.Root) {
+		fmt.Fprintln(os.Stderr, "sync git:", errNotGitRepo)
+		return ExitUsage
+	}
+	// Keep other tasker processes out while files change under them.
+	unlock, err := ws.Lock(ws.WriteLockTimeout())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sync git:", err)
+		return ExitInternal
+	}
+	defer unlock()
+
+	res := gitSyncResult{Remote: *remote, DryRun: *dryRun}
+	if res.Branch, err = runGit(ws.Root, "rev-parse", "--abbrev-ref", "HEAD"); err != nil {
+		fmt.Fprintln(os.Stderr, "sync git:", err)
+		return ExitInternal
+	}
+	if *dryRun {
+		status, _ := runGit(ws.Root, "status", "--porcelain")
+		if status != "" {
+			res.Pending = len(strings.Split(status, "\n"))
+		}
+	} else if res.Committed, err = gitCommitAll(ws.Root, "tasker sync"); err != nil {
+		fmt.Fprintln(os.Stderr, "sync git:", err)
+		return ExitInternal
+	}
+	if _, err := runGit(ws.Root, "remote", "get-url", *remote); err != nil {
+		fmt.Fprintf(os.Stderr, "sync git: no remote %q; add one with `tasker sync git init --remote <url>`\n", *remote)
+		return ExitUsage
+	}
+	if _, err := runGit(ws.Root, "fetch", "-q", *remote); err != nil {
+		fmt.Fprintln(os.Stderr, "sync git:", err)
+		return ExitInternal
+	}
+	upstream := *remote + "/" + res.Branch
+	hasUpstream := false
+	if _, err := runGit(ws.Root, "rev-parse", "--verify", "--quiet", "refs/remotes/"+upstream); err == nil {
+		hasUpstream = true
+		res.Pulled = gitRevCount(ws.Root, "HEAD.."+upstream)
+		res.Pushed = gitRevCount(ws.Root, upstream+"..HEAD")
+	} else {
+		res.Pushed = gitRevCount(ws.Root, "HEAD")
+	}
+	if *noPush {
+		res.Pushed = 0
+	}
+
+	code := ExitOK
+	if !*dryRun && hasUpstream && res.Pulled > 0 {
+		if _, err := runGit(ws.Root, gitIdentity(ws.Root, "merge", "--no-edit", "-q", upstream)...); err != nil {
+			// Report the files both sides changed and leave the store as it was.
+			conflicts, _ := runGit(ws.Root, "diff", "--name-only", "--diff-filter=U")
+			_, _ = runGit(ws.Root, "merge", "--abort")
+			if conflicts == "" {
+				fmt.Fprintln(os.Stderr, "sync git:", err)
+				return ExitInternal
+			}
+			res.Conflicts = strings.Split(conflicts, "\n")
+			res.Pulled, res.Pushed = 0, 0
+			code = ExitConflict
+		}
+	}
+	if !*dryRun && code == ExitOK && res.Pushed > 0 {
+		if _, err := runGit(ws.Root, "push", "-q", "-u", *remote, "HEAD:"+res.Branch); err != nil {
+			fmt.Fprintln(os.Stderr, "sync git:", err)
+			return ExitInternal
+		}
+	}
+	if len(res.Conflicts) > 0 {
+		fmt.Fprintf(os.Stderr, "sync git: %s changed %d file(s) that also changed here; nothing was merged:\n", upstream, len(res.Conflicts))
+		for _, path := range res.Conflicts {
+			fmt.Fprintln(os.Stderr, "  "+path)
+		}
+		fmt.Fprintf(os.Stderr, "Resolve them with `git -C %s merge %s`, then run sync again.\n", ws.Root, upstream)
+	}
+
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "committed\t%t\npulled\t%d\npushed\t%d\n", res.Committed, res.Pulled, res.Pushed)
+		for _, path := range res.Conflicts {
+			fmt.Fprintf(os.Stdout, "conflict\t%s\n", path)
+		}
+		return code
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "sync git", "sync-git", res); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.Quiet || code != ExitOK {
+		return code
+	}
+	if *dryRun {
+		fmt.Printf("%d uncommitted change(s); %d commit(s) to pull from %s, %d to push\n", res.Pending, res.Pulled, upstream, res.Pushed)
+		return code
+	}
+	if res.Committed {
+		fmt.Println("Committed local changes")
+	}
+	switch {
+	case res.Pulled == 0 && res.Pushed == 0:
+		fmt.Printf("Already in sync with %s\n", upstream)
+	default:
+		fmt.Printf("Pulled %d commit(s) from %s, pushed %d\n", res.Pulled, upstream, res.Pushed)
+	}
+	return code
+}
+
+// cmdSyncGitInit makes the root a git repository with a .gitignore for
+// machine-local state, turns on sync.auto_commit and commits the store.
+func cmdSyncGitInit(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--remote":         true,
+		"--no-auto-commit": false,
+	})
+	fs := flag.NewFlagSet("sync git init", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	remote := fs.String("remote", "", "URL of the origin remote")
+	noAuto := fs.Bool("no-auto-commit", false, "Leave sync.auto_commit off; commit on sync only")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, gitSyncUsage)
+		return ExitUsage
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Fprintln(os.Stderr, "sync git init: git is not installed")
+		return ExitInternal
+	}
+	fail := func(err error) int {
+		fmt.Fprintln(os.Stderr, "sync git init:", err)
+		return ExitInternal
+	}
+	created := false
+	if !isGitRepo(ws.Root) {
+		if err := os.MkdirAll(ws.Root, 0o755); err != nil {
+			return fail(err)
+		}
+		if _, err := runGit(ws.Root, "init", "-q"); err != nil {
+			return fail(err)
+		}
+		created = true
+	}
+	for name, content := range map[string]string{".gitignore": gitIgnore, ".gitattributes": gitAttributes} {
+		path := filepath.Join(ws.Root, name)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return fail(err)
+			}
+		}
+	}
+	if !*noAuto {
+		unlock, err := ws.LockConfig(ws.WriteLockTimeout())
+		if err != nil {
+			return fail(err)
+		}
+		cfg := ws.Config()
+		if cfg.Sync == nil {
+			cfg.Sync = &store.SyncConfig{}
+		}
+		cfg.Sync.AutoCommit = true
+		err = ws.SaveConfig(cfg)
+		unlock()
+		if err != nil {
+			return fail(err)
+		}
+	}
+	if _, err := gitCommitAll(ws.Root, "tasker sync git init"); err != nil {
+		return fail(err)
+	}
+	if url := strings.TrimSpace(*remote); url != "" {
+		verb := "add"
+		if _, err := runGit(ws.Root, "remote", "get-url", "origin"); err == nil {
+			verb = "set-url"
+		}
+		if _, err := runGit(ws.Root, "remote", verb, "origin", url); err != nil {
+			return fail(err)
+		}
+	}
+
+	autoCommit := ws.Config().SyncAutoCommit()
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "root\t%s\ncreated\t%t\nauto_commit\t%t\n", ws.Root, created, autoCommit)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "sync git init", "sync-git-init", map[string]any{
+			"root":        ws.Root,
+			"created":     created,
+			"auto_commit": autoCommit,
+			"remote":      strings.TrimSpace(*remote),
+		})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	verb := "Initialized"
+	if !created {
+		verb = "Reused"
+	}
+	state := "off (commits on sync only)"
+	if autoCommit {
+		state = "on"
+	}
+	fmt.Printf("%s git repository in %s; auto-commit %s\n", verb, ws.Root, state)
+	if *remote == "" {
+		fmt.Println("Add a remote with `tasker sync git init --remote <url>`, then run `tasker sync git`.")
+	}
+	return ExitOK
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// isolateGit keeps the user's git config out of the test and pins the
+// default branch, so every repository the test makes agrees on it.
+func isolateGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "init.defaultBranch")
+	t.Setenv("GIT_CONFIG_VALUE_0", "main")
+}
+
+func mustGit(t *testing.T, root string, args ...string) string {
+	t.Helper()
+	out, err := runGit(root, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestSyncGitRoundTrip(t *testing.T) {
+	isolateGit(t)
+	quiet := GlobalFlags{Quiet: true}
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	mustGit(t, t.TempDir(), "init", "-q", "--bare", remote)
+
+	ws := newTestWorkspace(t)
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitUsage {
+		t.Fatalf("sync before init: expected ExitUsage, got %d", code)
+	}
+	if code := cmdSyncGitInit(ws, quiet, []string{"--remote", remote}); code != ExitOK {
+		t.Fatalf("sync git init: expected ExitOK, got %d", code)
+	}
+	if !ws.Config().SyncAutoCommit() {
+		t.Fatalf("expected sync.auto_commit on after init")
+	}
+	if status := mustGit(t, ws.Root, "status", "--porcelain"); status != "" {
+		t.Fatalf("expected init to commit the store, got status:\n%s", status)
+	}
+	if ignored := mustGit(t, ws.Root, "check-ignore", ".journal/x", ".lock"); ignored == "" {
+		t.Fatalf("expected machine-local state to be ignored")
+	}
+
+	// First sync pushes the initial commit.
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitOK {
+		t.Fatalf("first sync: expected ExitOK, got %d", code)
+	}
+	head := mustGit(t, ws.Root, "rev-parse", "HEAD")
+	if got := mustGit(t, remote, "rev-parse", "main"); got != head {
+		t.Fatalf("expected the remote at %s, got %s", head, got)
+	}
+
+	// With nothing changed on either side, sync commits and pushes nothing.
+	if committed, err := gitCommitAll(ws.Root, "noop"); err != nil || committed {
+		t.Fatalf("gitCommitAll on a clean tree = %t, %v", committed, err)
+	}
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitOK {
+		t.Fatalf("no-change sync: expected ExitOK, got %d", code)
+	}
+	if got := mustGit(t, ws.Root, "rev-parse", "HEAD"); got != head {
+		t.Fatalf("no-change sync moved HEAD from %s to %s", head, got)
+	}
+
+	// A task added on a second machine arrives on the next sync.
+	other := filepath.Join(t.TempDir(), "other")
+	mustGit(t, t.TempDir(), "clone", "-q", remote, other)
+	ows, err := store.Open(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := ows.AddTask(store.AddTaskInput{Title: "From elsewhere", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := cmdSyncGit(ows, quiet, nil); code != ExitOK {
+		t.Fatalf("sync from the clone: expected ExitOK, got %d", code)
+	}
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitOK {
+		t.Fatalf("pulling sync: expected ExitOK, got %d", code)
+	}
+	if _, err := ws.GetTaskByPrefix(task.ID); err != nil {
+		t.Fatalf("expected the pulled task: %v", err)
+	}
+
+	// Both sides retitle the same task: sync reports the conflict and
+	// leaves the local store as it was.
+	mine, theirs := "Mine", "Theirs"
+	if _, err := ows.EditTask(task.ID, store.TaskPatch{Title: &theirs}); err != nil {
+		t.Fatal(err)
+	}
+	if code := cmdSyncGit(ows, quiet, nil); code != ExitOK {
+		t.Fatalf("sync from the clone: expected ExitOK, got %d", code)
+	}
+	if _, err := ws.EditTask(task.ID, store.TaskPatch{Title: &mine}); err != nil {
+		t.Fatal(err)
+	}
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitConflict {
+		t.Fatalf("conflicting sync: expected ExitConflict, got %d", code)
+	}
+	got, err := ws.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != mine {
+		t.Fatalf("expected the local title to survive a conflict, got %q", got.Title)
+	}
+	if status := mustGit(t, ws.Root, "status", "--porcelain"); status != "" {
+		t.Fatalf("expected the merge aborted, got status:\n%s", status)
+	}
+}
+
+func TestSyncGitWithoutGit(t *testing.T) {
+	ws := newTestWorkspace(t)
+	t.Setenv("PATH", t.TempDir())
+	quiet := GlobalFlags{Quiet: true}
+	if code := cmdSyncGitInit(ws, quiet, nil); code != ExitInternal {
+		t.Fatalf("sync git init: expected ExitInternal, got %d", code)
+	}
+	if code := cmdSyncGit(ws, quiet, nil); code != ExitInternal {
+		t.Fatalf("sync git: expected ExitInternal, got %d", code)
+	}
+	if _, err := os.Stat(filepath.Join(ws.Root, ".git")); !os.IsNotExist(err) {
+		t.Fatalf("expected no repository without git, got %v", err)
+	}
+	if ws.Config().SyncAutoCommit() {
+		t.Fatalf("expected sync.auto_commit untouched without git")
+	}
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdHealth(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("health", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	report := ws.Health()
+	code := ExitOK
+	if !report.OK {
+		code = ExitInternal
+	}
+
+	if gf.NDJSON {
+		items := make([]any, 0, len(report.Checks))
+		for _, c := range report.Checks {
+			items = append(items, c)
+		}
+		if rc := emitNDJSONItems(gf, "health", "health", items); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "health", "health", report); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "CHECK\tSTATUS\tDETAIL")
+		for _, c := range report.Checks {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", c.Name, c.Status, c.Detail)
+		}
+		return code
+	}
+
+	for _, c := range report.Checks {
+		detail := ""
+		if c.Detail != "" {
+			detail = " — " + c.Detail
+		}
+		fmt.Printf("%-4s %s%s\n", strings.ToUpper(c.Status), c.Name, detail)
+	}
+	if report.OK {
+		fmt.Println("healthy")
+	} else {
+		fmt.Println("unhealthy")
+	}
+	return code
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const historyUsage = "Usage: tasker history [--since <age|date>] [--project <name>] [--limit <n>] [<selector...>]"
+
+// cmdHistory prints the audit log (<root>/events), oldest first: every
+// change to one task when a selector is given, else every change.
+func cmdHistory(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--since":   true,
+		"--project": true,
+		"--limit":   true,
+	})
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	since := fs.String("since", "", "Only events newer than an age (7d, 2w, 12h) or a date")
+	project := fs.String("project", "", "Only events of tasks in this project")
+	limit := fs.Int("limit", 0, "Keep only the newest n events (0: all)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if *limit < 0 {
+		fmt.Fprintln(os.Stderr, historyUsage)
+		return ExitUsage
+	}
+	filter := store.EventFilter{}
+	if strings.TrimSpace(*since) != "" {
+		t, err := parseSince(*since, store.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "history: --since:", err)
+			return ExitUsage
+		}
+		filter.Since = t
+	}
+	if strings.TrimSpace(*project) != "" {
+		// Not checked against the current projects: it may have been removed.
+		filter.Project = store.Slugify(*project)
+	}
+	if selector := strings.Join(fs.Args(), " "); strings.TrimSpace(selector) != "" {
+		id, code := historyTaskID(ws, gf, selector)
+		if code != ExitOK {
+			return code
+		}
+		filter.TaskID = id
+	}
+	events, err := ws.ListEvents(filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "history:", err)
+		return ExitInternal
+	}
+	if *limit > 0 && len(events) > *limit {
+		events = events[len(events)-*limit:]
+	}
+
+	if gf.Plain {
+		for _, e := range events {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", e.At.Format(time.RFC3339), e.Actor, e.Command, e.Action, e.TaskID, e.Title(), strings.Join(e.Changed, ","))
+		}
+		return ExitOK
+	}
+	if gf.NDJSON {
+		items := make([]any, 0, len(events))
+		for _, e := range events {
+			items = append(items, e)
+		}
+		return emitNDJSONItems(gf, "history", "history", items)
+	}
+	if gf.JSON {
+		if events == nil {
+			events = []store.Event{}
+		}
+		if rc := emitJSONPayload(gf, "history", "history", map[string]any{"events": events}); rc != ExitOK {
+			return rc
+		}
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	if len(events) == 0 {
+		fmt.Println("No history")
+		return ExitOK
+	}
+	for _, e := range events {
+		actor := ""
+		if e.Actor != "" {
+			actor = " " + e.Actor
+		}
+		fmt.Printf("%s%s %s: %s (%s) %s\n", e.At.Local().Format("2006-01-02 15:04"), actor, e.Command, taskTitleOrUntitled(e.Title()), e.TaskID, historyDetail(e))
+	}
+	return ExitOK
+}
+
+// historyTaskID resolves selector to a task id. Deleted tasks no longer
+// resolve, so an id prefix is used as is and a title is looked up in the
+// log itself.
+func historyTaskID(ws *store.Workspace, gf GlobalFlags, selector string) (string, int) {
+	task, err := ws.GetTaskBySelectorFiltered(selector, store.SelectorFilter{IncludeArchived: true, Match: "auto"})
+	if err == nil {
+		return task.ID, ExitOK
+	}
+	if errors.Is(err, store.ErrConflict) {
+		if !handleMatchConflict(gf, "history", err) {
+			fmt.Fprintln(os.Stderr, "history: ambiguous selector")
+		}
+		return "", ExitConflict
+	}
+	if !errors.Is(err, store.ErrNotFound) {
+		fmt.Fprintln(os.Stderr, "history:", err)
+		return "", ExitInternal
+	}
+	if strings.HasPrefix(strings.ToLower(selector), "tsk_") {
+		return selector, ExitOK
+	}
+	events, err := ws.ListEvents(store.EventFilter{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "history:", err)
+		return "", ExitInternal
+	}
+	ids := map[string]bool{}
+	id := ""
+	for _, e := range events {
+		if strings.EqualFold(strings.TrimSpace(e.Title()), strings.TrimSpace(selector)) {
+			ids[e.TaskID] = true
+			id = e.TaskID
+		}
+	}
+	switch len(ids) {
+	case 0:
+		fmt.Fprintln(os.Stderr, "history: not found")
+		return "", ExitNotFound
+	case 1:
+		return id, ExitOK
+	}
+	fmt.Fprintln(os.Stderr, "history: ambiguous selector (several deleted tasks share that title; use the id)")
+	return "", ExitConflict
+}
+
+// historyDetail says what an event did: where a task was created or moved,
+// or which fields changed.
+func historyDetail(e store.Event) string {
+	where := func(m *store.TaskMeta) string { return m.Project + "/" + m.Column }
+	switch e.Action {
+	case "create":
+		return "created in " + where(e.After)
+	case "delete":
+		return "deleted from " + where(e.Before)
+	case "move":
+		detail := where(e.Before) + " -> " + where(e.After)
+		if rest := withoutFields(e.Changed, "project", "column", "status", "moved_at", "completed_at", "archived_at"); len(rest) > 0 {
+			detail += "; changed " + strings.Join(rest, ", ")
+		}
+		return detail
+	}
+	return "changed " + strings.Join(e.Changed, ", ")
+}
+
+func withoutFields(fields []string, drop ...string) []string {
+	var out []string
+	for _, f := range fields {
+		keep := true
+		for _, d := range drop {
+			if f == d {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// parseSince reads a --since value: an age like 7d, 2w or 12h (before now),
+// or a date (--due syntax, so "yesterday" and "2026-01-20" work) meaning
+// the start of that day.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	if n := len(v); n > 1 && (v[n-1] == 'd' || v[n-1] == 'w') {
+		if count, err := strconv.Atoi(v[:n-1]); err == nil && count >= 0 {
+			days := count
+			if v[n-1] == 'w' {
+				days *= 7
+			}
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	date, err := resolveDue(value, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("use an age like 7d, 2w or 12h, or a date: %v", err)
+	}
+	return time.Parse("2006-01-02", date[:min(len(date), 10)])
+}
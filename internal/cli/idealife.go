@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const ideaUsage = "Usage: tasker idea <add|capture|ls|show|resolve|note|append|edit|tag|archive|unarchive|rm|score|triage|promote> ..."
+
+// ideaSelectorFlags are the --scope/--project/--match flags every idea
+// command that takes a selector shares.
+type ideaSelectorFlags struct {
+	scope, project, match *string
+}
+
+var ideaSelectorFlagSpec = map[string]bool{"--scope": true, "--project": true, "--match": true}
+
+func addIdeaSelectorFlags(fs *flag.FlagSet) ideaSelectorFlags {
+	return ideaSelectorFlags{
+		scope:   fs.String("scope", "", "Scope (root|project|all)"),
+		project: fs.String("project", "", "Project name/slug"),
+		match:   fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)"),
+	}
+}
+
+func (f ideaSelectorFlags) filter() (store.IdeaSelectorFilter, error) {
+	return ideaSelectorFilter(*f.project, *f.scope, *f.match)
+}
+
+// resolveIdea resolves an idea selector for cmd, reporting errors on stderr
+// and returning the exit code to use.
+func resolveIdea(ws *store.Workspace, cmd string, selector string, filter store.IdeaSelectorFilter) (*store.Idea, int) {
+	idea, err := ws.GetIdeaBySelectorFiltered(selector, filter)
+	if err == nil {
+		return idea, ExitOK
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		fmt.Fprintf(os.Stderr, "%s: not found: %s\n", cmd, selector)
+		return nil, ExitNotFound
+	}
+	if errors.Is(err, store.ErrConflict) {
+		if !handleIdeaMatchConflict(cmd, err) {
+			fmt.Fprintf(os.Stderr, "%s: ambiguous selector\n", cmd)
+		}
+		return nil, ExitConflict
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	return nil, ExitInternal
+}
+
+func emitIdea(gf GlobalFlags, cmd string, idea *store.Idea, human string) int {
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", idea.ID, ideaLocationLabel(idea.Project), idea.Title)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, cmd, "idea", map[string]any{"idea": idea})
+	}
+	if !gf.Quiet {
+		fmt.Println(human)
+	}
+	return ExitOK
+}
+
+func cmdIdeaEdit(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	spec := map[string]bool{"--set": true, "--add-tag": true, "--remove-tag": true}
+	for k, v := range ideaSelectorFlagSpec {
+		spec[k] = v
+	}
+	args = reorderFlags(args, spec)
+	fs := flag.NewFlagSet("idea edit", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sel := addIdeaSelectorFlags(fs)
+	var sets, addTags, removeTags stringList
+	fs.Var(&sets, "set", "Field to change as key=value: title|body|tags (repeatable)")
+	fs.Var(&addTags, "add-tag", "Tag to add (repeatable)")
+	fs.Var(&removeTags, "remove-tag", "Tag to remove (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if selector == "" {
+		fmt.Fprintln(os.Stderr, "Usage: tasker idea edit [--scope root|project|all] [--project <name>] [--match <m>] [--set title|body|tags=<value>]... [--add-tag <t>]... [--remove-tag <t>]... <selector>")
+		return ExitUsage
+	}
+	patch := store.IdeaPatch{AddTags: addTags, RemoveTags: removeTags}
+	for _, raw := range sets {
+		key, value, ok := strings.Cut(raw, "=")
+		if !ok {
+			fmt.Fprintf(os.Stderr, "idea edit: invalid --set %q (use key=value)\n", raw)
+			return ExitUsage
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "title":
+			patch.Title = &value
+		case "body":
+			body := strings.ReplaceAll(value, `\n`, "\n")
+			patch.Body = &body
+		case "tags":
+			tags := strings.Split(value, ",")
+			patch.Tags = &tags
+		default:
+			fmt.Fprintf(os.Stderr, "idea edit: unknown --set key %q (use title|body|tags)\n", key)
+			return ExitUsage
+		}
+	}
+	if patch.Title == nil && patch.Body == nil && patch.Tags == nil && len(addTags) == 0 && len(removeTags) == 0 {
+		fmt.Fprintln(os.Stderr, "idea edit: nothing to change (use --set, --add-tag or --remove-tag)")
+		return ExitUsage
+	}
+	filter, err := sel.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea edit:", err)
+		return ExitUsage
+	}
+	idea, code := resolveIdea(ws, "idea edit", selector, filter)
+	if code != ExitOK {
+		return code
+	}
+	if idea, err = ws.EditIdea(idea, patch); err != nil {
+		return storeErrCode("idea edit", err)
+	}
+	return emitIdea(gf, "idea edit", idea, "Edited idea "+taskTitleOrUntitled(idea.Title))
+}
+
+// cmdIdeaTag adds or removes tags: `idea tag add|rm <selector> <tag>...`.
+// The selector is one argument (quote multi-word titles).
+func cmdIdeaTag(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	const usage = "Usage: tasker idea tag add|rm [--scope root|project|all] [--project <name>] [--match <m>] <selector> <tag>..."
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return ExitUsage
+	}
+	sub := args[0]
+	if sub != "add" && sub != "rm" && sub != "remove" {
+		fmt.Fprintln(os.Stderr, usage)
+		return ExitUsage
+	}
+	cmd := "idea tag " + sub
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sel := addIdeaSelectorFlags(fs)
+	if err := fs.Parse(reorderFlags(args[1:], ideaSelectorFlagSpec)); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		return ExitUsage
+	}
+	filter, err := sel.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, cmd+":", err)
+		return ExitUsage
+	}
+	idea, code := resolveIdea(ws, cmd, fs.Arg(0), filter)
+	if code != ExitOK {
+		return code
+	}
+	tags := fs.Args()[1:]
+	patch := store.IdeaPatch{AddTags: tags}
+	verb := "Tagged"
+	if sub != "add" {
+		patch = store.IdeaPatch{RemoveTags: tags}
+		verb = "Untagged"
+	}
+	if idea, err = ws.EditIdea(idea, patch); err != nil {
+		return storeErrCode(cmd, err)
+	}
+	return emitIdea(gf, cmd, idea, fmt.Sprintf("%s idea %s (tags: %s)", verb, taskTitleOrUntitled(idea.Title), orDash(strings.Join(idea.Tags, ", "))))
+}
+
+// cmdIdeaArchive files an idea under archive/, or (unarchive) brings an
+// archived one back; unarchive selectors resolve among archived ideas.
+func cmdIdeaArchive(ws *store.Workspace, gf GlobalFlags, args []string, archive bool) int {
+	cmd := "idea archive"
+	if !archive {
+		cmd = "idea unarchive"
+	}
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sel := addIdeaSelectorFlags(fs)
+	if err := fs.Parse(reorderFlags(args, ideaSelectorFlagSpec)); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if selector == "" {
+		fmt.Fprintf(os.Stderr, "Usage: tasker %s [--scope root|project|all] [--project <name>] [--match <m>] <selector>\n", cmd)
+		return ExitUsage
+	}
+	filter, err := sel.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, cmd+":", err)
+		return ExitUsage
+	}
+	filter.Archived = !archive
+	idea, code := resolveIdea(ws, cmd, selector, filter)
+	if code != ExitOK {
+		return code
+	}
+	verb := "Archived"
+	if archive {
+		idea, err = ws.ArchiveIdea(idea)
+	} else {
+		idea, err = ws.UnarchiveIdea(idea)
+		verb = "Unarchived"
+	}
+	if err != nil {
+		return storeErrCode(cmd, err)
+	}
+	return emitIdea(gf, cmd, idea, fmt.Sprintf("%s idea %s", verb, taskTitleOrUntitled(idea.Title)))
+}
+
+// cmdIdeaRemove moves an idea to the trash (`trash restore` brings it back).
+func cmdIdeaRemove(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("idea rm", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sel := addIdeaSelectorFlags(fs)
+	if err := fs.Parse(reorderFlags(args, ideaSelectorFlagSpec)); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if selector == "" {
+		fmt.Fprintln(os.Stderr, "Usage: tasker idea rm [--scope root|project|all] [--project <name>] [--match <m>] <selector>")
+		return ExitUsage
+	}
+	filter, err := sel.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea rm:", err)
+		return ExitUsage
+	}
+	idea, code := resolveIdea(ws, "idea rm", selector, filter)
+	if code != ExitOK {
+		return code
+	}
+	trashed, err := ws.TrashIdea(idea)
+	if err != nil {
+		return storeErrCode("idea rm", err)
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", trashed.ID, trashed.TrashedOn, trashed.Title)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "idea rm", "idea", map[string]any{"idea": trashed})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Moved idea %s to the trash (restore with `tasker trash restore %s`)\n", taskTitleOrUntitled(trashed.Title), trashed.ID)
+	}
+	return ExitOK
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func ideaScoreLabel(s *store.IdeaScore) string {
+	if s == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(s.Score, 'g', -1, 64)
+}
+
+// cmdIdeaScore rates an idea: `idea score <selector> --impact N --effort N`.
+func cmdIdeaScore(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	spec := map[string]bool{"--impact": true, "--effort": true}
+	for k, v := range ideaSelectorFlagSpec {
+		spec[k] = v
+	}
+	fs := flag.NewFlagSet("idea score", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	sel := addIdeaSelectorFlags(fs)
+	impact := fs.Int("impact", 0, fmt.Sprintf("Impact, 1-%d", store.IdeaScoreMax))
+	effort := fs.Int("effort", 0, fmt.Sprintf("Effort, 1-%d", store.IdeaScoreMax))
+	if err := fs.Parse(reorderFlags(args, spec)); err != nil {
+		return ExitUsage
+	}
+	selector := strings.Join(fs.Args(), " ")
+	if selector == "" || *impact == 0 || *effort == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: tasker idea score [--scope root|project|all] [--project <name>] [--match <m>] --impact 1-%d --effort 1-%d <selector>\n", store.IdeaScoreMax, store.IdeaScoreMax)
+		return ExitUsage
+	}
+	filter, err := sel.filter()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea score:", err)
+		return ExitUsage
+	}
+	idea, code := resolveIdea(ws, "idea score", selector, filter)
+	if code != ExitOK {
+		return code
+	}
+	if idea, err = ws.ScoreIdea(idea, *impact, *effort); err != nil {
+		return storeErrCode("idea score", err)
+	}
+	return emitIdea(gf, "idea score", idea, fmt.Sprintf("Scored idea %s: %s (impact %d, effort %d)", taskTitleOrUntitled(idea.Title), ideaScoreLabel(idea.Score), idea.Score.Impact, idea.Score.Effort))
+}
+
+// cmdIdeaTriage walks the ideas that have no score yet, oldest first,
+// asking for impact and effort. Without a terminal (or with --json/--plain)
+// it lists that queue instead, for `idea score` to work through.
+func cmdIdeaTriage(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--scope": true, "--project": true, "--tag": true, "--any-tag": true, "--not-tag": true, "--limit": true})
+	fs := flag.NewFlagSet("idea triage", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	scope := fs.String("scope", "", "Scope (root|project|all)")
+	project := fs.String("project", "", "Project name/slug")
+	tags := addTagFlags(fs)
+	limit := fs.Int("limit", 0, "Review at most N ideas (0: all)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 || *limit < 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker idea triage [--scope root|project|all] [--project <name>] [--tag <t>...] [--limit N]")
+		return ExitUsage
+	}
+	scopeValue, err := resolveIdeaScope(*scope, *project)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea triage:", err)
+		return ExitUsage
+	}
+	ideas, err := ws.ListIdeas(store.IdeaListFilter{Project: *project, Scope: scopeValue, Tags: tags.filter(), Unscored: true})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea triage:", err)
+		return ExitInternal
+	}
+	// Oldest first, so nothing waits forever behind fresh captures.
+	for i, j := 0, len(ideas)-1; i < j; i, j = i+1, j-1 {
+		ideas[i], ideas[j] = ideas[j], ideas[i]
+	}
+	if *limit > 0 && len(ideas) > *limit {
+		ideas = ideas[:*limit]
+	}
+	if !isInteractive(gf) {
+		if gf.Plain {
+			for _, idea := range ideas {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", idea.ID, ideaLocationLabel(idea.Project), idea.Title)
+			}
+			return ExitOK
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "idea triage", "idea-triage", map[string]any{"ideas": ideas, "count": len(ideas)})
+		}
+		if !gf.Quiet {
+			fmt.Printf("%d idea(s) to triage; score each with `tasker idea score <id> --impact 1-%d --effort 1-%d`\n", len(ideas), store.IdeaScoreMax, store.IdeaScoreMax)
+			for _, idea := range ideas {
+				fmt.Printf("%s (%s)\n", formatIdeaListBullet(idea, ws.Config().HumanSnippetWidth()), idea.ID)
+			}
+		}
+		return ExitOK
+	}
+	if len(ideas) == 0 {
+		fmt.Println("Nothing to triage.")
+		return ExitOK
+	}
+	in := bufio.NewReader(os.Stdin)
+	scored := 0
+	for n := range ideas {
+		idea := &ideas[n]
+		fmt.Fprintf(os.Stderr, "\n[%d/%d] %s", n+1, len(ideas), idea.RenderHuman())
+		impact, ok := askIdeaRating(in, "Impact")
+		if !ok {
+			break
+		}
+		if impact == 0 {
+			continue
+		}
+		effort, ok := askIdeaRating(in, "Effort")
+		if !ok {
+			break
+		}
+		if effort == 0 {
+			continue
+		}
+		if _, err := ws.ScoreIdea(idea, impact, effort); err != nil {
+			return storeErrCode("idea triage", err)
+		}
+		scored++
+	}
+	fmt.Printf("Scored %d of %d idea(s)\n", scored, len(ideas))
+	return ExitOK
+}
+
+// askIdeaRating reads a 1..IdeaScoreMax rating from in. It returns 0 when
+// the idea is skipped (s or Enter) and false when triage stops (q or end of
+// input).
+func askIdeaRating(in *bufio.Reader, label string) (int, bool) {
+	for {
+		fmt.Fprintf(os.Stderr, "%s 1-%d (Enter or s to skip, q to quit): ", label, store.IdeaScoreMax)
+		line, err := in.ReadString('\n')
+		answer := strings.ToLower(strings.TrimSpace(line))
+		switch {
+		case answer == "" && err != nil:
+			fmt.Fprintln(os.Stderr)
+			return 0, false
+		case answer == "q" || answer == "quit":
+			return 0, false
+		case answer == "" || answer == "s" || answer == "skip":
+			return 0, true
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= store.IdeaScoreMax {
+			return n, true
+		}
+		if err != nil {
+			return 0, false
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const importUsage = "Usage: tasker import todotxt <file|-> [--project <name>] [--dry-run] | import csv <file|-> [--project <name>] [--map field=Header,...] [--dry-run]"
+
+func cmdImport(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, importUsage)
+		return ExitUsage
+	}
+	format := args[0]
+	switch format {
+	case "todotxt", "todo.txt":
+		format = "todotxt"
+	case "csv":
+	default:
+		fmt.Fprintln(os.Stderr, importUsage)
+		return ExitUsage
+	}
+	label := "import " + format
+	args = reorderFlags(args[1:], map[string]bool{
+		"--project": true,
+		"--map":     true,
+		"--dry-run": false,
+	})
+	fs := flag.NewFlagSet(label, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project for rows without one (default: the default project)")
+	mapSpec := fs.String("map", "", "CSV only: field=Header pairs, comma-separated")
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() != 1 || (*mapSpec != "" && format != "csv") {
+		fmt.Fprintln(os.Stderr, importUsage)
+		return ExitUsage
+	}
+	var in io.Reader = os.Stdin
+	if src := fs.Arg(0); src != "-" {
+		f, err := os.Open(store.ExpandHome(src))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, label+":", err)
+			return ExitNotFound
+		}
+		defer f.Close()
+		in = f
+	}
+	var items []store.ImportItem
+	if format == "csv" {
+		mapping, err := store.ParseCSVMapping(*mapSpec)
+		if err == nil {
+			items, err = store.ParseCSV(in, mapping, resolveProject(ws, *project))
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, label+":", err)
+			return ExitUsage
+		}
+	} else {
+		lines, err := store.ParseTodoTxt(in)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, label+":", err)
+			return ExitUsage
+		}
+		for _, it := range lines {
+			items = append(items, it.ImportItem(resolveProject(ws, *project)))
+		}
+	}
+	tasks, err := ws.ImportTasks(items, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrInvalid):
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, t := range tasks {
+			fmt.Fprintf(os.Stdout, "%s\t%s/%s\t%s\n", t.ID, t.Project, t.Column, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, label, "import", map[string]any{"tasks": tasks, "count": len(tasks), "dry_run": *dryRun})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %d task(s)\n", verb, len(tasks))
+	for _, t := range tasks {
+		fmt.Printf("  %s/%s: %s\n", t.Project, t.Column, taskTitleOrUntitled(t.Title))
+	}
+	return ExitOK
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdIndex(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker index <rebuild|status>")
+		return ExitUsage
+	}
+	switch args[0] {
+	case "rebuild":
+		start := time.Now()
+		n, err := ws.RebuildIndex()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "index rebuild:", err)
+			return ExitInternal
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "index rebuild", "index", map[string]any{"tasks": n})
+		}
+		if !gf.Quiet {
+			fmt.Printf("Indexed %d tasks in %s\n", n, time.Since(start).Round(time.Millisecond))
+		}
+		return ExitOK
+	case "status":
+		st, err := ws.IndexStatus()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "index status:", err)
+			return ExitInternal
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "index status", "index", map[string]any{"index": st})
+		}
+		if gf.Plain {
+			fmt.Fprintf(os.Stdout, "%s\t%t\t%d\t%d\t%d\n", st.Path, st.Exists, st.Entries, st.Stale, st.Missing)
+			return ExitOK
+		}
+		if !st.Exists {
+			fmt.Printf("No index at %s (built on first listing)\n", st.Path)
+			return ExitOK
+		}
+		fmt.Printf("%s: %d entries, %d stale, %d missing\n", st.Path, st.Entries, st.Stale, st.Missing)
+		return ExitOK
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: tasker index <rebuild|status>")
+		return ExitUsage
+	}
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const (
+	linkUsage   = "Usage: tasker link <selector-a> <selector-b> [--type relates|duplicates|follows] [--idea] [--project <name>]"
+	unlinkUsage = "Usage: tasker unlink <selector-a> <selector-b> [--idea] [--project <name>]"
+	linksUsage  = "Usage: tasker links <selector...> [--project <name>]"
+)
+
+// linkEnds resolves the two selectors of link/unlink. Either may be an
+// idea id; with --idea the second selector is matched against ideas. The
+// idea, if any, is returned apart from the tasks, and the task it links to
+// is returned as a.
+func linkEnds(ws *store.Workspace, gf GlobalFlags, cmd string, args []string) (a *store.Task, b *store.Task, idea *store.Idea, linkType string, code int) {
+	args = reorderFlags(args, map[string]bool{
+		"--type":    true,
+		"--project": true,
+		"--match":   true,
+		"--idea":    false,
+	})
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	usage := linkUsage
+	typ := new(string)
+	if cmd == "link" {
+		typ = fs.String("type", "", "Link type (relates|duplicates|follows), read as \"a <type> b\"")
+	} else {
+		usage = unlinkUsage
+	}
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	ideaFlag := fs.Bool("idea", false, "Match the second selector against ideas instead of tasks")
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, nil, "", ExitUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		return nil, nil, nil, "", ExitUsage
+	}
+	first, second := fs.Arg(0), fs.Arg(1)
+	firstIdea, secondIdea := isIdeaSelector(first), *ideaFlag || isIdeaSelector(second)
+	if firstIdea && secondIdea {
+		fmt.Fprintf(os.Stderr, "%s: ideas link to tasks, not to other ideas\n", cmd)
+		return nil, nil, nil, "", ExitUsage
+	}
+	if firstIdea {
+		first, second = second, first
+	}
+	linkType, err := store.NormalizeLinkType(*typ)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		return nil, nil, nil, "", ExitUsage
+	}
+	if (firstIdea || secondIdea) && linkType != "relates" {
+		fmt.Fprintf(os.Stderr, "%s: an idea and a task can only be linked as relates\n", cmd)
+		return nil, nil, nil, "", ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		return nil, nil, nil, "", ExitUsage
+	}
+	if a, code = resolveTaskSelector(ws, gf, cmd, first, filter); code != ExitOK {
+		return nil, nil, nil, "", code
+	}
+	if firstIdea || secondIdea {
+		idea, code = resolveIdea(ws, cmd, second, store.IdeaSelectorFilter{Scope: store.IdeaScopeAll, Match: *match})
+		return a, nil, idea, linkType, code
+	}
+	b, code = resolveTaskSelector(ws, gf, cmd, second, filter)
+	return a, b, nil, linkType, code
+}
+
+func isIdeaSelector(selector string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(selector)), "idea_")
+}
+
+func linkErrCode(cmd string, err error) int {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	}
+	return ExitInternal
+}
+
+func cmdLink(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	a, b, idea, linkType, code := linkEnds(ws, gf, "link", args)
+	if code != ExitOK {
+		return code
+	}
+	if idea != nil {
+		idea, a, err := ws.LinkIdea(idea, a.ID)
+		if err != nil {
+			return linkErrCode("link", err)
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "link", "link", map[string]any{"task": a, "idea": idea})
+		}
+		fmt.Printf("%s relates idea %s\n", taskTitleOrUntitled(a.Title), taskTitleOrUntitled(idea.Title))
+		return ExitOK
+	}
+	a, b, err := ws.LinkTasks(a.ID, b.ID, linkType)
+	if err != nil {
+		return linkErrCode("link", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "link", "link", map[string]any{"task": a, "other": b})
+	}
+	fmt.Printf("%s %s %s\n", taskTitleOrUntitled(a.Title), linkType, taskTitleOrUntitled(b.Title))
+	return ExitOK
+}
+
+// cmdUnlink removes a link made by `link` from both ends.
+func cmdUnlink(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	a, b, idea, _, code := linkEnds(ws, gf, "unlink", args)
+	if code != ExitOK {
+		return code
+	}
+	if idea != nil {
+		idea, a, err := ws.UnlinkIdea(idea, a.ID)
+		if err != nil {
+			return linkErrCode("unlink", err)
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "unlink", "link", map[string]any{"task": a, "idea": idea})
+		}
+		fmt.Printf("Unlinked %s and idea %s\n", taskTitleOrUntitled(a.Title), taskTitleOrUntitled(idea.Title))
+		return ExitOK
+	}
+	a, b, err := ws.UnlinkTasks(a.ID, b.ID)
+	if err != nil {
+		return linkErrCode("unlink", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "unlink", "link", map[string]any{"task": a, "other": b})
+	}
+	fmt.Printf("Unlinked %s and %s\n", taskTitleOrUntitled(a.Title), taskTitleOrUntitled(b.Title))
+	return ExitOK
+}
+
+func cmdLinks(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("links", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, linksUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "links:", err)
+		return ExitUsage
+	}
+	task, code := resolveTaskSelector(ws, gf, "links", strings.Join(fs.Args(), " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	links, err := ws.TaskLinks(task)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "links:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, l := range links {
+			status, title := "missing", ""
+			if l.Task != nil {
+				status, title = l.Task.Status, l.Task.Title
+			} else if l.Idea != nil {
+				status, title = "idea", l.Idea.Title
+			}
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", l.Type, l.ID, status, title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		if links == nil {
+			links = []store.LinkedTask{}
+		}
+		return emitJSONPayload(gf, "links", "links", map[string]any{"task": task, "links": links})
+	}
+	fmt.Println(taskTitleOrUntitled(task.Title))
+	if len(links) == 0 {
+		fmt.Println("  (no links)")
+		return ExitOK
+	}
+	for _, l := range links {
+		if l.Idea != nil {
+			fmt.Printf("  %s: idea %s (%s)\n", l.Type, taskTitleOrUntitled(l.Idea.Title), ideaLocationLabel(l.Idea.Project))
+			continue
+		}
+		if l.Task == nil {
+			fmt.Printf("  %s: %s (missing)\n", l.Type, l.ID)
+			continue
+		}
+		fmt.Printf("  %s: %s (%s/%s)\n", l.Type, taskTitleOrUntitled(l.Task.Title), l.Task.Project, l.Task.Column)
+	}
+	return ExitOK
+}
@@ -0,0 +1,549 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision tasker speaks.
+const mcpProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	call        func(ws *store.Workspace, args json.RawMessage) (any, error)
+	// writes names the CLI command a tool that changes the workspace stands
+	// for (e.g. "idea", "add"); nil for read-only tools.
+	writes []string
+}
+
+func cmdMCP(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker mcp")
+		return ExitUsage
+	}
+	if err := serveMCP(ws, gf, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "mcp:", err)
+		return ExitInternal
+	}
+	return ExitOK
+}
+
+// serveMCP answers newline-delimited JSON-RPC messages from in until EOF.
+// Notifications (no id) get no reply. Tools that write run under the
+// workspace lock and trigger the same post-write hooks as the CLI.
+func serveMCP(ws *store.Workspace, gf GlobalFlags, in io.Reader, out io.Writer) error {
+	tools := mcpTools()
+	enc := json.NewEncoder(out)
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 64*1024), maxServeBody)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			_ = enc.Encode(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}})
+			continue
+		}
+		if len(req.ID) == 0 {
+			continue
+		}
+		resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+		result, rerr := handleMCP(ws, gf, tools, req)
+		if rerr != nil {
+			resp.Error = rerr
+		} else {
+			resp.Result = result
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func handleMCP(ws *store.Workspace, gf GlobalFlags, tools []mcpTool, req rpcRequest) (any, *rpcError) {
+	if req.JSONRPC != "2.0" {
+		return nil, &rpcError{Code: rpcInvalidRequest, Message: "jsonrpc must be \"2.0\""}
+	}
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "tasker", "version": "0.1"},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": tools}, nil
+	case "tools/call":
+		var p struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &p); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		for _, t := range tools {
+			if t.Name != p.Name {
+				continue
+			}
+			args := p.Arguments
+			if len(args) == 0 || string(args) == "null" {
+				args = json.RawMessage("{}")
+			}
+			var payload any
+			var err error
+			if t.writes != nil {
+				err = runWrite(ws, gf, t.writes, "mcp "+t.Name, func() error {
+					payload, err = t.call(ws, args)
+					return err
+				})
+			} else {
+				payload, err = t.call(ws, args)
+			}
+			if err != nil {
+				return mcpToolResult(map[string]any{"error": mcpErrorCode(err), "message": err.Error()}, true), nil
+			}
+			return mcpToolResult(payload, false), nil
+		}
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "unknown tool: " + p.Name}
+	}
+	return nil, &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + req.Method}
+}
+
+// mcpToolResult wraps payload as a single JSON text block, the shape agents
+// already parse from --json output.
+func mcpToolResult(payload any, isError bool) map[string]any {
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		b = []byte(err.Error())
+		isError = true
+	}
+	return map[string]any{
+		"content": []map[string]any{{"type": "text", "text": string(b)}},
+		"isError": isError,
+	}
+}
+
+func mcpErrorCode(err error) string {
+	switch {
+	case errors.Is(err, errBadRequest), errors.Is(err, store.ErrInvalid):
+		return "invalid"
+	case errors.Is(err, store.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, store.ErrConflict):
+		return "conflict"
+	}
+	return "internal"
+}
+
+func decodeToolArgs(args json.RawMessage, v any) error {
+	dec := json.NewDecoder(strings.NewReader(string(args)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid arguments: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+func schemaObject(required []string, props map[string]any) map[string]any {
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func schemaString(desc string) map[string]any {
+	return map[string]any{"type": "string", "description": desc}
+}
+
+func mcpTools() []mcpTool {
+	tags := map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Tags"}
+	return []mcpTool{
+		{
+			Name:        "add_task",
+			Description: "Create a task.",
+			InputSchema: schemaObject([]string{"title"}, map[string]any{
+				"title":       schemaString("Task title"),
+				"project":     schemaString("Project name/slug (default: agent.default_project)"),
+				"column":      schemaString("Column id (default inbox)"),
+				"due":         schemaString("Due date (YYYY-MM-DD or RFC3339)"),
+				"due_time":    schemaString("Time of day the task is due (HH:MM); omit for all day"),
+				"priority":    schemaString("low|normal|high|urgent"),
+				"tags":        tags,
+				"description": schemaString("Task notes"),
+				"repeat":      schemaString("Recurrence, e.g. weekly or every 2 weeks"),
+				"external_id": schemaString("Client key; adding again with the same key returns the existing task"),
+			}),
+			call:   mcpAddTask,
+			writes: []string{"add"},
+		},
+		{
+			Name:        "list_tasks",
+			Description: "List tasks, optionally filtered.",
+			InputSchema: schemaObject(nil, map[string]any{
+				"project": schemaString("Project name/slug"),
+				"column":  schemaString("Column id"),
+				"status":  schemaString("open|doing|blocked|done|archived"),
+				"tag":     schemaString("Tag"),
+				"search":  schemaString("Text search"),
+				"query":   schemaString("Filter expression as for ls --query, e.g. project=work and (priority>=high or tag=client)"),
+				"all":     map[string]any{"type": "boolean", "description": "Include archived tasks"},
+			}),
+			call: mcpListTasks,
+		},
+		{
+			Name:        "find",
+			Description: "Search tasks and ideas together; each hit says whether it is a task or an idea and where it lives.",
+			InputSchema: schemaObject([]string{"query"}, map[string]any{
+				"query":   schemaString("Text to find"),
+				"project": schemaString("Project name/slug (root ideas are left out)"),
+				"kind":    schemaString("task|idea (default both)"),
+				"match":   schemaString("auto|exact|prefix|contains|search"),
+				"all":     map[string]any{"type": "boolean", "description": "Include archived tasks"},
+			}),
+			call: mcpFind,
+		},
+		{
+			Name:        "move_task",
+			Description: "Move a task to another column (use column done to complete it).",
+			InputSchema: schemaObject([]string{"task", "to"}, map[string]any{
+				"task":    schemaString("Task id, id prefix, or title selector"),
+				"to":      schemaString("Target column id"),
+				"project": schemaString("Project to resolve the selector in"),
+				"force":   map[string]any{"type": "boolean", "description": "Complete even if blocked by open tasks"},
+			}),
+			call:   mcpMoveTask,
+			writes: []string{"mv"},
+		},
+		{
+			Name:        "add_idea",
+			Description: "Capture an idea.",
+			InputSchema: schemaObject([]string{"title"}, map[string]any{
+				"title":   schemaString("Idea title"),
+				"project": schemaString("Project name/slug (default: root ideas)"),
+				"tags":    tags,
+				"body":    schemaString("Idea body"),
+			}),
+			call:   mcpAddIdea,
+			writes: []string{"idea", "add"},
+		},
+		{
+			Name:        "promote_idea",
+			Description: "Turn an idea into a task.",
+			InputSchema: schemaObject([]string{"idea"}, map[string]any{
+				"idea":       schemaString("Idea id, id prefix, or title selector"),
+				"to_project": schemaString("Target task project (default: the idea's project)"),
+				"column":     schemaString("Target column id (default inbox)"),
+				"due":        schemaString("Due date (YYYY-MM-DD or RFC3339)"),
+				"priority":   schemaString("low|normal|high|urgent"),
+				"delete":     map[string]any{"type": "boolean", "description": "Delete the idea after promoting"},
+			}),
+			call:   mcpPromoteIdea,
+			writes: []string{"idea", "promote"},
+		},
+		{
+			Name:        "today",
+			Description: "Tasks due today plus overdue ones.",
+			InputSchema: schemaObject(nil, map[string]any{
+				"project": schemaString("Project name/slug"),
+				"group":   schemaString("project|column|none"),
+				"all":     map[string]any{"type": "boolean", "description": "Include done tasks"},
+			}),
+			call: mcpToday,
+		},
+		{
+			Name:        "week",
+			Description: "Tasks due in the coming days.",
+			InputSchema: schemaObject(nil, map[string]any{
+				"project": schemaString("Project name/slug"),
+				"days":    map[string]any{"type": "integer", "description": "Days ahead (default agent.week_days or 7)"},
+				"group":   schemaString("project|column|none|project,day|column,day"),
+				"all":     map[string]any{"type": "boolean", "description": "Include done tasks"},
+			}),
+			call: mcpWeek,
+		},
+		{
+			Name:        "brief",
+			Description: "A compact status for prompts: open, doing, blocked, due today and overdue counts plus the three most urgent tasks.",
+			InputSchema: schemaObject(nil, map[string]any{
+				"project":   schemaString("Project name/slug"),
+				"max_chars": map[string]any{"type": "integer", "description": "Longest text in characters (default 800, 0: no limit)"},
+			}),
+			call: mcpBrief,
+		},
+	}
+}
+
+func mcpAddTask(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in apiAddTask
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Title) == "" {
+		return nil, fmt.Errorf("%w: title is required", errBadRequest)
+	}
+	task, err := ws.AddTask(store.AddTaskInput{
+		Title:         in.Title,
+		Project:       resolveProject(ws, in.Project),
+		Column:        in.Column,
+		Due:           in.Due,
+		DueTime:       in.DueTime,
+		Priority:      in.Priority,
+		Tags:          in.Tags,
+		Description:   in.Description,
+		Repeat:        in.Repeat,
+		CreateProject: in.CreateProject,
+		ExternalID:    in.ExternalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"task": task}, nil
+}
+
+func mcpListTasks(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in struct {
+		Project string `json:"project"`
+		Column  string `json:"column"`
+		Status  string `json:"status"`
+		Tag     string `json:"tag"`
+		Search  string `json:"search"`
+		Query   string `json:"query"`
+		All     bool   `json:"all"`
+	}
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	project := resolveProject(ws, in.Project)
+	if err := checkProject(ws, project); err != nil {
+		return nil, fmt.Errorf("%w: %v", store.ErrNotFound, err)
+	}
+	query, err := parseQuery(in.Query)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := ws.ListTasks(store.ListFilter{Project: project, Column: in.Column, Status: in.Status, Tags: store.OneTag(in.Tag), Search: in.Search, Query: query, All: in.All})
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = []store.Task{}
+	}
+	return map[string]any{"tasks": tasks}, nil
+}
+
+func mcpFind(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in struct {
+		Query   string `json:"query"`
+		Project string `json:"project"`
+		Kind    string `json:"kind"`
+		Match   string `json:"match"`
+		All     bool   `json:"all"`
+	}
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if err := checkProject(ws, in.Project); err != nil {
+		return nil, fmt.Errorf("%w: %v", store.ErrNotFound, err)
+	}
+	hits, err := ws.Find(in.Query, store.FindOptions{Project: in.Project, Kind: in.Kind, Match: in.Match, All: in.All})
+	if err != nil {
+		return nil, err
+	}
+	if hits == nil {
+		hits = []store.FindHit{}
+	}
+	return map[string]any{"hits": hits}, nil
+}
+
+func mcpMoveTask(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in struct {
+		Task    string `json:"task"`
+		To      string `json:"to"`
+		Project string `json:"project"`
+		Force   bool   `json:"force"`
+	}
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Task) == "" || strings.TrimSpace(in.To) == "" {
+		return nil, fmt.Errorf("%w: task and to are required", errBadRequest)
+	}
+	filter, err := selectorFilter(ws, in.Project, "", "", true, "auto")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	task, err := ws.GetTaskBySelectorFiltered(in.Task, filter)
+	if err != nil {
+		return nil, err
+	}
+	moved, err := ws.MoveTaskWith(task.ID, strings.TrimSpace(in.To), store.MoveOptions{Force: in.Force})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"task": moved}, nil
+}
+
+func mcpAddIdea(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in struct {
+		Title   string   `json:"title"`
+		Project string   `json:"project"`
+		Tags    []string `json:"tags"`
+		Body    string   `json:"body"`
+	}
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Title) == "" {
+		return nil, fmt.Errorf("%w: title is required", errBadRequest)
+	}
+	idea, err := ws.AddIdea(store.AddIdeaInput{Title: in.Title, Project: in.Project, Tags: in.Tags, Body: in.Body})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"idea": idea}, nil
+}
+
+func mcpPromoteIdea(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in struct {
+		Idea      string `json:"idea"`
+		ToProject string `json:"to_project"`
+		Column    string `json:"column"`
+		Due       string `json:"due"`
+		Priority  string `json:"priority"`
+		Delete    bool   `json:"delete"`
+	}
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.Idea) == "" {
+		return nil, fmt.Errorf("%w: idea is required", errBadRequest)
+	}
+	idea, err := ws.GetIdeaBySelectorFiltered(in.Idea, store.IdeaSelectorFilter{Scope: store.IdeaScopeAll, Match: "auto"})
+	if err != nil {
+		return nil, err
+	}
+	project := strings.TrimSpace(in.ToProject)
+	if project == "" {
+		project = idea.Project
+	}
+	if project == "" {
+		project = resolveProject(ws, "")
+	}
+	title := strings.TrimSpace(idea.Title)
+	if title == "" {
+		title = "(untitled idea)"
+	}
+	task, err := ws.AddTask(store.AddTaskInput{
+		Title:       title,
+		Project:     project,
+		Column:      strings.TrimSpace(in.Column),
+		Due:         strings.TrimSpace(in.Due),
+		Priority:    strings.TrimSpace(in.Priority),
+		Tags:        append([]string{}, idea.Tags...),
+		Description: strings.TrimSpace(idea.Body),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if in.Delete {
+		if err := ws.DeleteIdea(idea); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]any{"task": task, "idea_id": idea.ID, "idea_deleted": in.Delete}, nil
+}
+
+type mcpAgendaArgs struct {
+	Project string `json:"project"`
+	Days    int    `json:"days"`
+	Group   string `json:"group"`
+	All     bool   `json:"all"`
+}
+
+func (a mcpAgendaArgs) resolve(ws *store.Workspace, week bool) (string, bool, string, error) {
+	project := resolveProject(ws, a.Project)
+	if err := checkProject(ws, project); err != nil {
+		return "", false, "", fmt.Errorf("%w: %v", store.ErrNotFound, err)
+	}
+	groupBy := resolveGroupBy(ws, project, a.Group)
+	if groupBy == "none" {
+		groupBy = ""
+	}
+	if week {
+		if _, _, err := store.SplitAgendaGroup(groupBy); err != nil {
+			return "", false, "", fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+	} else if groupBy != "" && groupBy != "project" && groupBy != "column" {
+		return "", false, "", fmt.Errorf("%w: group must be project|column|none", errBadRequest)
+	}
+	return project, resolveOpenOnly(ws, project, false, a.All), groupBy, nil
+}
+
+func mcpToday(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in mcpAgendaArgs
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	project, open, groupBy, err := in.resolve(ws, false)
+	if err != nil {
+		return nil, err
+	}
+	return ws.TodayView(project, open, groupBy)
+}
+
+func mcpWeek(ws *store.Workspace, args json.RawMessage) (any, error) {
+	var in mcpAgendaArgs
+	if err := decodeToolArgs(args, &in); err != nil {
+		return nil, err
+	}
+	if in.Days < 0 {
+		return nil, fmt.Errorf("%w: days must be positive", errBadRequest)
+	}
+	project, open, groupBy, err := in.resolve(ws, true)
+	if err != nil {
+		return nil, err
+	}
+	return ws.WeekView(project, resolveWeekDays(ws, project, in.Days), open, groupBy)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func TestServeMCPRoundTrip(t *testing.T) {
+	ws := newTestWorkspace(t)
+	in := strings.Join([]string{
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocolVersion": "2024-11-05", "capabilities": {}, "clientInfo": {"name": "test", "version": "1"}}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "tools/call", "params": {"name": "add_task", "arguments": {"title": "Ship", "project": "Work", "tags": ["mcp"]}}}`,
+		`{"jsonrpc": "2.0", "id": 4, "method": "tools/call", "params": {"name": "move_task", "arguments": {"task": "nope", "to": "done"}}}`,
+		`{"jsonrpc": "2.0", "id": 5, "method": "tools/call", "params": {"name": "fly"}}`,
+		`{"jsonrpc": "2.0", "id": 6, "method": "resources/list"}`,
+		`not json`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := serveMCP(ws, GlobalFlags{Quiet: true}, strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	type response struct {
+		ID     json.RawMessage `json:"id"`
+		Result struct {
+			ProtocolVersion string    `json:"protocolVersion"`
+			Tools           []mcpTool `json:"tools"`
+			Content         []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+		Error *rpcError `json:"error"`
+	}
+	var resps []response
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r response
+		if err := dec.Decode(&r); err != nil {
+			t.Fatal(err)
+		}
+		resps = append(resps, r)
+	}
+	// The notification gets no reply.
+	if len(resps) != 7 {
+		t.Fatalf("expected 7 responses, got %d: %s", len(resps), out.String())
+	}
+
+	if resps[0].Result.ProtocolVersion != mcpProtocolVersion {
+		t.Fatalf("unexpected initialize result: %+v", resps[0])
+	}
+	names := map[string]bool{}
+	for _, tool := range resps[1].Result.Tools {
+		names[tool.Name] = true
+	}
+	for _, name := range []string{"add_task", "list_tasks", "move_task", "today", "week"} {
+		if !names[name] {
+			t.Fatalf("tools/list is missing %s: %v", name, names)
+		}
+	}
+
+	call := resps[2].Result
+	if call.IsError || len(call.Content) != 1 {
+		t.Fatalf("unexpected add_task result: %+v", call)
+	}
+	var added struct {
+		Task store.Task `json:"task"`
+	}
+	if err := json.Unmarshal([]byte(call.Content[0].Text), &added); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := ws.GetTaskByPrefix(added.Task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Title != "Ship" || stored.Project != "work" || len(stored.Tags) != 1 {
+		t.Fatalf("unexpected stored task: %+v", stored.TaskMeta)
+	}
+
+	if !resps[3].Result.IsError || !strings.Contains(resps[3].Result.Content[0].Text, `"not_found"`) {
+		t.Fatalf("expected a not_found tool error, got %+v", resps[3].Result)
+	}
+	if resps[4].Error == nil || resps[4].Error.Code != rpcInvalidParams {
+		t.Fatalf("expected invalid params for an unknown tool, got %+v", resps[4])
+	}
+	if resps[5].Error == nil || resps[5].Error.Code != rpcMethodNotFound {
+		t.Fatalf("expected method not found, got %+v", resps[5])
+	}
+	if resps[6].Error == nil || resps[6].Error.Code != rpcParseError || string(resps[6].ID) != "null" {
+		t.Fatalf("expected a parse error, got %+v", resps[6])
+	}
+}
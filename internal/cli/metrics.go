@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdMetrics(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if err := writePrometheusMetrics(os.Stdout, ws); err != nil {
+		fmt.Fprintln(os.Stderr, "metrics:", err)
+		return ExitInternal
+	}
+	return ExitOK
+}
+
+func promLabel(v string) string {
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, "\n", `\n`)
+	return strings.ReplaceAll(v, `"`, `\"`)
+}
+
+// writePrometheusMetrics renders workspace gauges and, when the operations log
+// is enabled, command counters and latencies in the Prometheus text format.
+// Command counters include the entries that rotated out of the log, so they
+// never go backwards.
+func writePrometheusMetrics(out io.Writer, ws *store.Workspace) error {
+	m, err := ws.Metrics()
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString("# HELP tasker_projects Number of projects.\n# TYPE tasker_projects gauge\n")
+	fmt.Fprintf(&b, "tasker_projects %d\n", m.Projects)
+	b.WriteString("# HELP tasker_tasks Tasks by project and status.\n# TYPE tasker_tasks gauge\n")
+	for _, c := range m.Tasks {
+		fmt.Fprintf(&b, "tasker_tasks{project=\"%s\",status=\"%s\"} %d\n", promLabel(c.Project), promLabel(c.Status), c.Count)
+	}
+	b.WriteString("# HELP tasker_tasks_open Open, doing and blocked tasks.\n# TYPE tasker_tasks_open gauge\n")
+	fmt.Fprintf(&b, "tasker_tasks_open %d\n", m.Open)
+	b.WriteString("# HELP tasker_tasks_overdue Open tasks past their due date.\n# TYPE tasker_tasks_overdue gauge\n")
+	fmt.Fprintf(&b, "tasker_tasks_overdue %d\n", m.Overdue)
+	b.WriteString("# HELP tasker_tasks_due_today Open tasks due today.\n# TYPE tasker_tasks_due_today gauge\n")
+	fmt.Fprintf(&b, "tasker_tasks_due_today %d\n", m.DueToday)
+
+	enabled := 0
+	if opLogEnabled(ws) {
+		enabled = 1
+	}
+	b.WriteString("# HELP tasker_oplog_enabled Whether the operations log (command metrics source) is enabled.\n# TYPE tasker_oplog_enabled gauge\n")
+	fmt.Fprintf(&b, "tasker_oplog_enabled %d\n", enabled)
+
+	totals, err := ws.OpLogTotals()
+	if err != nil {
+		return err
+	}
+	b.WriteString("# HELP tasker_mutations_total Successful mutating commands (use rate() for mutations/sec).\n# TYPE tasker_mutations_total counter\n")
+	fmt.Fprintf(&b, "tasker_mutations_total %d\n", totals.Mutations)
+
+	commands := make([]string, 0, len(totals.Commands))
+	for c := range totals.Commands {
+		commands = append(commands, c)
+	}
+	sort.Strings(commands)
+	b.WriteString("# HELP tasker_commands_total Command invocations by result.\n# TYPE tasker_commands_total counter\n")
+	for _, c := range commands {
+		results := make([]string, 0, len(totals.Commands[c].Results))
+		for r := range totals.Commands[c].Results {
+			results = append(results, r)
+		}
+		sort.Strings(results)
+		for _, r := range results {
+			fmt.Fprintf(&b, "tasker_commands_total{command=\"%s\",result=\"%s\"} %d\n", promLabel(c), r, totals.Commands[c].Results[r])
+		}
+	}
+	b.WriteString("# HELP tasker_command_duration_seconds Command latency.\n# TYPE tasker_command_duration_seconds summary\n")
+	for _, c := range commands {
+		fmt.Fprintf(&b, "tasker_command_duration_seconds_sum{command=\"%s\"} %g\n", promLabel(c), float64(totals.Commands[c].DurationMS)/1000)
+		fmt.Fprintf(&b, "tasker_command_duration_seconds_count{command=\"%s\"} %d\n", promLabel(c), totals.Commands[c].Count)
+	}
+	_, err = io.WriteString(out, b.String())
+	return err
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdExportObsidian(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--all":     false,
+		"--out":     true,
+	})
+	fs := flag.NewFlagSet("export obsidian", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (default: all projects)")
+	all := fs.Bool("all", false, "Include archived tasks")
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/tasks[-<project>].md; point it into your vault)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "export obsidian:", err)
+		return ExitNotFound
+	}
+	name := strings.TrimSpace(*project)
+	tasks, err := ws.ListTasks(store.ListFilter{Project: name, All: *all})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export obsidian:", err)
+		return ExitInternal
+	}
+	data := ws.RenderObsidian(tasks)
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		file := obsidianFileName(name)
+		path = filepath.Join(gf.ExportDir, file)
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export obsidian:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Printf("Wrote %d task(s) to: %s\n", len(tasks), path)
+	}
+	return ExitOK
+}
+
+// obsidianFileName is tasks.md, or tasks-<project>.md for one project.
+func obsidianFileName(project string) string {
+	if project == "" {
+		return "tasks.md"
+	}
+	return "tasks-" + store.Slugify(project) + ".md"
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// secretFlagWords mark flags whose values never reach the operations log.
+var secretFlagWords = []string{"token", "secret", "password", "passwd", "apikey", "api-key", "auth", "credential"}
+
+func isSecretFlag(name string) bool {
+	name = strings.ToLower(strings.TrimLeft(name, "-"))
+	for _, w := range secretFlagWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactArgs masks values of secret-looking flags (`--token x` and `--token=x`).
+func redactArgs(args []string) []string {
+	out := make([]string, 0, len(args))
+	redactNext := false
+	for _, a := range args {
+		if redactNext {
+			out = append(out, "[redacted]")
+			redactNext = false
+			continue
+		}
+		if strings.HasPrefix(a, "-") && a != "--" {
+			if name, _, ok := strings.Cut(a, "="); ok && isSecretFlag(name) {
+				out = append(out, name+"=[redacted]")
+				continue
+			}
+			if isSecretFlag(a) {
+				redactNext = true
+			}
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// exitResult names an exit code as listed by `tasker exitcodes`.
+func exitResult(code int) string {
+	for _, c := range exitCodes {
+		if c.Code == code {
+			return c.Name
+		}
+	}
+	return "internal"
+}
+
+func opLogEnabled(ws *store.Workspace) bool {
+	if v, ok := envBool("TASKER_LOG"); ok {
+		return v
+	}
+	return ws.OpLogEnabled()
+}
+
+func currentUser() string {
+	if user := os.Getenv("USER"); user != "" {
+		return user
+	}
+	return os.Getenv("USERNAME")
+}
+
+// invocationActor names who runs this command in the audit log:
+// TASKER_ACTOR (e.g. the agent's name), else the OS user.
+func invocationActor() string {
+	if actor := strings.TrimSpace(os.Getenv("TASKER_ACTOR")); actor != "" {
+		return actor
+	}
+	return currentUser()
+}
+
+// logInvocation records one command run. Failures to log never change the exit code.
+func logInvocation(ws *store.Workspace, gf GlobalFlags, cmd string, args []string, mutating bool, started time.Time, code int) {
+	if !opLogEnabled(ws) || cmd == "_complete" {
+		// Completion runs on every keypress; it would drown the log.
+		return
+	}
+	user := currentUser()
+	host, _ := os.Hostname()
+	entry := store.OpLogEntry{
+		At:         started.UTC(),
+		Command:    cmd,
+		Args:       redactArgs(args),
+		DurationMS: time.Since(started).Milliseconds(),
+		ExitCode:   code,
+		Result:     exitResult(code),
+		Mutating:   mutating,
+		User:       user,
+		Host:       host,
+		PID:        os.Getpid(),
+	}
+	if err := ws.AppendOpLog(entry); err != nil {
+		if gf.Verbose {
+			fmt.Fprintln(os.Stderr, "tasker: ops log:", err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const projectUsage = "Usage: tasker project <add|ls|set|rename|archive|unarchive|rm|export|import> ..."
+
+// storeErrCode maps a store error from the project and idea lifecycle
+// commands to an exit code.
+func storeErrCode(cmd string, err error) int {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrConflict):
+		return ExitConflict
+	case errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	}
+	return ExitInternal
+}
+
+func emitProject(gf GlobalFlags, cmd string, p *store.Project, human string) int {
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\n", p.Slug, p.Name)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, cmd, "project", map[string]any{"project": p})
+	}
+	if !gf.Quiet {
+		fmt.Println(human)
+	}
+	return ExitOK
+}
+
+// cmdProjectRename renames a project; a new slug moves its directory and
+// rewrites the project of every task in it.
+func cmdProjectRename(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker project rename <name> \"<new name>\"")
+		return ExitUsage
+	}
+	old := args[0]
+	p, err := ws.RenameProject(old, strings.Join(args[1:], " "))
+	if err != nil {
+		return storeErrCode("project rename", err)
+	}
+	if def := strings.TrimSpace(ws.Config().Agent.DefaultProject); def != "" && def != p.Slug && store.Slugify(def) == store.Slugify(old) {
+		fmt.Fprintf(os.Stderr, "project rename: agent.default_project is still %q; run `tasker config set agent.default_project %s`\n", def, p.Slug)
+	}
+	return emitProject(gf, "project rename", p, fmt.Sprintf("Renamed project to %s (%s)", p.Name, p.Slug))
+}
+
+// cmdProjectArchive hides a project from listings, globs and views, or
+// (unarchive) brings it back.
+func cmdProjectArchive(ws *store.Workspace, gf GlobalFlags, args []string, archived bool) int {
+	cmd := "project archive"
+	if !archived {
+		cmd = "project unarchive"
+	}
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: tasker %s <name>\n", cmd)
+		return ExitUsage
+	}
+	p, err := ws.SetProjectArchived(args[0], archived)
+	if err != nil {
+		return storeErrCode(cmd, err)
+	}
+	human := fmt.Sprintf("Archived project %s (%s)", p.Name, p.Slug)
+	if !archived {
+		human = fmt.Sprintf("Unarchived project %s (%s)", p.Name, p.Slug)
+	}
+	return emitProject(gf, cmd, p, human)
+}
+
+// cmdProjectRemove deletes a project; one that still has tasks or ideas
+// needs --force, which moves them to the trash.
+func cmdProjectRemove(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--force": false})
+	fs := flag.NewFlagSet("project rm", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	force := fs.Bool("force", false, "Remove a project that still has tasks or ideas (they go to the trash)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker project rm <name> [--force]")
+		return ExitUsage
+	}
+	r, err := ws.RemoveProject(fs.Arg(0), *force)
+	if err != nil {
+		return storeErrCode("project rm", err)
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%d\t%d\n", r.Project.Slug, r.Tasks, r.Ideas)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "project rm", "project-rm", r)
+	}
+	if !gf.Quiet {
+		fmt.Printf("Removed project %s (%s)\n", r.Project.Name, r.Project.Slug)
+		if r.TrashedOn != "" {
+			fmt.Printf("Moved %d task(s) and %d idea(s) to the trash (%s)\n", r.Tasks, r.Ideas, r.TrashedOn)
+		}
+	}
+	return ExitOK
+}
+
+// projectDefaultKeys are the settings `project set` accepts.
+var projectDefaultKeys = []string{"column", "open_only", "week_days", "summary_group"}
+
+// cmdProjectSet sets one of a project's defaults, which win over the agent
+// config for commands scoped to that project; "unset" defers to the config
+// again.
+func cmdProjectSet(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) != 3 {
+		fmt.Fprintf(os.Stderr, "Usage: tasker project set <name> <%s> <value|unset>\n", strings.Join(projectDefaultKeys, "|"))
+		return ExitUsage
+	}
+	p, err := ws.GetProject(args[0])
+	if err != nil {
+		return storeErrCode("project set", err)
+	}
+	var d store.ProjectDefaults
+	if p.Defaults != nil {
+		d = *p.Defaults
+	}
+	key, value := strings.ToLower(args[1]), strings.TrimSpace(args[2])
+	unset := strings.EqualFold(value, "unset")
+	switch key {
+	case "column", "default_column":
+		d.Column = value
+		if unset {
+			d.Column = ""
+		}
+	case "open_only":
+		d.OpenOnly = nil
+		if !unset {
+			v, ok := parseBool(value)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "project set: invalid open_only %q (use true|false|unset)\n", value)
+				return ExitUsage
+			}
+			d.OpenOnly = &v
+		}
+	case "week_days":
+		d.WeekDays = 0
+		if !unset {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "project set: invalid week_days %q (use a positive number or unset)\n", value)
+				return ExitUsage
+			}
+			d.WeekDays = n
+		}
+	case "summary_group":
+		d.SummaryGroup = value
+		if unset {
+			d.SummaryGroup = ""
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "project set: unknown key %q (use %s)\n", args[1], strings.Join(projectDefaultKeys, "|"))
+		return ExitUsage
+	}
+	if p, err = ws.SetProjectDefaults(p.Slug, d); err != nil {
+		return storeErrCode("project set", err)
+	}
+	return emitProject(gf, "project set", p, fmt.Sprintf("Set %s for project %s", key, p.Slug))
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// errPromptCancelled is returned when the user quits a picker.
+var errPromptCancelled = errors.New("cancelled")
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// isInteractive reports whether a command may ask a question instead of
+// guessing: stdin and stderr are terminals, output is for a human, and
+// TASKER_NO_INPUT is not set.
+func isInteractive(gf GlobalFlags) bool {
+	if gf.JSON || gf.NDJSON || gf.Plain || gf.Quiet || gf.Format == "telegram" {
+		return false
+	}
+	if v, ok := envBool("TASKER_NO_INPUT"); ok && v {
+		return false
+	}
+	return isTerminal(os.Stdin) && isTerminal(os.Stderr)
+}
+
+// pickOption lists options on stderr and reads a choice from stdin: a number,
+// an option typed out, or Enter for def. q or end of input cancels.
+func pickOption(question string, options []string, def int) (string, error) {
+	fmt.Fprintln(os.Stderr, question)
+	for i, o := range options {
+		marker := " "
+		if i == def {
+			marker = "*"
+		}
+		fmt.Fprintf(os.Stderr, " %s %d) %s\n", marker, i+1, o)
+	}
+	in := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Fprintf(os.Stderr, "Choose 1-%d (Enter for %d, q to cancel): ", len(options), def+1)
+		line, err := in.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if answer == "" && err != nil {
+			fmt.Fprintln(os.Stderr)
+			return "", errPromptCancelled
+		}
+		switch strings.ToLower(answer) {
+		case "":
+			return options[def], nil
+		case "q", "quit":
+			return "", errPromptCancelled
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+		for _, o := range options {
+			if strings.EqualFold(o, answer) {
+				return o, nil
+			}
+		}
+		if err != nil {
+			return "", errPromptCancelled
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const reportUsage = "Usage: tasker report <burndown|cfd> [--project <name|glob>...] [--days N]"
+
+// reportBarWidth is the width of the longest bar in report charts.
+const reportBarWidth = 40
+
+// cmdReport renders day-by-day charts of the board: burndown (open vs done)
+// and cfd (tasks per column, stacked).
+func cmdReport(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, reportUsage)
+		return ExitUsage
+	}
+	kind := args[0]
+	if kind != "burndown" && kind != "cfd" {
+		fmt.Fprintln(os.Stderr, reportUsage)
+		return ExitUsage
+	}
+	label := "report " + kind
+	args = reorderFlags(args[1:], map[string]bool{
+		"--project": true,
+		"--days":    true,
+	})
+	fs := flag.NewFlagSet(label, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	projects := multiFlag{}
+	fs.Var(&projects, "project", "Project name/slug or glob (repeatable; default: all projects)")
+	days := fs.Int("days", store.DefaultFlowDays, "Days to chart, ending today")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 || *days < 1 {
+		fmt.Fprintln(os.Stderr, reportUsage)
+		return ExitUsage
+	}
+	project := store.JoinProjectSpec(projects.Values)
+	if err := checkProjectSpec(ws, project); err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		return ExitNotFound
+	}
+	report, err := ws.Flow(project, *days)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, label+":", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+
+	if gf.Plain {
+		if kind == "burndown" {
+			fmt.Fprintln(os.Stdout, "DATE\tOPEN\tDONE")
+			for _, p := range report.Points {
+				fmt.Fprintf(os.Stdout, "%s\t%d\t%d\n", p.Date, p.Open, p.Done)
+			}
+			return ExitOK
+		}
+		fmt.Fprintln(os.Stdout, "DATE\t"+strings.ToUpper(strings.Join(report.Columns, "\t")))
+		for _, p := range report.Points {
+			row := []string{p.Date}
+			for _, c := range report.Columns {
+				row = append(row, fmt.Sprint(p.Columns[c]))
+			}
+			fmt.Fprintln(os.Stdout, strings.Join(row, "\t"))
+		}
+		return ExitOK
+	}
+	if gf.NDJSON {
+		items := make([]any, 0, len(report.Points))
+		for _, p := range report.Points {
+			items = append(items, p)
+		}
+		return emitNDJSONItems(gf, label, kind, items)
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, label, kind, map[string]any{"report": kind, "flow": report}); rc != ExitOK {
+			return rc
+		}
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	scope := "all projects"
+	if project != "" {
+		scope = project
+	}
+	if kind == "burndown" {
+		fmt.Printf("Burndown: %s, %s -> %s\n", scope, report.Start, report.End)
+		fmt.Print(renderBurndown(report, gf.ASCII))
+		return ExitOK
+	}
+	fmt.Printf("Cumulative flow: %s, %s -> %s\n", scope, report.Start, report.End)
+	fmt.Print(renderCFD(report))
+	return ExitOK
+}
+
+// renderBurndown draws one bar per day: open tasks, then done ones.
+func renderBurndown(r *store.FlowReport, ascii bool) string {
+	openMark, doneMark := "█", "░"
+	if ascii {
+		openMark, doneMark = "#", "."
+	}
+	most := 0
+	for _, p := range r.Points {
+		most = max(most, p.Open+p.Done)
+	}
+	var b strings.Builder
+	for _, p := range r.Points {
+		open, done := reportBar(p.Open, most), reportBar(p.Done, most)
+		fmt.Fprintf(&b, "%s  %s%s%s  open %d, done %d\n", p.Date[5:], strings.Repeat(openMark, open), strings.Repeat(doneMark, done), strings.Repeat(" ", max(0, reportBarWidth-open-done)), p.Open, p.Done)
+	}
+	fmt.Fprintf(&b, "%s open  %s done\n", openMark, doneMark)
+	return b.String()
+}
+
+// cfdMarks tell the columns apart in renderCFD, in board order.
+var cfdMarks = []string{"#", "=", "+", "-", "%", ":", "*", "~", "."}
+
+// renderCFD draws one stacked bar per day, the last column (usually done or
+// archive) on the left so its band grows steadily.
+func renderCFD(r *store.FlowReport) string {
+	most := 0
+	for _, p := range r.Points {
+		total := 0
+		for _, n := range p.Columns {
+			total += n
+		}
+		most = max(most, total)
+	}
+	var b strings.Builder
+	for _, p := range r.Points {
+		bar := ""
+		var counts []string
+		for i := len(r.Columns) - 1; i >= 0; i-- {
+			c := r.Columns[i]
+			bar += strings.Repeat(cfdMarks[i%len(cfdMarks)], reportBar(p.Columns[c], most))
+		}
+		for _, c := range r.Columns {
+			counts = append(counts, fmt.Sprintf("%s %d", c, p.Columns[c]))
+		}
+		fmt.Fprintf(&b, "%s  %-*s  %s\n", p.Date[5:], reportBarWidth, bar, strings.Join(counts, ", "))
+	}
+	var legend []string
+	for i, c := range r.Columns {
+		legend = append(legend, cfdMarks[i%len(cfdMarks)]+" "+c)
+	}
+	b.WriteString(strings.Join(legend, "  ") + "\n")
+	return b.String()
+}
+
+// reportBar scales n against most to a bar length, at least 1 for any n > 0.
+func reportBar(n, most int) int {
+	if n <= 0 || most <= 0 {
+		return 0
+	}
+	return max(1, n*reportBarWidth/most)
+}
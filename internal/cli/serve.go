@@ -0,0 +1,603 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const defaultServeAddr = "127.0.0.1:8787"
+
+// maxServeBody caps request bodies; tasks and ideas are small documents.
+const maxServeBody = 1 << 20
+
+func cmdServe(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--addr": true,
+	})
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	addr := fs.String("addr", defaultServeAddr, "Listen address (host:port)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if !gf.Quiet {
+		fmt.Printf("Serving %s on http://%s (JSON API at /tasks, /ideas, /projects, /board, /today, /week; metrics at /metrics)\n", ws.Root, *addr)
+	}
+	if err := http.ListenAndServe(*addr, newServeMux(ws, gf, *addr)); err != nil {
+		fmt.Fprintln(os.Stderr, "serve:", err)
+		return ExitInternal
+	}
+	return ExitOK
+}
+
+// newServeMux routes the JSON API and /metrics for a server listening on
+// addr.
+func newServeMux(ws *store.Workspace, gf GlobalFlags, addr string) *http.ServeMux {
+	api := &serveAPI{ws: ws, gf: gf, addr: addr}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", api.locked(metricsHandler(ws)))
+	api.routes(mux)
+	return mux
+}
+
+func metricsHandler(ws *store.Workspace) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var buf bytes.Buffer
+		if err := writePrometheusMetrics(&buf, ws); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// serveAPI is the JSON API behind tasker serve. The Workspace caches state
+// (config, task index) and is not safe for concurrent use, so requests are
+// handled one at a time.
+type serveAPI struct {
+	ws   *store.Workspace
+	gf   GlobalFlags
+	addr string
+	mu   sync.Mutex
+}
+
+type apiHandler func(r *http.Request) (int, any, error)
+
+func (s *serveAPI) routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /tasks", s.handle(s.listTasks))
+	mux.HandleFunc("POST /tasks", s.handle(s.write(s.addTask, "add")))
+	mux.HandleFunc("GET /tasks/{id}", s.handle(s.getTask))
+	mux.HandleFunc("PATCH /tasks/{id}", s.handle(s.write(s.editTask, "edit")))
+	mux.HandleFunc("POST /tasks/{id}/move", s.handle(s.write(s.moveTask, "mv")))
+	mux.HandleFunc("POST /tasks/{id}/notes", s.handle(s.write(s.noteTask, "note")))
+	mux.HandleFunc("GET /ideas", s.handle(s.listIdeas))
+	mux.HandleFunc("POST /ideas", s.handle(s.write(s.addIdea, "idea", "add")))
+	mux.HandleFunc("GET /ideas/{id}", s.handle(s.getIdea))
+	mux.HandleFunc("GET /projects", s.handle(s.listProjects))
+	mux.HandleFunc("POST /projects", s.handle(s.write(s.addProject, "project", "add")))
+	mux.HandleFunc("GET /board", s.handle(s.board))
+	mux.HandleFunc("GET /today", s.handle(s.today))
+	mux.HandleFunc("GET /week", s.handle(s.week))
+}
+
+// locked serves one request at a time, and only to clients that reached the
+// server by a loopback name or its own listen address: a web page cannot
+// rebind some other host name to 127.0.0.1 and drive the API. The config is
+// re-read first when another process changed it.
+func (s *serveAPI) locked(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeAPIError(w, fmt.Errorf("%w: host %q", errForbiddenHost, r.Host))
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, err := s.ws.ReloadConfig(); err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		h(w, r)
+	}
+}
+
+// allowedHost reports whether a request's Host header names this server:
+// localhost, a loopback address, or the host of the listen address.
+func (s *serveAPI) allowedHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return true
+	}
+	listen := s.addr
+	if h, _, err := net.SplitHostPort(s.addr); err == nil {
+		listen = h
+	}
+	return host != "" && strings.EqualFold(host, strings.Trim(listen, "[]"))
+}
+
+// handle runs fn and writes its payload as JSON. Store errors map onto HTTP
+// statuses the same way the CLI maps them onto exit codes. Requests that
+// change something must send JSON, which a cross-site form cannot.
+func (s *serveAPI) handle(fn apiHandler) http.HandlerFunc {
+	return s.locked(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+				writeAPIError(w, fmt.Errorf("%w: Content-Type must be application/json", errUnsupportedMedia))
+				return
+			}
+		}
+		status, payload, err := fn(r)
+		if err != nil {
+			writeAPIError(w, err)
+			return
+		}
+		writeAPIJSON(w, status, payload)
+	})
+}
+
+// write runs a handler that changes the workspace as the CLI command named by
+// command would run: under the workspace lock, followed by the post-write
+// hooks (auto-archive, auto-exports, auto-commit), and logged.
+func (s *serveAPI) write(fn apiHandler, command ...string) apiHandler {
+	return func(r *http.Request) (int, any, error) {
+		var status int
+		var payload any
+		err := runWrite(s.ws, s.gf, command, "serve "+r.Method+" "+r.URL.Path, func() error {
+			var err error
+			status, payload, err = fn(r)
+			return err
+		})
+		return status, payload, err
+	}
+}
+
+func writeAPIJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	status := apiErrorStatus(err)
+	writeAPIJSON(w, status, map[string]any{"error": apiErrorCode(status), "message": err.Error()})
+}
+
+var (
+	// errBadRequest marks request decoding/validation errors.
+	errBadRequest = errors.New("bad request")
+	// errUnsupportedMedia marks a mutating request without a JSON body.
+	errUnsupportedMedia = errors.New("unsupported media type")
+	// errForbiddenHost marks a request for a host name this server is not.
+	errForbiddenHost = errors.New("forbidden")
+)
+
+func apiErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest), errors.Is(err, store.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, errUnsupportedMedia):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, errForbiddenHost):
+		return http.StatusForbidden
+	case errors.Is(err, store.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, store.ErrLocked):
+		return http.StatusLocked
+	case errors.Is(err, store.ErrReadOnly):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, store.ErrConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func apiErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusUnsupportedMediaType:
+		return "unsupported_media_type"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusLocked:
+		return "locked"
+	case http.StatusServiceUnavailable:
+		return "read_only"
+	}
+	return "internal"
+}
+
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxServeBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: invalid JSON body: %v", errBadRequest, err)
+	}
+	return nil
+}
+
+func queryBool(r *http.Request, key string) (bool, error) {
+	v := strings.TrimSpace(r.URL.Query().Get(key))
+	if v == "" {
+		return false, nil
+	}
+	b, ok := parseBool(v)
+	if !ok {
+		return false, fmt.Errorf("%w: %s must be a boolean", errBadRequest, key)
+	}
+	return b, nil
+}
+
+func (s *serveAPI) checkProject(project string) error {
+	if err := checkProject(s.ws, project); err != nil {
+		return fmt.Errorf("%w: %v", store.ErrNotFound, err)
+	}
+	return nil
+}
+
+func (s *serveAPI) listTasks(r *http.Request) (int, any, error) {
+	q := r.URL.Query()
+	all, err := queryBool(r, "all")
+	if err != nil {
+		return 0, nil, err
+	}
+	project := strings.TrimSpace(q.Get("project"))
+	if err := s.checkProject(project); err != nil {
+		return 0, nil, err
+	}
+	query, err := parseQuery(q.Get("query"))
+	if err != nil {
+		return 0, nil, err
+	}
+	tasks, err := s.ws.ListTasks(store.ListFilter{
+		Project: project,
+		Column:  strings.TrimSpace(q.Get("column")),
+		Status:  strings.TrimSpace(q.Get("status")),
+		Tags:    store.TagFilter{All: q["tag"], Any: q["any_tag"], Not: q["not_tag"]},
+		Search:  strings.TrimSpace(q.Get("q")),
+		Query:   query,
+		All:     all,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if tasks == nil {
+		tasks = []store.Task{}
+	}
+	return http.StatusOK, map[string]any{"tasks": tasks}, nil
+}
+
+type apiAddTask struct {
+	Title         string   `json:"title"`
+	Project       string   `json:"project"`
+	Column        string   `json:"column"`
+	Due           string   `json:"due"`
+	DueTime       string   `json:"due_time"`
+	Priority      string   `json:"priority"`
+	Tags          []string `json:"tags"`
+	Description   string   `json:"description"`
+	Repeat        string   `json:"repeat"`
+	CreateProject bool     `json:"create_project"`
+	ExternalID    string   `json:"external_id"`
+}
+
+func (s *serveAPI) addTask(r *http.Request) (int, any, error) {
+	var in apiAddTask
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(in.Title) == "" {
+		return 0, nil, fmt.Errorf("%w: title is required", errBadRequest)
+	}
+	task, err := s.ws.AddTask(store.AddTaskInput{
+		Title:         in.Title,
+		Project:       resolveProject(s.ws, in.Project),
+		Column:        in.Column,
+		Due:           in.Due,
+		DueTime:       in.DueTime,
+		Priority:      in.Priority,
+		Tags:          in.Tags,
+		Description:   in.Description,
+		Repeat:        in.Repeat,
+		CreateProject: in.CreateProject,
+		ExternalID:    in.ExternalID,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if task.Existing {
+		return http.StatusOK, map[string]any{"task": task}, nil
+	}
+	return http.StatusCreated, map[string]any{"task": task}, nil
+}
+
+func (s *serveAPI) getTask(r *http.Request) (int, any, error) {
+	task, err := s.ws.GetTaskByPrefix(r.PathValue("id"))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"task": task}, nil
+}
+
+type apiTaskPatch struct {
+	Title      *string   `json:"title"`
+	Due        *string   `json:"due"`
+	DueTime    *string   `json:"due_time"`
+	Priority   *string   `json:"priority"`
+	Repeat     *string   `json:"repeat"`
+	Tags       *[]string `json:"tags"`
+	AddTags    []string  `json:"add_tags"`
+	RemoveTags []string  `json:"remove_tags"`
+}
+
+func (s *serveAPI) editTask(r *http.Request) (int, any, error) {
+	var in apiTaskPatch
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	task, err := s.ws.EditTask(r.PathValue("id"), store.TaskPatch{
+		Title:      in.Title,
+		Due:        in.Due,
+		DueTime:    in.DueTime,
+		Priority:   in.Priority,
+		Repeat:     in.Repeat,
+		Tags:       in.Tags,
+		AddTags:    in.AddTags,
+		RemoveTags: in.RemoveTags,
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"task": task}, nil
+}
+
+func (s *serveAPI) moveTask(r *http.Request) (int, any, error) {
+	var in struct {
+		To    string `json:"to"`
+		Force bool   `json:"force"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(in.To) == "" {
+		return 0, nil, fmt.Errorf("%w: to is required", errBadRequest)
+	}
+	task, err := s.ws.MoveTaskWith(r.PathValue("id"), strings.TrimSpace(in.To), store.MoveOptions{Force: in.Force})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"task": task}, nil
+}
+
+func (s *serveAPI) noteTask(r *http.Request) (int, any, error) {
+	var in struct {
+		Text string `json:"text"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(in.Text) == "" {
+		return 0, nil, fmt.Errorf("%w: text is required", errBadRequest)
+	}
+	task, err := s.ws.AddNote(r.PathValue("id"), in.Text)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"task": task}, nil
+}
+
+func (s *serveAPI) listIdeas(r *http.Request) (int, any, error) {
+	q := r.URL.Query()
+	project := strings.TrimSpace(q.Get("project"))
+	if err := s.checkProject(project); err != nil {
+		return 0, nil, err
+	}
+	ideas, err := s.ws.ListIdeas(store.IdeaListFilter{
+		Project: project,
+		Scope:   strings.TrimSpace(q.Get("scope")),
+		Tags:    store.TagFilter{All: q["tag"], Any: q["any_tag"], Not: q["not_tag"]},
+		Search:  strings.TrimSpace(q.Get("q")),
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	if ideas == nil {
+		ideas = []store.Idea{}
+	}
+	return http.StatusOK, map[string]any{"ideas": ideas}, nil
+}
+
+func (s *serveAPI) addIdea(r *http.Request) (int, any, error) {
+	var in struct {
+		Title   string   `json:"title"`
+		Project string   `json:"project"`
+		Tags    []string `json:"tags"`
+		Body    string   `json:"body"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(in.Title) == "" {
+		return 0, nil, fmt.Errorf("%w: title is required", errBadRequest)
+	}
+	idea, err := s.ws.AddIdea(store.AddIdeaInput{Title: in.Title, Project: in.Project, Tags: in.Tags, Body: in.Body})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, map[string]any{"idea": idea}, nil
+}
+
+func (s *serveAPI) getIdea(r *http.Request) (int, any, error) {
+	idea, err := s.ws.GetIdeaBySelectorFiltered(r.PathValue("id"), store.IdeaSelectorFilter{Scope: store.IdeaScopeAll})
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"idea": idea}, nil
+}
+
+func (s *serveAPI) listProjects(r *http.Request) (int, any, error) {
+	all, err := queryBool(r, "all")
+	if err != nil {
+		return 0, nil, err
+	}
+	projects, err := s.ws.ProjectOverviews(r.URL.Query().Get("sort"), all)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, map[string]any{"projects": projects}, nil
+}
+
+func (s *serveAPI) addProject(r *http.Request) (int, any, error) {
+	var in struct {
+		Name string `json:"name"`
+	}
+	if err := decodeBody(r, &in); err != nil {
+		return 0, nil, err
+	}
+	if strings.TrimSpace(in.Name) == "" {
+		return 0, nil, fmt.Errorf("%w: name is required", errBadRequest)
+	}
+	p, err := s.ws.CreateProject(strings.TrimSpace(in.Name))
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusCreated, map[string]any{"project": p}, nil
+}
+
+type apiBoardColumn struct {
+	ID    string       `json:"id"`
+	Name  string       `json:"name"`
+	Tasks []store.Task `json:"tasks"`
+}
+
+// board returns the board grouped by configured column; like the CLI it is
+// open-only unless all=true.
+func (s *serveAPI) board(r *http.Request) (int, any, error) {
+	project := strings.TrimSpace(r.URL.Query().Get("project"))
+	if project == "" {
+		project = resolveProject(s.ws, "")
+	}
+	if project == "" {
+		return 0, nil, fmt.Errorf("%w: project is required", errBadRequest)
+	}
+	if err := s.checkProject(project); err != nil {
+		return 0, nil, err
+	}
+	all, err := queryBool(r, "all")
+	if err != nil {
+		return 0, nil, err
+	}
+	payload, err := boardPayload(s.ws, project, all)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, payload, nil
+}
+
+// boardPayload is the JSON form of a project board.
+func boardPayload(ws *store.Workspace, project string, all bool) (map[string]any, error) {
+	tasks, err := ws.ListTasks(store.ListFilter{Project: project, All: all})
+	if err != nil {
+		return nil, err
+	}
+	var columns []apiBoardColumn
+	for _, c := range ws.Columns(project) {
+		if !all && !ws.Config().IsOpenStatus(c.Status) {
+			continue
+		}
+		col := apiBoardColumn{ID: c.ID, Name: c.Name, Tasks: []store.Task{}}
+		for _, t := range tasks {
+			if t.Column == c.ID {
+				col.Tasks = append(col.Tasks, t)
+			}
+		}
+		columns = append(columns, col)
+	}
+	return map[string]any{"project": project, "columns": columns}, nil
+}
+
+func (s *serveAPI) agendaParams(r *http.Request, week bool) (project string, open bool, groupBy string, err error) {
+	q := r.URL.Query()
+	project = resolveProject(s.ws, q.Get("project"))
+	if err = s.checkProject(project); err != nil {
+		return "", false, "", err
+	}
+	all, err := queryBool(r, "all")
+	if err != nil {
+		return "", false, "", err
+	}
+	open = resolveOpenOnly(s.ws, project, false, all)
+	groupBy = resolveGroupBy(s.ws, project, q.Get("group"))
+	if groupBy == "none" {
+		groupBy = ""
+	}
+	if week {
+		if _, _, err := store.SplitAgendaGroup(groupBy); err != nil {
+			return "", false, "", fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+	} else if groupBy != "" && groupBy != "project" && groupBy != "column" {
+		return "", false, "", fmt.Errorf("%w: group must be project|column|none", errBadRequest)
+	}
+	return project, open, groupBy, nil
+}
+
+func (s *serveAPI) today(r *http.Request) (int, any, error) {
+	project, open, groupBy, err := s.agendaParams(r, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	view, err := s.ws.TodayView(project, open, groupBy)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, view, nil
+}
+
+func (s *serveAPI) week(r *http.Request) (int, any, error) {
+	project, open, groupBy, err := s.agendaParams(r, true)
+	if err != nil {
+		return 0, nil, err
+	}
+	days := 0
+	if v := strings.TrimSpace(r.URL.Query().Get("days")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return 0, nil, fmt.Errorf("%w: days must be a positive integer", errBadRequest)
+		}
+		days = n
+	}
+	view, err := s.ws.WeekView(project, resolveWeekDays(s.ws, project, days), open, groupBy)
+	if err != nil {
+		return 0, nil, err
+	}
+	return http.StatusOK, view, nil
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// newTestWorkspace is an initialised workspace with a Work project.
+func newTestWorkspace(t *testing.T) *store.Workspace {
+	t.Helper()
+	t.Setenv("TASKER_PROJECT", "")
+	ws, err := store.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.Init("Work"); err != nil {
+		t.Fatal(err)
+	}
+	return ws
+}
+
+// serveRequest sends one request to h as a local client would; contentType
+// "" leaves the header out.
+func serveRequest(t *testing.T, h http.Handler, host, method, target, contentType, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Host = host
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var payload map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &payload)
+	return rec.Code, payload
+}
+
+func TestServeRoutes(t *testing.T) {
+	ws := newTestWorkspace(t)
+	h := newServeMux(ws, GlobalFlags{Quiet: true}, defaultServeAddr)
+	do := func(method, target, body string) (int, map[string]any) {
+		contentType := ""
+		if method != http.MethodGet {
+			contentType = "application/json"
+		}
+		return serveRequest(t, h, defaultServeAddr, method, target, contentType, body)
+	}
+
+	code, payload := do("POST", "/tasks", `{"title": "Ship", "project": "Work"}`)
+	if code != http.StatusCreated {
+		t.Fatalf("POST /tasks: %d %v", code, payload)
+	}
+	id := payload["task"].(map[string]any)["id"].(string)
+	blocker, err := ws.AddTask(store.AddTaskInput{Title: "Review", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ws.AddDependency(blocker.ID, id); err != nil {
+		t.Fatal(err)
+	}
+	code, payload = do("POST", "/ideas", `{"title": "Later"}`)
+	if code != http.StatusCreated {
+		t.Fatalf("POST /ideas: %d %v", code, payload)
+	}
+	ideaID := payload["idea"].(map[string]any)["id"].(string)
+
+	cases := []struct {
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"GET", "/tasks", "", http.StatusOK},
+		{"GET", "/tasks?all=maybe", "", http.StatusBadRequest},
+		{"GET", "/tasks?project=nope", "", http.StatusNotFound},
+		{"POST", "/tasks", `{"title": ""}`, http.StatusBadRequest},
+		{"POST", "/tasks", `{"title": "Ship", "bogus": 1}`, http.StatusBadRequest},
+		{"GET", "/tasks/" + id, "", http.StatusOK},
+		{"GET", "/tasks/ZZZZZZZZ", "", http.StatusNotFound},
+		{"PATCH", "/tasks/" + id, `{"priority": "high"}`, http.StatusOK},
+		{"PATCH", "/tasks/" + id, `{"priority": 1}`, http.StatusBadRequest},
+		{"PATCH", "/tasks/ZZZZZZZZ", `{"priority": "high"}`, http.StatusNotFound},
+		{"POST", "/tasks/" + id + "/move", `{"to": "doing"}`, http.StatusOK},
+		{"POST", "/tasks/" + id + "/move", `{"to": ""}`, http.StatusBadRequest},
+		{"POST", "/tasks/" + id + "/move", `{"to": "done"}`, http.StatusConflict},
+		{"POST", "/tasks/" + id + "/notes", `{"text": "pinged"}`, http.StatusOK},
+		{"POST", "/tasks/" + id + "/notes", `{"text": " "}`, http.StatusBadRequest},
+		{"GET", "/ideas", "", http.StatusOK},
+		{"GET", "/ideas/" + ideaID, "", http.StatusOK},
+		{"GET", "/ideas/nope", "", http.StatusNotFound},
+		{"POST", "/ideas", `{"title": ""}`, http.StatusBadRequest},
+		{"GET", "/projects", "", http.StatusOK},
+		{"POST", "/projects", `{"name": "Ops"}`, http.StatusCreated},
+		{"POST", "/projects", `{"name": ""}`, http.StatusBadRequest},
+		{"GET", "/board?project=Work", "", http.StatusOK},
+		{"GET", "/board?project=nope", "", http.StatusNotFound},
+		{"GET", "/today", "", http.StatusOK},
+		{"GET", "/today?group=tag", "", http.StatusBadRequest},
+		{"GET", "/week?days=3", "", http.StatusOK},
+		{"GET", "/week?days=0", "", http.StatusBadRequest},
+		{"GET", "/metrics", "", http.StatusOK},
+		{"POST", "/metrics", "", http.StatusMethodNotAllowed},
+	}
+	for _, c := range cases {
+		code, payload := do(c.method, c.target, c.body)
+		if code != c.want {
+			t.Fatalf("%s %s: expected %d, got %d %v", c.method, c.target, c.want, code, payload)
+		}
+		if code >= 400 && c.target != "/metrics" && payload["error"] != apiErrorCode(code) {
+			t.Fatalf("%s %s: expected error %q, got %v", c.method, c.target, apiErrorCode(code), payload)
+		}
+	}
+}
+
+func TestServeRejectsForeignHostsAndNonJSON(t *testing.T) {
+	ws := newTestWorkspace(t)
+	h := newServeMux(ws, GlobalFlags{Quiet: true}, "192.168.1.5:8787")
+	for host, want := range map[string]int{
+		"localhost:8787":   http.StatusOK,
+		"127.0.0.1:8787":   http.StatusOK,
+		"[::1]:8787":       http.StatusOK,
+		"192.168.1.5:8787": http.StatusOK,
+		"evil.example":     http.StatusForbidden,
+		"127.0.0.1.nip.io": http.StatusForbidden,
+	} {
+		if code, payload := serveRequest(t, h, host, "GET", "/tasks", "", ""); code != want {
+			t.Fatalf("host %s: expected %d, got %d %v", host, want, code, payload)
+		}
+	}
+	if code, _ := serveRequest(t, h, "evil.example", "GET", "/metrics", "", ""); code != http.StatusForbidden {
+		t.Fatalf("expected /metrics to check the host too, got %d", code)
+	}
+	for _, contentType := range []string{"", "text/plain", "application/x-www-form-urlencoded", "application/json; charset=utf-8"} {
+		want := http.StatusUnsupportedMediaType
+		if strings.HasPrefix(contentType, "application/json") {
+			want = http.StatusCreated
+		}
+		code, payload := serveRequest(t, h, "localhost", "POST", "/projects", contentType, `{"name": "Ops `+contentType+`"}`)
+		if code != want {
+			t.Fatalf("content type %q: expected %d, got %d %v", contentType, want, code, payload)
+		}
+	}
+}
+
+func TestServeReloadsChangedConfig(t *testing.T) {
+	ws := newTestWorkspace(t)
+	h := newServeMux(ws, GlobalFlags{Quiet: true}, defaultServeAddr)
+	other, err := store.Open(ws.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.AddColumn(store.ColumnChange{}, store.ColumnDef{ID: "review"}, "doing"); err != nil {
+		t.Fatal(err)
+	}
+	code, payload := serveRequest(t, h, defaultServeAddr, "GET", "/board?project=Work", "", "")
+	if code != http.StatusOK {
+		t.Fatalf("GET /board: %d %v", code, payload)
+	}
+	var ids []string
+	for _, c := range payload["columns"].([]any) {
+		ids = append(ids, c.(map[string]any)["id"].(string))
+	}
+	if strings.Join(ids, ",") != "inbox,todo,doing,review,blocked" {
+		t.Fatalf("expected the column added by another process, got %v", ids)
+	}
+}
+
+func TestAPIWritesRunPostWriteHooks(t *testing.T) {
+	ws := newTestWorkspace(t)
+	t.Setenv("TASKER_LOG", "1")
+	cfg := ws.Config()
+	cfg.Exports = &store.ExportsConfig{Auto: []string{"metrics"}}
+	if err := ws.SaveConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	gf := GlobalFlags{Quiet: true, ExportDir: filepath.Join(t.TempDir(), "exports")}
+	exported := filepath.Join(gf.ExportDir, "metrics.json")
+
+	h := newServeMux(ws, gf, defaultServeAddr)
+	if code, payload := serveRequest(t, h, defaultServeAddr, "POST", "/tasks", "application/json", `{"title": "Ship", "project": "Work"}`); code != http.StatusCreated {
+		t.Fatalf("POST /tasks: %d %v", code, payload)
+	}
+	if _, err := os.Stat(exported); err != nil {
+		t.Fatalf("expected an API write to refresh the auto-exports: %v", err)
+	}
+	// Reads change nothing, so they leave the exports and the log alone.
+	if err := os.Remove(exported); err != nil {
+		t.Fatal(err)
+	}
+	if code, _ := serveRequest(t, h, defaultServeAddr, "GET", "/tasks", "", ""); code != http.StatusOK {
+		t.Fatalf("GET /tasks: %d", code)
+	}
+	if _, err := os.Stat(exported); !os.IsNotExist(err) {
+		t.Fatalf("expected a read to skip the auto-exports, got %v", err)
+	}
+
+	in := `{"jsonrpc": "2.0", "id": 1, "method": "tools/call", "params": {"name": "add_idea", "arguments": {"title": "Later"}}}`
+	var out bytes.Buffer
+	if err := serveMCP(ws, gf, strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `"isError":false`) {
+		t.Fatalf("add_idea failed: %s", out.String())
+	}
+	if _, err := os.Stat(exported); err != nil {
+		t.Fatalf("expected an MCP write to refresh the auto-exports: %v", err)
+	}
+
+	entries, err := ws.ReadOpLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		if !e.Mutating || e.Result != "ok" {
+			t.Fatalf("unexpected log entry: %+v", e)
+		}
+		got = append(got, e.Command+" "+strings.Join(e.Args, " "))
+	}
+	want := []string{"add serve POST /tasks", "idea add mcp add_idea"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected log entries %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdSnapshot(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker snapshot <create|ls|restore|rm> ...")
+		return ExitUsage
+	}
+	sub := args[0]
+	switch sub {
+	case "create", "new":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: tasker snapshot create \"<name>\"")
+			return ExitUsage
+		}
+		snap, err := ws.CreateSnapshot(strings.Join(args[1:], " "))
+		if err != nil {
+			return snapshotError("snapshot create", err)
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "snapshot create", "snapshot", map[string]any{"snapshot": snap})
+		}
+		if !gf.Quiet {
+			fmt.Printf("Created snapshot %s (%d files)\n", snap.Slug, snap.Files)
+		}
+		return ExitOK
+	case "ls", "list":
+		snaps, err := ws.ListSnapshots()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "snapshot ls:", err)
+			return ExitInternal
+		}
+		if gf.JSON {
+			return emitJSONPayload(gf, "snapshot ls", "snapshots", map[string]any{"snapshots": snaps})
+		}
+		if gf.Plain {
+			fmt.Fprintln(os.Stdout, "SLUG\tNAME\tCREATED\tFILES")
+			for _, s := range snaps {
+				fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%d\n", s.Slug, s.Name, s.CreatedAt.Format(time.RFC3339), s.Files)
+			}
+			return ExitOK
+		}
+		if len(snaps) == 0 {
+			fmt.Println("No snapshots.")
+			return ExitOK
+		}
+		w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "SLUG\tNAME\tCREATED\tFILES")
+		for _, s := range snaps {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", s.Slug, s.Name, s.CreatedAt.Format(time.RFC3339), s.Files)
+		}
+		_ = w.Flush()
+		return ExitOK
+	case "restore":
+		return cmdSnapshotRestore(ws, gf, args[1:])
+	case "rm", "delete":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: tasker snapshot rm <name>")
+			return ExitUsage
+		}
+		name := strings.Join(args[1:], " ")
+		if err := ws.DeleteSnapshot(name); err != nil {
+			return snapshotError("snapshot rm", err)
+		}
+		if !gf.Quiet {
+			fmt.Printf("Deleted snapshot %s\n", store.Slugify(name))
+		}
+		return ExitOK
+	default:
+		fmt.Fprintln(os.Stderr, "Usage: tasker snapshot <create|ls|restore|rm> ...")
+		return ExitUsage
+	}
+}
+
+func cmdSnapshotRestore(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--no-backup": false,
+		"--timeout":   true,
+	})
+	fs := flag.NewFlagSet("snapshot restore", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	noBackup := fs.Bool("no-backup", false, "Do not snapshot the current state before restoring")
+	timeout := fs.Duration("timeout", ws.WriteLockTimeout(), "How long to wait for the workspace lock")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker snapshot restore [--no-backup] <name>")
+		return ExitUsage
+	}
+	name := strings.Join(rest, " ")
+	if _, err := ws.GetSnapshot(name); err != nil {
+		return snapshotError("snapshot restore", err)
+	}
+	var backup *store.Snapshot
+	if !*noBackup {
+		var err error
+		backup, err = ws.CreateSnapshot("pre-restore-" + time.Now().UTC().Format("20060102-150405"))
+		if err != nil {
+			return snapshotError("snapshot restore", err)
+		}
+	}
+	snap, err := ws.RestoreSnapshot(name, *timeout)
+	if err != nil {
+		return snapshotError("snapshot restore", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "snapshot restore", "snapshot-restore", map[string]any{"snapshot": snap, "backup": backup})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Restored snapshot %s\n", snap.Slug)
+		if backup != nil {
+			fmt.Printf("Previous state saved as snapshot %s\n", backup.Slug)
+		}
+	}
+	return ExitOK
+}
+
+func snapshotError(cmd string, err error) int {
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		fmt.Fprintln(os.Stderr, cmd+": snapshot not found")
+		return ExitNotFound
+	case errors.Is(err, store.ErrConflict):
+		fmt.Fprintln(os.Stderr, cmd+":", err)
+		return ExitConflict
+	case errors.Is(err, store.ErrInvalid):
+		fmt.Fprintln(os.Stderr, cmd+":", err)
+		return ExitUsage
+	default:
+		fmt.Fprintln(os.Stderr, cmd+":", err)
+		return ExitInternal
+	}
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const subtaskUsage = "Usage: tasker subtask <add|done|undo|ls> [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector...> [-- <text...>|<n>]"
+
+// cmdSubtask maintains the "## Checklist" section of a task body.
+func cmdSubtask(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, subtaskUsage)
+		return ExitUsage
+	}
+	sub := args[0]
+	switch sub {
+	case "add", "done", "check", "undo", "uncheck", "ls", "list":
+	default:
+		fmt.Fprintln(os.Stderr, subtaskUsage)
+		return ExitUsage
+	}
+	rawArgs := args[1:]
+	var textTokens []string
+	split := -1
+	for i, arg := range rawArgs {
+		if arg == "--" {
+			split = i
+			break
+		}
+	}
+	if split >= 0 {
+		textTokens = rawArgs[split+1:]
+		rawArgs = rawArgs[:split]
+	}
+	args = reorderFlags(rawArgs, map[string]bool{
+		"--project": true,
+		"--column":  true,
+		"--status":  true,
+		"--all":     false,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("subtask "+sub, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	column := fs.String("column", "", "Column id (filter)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
+	all := fs.Bool("all", false, "Include archived")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	filter, err := selectorFilter(ws, *project, *column, *status, *all, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "subtask:", err)
+		return ExitUsage
+	}
+
+	var taskID, text string
+	index := 0
+	switch sub {
+	case "add":
+		if len(textTokens) > 0 {
+			if len(rest) == 0 {
+				fmt.Fprintln(os.Stderr, subtaskUsage)
+				return ExitUsage
+			}
+			ref, code := resolveSubtaskTask(ws, gf, strings.Join(rest, " "), filter)
+			if code != ExitOK {
+				return code
+			}
+			taskID, text = ref.ID, strings.Join(textTokens, " ")
+		} else {
+			if len(rest) < 2 {
+				fmt.Fprintln(os.Stderr, subtaskUsage)
+				return ExitUsage
+			}
+			taskID, _, text, err = splitNoteInput(ws, filter, rest)
+			if err != nil {
+				if errors.Is(err, store.ErrNotFound) {
+					fmt.Fprintln(os.Stderr, "subtask: not found")
+					return ExitNotFound
+				}
+				if err.Error() == "ambiguous selector split" {
+					fmt.Fprintln(os.Stderr, "subtask: ambiguous selector; use -- to separate selector and item text")
+					return ExitConflict
+				}
+				fmt.Fprintln(os.Stderr, "subtask:", err)
+				return ExitInternal
+			}
+		}
+		if strings.TrimSpace(text) == "" {
+			fmt.Fprintln(os.Stderr, "subtask: item text is required (use -- to separate selector and item text)")
+			return ExitUsage
+		}
+	case "done", "check", "undo", "uncheck":
+		// The item number is the last token (or the token after --).
+		tokens := rest
+		if len(textTokens) > 0 {
+			tokens = append(append([]string{}, rest...), textTokens...)
+		}
+		if len(tokens) < 2 {
+			fmt.Fprintln(os.Stderr, subtaskUsage)
+			return ExitUsage
+		}
+		n, err := strconv.Atoi(tokens[len(tokens)-1])
+		if err != nil || n < 1 {
+			fmt.Fprintf(os.Stderr, "subtask: item number must be a positive integer, got %q\n", tokens[len(tokens)-1])
+			return ExitUsage
+		}
+		index = n
+		ref, code := resolveSubtaskTask(ws, gf, strings.Join(tokens[:len(tokens)-1], " "), filter)
+		if code != ExitOK {
+			return code
+		}
+		taskID = ref.ID
+	default:
+		if len(rest) == 0 {
+			fmt.Fprintln(os.Stderr, subtaskUsage)
+			return ExitUsage
+		}
+		ref, code := resolveSubtaskTask(ws, gf, strings.Join(rest, " "), filter)
+		if code != ExitOK {
+			return code
+		}
+		return emitChecklist(gf, ref)
+	}
+
+	var task *store.Task
+	switch sub {
+	case "add":
+		task, err = ws.AddChecklistItem(taskID, text)
+	case "done", "check":
+		task, err = ws.SetChecklistItem(taskID, index, true)
+	default:
+		task, err = ws.SetChecklistItem(taskID, index, false)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "subtask:", err)
+		if errors.Is(err, store.ErrNotFound) {
+			return ExitNotFound
+		}
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	return emitChecklist(gf, task)
+}
+
+func resolveSubtaskTask(ws *store.Workspace, gf GlobalFlags, selector string, filter store.SelectorFilter) (*store.Task, int) {
+	task, err := ws.GetTaskBySelectorFiltered(selector, filter)
+	if err == nil {
+		return task, ExitOK
+	}
+	if errors.Is(err, store.ErrNotFound) {
+		fmt.Fprintln(os.Stderr, "subtask: not found")
+		return nil, ExitNotFound
+	}
+	if errors.Is(err, store.ErrConflict) {
+		if handleMatchConflict(gf, "subtask", err) {
+			return nil, ExitConflict
+		}
+		fmt.Fprintln(os.Stderr, "subtask: ambiguous selector")
+		return nil, ExitConflict
+	}
+	fmt.Fprintln(os.Stderr, "subtask:", err)
+	return nil, ExitInternal
+}
+
+func emitChecklist(gf GlobalFlags, task *store.Task) int {
+	items := task.Checklist()
+	if gf.Plain {
+		for _, it := range items {
+			mark := " "
+			if it.Done {
+				mark = "x"
+			}
+			fmt.Fprintf(os.Stdout, "%d\t%s\t%s\n", it.Index, mark, it.Text)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		payload := map[string]any{"task": task, "checklist": items, "progress": task.ChecklistProgress()}
+		if gf.StdoutJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			_ = enc.Encode(payload)
+			return ExitOK
+		}
+		path, err := writeJSONExport(gf, "checklist", payload)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "subtask:", err)
+			return ExitInternal
+		}
+		if !gf.Quiet {
+			fmt.Println("Wrote JSON to:", path)
+		}
+		return ExitOK
+	}
+	title := strings.TrimSpace(task.Title)
+	if title == "" {
+		title = "(untitled)"
+	}
+	if len(items) == 0 {
+		fmt.Printf("%s: no checklist items\n", title)
+		return ExitOK
+	}
+	fmt.Printf("%s [%s]\n", title, task.ChecklistProgress())
+	for _, it := range items {
+		mark := "[ ]"
+		if it.Done {
+			mark = "[x]"
+		}
+		fmt.Printf("  %d. %s %s\n", it.Index, mark, it.Text)
+	}
+	return ExitOK
+}
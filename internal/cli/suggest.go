@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const maxSuggestions = 3
+
+// editDistance is the optimal string alignment distance: Levenshtein plus
+// adjacent transpositions, so "borad" is one edit away from "board".
+func editDistance(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := 0; j <= len(rb); j++ {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(minInt(d[i-1][j]+1, d[i][j-1]+1), d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// suggest returns up to maxSuggestions candidates close to input, nearest first.
+// Prefix matches always qualify; otherwise the edit distance must stay within
+// roughly a third of the input length.
+func suggest(input string, candidates []string) []string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil
+	}
+	limit := len([]rune(input)) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	type scored struct {
+		value string
+		dist  int
+	}
+	seen := map[string]bool{}
+	var hits []scored
+	for _, c := range candidates {
+		key := strings.ToLower(c)
+		if c == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		d := editDistance(input, key)
+		if strings.HasPrefix(key, input) || strings.HasPrefix(input, key) {
+			d = minInt(d, 1)
+		}
+		if d <= limit {
+			hits = append(hits, scored{value: c, dist: d})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].dist != hits[j].dist {
+			return hits[i].dist < hits[j].dist
+		}
+		return hits[i].value < hits[j].value
+	})
+	if len(hits) > maxSuggestions {
+		hits = hits[:maxSuggestions]
+	}
+	out := make([]string, 0, len(hits))
+	for _, h := range hits {
+		out = append(out, h.value)
+	}
+	return out
+}
+
+func didYouMean(input string, candidates []string) string {
+	hits := suggest(input, candidates)
+	if len(hits) == 0 {
+		return ""
+	}
+	return "Did you mean: " + strings.Join(hits, ", ") + "?"
+}
+
+func columnIDs(ws *store.Workspace) []string {
+	return ws.KnownColumnIDs()
+}
+
+// checkColumn returns an error (with suggestions) when column is set but not configured.
+func checkColumn(ws *store.Workspace, column string) error {
+	column = strings.ToLower(strings.TrimSpace(column))
+	if column == "" {
+		return nil
+	}
+	ids := columnIDs(ws)
+	for _, id := range ids {
+		if id == column {
+			return nil
+		}
+	}
+	msg := fmt.Sprintf("unknown column %q (use %s)", column, strings.Join(ids, "|"))
+	if hint := didYouMean(column, ids); hint != "" {
+		msg += ". " + hint
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// checkProjectColumn is checkColumn against the columns of one project, which
+// may override the workspace columns.
+func checkProjectColumn(ws *store.Workspace, project string, column string) error {
+	column = strings.ToLower(strings.TrimSpace(column))
+	if column == "" {
+		return nil
+	}
+	var ids []string
+	for _, c := range ws.Columns(project) {
+		if c.ID == column {
+			return nil
+		}
+		ids = append(ids, c.ID)
+	}
+	msg := fmt.Sprintf("unknown column %q in project %s (use %s)", column, store.Slugify(project), strings.Join(ids, "|"))
+	if hint := didYouMean(column, ids); hint != "" {
+		msg += ". " + hint
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// checkProject returns an error (with suggestions) when an explicit project does
+// not exist in the workspace. Empty values and the none|all selectors pass.
+func checkProject(ws *store.Workspace, project string) error {
+	project = strings.TrimSpace(project)
+	switch strings.ToLower(project) {
+	case "", "none", "all":
+		return nil
+	}
+	projects, err := ws.ListProjects()
+	if err != nil {
+		return nil
+	}
+	slug := store.Slugify(project)
+	candidates := make([]string, 0, len(projects))
+	for _, p := range projects {
+		if p.Slug == slug || strings.EqualFold(p.Name, project) {
+			return nil
+		}
+		candidates = append(candidates, p.Slug)
+	}
+	msg := fmt.Sprintf("project not found: %s", project)
+	if hint := didYouMean(slug, candidates); hint != "" {
+		msg += ". " + hint
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// checkProjectSpec is checkProject for a project spec (repeated --project
+// values, globs): every name must exist and every glob match a project.
+func checkProjectSpec(ws *store.Workspace, spec string) error {
+	for _, part := range store.ProjectSpecParts(spec) {
+		if !store.IsProjectGlob(part) {
+			if err := checkProject(ws, part); err != nil {
+				return err
+			}
+			continue
+		}
+		slugs, err := ws.ExpandProjectSpec(part)
+		if err != nil {
+			return err
+		}
+		if len(slugs) == 0 {
+			return fmt.Errorf("no project matches %s", part)
+		}
+	}
+	return nil
+}
+
+// checkAddProject guards add/capture when projects.auto_create is off: the
+// target project (Personal when unset) must exist unless --create-project.
+func checkAddProject(ws *store.Workspace, project string, create bool) error {
+	if create || ws.Config().AutoCreateProjects() {
+		return nil
+	}
+	if strings.TrimSpace(project) == "" {
+		project = "Personal"
+	}
+	if err := checkProject(ws, project); err != nil {
+		return fmt.Errorf("%v (pass --create-project to create it)", err)
+	}
+	return nil
+}
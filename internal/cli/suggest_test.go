@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func TestColumnChecksIgnoreCase(t *testing.T) {
+	ws := newTestWorkspace(t)
+	for _, column := range []string{"", "doing", "Doing", " Inbox ", "DONE"} {
+		if err := checkColumn(ws, column); err != nil {
+			t.Fatalf("checkColumn(%q): %v", column, err)
+		}
+		if err := checkProjectColumn(ws, "work", column); err != nil {
+			t.Fatalf("checkProjectColumn(%q): %v", column, err)
+		}
+	}
+	if err := checkColumn(ws, "Dine"); err == nil || !strings.Contains(err.Error(), "Did you mean: done") {
+		t.Fatalf("expected an unknown column with a hint, got %v", err)
+	}
+
+	// The commands the check guards accept mixed case end to end.
+	root := []string{"--root", ws.Root, "--quiet"}
+	for _, args := range [][]string{
+		{"add", "Milk", "--project", "Work", "--column", "Doing"},
+		{"mv", "Milk", "Blocked"},
+		{"ls", "--column", "BLOCKED"},
+	} {
+		if code := Run(append(root, args...)); code != ExitOK {
+			t.Fatalf("%v: expected ExitOK, got %d", args, code)
+		}
+	}
+	tasks, err := ws.ListTasks(store.ListFilter{Column: "Blocked"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Column != "blocked" {
+		t.Fatalf("expected Milk in blocked, got %+v", tasks)
+	}
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const syncUsage = "Usage: tasker sync github --repo <owner/name> [--project <name>] [--create-project] [--dry-run] | sync git [init] ..."
+
+var githubRepoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+func cmdSync(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, syncUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "github", "gh":
+		return cmdSyncGitHub(ws, gf, args[1:])
+	case "git":
+		return cmdSyncGit(ws, gf, args[1:])
+	}
+	fmt.Fprintln(os.Stderr, syncUsage)
+	return ExitUsage
+}
+
+// issueClose is one done task whose issue sync closed (or would close).
+type issueClose struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+}
+
+func syncErrCode(err error) int {
+	switch {
+	case errors.Is(err, errRateLimited):
+		return ExitRateLimited
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	case errors.Is(err, store.ErrConflict):
+		return ExitConflict
+	}
+	return ExitInternal
+}
+
+// cmdSyncGitHub pulls the open issues of a repository into tasks and closes
+// the issues whose tasks are done. Pulling is keyed by external ID, so
+// running it again only adds issues opened since.
+func cmdSyncGitHub(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--repo":           true,
+		"--project":        true,
+		"--create-project": false,
+		"--dry-run":        false,
+	})
+	fs := flag.NewFlagSet("sync github", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	repo := fs.String("repo", "", "Repository as owner/name")
+	project := fs.String("project", "", "Project for pulled issues (default: the repository name)")
+	createProject := fs.Bool("create-project", false, "Create the project if it does not exist")
+	dryRun := fs.Bool("dry-run", false, "Show what would be pulled and closed without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 || !githubRepoPattern.MatchString(strings.TrimSpace(*repo)) {
+		fmt.Fprintln(os.Stderr, syncUsage)
+		return ExitUsage
+	}
+	name := strings.TrimSpace(*repo)
+	target := strings.TrimSpace(*project)
+	if target == "" {
+		target = path.Base(name)
+	}
+	gh := newGitHubClient()
+	issues, err := gh.openIssues(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sync github:", err)
+		return syncErrCode(err)
+	}
+	pulled, err := ws.ImportIssues(target, issues, *createProject, *dryRun)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sync github:", err)
+		return syncErrCode(err)
+	}
+	open := make(map[int]bool, len(issues))
+	for _, is := range issues {
+		open[is.Number] = true
+	}
+	done, err := ws.IssueTasksToClose("github", name, open)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sync github:", err)
+		return ExitInternal
+	}
+	var closed []issueClose
+	code := ExitOK
+	if len(done) > 0 && gh.token == "" && !*dryRun {
+		fmt.Fprintf(os.Stderr, "sync github: %d done task(s) have open issues; set GITHUB_TOKEN to close them\n", len(done))
+		code = ExitUsage
+		done = nil
+	}
+	for _, t := range done {
+		if !*dryRun {
+			if err := gh.closeIssue(name, t.Issue.Number); err != nil {
+				fmt.Fprintf(os.Stderr, "sync github: close #%d: %v\n", t.Issue.Number, err)
+				code = syncErrCode(err)
+				break
+			}
+			if _, err := ws.SetIssueState(t.ID, "closed"); err != nil {
+				fmt.Fprintln(os.Stderr, "sync github:", err)
+				code = ExitInternal
+				break
+			}
+		}
+		closed = append(closed, issueClose{ID: t.ID, Title: t.Title, Number: t.Issue.Number, URL: t.Issue.URL})
+	}
+
+	if gf.Plain {
+		for _, t := range pulled.Added {
+			fmt.Fprintf(os.Stdout, "added\t%s\t#%d\t%s\n", t.ID, t.Issue.Number, t.Title)
+		}
+		for _, c := range closed {
+			fmt.Fprintf(os.Stdout, "closed\t%s\t#%d\t%s\n", c.ID, c.Number, c.Title)
+		}
+		return code
+	}
+	if gf.JSON {
+		if rc := emitJSONPayload(gf, "sync github", "sync-github", map[string]any{
+			"repo":     name,
+			"project":  target,
+			"dry_run":  *dryRun,
+			"added":    pulled.Added,
+			"existing": len(pulled.Existing),
+			"closed":   closed,
+		}); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.Quiet {
+		return code
+	}
+	pullVerb, closeVerb := "Pulled", "Closed"
+	if *dryRun {
+		pullVerb, closeVerb = "Would pull", "Would close"
+	}
+	fmt.Printf("%s %d new issue(s) from %s into %s (%d already tracked)\n", pullVerb, len(pulled.Added), name, target, len(pulled.Existing))
+	for _, t := range pulled.Added {
+		fmt.Printf("  #%d %s\n", t.Issue.Number, taskTitleOrUntitled(t.Title))
+	}
+	for _, c := range closed {
+		fmt.Printf("%s #%d: %s\n", closeVerb, c.Number, taskTitleOrUntitled(c.Title))
+	}
+	return code
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const tagUsage = "Usage: tasker tag <ls|rename|rm> ..."
+
+func cmdTag(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, tagUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "ls", "list":
+		return cmdTagList(ws, gf, args[1:])
+	case "rename", "mv":
+		return cmdTagRename(ws, gf, args[1:])
+	case "rm", "remove":
+		return cmdTagRemove(ws, gf, args[1:])
+	default:
+		fmt.Fprintln(os.Stderr, tagUsage)
+		return ExitUsage
+	}
+}
+
+func cmdTagList(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--project": true})
+	fs := flag.NewFlagSet("tag ls", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Only this project's tasks and ideas")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker tag ls [--project <name>]")
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "tag ls:", err)
+		return ExitNotFound
+	}
+	tags, err := ws.TagCounts(*project)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "tag ls:", err)
+		return ExitInternal
+	}
+	if gf.NDJSON {
+		items := make([]any, 0, len(tags))
+		for _, t := range tags {
+			items = append(items, t)
+		}
+		return emitNDJSONItems(gf, "tag ls", "tags", items)
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "TAG\tTASKS\tIDEAS")
+		for _, t := range tags {
+			fmt.Fprintf(os.Stdout, "%s\t%d\t%d\n", t.Tag, t.Tasks, t.Ideas)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "tag ls", "tags", map[string]any{"tags": tags, "count": len(tags)})
+	}
+	if len(tags) == 0 {
+		if !gf.Quiet {
+			fmt.Println("No tags.")
+		}
+		return ExitOK
+	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TAG\tTASKS\tIDEAS")
+	for _, t := range tags {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", t.Tag, t.Tasks, t.Ideas)
+	}
+	_ = w.Flush()
+	return ExitOK
+}
+
+func cmdTagRename(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--dry-run": false})
+	fs := flag.NewFlagSet("tag rename", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	dryRun := fs.Bool("dry-run", false, "Show what would change without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker tag rename <old> <new> [--dry-run]")
+		return ExitUsage
+	}
+	c, err := ws.RenameTag(fs.Arg(0), fs.Arg(1), *dryRun)
+	if err != nil {
+		return bulkError("tag rename", err)
+	}
+	verb := "Renamed"
+	if *dryRun {
+		verb = "Would rename"
+	}
+	return emitTagChange(gf, "tag rename", c, *dryRun, fmt.Sprintf("%s tag %s to %s", verb, c.Tag, c.To))
+}
+
+func cmdTagRemove(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{"--from-done-only": false, "--dry-run": false})
+	fs := flag.NewFlagSet("tag rm", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	doneOnly := fs.Bool("from-done-only", false, "Only untag closed tasks (ideas are left alone)")
+	dryRun := fs.Bool("dry-run", false, "Show what would change without writing")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker tag rm <tag> [--from-done-only] [--dry-run]")
+		return ExitUsage
+	}
+	c, err := ws.RemoveTag(fs.Arg(0), *doneOnly, *dryRun)
+	if err != nil {
+		return bulkError("tag rm", err)
+	}
+	verb := "Removed"
+	if *dryRun {
+		verb = "Would remove"
+	}
+	return emitTagChange(gf, "tag rm", c, *dryRun, fmt.Sprintf("%s tag %s", verb, c.Tag))
+}
+
+// emitTagChange prints the tasks and ideas a tag command changed (or would
+// change). Nothing carrying the tag exits 3.
+func emitTagChange(gf GlobalFlags, cmd string, c *store.TagChange, dryRun bool, summary string) int {
+	if len(c.Tasks)+len(c.Ideas) == 0 {
+		fmt.Fprintf(os.Stderr, "%s: no tasks or ideas tagged %s\n", cmd, c.Tag)
+		return ExitNotFound
+	}
+	if gf.Plain {
+		for _, t := range c.Tasks {
+			fmt.Fprintf(os.Stdout, "task\t%s\t%s\n", t.ID, t.Title)
+		}
+		for _, idea := range c.Ideas {
+			fmt.Fprintf(os.Stdout, "idea\t%s\t%s\n", idea.ID, idea.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, cmd, "tag", map[string]any{"tag": c.Tag, "to": c.To, "tasks": c.Tasks, "ideas": c.Ideas, "dry_run": dryRun})
+	}
+	if !gf.Quiet {
+		fmt.Printf("%s on %d task(s) and %d idea(s)\n", summary, len(c.Tasks), len(c.Ideas))
+		for _, t := range c.Tasks {
+			fmt.Printf("- task %s/%s: %s (%s)\n", t.Project, t.Column, taskTitleOrUntitled(t.Title), t.ID)
+		}
+		for _, idea := range c.Ideas {
+			fmt.Printf("- idea %s: %s (%s)\n", ideaLocationLabel(idea.Project), taskTitleOrUntitled(idea.Title), idea.ID)
+		}
+	}
+	return ExitOK
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func TestParseTextParts(t *testing.T) {
+	store.SetNow(time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+	defer store.SetNow(time.Time{})
+
+	cases := []struct {
+		text         string
+		allowProject bool
+		title        string
+		details      string
+		due          string
+		priority     string
+		tags         []string
+		project      string
+	}{
+		{"Fix login +webapp @backend #bug due tomorrow", true, "Fix login @backend", "", "tomorrow", "", []string{"backend", "bug"}, "webapp"},
+		{"Give +1 to proposal +webapp", true, "Give +1 to proposal", "", "", "", nil, "webapp"},
+		{"Give +1 to proposal", false, "Give +1 to proposal", "", "", "", nil, ""},
+		{"Ship +webapp then +api", true, "Ship then +api", "", "", "", nil, "webapp"},
+		{"Ship +webapp", false, "Ship +webapp", "", "", "", nil, ""},
+		{"Rename +web_app module", true, "Rename +web_app module", "", "", "", nil, ""},
+		{"Reply to @alice.", true, "Reply to @alice.", "", "", "", []string{"alice"}, ""},
+		{"Fix #42 crash", true, "Fix #42 crash", "", "", "", nil, ""},
+		{"Finish due diligence", true, "Finish due diligence", "", "", "", nil, ""},
+		{"Draft | outline scope | due 2026-01-23 | pri high | #ops", true, "Draft", "outline scope", "2026-01-23", "high", []string{"ops"}, ""},
+		{"Pay rent due friday | due 2026-04-01", true, "Pay rent due friday", "", "2026-04-01", "", nil, ""},
+	}
+	for _, c := range cases {
+		title, details, due, priority, tags, project := parseTextParts(c.text, c.allowProject)
+		if title != c.title || details != c.details || due != c.due || priority != c.priority || project != c.project || !reflect.DeepEqual(tags, c.tags) {
+			t.Fatalf("parseTextParts(%q, %v) = %q, %q, %q, %q, %q, %q", c.text, c.allowProject, title, details, due, priority, tags, project)
+		}
+	}
+}
+
+func TestCutTitleDue(t *testing.T) {
+	store.SetNow(time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+	defer store.SetNow(time.Time{})
+
+	cases := []struct {
+		title string
+		want  string
+		due   string
+	}{
+		{"Call the bank due tomorrow", "Call the bank", "tomorrow"},
+		{"Send report by friday 17:00", "Send report", "friday 17:00"},
+		{"Standup due 09:30", "Standup", "09:30"},
+		{"File taxes due 2026-04-15", "File taxes", "2026-04-15"},
+		{"Finish due diligence", "Finish due diligence", ""},
+		{"Stand by me", "Stand by me", ""},
+		{"due tomorrow", "due tomorrow", ""},
+		{"Renew by 2026-02-30", "Renew by 2026-02-30", ""},
+	}
+	for _, c := range cases {
+		title, due := cutTitleDue(c.title)
+		if title != c.want || due != c.due {
+			t.Fatalf("cutTitleDue(%q) = %q, %q; want %q, %q", c.title, title, due, c.want, c.due)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const (
+	startUsage     = "Usage: tasker start <selector...> [--project <name>]"
+	stopUsage      = "Usage: tasker stop [<selector...>] [--project <name>]"
+	logUsage       = "Usage: tasker log <selector...> --hours <n> [--date <date>] [--project <name>] [-- <text...>]"
+	timesheetUsage = "Usage: tasker timesheet [--week|--days N] [--project <name>]"
+)
+
+// timeErrCode maps a store error from the time commands to an exit code.
+func timeErrCode(cmd string, err error) int {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, store.ErrConflict):
+		return ExitConflict
+	case errors.Is(err, store.ErrInvalid):
+		return ExitUsage
+	}
+	return ExitInternal
+}
+
+func cmdStart(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("start", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, startUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, "", "", false, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "start:", err)
+		return ExitUsage
+	}
+	task, code := resolveTaskSelector(ws, gf, "start", strings.Join(fs.Args(), " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	task, stopped, err := ws.StartTimer(task.ID)
+	if err != nil {
+		return timeErrCode("start", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "start", "timer", map[string]any{"task": task, "stopped": stopped})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	for _, t := range stopped {
+		fmt.Printf("Stopped: %s\n", taskTitleOrUntitled(t.Title))
+	}
+	fmt.Printf("Started: %s\n", taskTitleOrUntitled(task.Title))
+	return ExitOK
+}
+
+func cmdStop(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("stop", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	id := ""
+	if fs.NArg() > 0 {
+		filter, err := selectorFilter(ws, *project, "", "", true, *match)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "stop:", err)
+			return ExitUsage
+		}
+		task, code := resolveTaskSelector(ws, gf, "stop", strings.Join(fs.Args(), " "), filter)
+		if code != ExitOK {
+			return code
+		}
+		id = task.ID
+	}
+	task, spent, err := ws.StopTimer(id)
+	if err != nil {
+		return timeErrCode("stop", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "stop", "timer", map[string]any{"task": task, "minutes": int(spent / time.Minute)})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	fmt.Printf("Stopped: %s (%s)\n", taskTitleOrUntitled(task.Title), store.FormatSpent(spent))
+	return ExitOK
+}
+
+func cmdLog(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	var textTokens []string
+	for i, arg := range args {
+		if arg == "--" {
+			textTokens = args[i+1:]
+			args = args[:i]
+			break
+		}
+	}
+	args = reorderFlags(args, map[string]bool{
+		"--hours":   true,
+		"--date":    true,
+		"--project": true,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("log", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	hours := fs.Float64("hours", 0, "Hours spent (e.g. 1.5)")
+	date := fs.String("date", "", "Day the work was done (default today; accepts relative dates)")
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() == 0 || *hours <= 0 || math.IsInf(*hours, 0) {
+		fmt.Fprintln(os.Stderr, logUsage)
+		return ExitUsage
+	}
+	var at time.Time
+	if strings.TrimSpace(*date) != "" {
+		day, err := resolveDateArg(gf, "date", *date)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "log:", err)
+			return ExitUsage
+		}
+		if len(day) > 10 {
+			day = day[:10]
+		}
+		d, err := time.Parse("2006-01-02", day)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "log: invalid date:", *date)
+			return ExitUsage
+		}
+		now := store.Now()
+		at = d.Add(now.Sub(now.Truncate(24 * time.Hour))).Truncate(time.Second)
+	}
+	filter, err := selectorFilter(ws, *project, "", "", true, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "log:", err)
+		return ExitUsage
+	}
+	task, code := resolveTaskSelector(ws, gf, "log", strings.Join(fs.Args(), " "), filter)
+	if code != ExitOK {
+		return code
+	}
+	spent := time.Duration(*hours * float64(time.Hour))
+	task, err = ws.LogTime(task.ID, spent, at, strings.Join(textTokens, " "))
+	if err != nil {
+		return timeErrCode("log", err)
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "log", "task", map[string]any{"task": task, "minutes": int(spent.Round(time.Minute) / time.Minute)})
+	}
+	if gf.Quiet {
+		return ExitOK
+	}
+	fmt.Printf("Logged %s on %s\n", store.FormatSpent(spent), taskTitleOrUntitled(task.Title))
+	return ExitOK
+}
+
+func cmdTimesheet(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--week":    false,
+		"--days":    true,
+		"--project": true,
+	})
+	fs := flag.NewFlagSet("timesheet", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Bool("week", true, "This week, Monday to Sunday (default)")
+	days := fs.Int("days", 0, "The last N days, including today")
+	project := fs.String("project", "", "Project name/slug")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 || *days < 0 {
+		fmt.Fprintln(os.Stderr, timesheetUsage)
+		return ExitUsage
+	}
+	today := store.Now().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	to := from.AddDate(0, 0, 6)
+	if *days > 0 {
+		from, to = today.AddDate(0, 0, -(*days-1)), today
+	}
+	sheet, err := ws.Timesheet(resolveProject(ws, *project), from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return timeErrCode("timesheet", err)
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "total\t%s..%s\t%d\n", sheet.From, sheet.To, sheet.Minutes)
+		for _, r := range sheet.Projects {
+			fmt.Fprintf(os.Stdout, "project\t%s\t%d\n", r.Key, r.Minutes)
+		}
+		for _, r := range sheet.Tags {
+			fmt.Fprintf(os.Stdout, "tag\t%s\t%d\n", r.Key, r.Minutes)
+		}
+		for _, r := range sheet.Tasks {
+			fmt.Fprintf(os.Stdout, "task\t%s\t%d\t%s\n", r.Key, r.Minutes, r.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "timesheet", "timesheet", sheet)
+	}
+	spent := func(minutes int) string { return store.FormatSpent(time.Duration(minutes) * time.Minute) }
+	fmt.Printf("Timesheet %s..%s: %s\n", sheet.From, sheet.To, spent(sheet.Minutes))
+	if sheet.Minutes == 0 {
+		fmt.Println("  (no time logged)")
+		return ExitOK
+	}
+	if sheet.Running > 0 {
+		fmt.Printf("  (includes %d running timer(s))\n", sheet.Running)
+	}
+	fmt.Println("\nBy project:")
+	for _, r := range sheet.Projects {
+		fmt.Printf("  %-8s %s\n", spent(r.Minutes), r.Key)
+	}
+	if len(sheet.Tags) > 0 {
+		fmt.Println("\nBy tag:")
+		for _, r := range sheet.Tags {
+			fmt.Printf("  %-8s #%s\n", spent(r.Minutes), r.Key)
+		}
+	}
+	fmt.Println("\nBy task:")
+	for _, r := range sheet.Tasks {
+		fmt.Printf("  %-8s %s (%s)\n", spent(r.Minutes), taskTitleOrUntitled(r.Title), r.Project)
+	}
+	return ExitOK
+}
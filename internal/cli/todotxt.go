@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdExportTodoTxt(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--all":     false,
+		"--out":     true,
+	})
+	fs := flag.NewFlagSet("export todotxt", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (default: all projects)")
+	all := fs.Bool("all", false, "Include archived tasks")
+	out := fs.String("out", "", "Output file, or - for stdout (default: <export dir>/todo[-<project>].txt)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, exportUsage)
+		return ExitUsage
+	}
+	if err := checkProject(ws, *project); err != nil {
+		fmt.Fprintln(os.Stderr, "export todotxt:", err)
+		return ExitNotFound
+	}
+	name := strings.TrimSpace(*project)
+	tasks, err := ws.ListTasks(store.ListFilter{Project: name, All: *all})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "export todotxt:", err)
+		return ExitInternal
+	}
+	data := ws.RenderTodoTxt(tasks)
+	if *out == "-" {
+		_, _ = os.Stdout.Write(data)
+		return ExitOK
+	}
+	path := *out
+	if path == "" {
+		file := "todo.txt"
+		if name != "" {
+			file = "todo-" + store.Slugify(name) + ".txt"
+		}
+		path = filepath.Join(gf.ExportDir, file)
+	}
+	if err := writeStableExport(filepath.Dir(path), filepath.Base(path), data); err != nil {
+		fmt.Fprintln(os.Stderr, "export todotxt:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Println(path)
+	} else if !gf.Quiet {
+		fmt.Printf("Wrote %d task(s) to: %s\n", len(tasks), path)
+	}
+	return ExitOK
+}
@@ -0,0 +1,262 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const rmUsage = "Usage: tasker rm [--project <name>|none|all] [--column <col>] [--status <s>] [--all] [--match <m>] <selector>"
+
+const trashUsage = "Usage: tasker trash <ls|restore <task-or-idea-id>>"
+
+func cmdRm(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	args = reorderFlags(args, map[string]bool{
+		"--project": true,
+		"--column":  true,
+		"--status":  true,
+		"--all":     false,
+		"--match":   true,
+	})
+	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	project := fs.String("project", "", "Project name/slug (use none|all for all projects)")
+	column := fs.String("column", "", "Column id (filter)")
+	status := fs.String("status", "", "Status (open|doing|blocked|done|archived, or one declared in config statuses)")
+	all := fs.Bool("all", false, "Include archived")
+	match := fs.String("match", "auto", "Match mode (auto|exact|prefix|contains|search)")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	rest := fs.Args()
+	if len(rest) < 1 {
+		fmt.Fprintln(os.Stderr, rmUsage)
+		return ExitUsage
+	}
+	filter, err := selectorFilter(ws, *project, *column, *status, *all, *match)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rm:", err)
+		return ExitUsage
+	}
+	taskRef, err := ws.GetTaskBySelectorFiltered(strings.Join(rest, " "), filter)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "rm: not found")
+			return ExitNotFound
+		}
+		if errors.Is(err, store.ErrConflict) {
+			if handleMatchConflict(gf, "rm", err) {
+				return ExitConflict
+			}
+			fmt.Fprintln(os.Stderr, "rm: ambiguous selector")
+			return ExitConflict
+		}
+		fmt.Fprintln(os.Stderr, "rm:", err)
+		return ExitInternal
+	}
+	trashed, err := ws.TrashTask(taskRef.ID)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "rm:", err)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			return ExitConflict
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", trashed.ID, trashed.TrashedOn, trashed.Title)
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "rm", "task", map[string]any{"task": trashed})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Removed %s (restore with: tasker trash restore %s)\n", taskTitleOrUntitled(trashed.Title), trashed.ID)
+	}
+	return ExitOK
+}
+
+func cmdTrash(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, trashUsage)
+		return ExitUsage
+	}
+	switch args[0] {
+	case "ls", "list":
+		if len(args) > 1 {
+			fmt.Fprintln(os.Stderr, trashUsage)
+			return ExitUsage
+		}
+		return cmdTrashList(ws, gf)
+	case "restore":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, trashUsage)
+			return ExitUsage
+		}
+		return cmdTrashRestore(ws, gf, args[1])
+	default:
+		fmt.Fprintln(os.Stderr, trashUsage)
+		return ExitUsage
+	}
+}
+
+func cmdTrashList(ws *store.Workspace, gf GlobalFlags) int {
+	trashed, err := ws.ListTrash()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "trash ls:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, t := range trashed {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n", t.ID, t.TrashedOn, t.Project, t.Column, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		if trashed == nil {
+			trashed = []store.TrashedTask{}
+		}
+		return emitJSONPayload(gf, "trash ls", "trash", map[string]any{"trash": trashed})
+	}
+	if len(trashed) == 0 {
+		fmt.Println("Trash is empty")
+		return ExitOK
+	}
+	for _, t := range trashed {
+		fmt.Printf("- %s %s/%s: %s (%s)\n", t.TrashedOn, t.Project, t.Column, taskTitleOrUntitled(t.Title), t.ID)
+	}
+	return ExitOK
+}
+
+// listDeletedTasks is `ls --deleted`: the trashed tasks matching filter,
+// each with the command that restores it.
+func listDeletedTasks(ws *store.Workspace, gf GlobalFlags, filter store.ListFilter) int {
+	trashed, err := ws.ListDeletedTasks(filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ls:", err)
+		if errors.Is(err, store.ErrInvalid) {
+			return ExitUsage
+		}
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "ID\tTRASHED\tPROJECT/COL\tTITLE")
+		for _, t := range trashed {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s/%s\t%s\n", t.ID, t.TrashedOn, t.Project, t.Column, t.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		if trashed == nil {
+			trashed = []store.TrashedTask{}
+		}
+		return emitJSONPayload(gf, "ls", "tasks-deleted", map[string]any{"deleted": trashed})
+	}
+	if len(trashed) == 0 {
+		if !gf.Quiet {
+			fmt.Println("No deleted tasks")
+		}
+		return ExitOK
+	}
+	for _, t := range trashed {
+		fmt.Printf("- %s %s/%s: %s — restore: tasker trash restore %s\n", t.TrashedOn, t.Project, t.Column, taskTitleOrUntitled(t.Title), t.ID)
+	}
+	return ExitOK
+}
+
+// listDeletedIdeas is `idea ls --deleted`.
+func listDeletedIdeas(ws *store.Workspace, gf GlobalFlags, filter store.IdeaListFilter) int {
+	trashed, err := ws.ListDeletedIdeas(filter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "idea ls:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		fmt.Fprintln(os.Stdout, "ID\tTRASHED\tSCOPE\tTITLE")
+		for _, idea := range trashed {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\n", idea.ID, idea.TrashedOn, ideaLocationLabel(idea.Project), idea.Title)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		if trashed == nil {
+			trashed = []store.TrashedIdea{}
+		}
+		return emitJSONPayload(gf, "idea ls", "ideas-deleted", map[string]any{"deleted": trashed})
+	}
+	if len(trashed) == 0 {
+		if !gf.Quiet {
+			fmt.Println("No deleted ideas")
+		}
+		return ExitOK
+	}
+	for _, idea := range trashed {
+		title := strings.TrimSpace(idea.Title)
+		if title == "" {
+			title = "(untitled)"
+		}
+		fmt.Printf("- %s %s: %s — restore: tasker trash restore %s\n", idea.TrashedOn, ideaLocationLabel(idea.Project), title, idea.ID)
+	}
+	return ExitOK
+}
+
+func cmdTrashRestore(ws *store.Workspace, gf GlobalFlags, id string) int {
+	if strings.HasPrefix(strings.ToLower(id), "idea_") {
+		return cmdTrashRestoreIdea(ws, gf, id)
+	}
+	task, err := ws.RestoreTrash(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			fmt.Fprintf(os.Stderr, "trash restore: not found in trash: %s\n", id)
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			fmt.Fprintln(os.Stderr, "trash restore:", err)
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			fmt.Fprintln(os.Stderr, "trash restore:", err)
+			return ExitUsage
+		}
+		fmt.Fprintln(os.Stderr, "trash restore:", err)
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "trash restore", "task", map[string]any{"task": task})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Restored %s (%s/%s)\n", taskTitleOrUntitled(task.Title), task.Project, task.Column)
+	}
+	return ExitOK
+}
+
+func cmdTrashRestoreIdea(ws *store.Workspace, gf GlobalFlags, id string) int {
+	idea, err := ws.RestoreTrashedIdea(id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			fmt.Fprintf(os.Stderr, "trash restore: not found in trash: %s\n", id)
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			fmt.Fprintln(os.Stderr, "trash restore:", err)
+			return ExitConflict
+		case errors.Is(err, store.ErrInvalid):
+			fmt.Fprintln(os.Stderr, "trash restore:", err)
+			return ExitUsage
+		}
+		fmt.Fprintln(os.Stderr, "trash restore:", err)
+		return ExitInternal
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "trash restore", "idea", map[string]any{"idea": idea})
+	}
+	if !gf.Quiet {
+		fmt.Printf("Restored idea %s (%s)\n", idea.Title, ideaLocationLabel(idea.Project))
+	}
+	return ExitOK
+}
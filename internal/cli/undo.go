@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func cmdUndo(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("undo", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	force := fs.Bool("force", false, "Undo even if the files changed since")
+	dryRun := fs.Bool("dry-run", false, "Show what would be undone without writing")
+	list := fs.Bool("list", false, "List operations that can be undone, newest first")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker undo [--dry-run] [--force] | undo --list")
+		return ExitUsage
+	}
+	if *list {
+		return cmdUndoList(ws, gf)
+	}
+	entry, err := ws.Undo(*force, *dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			fmt.Fprintln(os.Stderr, "undo: nothing to undo")
+			return ExitNotFound
+		case errors.Is(err, store.ErrConflict):
+			fmt.Fprintln(os.Stderr, "undo:", err)
+			return ExitConflict
+		}
+		fmt.Fprintln(os.Stderr, "undo:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, c := range entry.Changes {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\n", entry.Op, entry.ID, c.Path)
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		return emitJSONPayload(gf, "undo", "undo", map[string]any{"undone": journalSummary(*entry), "dry_run": *dryRun})
+	}
+	verb := "Undid"
+	if *dryRun {
+		verb = "Would undo"
+	}
+	fmt.Printf("%s %s from %s\n", verb, entry.Op, entry.At.Format(time.RFC3339))
+	for _, c := range entry.Changes {
+		fmt.Printf("  %s\n", c.Path)
+	}
+	return ExitOK
+}
+
+func cmdUndoList(ws *store.Workspace, gf GlobalFlags) int {
+	history, err := ws.JournalHistory()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "undo:", err)
+		return ExitInternal
+	}
+	if gf.Plain {
+		for _, e := range history {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%d\n", e.ID, e.Op, e.At.Format(time.RFC3339), len(e.Changes))
+		}
+		return ExitOK
+	}
+	if gf.JSON {
+		out := make([]map[string]any, 0, len(history))
+		for _, e := range history {
+			out = append(out, journalSummary(e))
+		}
+		return emitJSONPayload(gf, "undo", "undo", map[string]any{"history": out})
+	}
+	if len(history) == 0 {
+		fmt.Println("Nothing to undo")
+		return ExitOK
+	}
+	for _, e := range history {
+		fmt.Printf("- %s %s (%d file(s))\n", e.At.Format(time.RFC3339), e.Op, len(e.Changes))
+	}
+	return ExitOK
+}
+
+// journalSummary drops file contents, which can be large, from an entry.
+func journalSummary(e store.JournalEntry) map[string]any {
+	paths := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		paths = append(paths, c.Path)
+	}
+	return map[string]any{"id": e.ID, "op": e.Op, "at": e.At, "paths": paths}
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+// cmdValidate checks every task and idea file, for git hooks and CI. Errors
+// (unparseable files, duplicated IDs) always fail; --strict also fails on
+// file names and frontmatter that drifted from the layout.
+func cmdValidate(ws *store.Workspace, gf GlobalFlags, args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	strict := fs.Bool("strict", false, "Fail on any issue, not only on errors")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+	if len(fs.Args()) > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: tasker validate [--strict]")
+		return ExitUsage
+	}
+	report, err := ws.Validate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "validate:", err)
+		return ExitInternal
+	}
+	errs := report.Errors()
+	warnings := len(report.Issues) - errs
+	code := ExitOK
+	if errs > 0 || (*strict && warnings > 0) {
+		code = ExitInvalid
+	}
+
+	if gf.NDJSON {
+		items := make([]any, 0, len(report.Issues))
+		for _, i := range report.Issues {
+			items = append(items, i)
+		}
+		if rc := emitNDJSONItems(gf, "validate", "validate", items); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.JSON {
+		payload := map[string]any{"ok": code == ExitOK, "strict": *strict, "root": report.Root, "tasks": report.Tasks, "ideas": report.Ideas, "errors": errs, "warnings": warnings, "issues": report.Issues}
+		if rc := emitJSONPayload(gf, "validate", "validate", payload); rc != ExitOK {
+			return rc
+		}
+		return code
+	}
+	if gf.Plain {
+		for _, i := range report.Issues {
+			fmt.Fprintf(os.Stdout, "%s\t%s\t%s\t%s\t%s\n", issueLevel(i), i.Kind, i.Path, orDash(i.ID), i.Detail)
+		}
+		return code
+	}
+	if !gf.Quiet || code != ExitOK {
+		for _, i := range report.Issues {
+			fmt.Printf("%-5s %s %s: %s\n", issueLevel(i), i.Kind, i.Path, i.Detail)
+		}
+	}
+	if !gf.Quiet {
+		fmt.Printf("Checked %d task file(s) and %d idea(s): %d error(s), %d warning(s)\n", report.Tasks, report.Ideas, errs, warnings)
+	}
+	return code
+}
+
+func issueLevel(i store.ValidationIssue) string {
+	if i.Error {
+		return "ERROR"
+	}
+	return "WARN"
+}
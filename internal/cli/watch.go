@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+const defaultWatchInterval = time.Second
+
+// watchSettle is how long a redraw waits after a change for the rest of its
+// burst (a move writes, renames and journals several files).
+const watchSettle = 100 * time.Millisecond
+
+// watchRender clears the terminal and redraws render's output whenever the
+// workspace changes, until interrupted.
+func watchRender(ws *store.Workspace, cmd string, interval time.Duration, render func() (string, error)) int {
+	if interval <= 0 {
+		fmt.Fprintf(os.Stderr, "%s: --interval must be > 0\n", cmd)
+		return ExitUsage
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return watchLoop(ctx, ws, cmd, interval, os.Stdout, render)
+}
+
+// watchLoop redraws on file-system notifications from ws.WatchChanges,
+// re-reading a changed config first. Every interval it also checks the
+// date, so date-relative views roll over at midnight; where notifications
+// are unavailable it polls ws.ChangeStamp at that interval instead.
+func watchLoop(ctx context.Context, ws *store.Workspace, cmd string, interval time.Duration, out io.Writer, render func() (string, error)) int {
+	var changes <-chan struct{}
+	var watchErrs <-chan error
+	if cw, err := ws.WatchChanges(); err == nil {
+		defer cw.Close()
+		changes, watchErrs = cw.Changes, cw.Errors
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: file notifications unavailable (%v); polling every %s\n", cmd, err, interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	stamp := func() (string, error) {
+		day := store.Now().Format("2006-01-02")
+		if changes != nil {
+			return day, nil
+		}
+		s, err := ws.ChangeStamp()
+		return s + day, err
+	}
+	last := ""
+	redraw := true
+	for {
+		current, err := stamp()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+			return ExitInternal
+		}
+		if redraw || current != last {
+			last = current
+			if _, err := ws.ReloadConfig(); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+				return ExitInternal
+			}
+			view, err := render()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+				return ExitInternal
+			}
+			// Home the cursor and clear the screen, then redraw.
+			fmt.Fprint(out, "\x1b[H\x1b[2J")
+			fmt.Fprintln(out, view)
+			fmt.Fprintf(out, "\nUpdated %s - watching %s (Ctrl-C to stop)\n", time.Now().Format("15:04:05"), ws.Root)
+		}
+		redraw = false
+		select {
+		case <-ctx.Done():
+			return ExitOK
+		case <-ticker.C:
+		case _, ok := <-changes:
+			if !ok {
+				fmt.Fprintf(os.Stderr, "%s: file watcher stopped\n", cmd)
+				return ExitInternal
+			}
+			redraw = settle(ctx, changes)
+		case err := <-watchErrs:
+			fmt.Fprintf(os.Stderr, "%s: %v\n", cmd, err)
+		}
+	}
+}
+
+// settle waits watchSettle for the rest of a burst of changes, so one move
+// draws once; it reports false when ctx ended meanwhile.
+func settle(ctx context.Context, changes <-chan struct{}) bool {
+	timer := time.NewTimer(watchSettle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		case _, ok := <-changes:
+			if !ok {
+				return true
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amirbrooks/tasker-docstore-framework/internal/store"
+)
+
+func TestWatchLoopRedrawsOnChanges(t *testing.T) {
+	ws := newTestWorkspace(t)
+	renders := make(chan string, 16)
+	render := func() (string, error) {
+		var ids []string
+		for _, c := range ws.Config().Columns {
+			ids = append(ids, c.ID)
+		}
+		tasks, err := ws.ListTasks(store.ListFilter{})
+		if err != nil {
+			return "", err
+		}
+		view := strings.Join(ids, ",") + " " + strings.Repeat("*", len(tasks))
+		select {
+		case renders <- view:
+		default:
+		}
+		return view, nil
+	}
+	// waitFor reads redraws until one shows what ok looks for; a change may
+	// draw more than once.
+	waitFor := func(what string, ok func(string) bool) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case view := <-renders:
+				if ok(view) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("no redraw showing %s", what)
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+	go func() { done <- watchLoop(ctx, ws, "board", time.Hour, io.Discard, render) }()
+	waitFor("the board", func(view string) bool { return view == "inbox,todo,doing,blocked,done,archive " })
+
+	// Another process writes the store: a task, then a column.
+	other, err := store.Open(ws.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.AddTask(store.AddTaskInput{Title: "Ship", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("the new task", func(view string) bool { return strings.HasSuffix(view, " *") })
+	if _, err := other.AddColumn(store.ColumnChange{}, store.ColumnDef{ID: "review"}, "doing"); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("the re-read config", func(view string) bool { return strings.HasPrefix(view, "inbox,todo,doing,review,") })
+
+	cancel()
+	select {
+	case code := <-done:
+		if code != ExitOK {
+			t.Fatalf("expected ExitOK, got %d", code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("watch did not stop")
+	}
+}
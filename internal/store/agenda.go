@@ -0,0 +1,386 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AgendaGroup is the task count for one group within a section.
+type AgendaGroup struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// AgendaSection is one block of the today/week views: overdue, due today,
+// due soon, or a single day of the week window.
+type AgendaSection struct {
+	Key    string        `json:"key"` // overdue|today|due_soon|YYYY-MM-DD
+	Label  string        `json:"label"`
+	Date   string        `json:"date,omitempty"`
+	Count  int           `json:"count"`
+	Groups []AgendaGroup `json:"groups,omitempty"`
+	Tasks  []Task        `json:"tasks"`
+	// More counts tasks of the section left out of Tasks by the view page.
+	More int `json:"more,omitempty"`
+}
+
+// AgendaTotals summarizes an agenda view.
+type AgendaTotals struct {
+	Due     int `json:"due"`
+	DueSoon int `json:"due_soon,omitempty"`
+	Overdue int `json:"overdue"`
+}
+
+// DefaultDueSoon is agenda.due_soon when unset.
+const DefaultDueSoon = 48 * time.Hour
+
+// AgendaConfig tunes the today view.
+type AgendaConfig struct {
+	// DueSoon is how far past today the "Due soon" section of the today view
+	// looks, as a duration ("48h", "3d"); "off" hides the section.
+	DueSoon string `json:"due_soon,omitempty"`
+}
+
+// DueSoonHorizon is agenda.due_soon, DefaultDueSoon when unset, 0 when off.
+func (c Config) DueSoonHorizon() time.Duration {
+	if c.Agenda == nil || strings.TrimSpace(c.Agenda.DueSoon) == "" {
+		return DefaultDueSoon
+	}
+	d, err := ParseHorizon(c.Agenda.DueSoon)
+	if err != nil {
+		return DefaultDueSoon
+	}
+	return d
+}
+
+// ParseHorizon parses a due-soon horizon: a Go duration ("48h"), whole days
+// ("3d"), or off/none for 0.
+func ParseHorizon(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "off", "none", "0":
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: horizon %q (use e.g. 48h, 3d or off)", ErrInvalid, s)
+	}
+	return d, nil
+}
+
+// AgendaView is the structured form of RenderToday/RenderAgenda, built from
+// the same aggregation so bots can render their own UI.
+type AgendaView struct {
+	View        string          `json:"view"` // today|week
+	GeneratedAt time.Time       `json:"generated_at"`
+	Project     string          `json:"project,omitempty"`
+	Start       string          `json:"start"`
+	End         string          `json:"end"`
+	Days        int             `json:"days"`
+	OpenOnly    bool            `json:"open_only"`
+	GroupBy     string          `json:"group_by,omitempty"`
+	Totals      AgendaTotals    `json:"totals"`
+	Sections    []AgendaSection `json:"sections"`
+	// Buckets is set for two-level grouping (e.g. project,day): the same
+	// tasks regrouped per project/column, each with its non-empty sections.
+	Buckets []AgendaBucket `json:"buckets,omitempty"`
+}
+
+// AgendaBucket is one outer group of a two-level agenda.
+type AgendaBucket struct {
+	Key      string          `json:"key"`
+	Count    int             `json:"count"`
+	Sections []AgendaSection `json:"sections"`
+}
+
+// SplitAgendaGroup parses a week --group value into an outer grouping
+// (sections per project/column, then days) and an inner one (days, then
+// groups within each day). Accepted: none|day, project|column (same as
+// day,project|day,column), and project,day|column,day.
+func SplitAgendaGroup(group string) (outer string, inner string, err error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(group)), ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	switch {
+	case len(parts) == 1 && (parts[0] == "" || parts[0] == "none" || parts[0] == "day"):
+		return "", "", nil
+	case len(parts) == 1 && (parts[0] == "project" || parts[0] == "column"):
+		return "", parts[0], nil
+	case len(parts) == 2 && parts[0] == "day" && (parts[1] == "project" || parts[1] == "column"):
+		return "", parts[1], nil
+	case len(parts) == 2 && parts[1] == "day" && (parts[0] == "project" || parts[0] == "column"):
+		return parts[0], "", nil
+	}
+	return "", "", fmt.Errorf("%w: group must be none|day|project|column|project,day|column,day", ErrInvalid)
+}
+
+// agendaBucket holds one outer group's share of the week data.
+type agendaBucket struct {
+	key     string
+	overdue []Task
+	byDate  map[string][]Task
+}
+
+func (b agendaBucket) count() int {
+	return len(b.overdue) + lenByDate(b.byDate)
+}
+
+// bucketAgenda regroups overdue and per-day tasks under the outer key.
+func bucketAgenda(overdue []Task, byDate map[string][]Task, outer string) []agendaBucket {
+	buckets := map[string]*agendaBucket{}
+	get := func(t Task) *agendaBucket {
+		keys, _ := groupTasks([]Task{t}, outer)
+		b, ok := buckets[keys[0]]
+		if !ok {
+			b = &agendaBucket{key: keys[0], byDate: map[string][]Task{}}
+			buckets[keys[0]] = b
+		}
+		return b
+	}
+	for _, t := range overdue {
+		b := get(t)
+		b.overdue = append(b.overdue, t)
+	}
+	for day, tasks := range byDate {
+		for _, t := range tasks {
+			b := get(t)
+			b.byDate[day] = append(b.byDate[day], t)
+		}
+	}
+	out := make([]agendaBucket, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, *b)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+// collectToday splits tasks into due today, due soon (after today but within
+// agenda.due_soon of now) and overdue.
+func (w *Workspace) collectToday(project string, openOnly bool) (string, []Task, []Task, []Task, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: false})
+	if err != nil {
+		return "", nil, nil, nil, err
+	}
+	now := timeNow()
+	dueToday, dueSoon, overdue := bucketToday(w.openFilter(tasks, openOnly), now, w.cfg.DueSoonHorizon())
+	w.applyEscalation(overdue)
+	for _, section := range [][]Task{dueToday, dueSoon, overdue} {
+		w.ViewSort.Apply(section)
+	}
+	return now.Format("2006-01-02"), dueToday, dueSoon, overdue, nil
+}
+
+// bucketToday sorts tasks into due today (by time of day), due soon (due
+// after today but no later than horizon past now, soonest first) and
+// overdue. Tasks without a due date or whose start date is still ahead are
+// left out.
+func bucketToday(tasks []Task, now time.Time, horizon time.Duration) (dueToday []Task, dueSoon []Task, overdue []Task) {
+	today := now.Format("2006-01-02")
+	until := now.Add(horizon)
+	for _, t := range tasks {
+		if t.NotStarted(today) {
+			continue
+		}
+		dueDate, ok := t.DueAt()
+		if !ok {
+			continue
+		}
+		d := dueDate.Format("2006-01-02")
+		switch {
+		case d == today:
+			dueToday = append(dueToday, t)
+		case d < today:
+			overdue = append(overdue, t)
+		case !dueDate.After(until):
+			dueSoon = append(dueSoon, t)
+		}
+	}
+	sortByDueTime(dueToday)
+	sort.SliceStable(dueSoon, func(i, j int) bool {
+		a, _ := dueSoon[i].DueAt()
+		b, _ := dueSoon[j].DueAt()
+		return a.Before(b)
+	})
+	return dueToday, dueSoon, overdue
+}
+
+// collectAgenda buckets tasks due in the next days by date, plus overdue.
+func (w *Workspace) collectAgenda(project string, days int, openOnly bool) (time.Time, time.Time, []Task, map[string][]Task, error) {
+	start := timeNow().UTC()
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, days-1)
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: false})
+	if err != nil {
+		return start, end, nil, nil, err
+	}
+	overdue, byDate := bucketWeek(w.openFilter(tasks, openOnly), start, end)
+	for _, tasks := range byDate {
+		w.ViewSort.Apply(tasks)
+	}
+	w.applyEscalation(overdue)
+	w.ViewSort.Apply(overdue)
+	return start, end, overdue, byDate, nil
+}
+
+// bucketWeek sorts tasks due from start to end (UTC days, inclusive) into
+// one list per YYYY-MM-DD, each by time of day, and those due before start
+// into overdue. Tasks without a due date, due after end, or whose start
+// date is still ahead are left out.
+func bucketWeek(tasks []Task, start time.Time, end time.Time) (overdue []Task, byDate map[string][]Task) {
+	byDate = map[string][]Task{}
+	for _, t := range tasks {
+		if t.NotStarted(start.Format("2006-01-02")) {
+			continue
+		}
+		dueDate, ok := parseDueDate(t.Due)
+		if !ok {
+			continue
+		}
+		d := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+		if d.Before(start) {
+			overdue = append(overdue, t)
+			continue
+		}
+		if d.After(end) {
+			continue
+		}
+		key := d.Format("2006-01-02")
+		byDate[key] = append(byDate[key], t)
+	}
+	for _, tasks := range byDate {
+		sortByDueTime(tasks)
+	}
+	return overdue, byDate
+}
+
+// openFilter keeps the tasks with an open-like status when openOnly is set.
+func (w *Workspace) openFilter(tasks []Task, openOnly bool) []Task {
+	if !openOnly {
+		return tasks
+	}
+	var out []Task
+	for _, t := range tasks {
+		if w.cfg.IsOpenStatus(t.Status) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (w *Workspace) agendaSection(key string, label string, date string, tasks []Task, groupBy string) AgendaSection {
+	s := AgendaSection{Key: key, Label: label, Date: date, Count: len(tasks), Tasks: tasks}
+	if groupBy != "" {
+		keys, grouped := groupTasks(tasks, groupBy)
+		for _, k := range keys {
+			s.Groups = append(s.Groups, AgendaGroup{Key: k, Count: len(grouped[k])})
+		}
+	}
+	var more, hidden int
+	s.Tasks, more = w.ViewPage.section(s.Tasks)
+	s.Tasks, hidden = w.ViewPage.capGroups(s.Tasks, groupBy)
+	s.More = more + hidden
+	if s.Tasks == nil {
+		s.Tasks = []Task{}
+	}
+	return s
+}
+
+// bucketSection is a section of a project or column block in the two-level
+// week view; the block is the group --max-per-group caps.
+func (w *Workspace) bucketSection(key string, label string, date string, tasks []Task) AgendaSection {
+	s := w.agendaSection(key, label, date, tasks, "")
+	var hidden int
+	s.Tasks, hidden = w.ViewPage.group(s.Tasks)
+	s.More += hidden
+	return s
+}
+
+// TodayView returns the today view (due today + overdue) as data.
+func (w *Workspace) TodayView(project string, openOnly bool, groupBy string) (*AgendaView, error) {
+	today, dueToday, dueSoon, overdue, err := w.collectToday(project, openOnly)
+	if err != nil {
+		return nil, err
+	}
+	groupBy = normalizeGroupBy(groupBy)
+	return &AgendaView{
+		View:        "today",
+		GeneratedAt: timeNow(),
+		Project:     project,
+		Start:       today,
+		End:         today,
+		Days:        1,
+		OpenOnly:    openOnly,
+		GroupBy:     groupBy,
+		Totals:      AgendaTotals{Due: len(dueToday), DueSoon: len(dueSoon), Overdue: len(overdue)},
+		Sections: []AgendaSection{
+			w.agendaSection("today", "Due today", today, dueToday, groupBy),
+			w.agendaSection("due_soon", "Due soon", "", dueSoon, groupBy),
+			w.agendaSection("overdue", "Overdue", "", overdue, groupBy),
+		},
+	}, nil
+}
+
+// WeekView returns the week view (overdue + one section per day) as data.
+func (w *Workspace) WeekView(project string, days int, openOnly bool, groupBy string) (*AgendaView, error) {
+	if days <= 0 {
+		days = 7
+	}
+	start, end, overdue, byDate, err := w.collectAgenda(project, days, openOnly)
+	if err != nil {
+		return nil, err
+	}
+	outer, inner, err := SplitAgendaGroup(groupBy)
+	if err != nil {
+		return nil, err
+	}
+	if outer != "" {
+		groupBy = outer + ",day"
+	} else {
+		groupBy = inner
+	}
+	view := &AgendaView{
+		View:        "week",
+		GeneratedAt: timeNow(),
+		Project:     project,
+		Start:       start.Format("2006-01-02"),
+		End:         end.Format("2006-01-02"),
+		Days:        days,
+		OpenOnly:    openOnly,
+		GroupBy:     groupBy,
+		Totals:      AgendaTotals{Due: lenByDate(byDate), Overdue: len(overdue)},
+		Sections:    []AgendaSection{w.agendaSection("overdue", "Overdue", "", overdue, inner)},
+	}
+	for i := 0; i < days; i++ {
+		d := start.AddDate(0, 0, i)
+		key := d.Format("2006-01-02")
+		view.Sections = append(view.Sections, w.agendaSection(key, w.cfg.DayLabel(d), key, byDate[key], inner))
+	}
+	if outer == "" {
+		return view, nil
+	}
+	for _, b := range bucketAgenda(overdue, byDate, outer) {
+		bucket := AgendaBucket{Key: b.key, Count: b.count()}
+		if len(b.overdue) > 0 {
+			bucket.Sections = append(bucket.Sections, w.bucketSection("overdue", "Overdue", "", b.overdue))
+		}
+		for i := 0; i < days; i++ {
+			d := start.AddDate(0, 0, i)
+			key := d.Format("2006-01-02")
+			if len(b.byDate[key]) > 0 {
+				bucket.Sections = append(bucket.Sections, w.bucketSection(key, w.cfg.DayLabel(d), key, b.byDate[key]))
+			}
+		}
+		view.Buckets = append(view.Buckets, bucket)
+	}
+	return view, nil
+}
@@ -0,0 +1,256 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeekViewProjectDayBuckets(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Invoice", Project: "Acme", Due: "2026-01-20"},
+		{Title: "Kickoff", Project: "Acme", Due: "2026-01-15"},
+		{Title: "Review", Project: "Beta", Due: "2026-01-21"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	view, err := w.WeekView("", 7, true, "project,day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.GroupBy != "project,day" || len(view.Buckets) != 2 {
+		t.Fatalf("expected two project buckets, got %q %+v", view.GroupBy, view.Buckets)
+	}
+	acme := view.Buckets[0]
+	if acme.Key != "acme" || acme.Count != 2 || len(acme.Sections) != 2 {
+		t.Fatalf("unexpected acme bucket: %+v", acme)
+	}
+	if acme.Sections[0].Key != "overdue" || acme.Sections[1].Key != "2026-01-20" {
+		t.Fatalf("expected overdue then 2026-01-20, got %s, %s", acme.Sections[0].Key, acme.Sections[1].Key)
+	}
+	if _, _, err := SplitAgendaGroup("day,day"); err == nil {
+		t.Fatalf("expected invalid grouping to fail")
+	}
+}
+
+func TestTodayViewDueSoon(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Today", Project: "Work", Due: "2026-01-19"},
+		{Title: "Tomorrow", Project: "Work", Due: "2026-01-20"},
+		{Title: "Later", Project: "Work", Due: "2026-01-25"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	view, err := w.TodayView("", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Totals.Due != 1 || view.Totals.DueSoon != 1 || view.Sections[1].Tasks[0].Title != "Tomorrow" {
+		t.Fatalf("expected one due today and Tomorrow due soon, got %+v", view.Totals)
+	}
+	w.cfg.Agenda = &AgendaConfig{DueSoon: "off"}
+	if view, err = w.TodayView("", true, ""); err != nil || view.Totals.DueSoon != 0 {
+		t.Fatalf("expected no due soon when off, got %+v (%v)", view.Totals, err)
+	}
+	if d, err := ParseHorizon("3d"); err != nil || d != 72*time.Hour {
+		t.Fatalf("expected 3d = 72h, got %v (%v)", d, err)
+	}
+}
+
+func TestStartDateHidesUntilActive(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	if _, err := w.AddTask(AddTaskInput{Title: "Later", Project: "Work", Due: "2026-01-20", Start: "2026-01-22"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Now", Project: "Work", Due: "2026-01-20", Start: "2026-01-19"}); err != nil {
+		t.Fatal(err)
+	}
+	week, err := w.WeekView("", 7, true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if week.Totals.Due != 1 {
+		t.Fatalf("expected only the started task in the week, got %d", week.Totals.Due)
+	}
+	scheduled, err := w.ScheduledTasks("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scheduled) != 1 || scheduled[0].Title != "Later" {
+		t.Fatalf("expected Later scheduled, got %+v", scheduled)
+	}
+	if got := shiftStart("2026-01-17", "2026-01-20", "2026-01-27"); got != "2026-01-24" {
+		t.Fatalf("expected start to keep its 3-day lead, got %s", got)
+	}
+}
+
+func TestSetNowPinsToday(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	SetNow(time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC))
+	defer SetNow(time.Time{})
+
+	if _, err := w.AddTask(AddTaskInput{Title: "Standup notes", Project: "Work", Due: "2026-01-20"}); err != nil {
+		t.Fatal(err)
+	}
+	view, err := w.TodayView("", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if view.Start != "2026-01-20" || view.Totals.Due != 1 {
+		t.Fatalf("expected the pinned day with one task due, got %s %+v", view.Start, view.Totals)
+	}
+}
+
+func TestTodayViewSortsByDueTime(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Call", Project: "Work", Due: "2026-01-19", DueTime: "3pm"},
+		{Title: "Standup", Project: "Work", Due: "2026-01-19", DueTime: "9:30"},
+		{Title: "Errands", Project: "Work", Due: "2026-01-19"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	view, err := w.TodayView("", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, task := range view.Sections[0].Tasks {
+		got = append(got, task.Title+"@"+task.DueTime)
+	}
+	if len(got) != 3 || got[0] != "Errands@" || got[1] != "Standup@09:30" || got[2] != "Call@15:00" {
+		t.Fatalf("expected all-day first, then by time, got %v", got)
+	}
+	if !view.Sections[0].Tasks[0].AllDay || view.Sections[0].Tasks[1].AllDay {
+		t.Fatalf("expected all_day only on the untimed task")
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Late", Due: "2026-01-19", DueTime: "25:00"}); err == nil {
+		t.Fatalf("expected an invalid due time to fail")
+	}
+}
+
+func TestBucketToday(t *testing.T) {
+	now := time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		due, dueTime, start string
+		horizon             time.Duration
+		want                string // today|soon|overdue, "" when left out
+	}{
+		{"2026-01-19", "", "", DefaultDueSoon, "today"},
+		{"2026-01-19", "23:30", "", DefaultDueSoon, "today"},
+		{"2026-01-19T18:00:00Z", "", "", DefaultDueSoon, "today"},
+		{"2026-01-18", "", "", DefaultDueSoon, "overdue"},
+		{"2025-12-31", "", "", 0, "overdue"},
+		{"2026-01-20", "", "", DefaultDueSoon, "soon"},
+		{"2026-01-21", "09:00", "", DefaultDueSoon, "soon"},
+		{"2026-01-21", "09:30", "", DefaultDueSoon, ""},
+		{"2026-01-20", "", "", 0, ""},
+		{"2026-01-25", "", "", DefaultDueSoon, ""},
+		{"", "", "", DefaultDueSoon, ""},
+		{"someday", "", "", DefaultDueSoon, ""},
+		{"2026-01-18", "", "2026-01-20", DefaultDueSoon, ""},
+		{"2026-01-19", "", "2026-01-19", DefaultDueSoon, "today"},
+	}
+	for _, c := range cases {
+		task := Task{TaskMeta: TaskMeta{Title: "T", Due: c.due, DueTime: c.dueTime, Start: c.start}}
+		dueToday, dueSoon, overdue := bucketToday([]Task{task}, now, c.horizon)
+		got := ""
+		switch {
+		case len(dueToday) == 1:
+			got = "today"
+		case len(dueSoon) == 1:
+			got = "soon"
+		case len(overdue) == 1:
+			got = "overdue"
+		}
+		if got != c.want || len(dueToday)+len(dueSoon)+len(overdue) > 1 {
+			t.Fatalf("due %q %q start %q horizon %s: got %q, want %q", c.due, c.dueTime, c.start, c.horizon, got, c.want)
+		}
+	}
+
+	dueToday, dueSoon, _ := bucketToday([]Task{
+		{TaskMeta: TaskMeta{Title: "Call", Due: "2026-01-19", DueTime: "15:00"}},
+		{TaskMeta: TaskMeta{Title: "Errands", Due: "2026-01-19"}},
+		{TaskMeta: TaskMeta{Title: "Thursday", Due: "2026-01-21", DueTime: "08:00"}},
+		{TaskMeta: TaskMeta{Title: "Tuesday", Due: "2026-01-20"}},
+	}, now, DefaultDueSoon)
+	if titles(dueToday) != "Errands,Call" || titles(dueSoon) != "Tuesday,Thursday" {
+		t.Fatalf("unexpected order: today %s, soon %s", titles(dueToday), titles(dueSoon))
+	}
+}
+
+func TestBucketWeek(t *testing.T) {
+	start := time.Date(2026, 1, 19, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 6)
+	cases := []struct {
+		due, start string
+		want       string // overdue or the day, "" when left out
+	}{
+		{"2026-01-18", "", "overdue"},
+		{"2026-01-19", "", "2026-01-19"},
+		{"2026-01-19T23:00:00Z", "", "2026-01-19"},
+		{"2026-01-25", "", "2026-01-25"},
+		{"2026-01-26", "", ""},
+		{"", "", ""},
+		{"2026-01-22", "2026-01-20", ""},
+		{"2026-01-22", "2026-01-19", "2026-01-22"},
+	}
+	for _, c := range cases {
+		task := Task{TaskMeta: TaskMeta{Title: "T", Due: c.due, Start: c.start}}
+		overdue, byDate := bucketWeek([]Task{task}, start, end)
+		got := ""
+		if len(overdue) == 1 {
+			got = "overdue"
+		}
+		for day, tasks := range byDate {
+			if len(tasks) > 0 {
+				got += day
+			}
+		}
+		if got != c.want {
+			t.Fatalf("due %q start %q: got %q, want %q", c.due, c.start, got, c.want)
+		}
+	}
+
+	_, byDate := bucketWeek([]Task{
+		{TaskMeta: TaskMeta{Title: "Call", Due: "2026-01-20", DueTime: "15:00"}},
+		{TaskMeta: TaskMeta{Title: "Standup", Due: "2026-01-20", DueTime: "09:30"}},
+		{TaskMeta: TaskMeta{Title: "Errands", Due: "2026-01-20"}},
+	}, start, end)
+	if got := titles(byDate["2026-01-20"]); got != "Errands,Standup,Call" {
+		t.Fatalf("expected all-day first, then by time, got %s", got)
+	}
+}
+
+// titles joins the titles of tasks with commas.
+func titles(tasks []Task) string {
+	var out []string
+	for _, task := range tasks {
+		out = append(out, task.Title)
+	}
+	return strings.Join(out, ",")
+}
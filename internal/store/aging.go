@@ -0,0 +1,101 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultStaleDays is aging.stale_days when unset.
+const DefaultStaleDays = 7
+
+// AgingConfig controls the "(doing 6d)" indicators on open tasks.
+type AgingConfig struct {
+	// Enabled defaults to true; set false to hide the indicators.
+	Enabled *bool `json:"enabled,omitempty"`
+	// StaleDays highlights tasks that sat in one column at least this many
+	// days. 0 uses DefaultStaleDays; a negative value turns highlighting off.
+	StaleDays int `json:"stale_days,omitempty"`
+}
+
+// AgingEnabled is aging.enabled, true when unset.
+func (c Config) AgingEnabled() bool {
+	if c.Aging == nil || c.Aging.Enabled == nil {
+		return true
+	}
+	return *c.Aging.Enabled
+}
+
+// StaleDays is aging.stale_days, or DefaultStaleDays; 0 means never stale.
+func (c Config) StaleDays() int {
+	if c.Aging == nil || c.Aging.StaleDays == 0 {
+		return DefaultStaleDays
+	}
+	if c.Aging.StaleDays < 0 {
+		return 0
+	}
+	return c.Aging.StaleDays
+}
+
+// ColumnSince is when t entered its current column. Tasks written before
+// moved_at existed fall back to updated_at, then created_at.
+func (t *Task) ColumnSince() *time.Time {
+	switch {
+	case t.MovedAt != nil:
+		return t.MovedAt
+	case t.UpdatedAt != nil:
+		return t.UpdatedAt
+	}
+	return t.CreatedAt
+}
+
+// TaskAge is how long an open task has been in its column. Done/archived
+// tasks and tasks younger than a day report 0 and no label.
+type TaskAge struct {
+	Column string
+	Days   int
+	Stale  bool
+}
+
+// Label renders "doing 6d".
+func (a TaskAge) Label() string {
+	if a.Days < 1 {
+		return ""
+	}
+	return fmt.Sprintf("%s %dd", a.Column, a.Days)
+}
+
+// Age computes the aging indicator for t; the zero value means "nothing to
+// show" (aging disabled, closed task, or under a day).
+func (w *Workspace) Age(t Task) TaskAge {
+	if !w.cfg.AgingEnabled() || !w.cfg.IsOpenStatus(t.Status) {
+		return TaskAge{}
+	}
+	since := t.ColumnSince()
+	if since == nil {
+		return TaskAge{}
+	}
+	days := int(timeNow().Sub(*since).Hours() / 24)
+	if days < 1 {
+		return TaskAge{}
+	}
+	stale := w.cfg.StaleDays()
+	return TaskAge{Column: t.Column, Days: days, Stale: stale > 0 && days >= stale}
+}
+
+// AgingSuffix is " (doing 6d)", with a marker when the task is stale:
+// " (⚠ doing 9d)", or " (! doing 9d)" in ASCII mode or without icons.
+func (w *Workspace) AgingSuffix(t Task, ascii bool) string {
+	age := w.Age(t)
+	label := age.Label()
+	if label == "" {
+		return ""
+	}
+	if age.Stale {
+		marker := w.cfg.SectionIcon("stale") + " "
+		if ascii || marker == " " {
+			marker = "! "
+		}
+		label = marker + label
+	}
+	return " (" + label + ")"
+}
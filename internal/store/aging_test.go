@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgeTracksColumnEntry(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := start
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	task, err := w.AddTask(AddTaskInput{Title: "Stuck", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = start.AddDate(0, 0, 3)
+	moved, err := w.MoveTask(task.ID, "doing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = start.AddDate(0, 0, 5)
+	if moved, err = w.AddNote(task.ID, "still going"); err != nil {
+		t.Fatal(err)
+	}
+	now = start.AddDate(0, 0, 9)
+	if got := w.AgingSuffix(*moved, true); got != " (doing 6d)" {
+		t.Fatalf("expected doing 6d, got %q", got)
+	}
+	now = start.AddDate(0, 0, 11)
+	if got := w.AgingSuffix(*moved, true); got != " (! doing 8d)" {
+		t.Fatalf("expected stale marker, got %q", got)
+	}
+	done, err := w.MoveTask(task.ID, "done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := w.AgingSuffix(*done, true); got != "" {
+		t.Fatalf("expected no aging on done tasks, got %q", got)
+	}
+}
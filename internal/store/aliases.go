@@ -0,0 +1,77 @@
+package store
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var aliasName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Alias returns the expansion stored for name.
+func (c Config) Alias(name string) (string, bool) {
+	expansion, ok := c.Aliases[name]
+	return expansion, ok
+}
+
+// AliasNames returns the configured alias names, sorted.
+func (c Config) AliasNames() []string {
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetAlias stores name -> expansion, replacing an existing alias. Checking
+// that name does not shadow a command is left to the caller.
+func (w *Workspace) SetAlias(name string, expansion string) error {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	name = strings.ToLower(strings.TrimSpace(name))
+	expansion = strings.TrimSpace(expansion)
+	if !aliasName.MatchString(name) {
+		return fmt.Errorf("%w: alias name %q must be lowercase letters, digits, - or _", ErrInvalid, name)
+	}
+	if expansion == "" {
+		return fmt.Errorf("%w: alias %q needs a command to expand to", ErrInvalid, name)
+	}
+	cfg := w.cfg
+	aliases := make(map[string]string, len(cfg.Aliases)+1)
+	for k, v := range cfg.Aliases {
+		aliases[k] = v
+	}
+	aliases[name] = expansion
+	cfg.Aliases = aliases
+	return w.SaveConfig(cfg)
+}
+
+// RemoveAlias deletes an alias.
+func (w *Workspace) RemoveAlias(name string) error {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := w.cfg.Aliases[name]; !ok {
+		return fmt.Errorf("%w: alias %q", ErrNotFound, name)
+	}
+	cfg := w.cfg
+	aliases := make(map[string]string, len(cfg.Aliases))
+	for k, v := range cfg.Aliases {
+		if k != name {
+			aliases[k] = v
+		}
+	}
+	if len(aliases) == 0 {
+		aliases = nil
+	}
+	cfg.Aliases = aliases
+	return w.SaveConfig(cfg)
+}
@@ -0,0 +1,29 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAliasesPersist(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if err := w.SetAlias("Standup", "tasks --group project --format telegram"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetAlias("-x", "ls"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid name to be rejected, got %v", err)
+	}
+	reopened, err := Open(w.Root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := reopened.Config().Alias("standup"); !ok || got != "tasks --group project --format telegram" {
+		t.Fatalf("expected alias to survive reopen, got %q %v", got, ok)
+	}
+	if err := reopened.RemoveAlias("standup"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.RemoveAlias("standup"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found on second remove, got %v", err)
+	}
+}
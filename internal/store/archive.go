@@ -0,0 +1,81 @@
+package store
+
+import (
+	"sort"
+	"time"
+)
+
+// StaleDone lists the done tasks in project (a project spec; "" for every
+// project) that were completed more than olderThan ago, oldest first. A task
+// without completed_at counts from its last move, then its last update.
+// Projects without an archived column are skipped.
+func (w *Workspace) StaleDone(project string, olderThan time.Duration) ([]Task, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project, Status: "done"})
+	if err != nil {
+		return nil, err
+	}
+	cutoff := timeNow().Add(-olderThan)
+	var out []Task
+	for _, t := range tasks {
+		at := doneSince(t)
+		if at == nil || !at.Before(cutoff) {
+			continue
+		}
+		if _, ok := w.statusColumn(t.Project, "archived"); !ok {
+			continue
+		}
+		out = append(out, t)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return doneSince(out[i]).Before(*doneSince(out[j])) })
+	return out, nil
+}
+
+func doneSince(t Task) *time.Time {
+	for _, at := range []*time.Time{t.CompletedAt, t.MovedAt, t.UpdatedAt} {
+		if at != nil {
+			return at
+		}
+	}
+	return nil
+}
+
+// ArchiveDone moves every StaleDone task into its project's archived column
+// under one journal entry named op ("archive", or "auto-archive" for the
+// agent.auto_archive_days policy). With dryRun nothing is written. No stale
+// tasks is not an error: the result is simply empty.
+func (w *Workspace) ArchiveDone(op string, project string, olderThan time.Duration, dryRun bool) ([]Task, error) {
+	stale, err := w.StaleDone(project, olderThan)
+	if err != nil || len(stale) == 0 {
+		return nil, err
+	}
+	ids := make([]string, 0, len(stale))
+	for _, t := range stale {
+		ids = append(ids, t.ID)
+	}
+	return w.runBatch(op, ids, dryRun, func(id string) (*Task, error) {
+		task, err := w.GetTaskByPrefix(id)
+		if err != nil {
+			return nil, err
+		}
+		col, _ := w.statusColumn(task.Project, "archived")
+		return w.MoveTaskWith(id, col.ID, MoveOptions{})
+	})
+}
+
+// AutoArchive applies agent.auto_archive_days across the workspace. It does
+// nothing when the policy is off.
+func (w *Workspace) AutoArchive() ([]Task, error) {
+	days := w.cfg.AutoArchiveDays()
+	if days <= 0 {
+		return nil, nil
+	}
+	return w.ArchiveDone("auto-archive", "", time.Duration(days)*24*time.Hour, false)
+}
+
+// AutoArchiveDays is agent.auto_archive_days; 0 means off.
+func (c Config) AutoArchiveDays() int {
+	if c.Agent == nil || c.Agent.AutoArchiveDays < 0 {
+		return 0
+	}
+	return c.Agent.AutoArchiveDays
+}
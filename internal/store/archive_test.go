@@ -0,0 +1,61 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArchiveDone(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	defer func() { timeNow = orig }()
+	day := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return day }
+
+	var ids []string
+	for _, title := range []string{"Old", "Recent", "Open"} {
+		task, err := w.AddTask(AddTaskInput{Title: title, Project: "Work"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, task.ID)
+	}
+	if _, err := w.MoveTask(ids[0], "done"); err != nil {
+		t.Fatal(err)
+	}
+	timeNow = func() time.Time { return day.AddDate(0, 0, 25) }
+	if _, err := w.MoveTask(ids[1], "done"); err != nil {
+		t.Fatal(err)
+	}
+	timeNow = func() time.Time { return day.AddDate(0, 0, 40) }
+
+	preview, err := w.ArchiveDone("archive", "work", 30*24*time.Hour, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(preview) != 1 || preview[0].ID != ids[0] {
+		t.Fatalf("expected only the old done task, got %+v", preview)
+	}
+	if task, _ := w.GetTaskByPrefix(ids[0]); task.Status != "done" {
+		t.Fatalf("dry run should not move anything, got %s", task.Status)
+	}
+
+	w.cfg.Agent = &AgentConfig{AutoArchiveDays: 30}
+	archived, err := w.AutoArchive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 1 {
+		t.Fatalf("expected one archived task, got %d", len(archived))
+	}
+	task, err := w.GetTaskByPrefix(ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != "archived" || task.ArchivedAt == nil || task.CompletedAt == nil || !task.CompletedAt.Equal(day) {
+		t.Fatalf("expected archived with completed_at kept: %+v", task.TaskMeta)
+	}
+	if again, err := w.AutoArchive(); err != nil || len(again) != 0 {
+		t.Fatalf("expected nothing left to archive, got %d (%v)", len(again), err)
+	}
+}
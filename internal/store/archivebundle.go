@@ -0,0 +1,175 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveBundleDir holds the yearly bundles archived tasks are compacted
+// into: <root>/archive/<year>.ndjson, one task per line.
+const ArchiveBundleDir = "archive"
+
+// bundledTask is one line of an archive bundle: the task file exactly as it
+// was, plus where it lived so the path rule still decides project and
+// column.
+type bundledTask struct {
+	ID      string `json:"id"`
+	Project string `json:"project"`
+	Column  string `json:"column"`
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// CompactArchive moves the archived task files of project (a project spec;
+// "" for every project) into archive/<year>.ndjson, by the year each task was
+// archived. Bundles are appended to, never rewritten from scratch, and the
+// whole compaction is one journal entry. With dryRun nothing is written. The
+// returned tasks carry the bundle they went (or would go) to.
+func (w *Workspace) CompactArchive(project string, dryRun bool) ([]Task, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project, Status: "archived", All: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return archivedSince(tasks[i]).Before(archivedSince(tasks[j])) })
+	lines := map[string][]string{}
+	var changes []fileChange
+	for i := range tasks {
+		t := &tasks[i]
+		b, err := os.ReadFile(t.Path)
+		if err != nil {
+			return nil, err
+		}
+		rel, err := w.relPath(t.Path)
+		if err != nil {
+			return nil, err
+		}
+		line, err := json.Marshal(bundledTask{ID: t.ID, Project: t.Project, Column: t.Column, Path: filepath.ToSlash(rel), Content: string(b)})
+		if err != nil {
+			return nil, err
+		}
+		bundle := bundleFor(archivedSince(*t))
+		lines[bundle] = append(lines[bundle], string(line))
+		changes = append(changes, fileChange{Path: t.Path})
+		t.Bundle = bundle
+	}
+	if dryRun {
+		return tasks, nil
+	}
+	bundles := make([]string, 0, len(lines))
+	for bundle := range lines {
+		bundles = append(bundles, bundle)
+	}
+	sort.Strings(bundles)
+	for _, bundle := range bundles {
+		abs := filepath.Join(w.Root, filepath.FromSlash(bundle))
+		before, err := readOptionalFile(abs)
+		if err != nil {
+			return nil, err
+		}
+		content := ""
+		if before != nil {
+			content = *before
+			if content != "" && !strings.HasSuffix(content, "\n") {
+				content += "\n"
+			}
+		}
+		content += strings.Join(lines[bundle], "\n") + "\n"
+		changes = append([]fileChange{{Path: abs, After: &content}}, changes...)
+	}
+	if err := w.commitChanges("archive-compact", changes); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// archivedSince is when a task was archived, falling back to when it was
+// done, moved or last touched.
+func archivedSince(t Task) time.Time {
+	if t.ArchivedAt != nil {
+		return *t.ArchivedAt
+	}
+	if at := doneSince(t); at != nil {
+		return *at
+	}
+	if t.CreatedAt != nil {
+		return *t.CreatedAt
+	}
+	return time.Time{}
+}
+
+// bundleFor names the bundle of a task archived at at.
+func bundleFor(at time.Time) string {
+	year := at.UTC().Year()
+	if at.IsZero() {
+		year = timeNow().UTC().Year()
+	}
+	return fmt.Sprintf("%s/%d.ndjson", ArchiveBundleDir, year)
+}
+
+// ArchiveBundles lists the bundle files, oldest year first, relative to
+// the root.
+func (w *Workspace) ArchiveBundles() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(w.Root, ArchiveBundleDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".ndjson") {
+			out = append(out, ArchiveBundleDir+"/"+e.Name())
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// BundledTasks reads every task out of the archive bundles. Lines that do
+// not parse are skipped, like unreadable task files.
+func (w *Workspace) BundledTasks() ([]Task, error) {
+	bundles, err := w.ArchiveBundles()
+	if err != nil {
+		return nil, err
+	}
+	var out []Task
+	for _, bundle := range bundles {
+		f, err := os.Open(filepath.Join(w.Root, filepath.FromSlash(bundle)))
+		if err != nil {
+			return nil, err
+		}
+		sc := bufio.NewScanner(f)
+		sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for sc.Scan() {
+			var line bundledTask
+			if json.Unmarshal(sc.Bytes(), &line) != nil {
+				continue
+			}
+			meta, body, err := parseFrontmatter([]byte(line.Content))
+			if err != nil {
+				continue
+			}
+			t := Task{TaskMeta: *meta, Path: filepath.Join(w.Root, filepath.FromSlash(line.Path)), Body: body, Bundle: bundle}
+			t.Project = line.Project
+			t.Column = line.Column
+			t.Status = "archived"
+			out = append(out, t)
+		}
+		err = sc.Err()
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", bundle, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCompactArchive(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	defer func() { timeNow = orig }()
+
+	var ids []string
+	for i, title := range []string{"Shipped v1", "Shipped v2", "Still open"} {
+		timeNow = func() time.Time { return time.Date(2025+i, 6, 1, 9, 0, 0, 0, time.UTC) }
+		task, err := w.AddTask(AddTaskInput{Title: title, Project: "Work", Tags: []string{"release"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, task.ID)
+		if i < 2 {
+			if _, err := w.MoveTask(task.ID, "archive"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	compacted, err := w.CompactArchive("", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(compacted) != 2 || compacted[0].Bundle != "archive/2025.ndjson" || compacted[1].Bundle != "archive/2026.ndjson" {
+		t.Fatalf("expected one task per yearly bundle, got %+v", compacted)
+	}
+	if _, err := os.Stat(compacted[0].Path); !os.IsNotExist(err) {
+		t.Fatalf("expected the archived file to be gone, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(w.Root, "archive", "2025.ndjson")); err != nil {
+		t.Fatal(err)
+	}
+
+	all, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].ID != ids[2] {
+		t.Fatalf("expected only the open task without bundles, got %d", len(all))
+	}
+	found, err := w.ListTasks(ListFilter{All: true, ArchiveBundles: true, Search: "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].ID != ids[1] || found[0].Status != "archived" || found[0].Column != "archive" || found[0].Tags[0] != "release" {
+		t.Fatalf("expected the bundled v2 task, got %+v", found)
+	}
+
+	if again, err := w.CompactArchive("", false); err != nil || len(again) != 0 {
+		t.Fatalf("expected nothing left to compact, got %d (%v)", len(again), err)
+	}
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AttachmentsDir is the directory under the root that attached files are
+// copied into, one subdirectory per task id.
+const AttachmentsDir = "attachments"
+
+// AttachFile copies the file at src into attachments/<task-id>/ and records
+// its path, relative to the root, in the task's attachments list. A file of
+// the same name and content is recorded once; a different one gets a -2, -3,
+// ... suffix. The copy is written directly, since attachments may be large
+// or binary; only the task change goes through the journal, so undo leaves
+// the copied file behind.
+func (w *Workspace) AttachFile(taskPrefix string, src string) (*Task, string, error) {
+	task, err := w.GetTaskByPrefix(taskPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := os.Stat(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, "", fmt.Errorf("%w: %s", ErrNotFound, src)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, "", fmt.Errorf("%w: %s is not a regular file", ErrInvalid, src)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return nil, "", err
+	}
+	dir := filepath.Join(w.Root, AttachmentsDir, task.ID)
+	name := filepath.Base(src)
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for n := 2; ; n++ {
+		existing, err := os.ReadFile(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			if err := atomicWriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+				return nil, "", err
+			}
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if bytes.Equal(existing, data) {
+			break
+		}
+		name = fmt.Sprintf("%s-%d%s", stem, n, ext)
+	}
+	rel := filepath.ToSlash(filepath.Join(AttachmentsDir, task.ID, name))
+	if containsString(task.Attachments, rel) {
+		return task, rel, nil
+	}
+	now := timeNow()
+	task.Attachments = append(task.Attachments, rel)
+	task.UpdatedAt = &now
+	if err := w.saveTask("attach", task); err != nil {
+		return nil, "", err
+	}
+	return task, rel, nil
+}
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAttachFile(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Review contract", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := filepath.Join(t.TempDir(), "contract.pdf")
+	if err := os.WriteFile(src, []byte("%PDF\x00\xff"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, rel, err := w.AttachFile(task.ID, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel != "attachments/"+task.ID+"/contract.pdf" || len(got.Attachments) != 1 {
+		t.Fatalf("unexpected attachment %q: %v", rel, got.Attachments)
+	}
+	b, err := os.ReadFile(filepath.Join(w.Root, filepath.FromSlash(rel)))
+	if err != nil || string(b) != "%PDF\x00\xff" {
+		t.Fatalf("copy differs: %q, %v", b, err)
+	}
+	// The same file again is recorded once.
+	if got, _, err = w.AttachFile(task.ID, src); err != nil || len(got.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %v, %v", got.Attachments, err)
+	}
+	// A different file with the same name gets a suffix.
+	if err := os.WriteFile(src, []byte("v2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, rel, err = w.AttachFile(task.ID, src); err != nil || rel != "attachments/"+task.ID+"/contract-2.pdf" {
+		t.Fatalf("expected suffixed name, got %q, %v", rel, err)
+	}
+	reread, err := w.GetTaskByPrefix(task.ID)
+	if err != nil || len(reread.Attachments) != 2 {
+		t.Fatalf("attachments not saved: %+v, %v", reread, err)
+	}
+	if _, _, err := w.AttachFile(task.ID, filepath.Join(t.TempDir(), "missing.txt")); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+	if _, _, err := w.AttachFile(task.ID, t.TempDir()); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid for a directory, got %v", err)
+	}
+}
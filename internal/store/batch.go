@@ -0,0 +1,66 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errBatchDryRun aborts a dry-run batch after every change was applied, so
+// the transaction rolls them back.
+var errBatchDryRun = errors.New("dry run")
+
+// MatchTasks returns every task the selector matches under filter. An empty
+// selector matches every task the filter lets through.
+func (w *Workspace) MatchTasks(selector string, filter SelectorFilter) ([]Task, error) {
+	if strings.TrimSpace(selector) != "" {
+		return w.ResolveTasks(selector, filter)
+	}
+	filter = normalizeSelectorFilter(filter)
+	tasks, err := w.ListTasks(filter.listFilter())
+	if err != nil {
+		return nil, err
+	}
+	return sortSelectorMatches(tasks), nil
+}
+
+// MoveTasks moves every task in ids to toColumnID under one journal entry:
+// either all of them move or none do. With dryRun the moves are made and
+// rolled back, and the returned tasks show what would have been written.
+func (w *Workspace) MoveTasks(ids []string, toColumnID string, opts MoveOptions, dryRun bool) ([]Task, error) {
+	return w.runBatch("mv", ids, dryRun, func(id string) (*Task, error) {
+		return w.MoveTaskWith(id, toColumnID, opts)
+	})
+}
+
+// EditTasks applies patch to every task in ids, all or nothing, like
+// MoveTasks.
+func (w *Workspace) EditTasks(ids []string, patch TaskPatch, dryRun bool) ([]Task, error) {
+	return w.runBatch("edit", ids, dryRun, func(id string) (*Task, error) {
+		return w.EditTask(id, patch)
+	})
+}
+
+func (w *Workspace) runBatch(op string, ids []string, dryRun bool, fn func(id string) (*Task, error)) ([]Task, error) {
+	if len(ids) == 0 {
+		return nil, ErrNotFound
+	}
+	out := make([]Task, 0, len(ids))
+	err := w.Transaction(op, w.WriteLockTimeout(), func() error {
+		for _, id := range ids {
+			task, err := fn(id)
+			if err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			out = append(out, *task)
+		}
+		if dryRun {
+			return errBatchDryRun
+		}
+		return nil
+	})
+	if err != nil && !(dryRun && errors.Is(err, errBatchDryRun)) {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMoveTasksDryRunAndCommit(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	for _, title := range []string{"Ship login", "Ship logout", "Write docs"} {
+		if _, err := w.AddTask(AddTaskInput{Title: title, Project: "Work", Column: "todo", Tags: []string{"sprint-12"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	matches, err := w.MatchTasks("ship", SelectorFilter{Project: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	ids := []string{matches[0].ID, matches[1].ID}
+
+	moved, err := w.MoveTasks(ids, "done", MoveOptions{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 2 || moved[0].Status != "done" {
+		t.Fatalf("expected dry run to report 2 done tasks, got %+v", moved)
+	}
+	for _, id := range ids {
+		task, err := w.GetTaskByPrefix(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Column != "todo" {
+			t.Fatalf("dry run wrote %s to %s", id, task.Column)
+		}
+	}
+
+	if _, err := w.MoveTasks(ids, "done", MoveOptions{}, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range ids {
+		task, err := w.GetTaskByPrefix(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if task.Column != "done" {
+			t.Fatalf("expected %s in done, got %s", id, task.Column)
+		}
+	}
+}
+
+func TestEditTasksByTag(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.AddTask(AddTaskInput{Title: "Tagged", Project: "Work", Column: "inbox", Tags: []string{"sprint-12"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Untagged", Project: "Work", Column: "inbox"}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := w.MatchTasks("", SelectorFilter{Project: "work", Tags: OneTag("sprint-12")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Title != "Tagged" {
+		t.Fatalf("expected only the tagged task, got %+v", matches)
+	}
+	high := "high"
+	edited, err := w.EditTasks([]string{matches[0].ID}, TaskPatch{Priority: &high}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited[0].Priority != "high" {
+		t.Fatalf("expected priority high, got %q", edited[0].Priority)
+	}
+}
+
+func TestPreviewTaskWritesNothing(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.PreviewTask(AddTaskInput{Title: "Build a shed", Project: "Garden", Due: "2026-02-01", Tags: []string{"diy"}, Description: "From an idea"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Project != "garden" || task.Column != "inbox" || task.Due != "2026-02-01" || !strings.Contains(task.Body, "From an idea") {
+		t.Fatalf("unexpected preview: %+v body=%q", task.TaskMeta, task.Body)
+	}
+	if _, err := os.Stat(filepath.Join(w.Root, "projects", "garden")); !os.IsNotExist(err) {
+		t.Fatalf("expected no project directory left behind, got %v", err)
+	}
+	if tasks, _ := w.ListTasks(ListFilter{All: true}); len(tasks) != 0 {
+		t.Fatalf("preview wrote %d task(s)", len(tasks))
+	}
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBriefMaxChars bounds `tasker brief` so it can go into an agent
+	// prompt as is.
+	DefaultBriefMaxChars = 800
+	// briefTop is how many of the most urgent tasks a brief names.
+	briefTop = 3
+)
+
+// Brief is a compact status of the open tasks: counts plus the few most
+// urgent ones.
+type Brief struct {
+	Date     string      `json:"date"`
+	Project  string      `json:"project,omitempty"`
+	Open     int         `json:"open"`
+	Doing    int         `json:"doing"`
+	Blocked  int         `json:"blocked"`
+	DueToday int         `json:"due_today"`
+	Overdue  int         `json:"overdue"`
+	Top      []BriefItem `json:"top"`
+}
+
+// BriefItem is one urgent task. Ref is the shortest ID prefix that selects
+// it; Why says what makes it urgent: "overdue 3d", "due 15:00", "due today",
+// "urgent", "high" or "doing".
+type BriefItem struct {
+	ID      string `json:"id"`
+	Ref     string `json:"ref"`
+	Title   string `json:"title"`
+	Project string `json:"project"`
+	Why     string `json:"why"`
+}
+
+// Brief counts the open tasks of project ("" for all) and picks the most
+// urgent: overdue first (oldest first), then due today (earliest first),
+// then urgent and high priority, then doing.
+func (w *Workspace) Brief(project string) (*Brief, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	today := timeNow().Format("2006-01-02")
+	b := &Brief{Date: today, Project: strings.TrimSpace(project), Top: []BriefItem{}}
+	type ranked struct {
+		task Task
+		rank int
+		key  string
+		why  string
+	}
+	var candidates []ranked
+	for _, t := range tasks {
+		if !w.cfg.IsOpenStatus(t.Status) || t.NotStarted(today) {
+			continue
+		}
+		b.Open++
+		switch t.Status {
+		case "doing":
+			b.Doing++
+		case "blocked":
+			b.Blocked++
+		}
+		r := ranked{task: t, rank: -1, key: t.dueSortKey()}
+		due, dated := parseDueDate(t.Due)
+		day := due.UTC().Format("2006-01-02")
+		switch {
+		case dated && day < today:
+			b.Overdue++
+			r.rank = 0
+			start, _ := time.Parse("2006-01-02", day)
+			end, _ := time.Parse("2006-01-02", today)
+			r.why = fmt.Sprintf("overdue %dd", int(end.Sub(start).Hours()/24))
+		case dated && day == today:
+			b.DueToday++
+			r.rank = 1
+			r.why = "due today"
+			if clock := t.DueClock(); clock != "" {
+				r.why = "due " + clock
+			}
+		case normalizePriority(t.Priority) == "urgent":
+			r.rank, r.why = 2, "urgent"
+		case normalizePriority(t.Priority) == "high":
+			r.rank, r.why = 3, "high"
+		case t.Status == "doing":
+			r.rank, r.why = 4, "doing"
+		}
+		if r.rank >= 0 {
+			candidates = append(candidates, r)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, c := candidates[i], candidates[j]
+		if a.rank != c.rank {
+			return a.rank < c.rank
+		}
+		if a.key != c.key {
+			return a.key < c.key
+		}
+		return a.task.ID < c.task.ID
+	})
+	for i := 0; i < len(candidates) && i < briefTop; i++ {
+		t := candidates[i].task
+		b.Top = append(b.Top, BriefItem{ID: t.ID, Ref: shortTaskID(t.ID, tasks), Title: t.Title, Project: t.Project, Why: candidates[i].why})
+	}
+	return b, nil
+}
+
+// Render writes the brief in at most maxChars characters (no limit when
+// maxChars <= 0): a counts line, then one line per urgent task. Titles are
+// shortened first, then the last tasks dropped, to fit.
+func (b *Brief) Render(maxChars int) string {
+	head := fmt.Sprintf("%s: %d open (%d doing, %d blocked), %d due today, %d overdue", b.Date, b.Open, b.Doing, b.Blocked, b.DueToday, b.Overdue)
+	if b.Project != "" {
+		head = b.Project + " " + head
+	}
+	render := func(items []BriefItem, titleWidth int) string {
+		lines := []string{head}
+		for i, it := range items {
+			title := it.Title
+			if titleWidth > 0 {
+				title = truncate(title, titleWidth, true)
+			}
+			lines = append(lines, fmt.Sprintf("%d. [%s] %s (%s) %s", i+1, it.Why, title, it.Project, it.Ref))
+		}
+		return strings.Join(lines, "\n") + "\n"
+	}
+	out := render(b.Top, 0)
+	if maxChars <= 0 {
+		return out
+	}
+	for n := len(b.Top); n >= 0; n-- {
+		for _, width := range []int{0, 60, 40, 24} {
+			if out = render(b.Top[:n], width); len([]rune(out)) <= maxChars {
+				return out
+			}
+		}
+	}
+	return truncate(out, maxChars-1, true) + "\n"
+}
+
+// shortTaskID is the shortest prefix of id, at least 12 characters
+// ("tsk_01M532HS"), that no other of tasks shares.
+func shortTaskID(id string, tasks []Task) string {
+	n := 12
+	for _, t := range tasks {
+		if t.ID == id {
+			continue
+		}
+		for n < len(id) && strings.HasPrefix(t.ID, id[:n]) {
+			n++
+		}
+	}
+	if n >= len(id) {
+		return id
+	}
+	return id[:n]
+}
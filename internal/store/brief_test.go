@@ -0,0 +1,49 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBriefRanksAndFits(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Later", Project: "Acme", Due: "2026-02-01"},
+		{Title: "Fix login", Project: "Acme", Priority: "urgent"},
+		{Title: "Call", Project: "Acme", Due: "2026-01-20", DueTime: "15:00"},
+		{Title: "Invoice", Project: "Acme", Due: "2026-01-17"},
+		{Title: "Review", Project: "Acme", Priority: "high"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := w.Brief("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Open != 5 || b.Overdue != 1 || b.DueToday != 1 || len(b.Top) != 3 {
+		t.Fatalf("unexpected counts: %+v", b)
+	}
+	want := []string{"Invoice/overdue 3d", "Call/due 15:00", "Fix login/urgent"}
+	for i, it := range b.Top {
+		if got := it.Title + "/" + it.Why; got != want[i] {
+			t.Fatalf("item %d: got %s, want %s", i, got, want[i])
+		}
+		if !strings.HasPrefix(it.ID, it.Ref) || len(it.Ref) < 12 {
+			t.Fatalf("unexpected ref %q for %s", it.Ref, it.ID)
+		}
+	}
+	full := b.Render(0)
+	if strings.Count(full, "\n") != 4 {
+		t.Fatalf("expected a counts line and three items, got %q", full)
+	}
+	if short := b.Render(120); len([]rune(short)) > 120 || !strings.HasPrefix(short, "2026-01-20: 5 open") {
+		t.Fatalf("expected at most 120 characters, got %d: %q", len([]rune(short)), short)
+	}
+}
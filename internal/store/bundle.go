@@ -0,0 +1,241 @@
+package store
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const bundleManifestName = "manifest.json"
+
+// BundleManifest describes a project bundle (a .tgz holding manifest.json and
+// the project directory under project/). Columns are recorded so tasks can be
+// mapped onto the importing workspace's column dirs by column id.
+type BundleManifest struct {
+	Schema     int         `json:"schema"`
+	Project    Project     `json:"project"`
+	Columns    []ColumnDef `json:"columns"`
+	ExportedAt time.Time   `json:"exported_at"`
+	Tasks      int         `json:"tasks"`
+	Ideas      int         `json:"ideas"`
+}
+
+// ExportProject writes the named project (tasks in every column, ideas and
+// project.json) as a gzipped tar bundle to out.
+func (w *Workspace) ExportProject(name string, out io.Writer) (*BundleManifest, error) {
+	slug := slugify(strings.TrimSpace(name))
+	projDir := filepath.Join(w.Root, "projects", slug)
+	p, err := readProject(filepath.Join(projDir, "project.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: project not found: %s", ErrNotFound, name)
+		}
+		return nil, err
+	}
+	manifest := &BundleManifest{Schema: 1, Project: *p, Columns: w.Columns(p.Slug), ExportedAt: timeNow()}
+
+	type entry struct {
+		rel  string
+		data []byte
+	}
+	var files []entry
+	err = filepath.WalkDir(projDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(projDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		switch {
+		case strings.HasPrefix(rel, "columns/"):
+			manifest.Tasks++
+		case strings.HasPrefix(rel, "ideas/"):
+			manifest.Ideas++
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files = append(files, entry{rel: "project/" + rel, data: b})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mb, _ := json.MarshalIndent(manifest, "", "  ")
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	files = append([]entry{{rel: bundleManifestName, data: mb}}, files...)
+	for _, f := range files {
+		hdr := &tar.Header{Name: f.rel, Mode: 0o644, Size: int64(len(f.data)), ModTime: manifest.ExportedAt}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// ImportProject unpacks a bundle made by ExportProject. IDs are kept as they
+// are, so the project must not exist yet and no task ID may clash with one
+// already in the workspace. Task files land in the column dir of the same
+// column id here, even if the dirs are named differently.
+func (w *Workspace) ImportProject(in io.Reader) (*BundleManifest, error) {
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a project bundle: %v", ErrInvalid, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	var manifest *BundleManifest
+	files := map[string][]byte{}
+	var order []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: not a project bundle: %v", ErrInvalid, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Name == bundleManifestName {
+			manifest = &BundleManifest{}
+			if err := json.Unmarshal(b, manifest); err != nil {
+				return nil, fmt.Errorf("%w: bundle manifest: %v", ErrInvalid, err)
+			}
+			continue
+		}
+		files[hdr.Name] = b
+		order = append(order, hdr.Name)
+	}
+	if manifest == nil || strings.TrimSpace(manifest.Project.Slug) == "" {
+		return nil, fmt.Errorf("%w: bundle has no manifest", ErrInvalid)
+	}
+	slug := slugify(manifest.Project.Slug)
+	projDir := filepath.Join(w.Root, "projects", slug)
+	if _, err := os.Stat(projDir); err == nil {
+		return nil, fmt.Errorf("%w: project %q already exists", ErrConflict, slug)
+	}
+
+	existing, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	ids := map[string]bool{}
+	for _, t := range existing {
+		ids[t.ID] = true
+	}
+	srcCols := map[string]string{}
+	for _, c := range manifest.Columns {
+		srcCols[c.Dir] = c.ID
+	}
+	// A project that brings its own columns keeps them; otherwise its tasks
+	// map onto this workspace's columns.
+	targetCols := w.cfg.Columns
+	if len(manifest.Project.Columns) > 0 {
+		targetCols = manifest.Project.Columns
+		for _, c := range targetCols {
+			if !safeBundleName(c.Dir) {
+				return nil, fmt.Errorf("%w: bad column dir %q in bundle", ErrInvalid, c.Dir)
+			}
+		}
+	}
+	columnByID := func(id string) (ColumnDef, bool) {
+		for _, c := range targetCols {
+			if c.ID == id {
+				return c, true
+			}
+		}
+		return ColumnDef{}, false
+	}
+
+	var changes []fileChange
+	for _, name := range order {
+		rel, ok := strings.CutPrefix(name, "project/")
+		parts := strings.Split(rel, "/")
+		for _, part := range parts {
+			ok = ok && safeBundleName(part)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: bad bundle path %q", ErrInvalid, name)
+		}
+		if parts[0] == "columns" {
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("%w: bad bundle path %q", ErrInvalid, name)
+			}
+			colID, ok := srcCols[parts[1]]
+			if !ok {
+				colID = parts[1]
+			}
+			col, ok := columnByID(colID)
+			if !ok {
+				return nil, fmt.Errorf("%w: bundle uses column %q, which this workspace does not have", ErrInvalid, colID)
+			}
+			if meta, _, err := parseFrontmatter(files[name]); err == nil && ids[meta.ID] {
+				return nil, fmt.Errorf("%w: task %s already exists in this workspace", ErrConflict, meta.ID)
+			}
+			rel = path.Join("columns", col.Dir, parts[2])
+		}
+		target := filepath.Join(projDir, filepath.FromSlash(rel))
+		if !withinDir(projDir, target) {
+			return nil, fmt.Errorf("%w: bad bundle path %q", ErrInvalid, name)
+		}
+		content := string(files[name])
+		changes = append(changes, fileChange{Path: target, After: &content})
+	}
+
+	for _, c := range targetCols {
+		if err := os.MkdirAll(filepath.Join(w.projectColumnsDir(slug), c.Dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(w.projectIdeasDir(slug), 0o755); err != nil {
+		return nil, err
+	}
+	if err := w.commitChanges("project import", changes); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// safeBundleName reports whether name is a single path segment a bundle
+// may create: not empty, not "." or "..", and without separators.
+func safeBundleName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`+"\x00")
+}
+
+// withinDir reports whether p, once cleaned, lies strictly below dir.
+func withinDir(dir string, p string) bool {
+	rel, err := filepath.Rel(dir, filepath.Clean(p))
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
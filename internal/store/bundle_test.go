@@ -0,0 +1,135 @@
+package store
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectBundleRoundTripKeepsIDs(t *testing.T) {
+	src := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := src.AddTask(AddTaskInput{Title: "Hand over", Project: "Client", Column: "doing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := src.ExportProject("Client", &buf); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	bundle := buf.Bytes()
+
+	dst := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := dst.ImportProject(bytes.NewReader(bundle)); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	got, err := dst.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Column != "doing" || got.Project != "client" {
+		t.Fatalf("unexpected imported task: %s/%s", got.Project, got.Column)
+	}
+	if _, err := dst.ImportProject(bytes.NewReader(bundle)); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict on re-import, got %v", err)
+	}
+}
+
+// hostileBundle is a bundle for project "evil" with the given column dirs
+// in its manifest and one file per entry name.
+func hostileBundle(t *testing.T, columnDirs []string, names ...string) []byte {
+	t.Helper()
+	manifest := BundleManifest{Schema: 1, Project: Project{Name: "Evil", Slug: "evil"}}
+	for i, dir := range columnDirs {
+		manifest.Project.Columns = append(manifest.Project.Columns, ColumnDef{ID: fmt.Sprintf("c%d", i), Name: "C", Dir: dir})
+	}
+	mb, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	entries := append([]string{bundleManifestName}, names...)
+	for _, name := range entries {
+		data := []byte("---\nid: tsk_evil\n---\n")
+		if name == bundleManifestName {
+			data = mb
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestImportProjectRejectsPathsOutsideTheProject(t *testing.T) {
+	cases := []struct {
+		dirs  []string
+		names []string
+	}{
+		{[]string{".."}, []string{"project/columns/c0/config.json"}},
+		{[]string{"../../.."}, []string{"project/columns/c0/tsk_evil__x.md"}},
+		{[]string{"../work/columns/00-inbox"}, nil},
+		{[]string{"."}, nil},
+		{[]string{""}, nil},
+		{[]string{`..\..`}, nil},
+		{nil, []string{"project/.."}},
+		{nil, []string{"project/../config.json"}},
+		{nil, []string{"project/ideas/../../work/project.json"}},
+		{nil, []string{"project/columns/inbox/.."}},
+		{nil, []string{"project/columns/../../../config.json"}},
+		{nil, []string{"project//project.json"}},
+		{nil, []string{`project/ideas/..\..\config.json`}},
+		{nil, []string{"config.json"}},
+	}
+	for _, c := range cases {
+		w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+		if _, err := w.AddTask(AddTaskInput{Title: "Keep", Project: "Work"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.SaveConfig(w.cfg); err != nil {
+			t.Fatal(err)
+		}
+		config, err := os.ReadFile(filepath.Join(w.Root, "config.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = w.ImportProject(bytes.NewReader(hostileBundle(t, c.dirs, c.names...)))
+		if !errors.Is(err, ErrInvalid) {
+			t.Fatalf("dirs %q names %q: expected ErrInvalid, got %v", c.dirs, c.names, err)
+		}
+		if after, _ := os.ReadFile(filepath.Join(w.Root, "config.json")); !bytes.Equal(after, config) {
+			t.Fatalf("dirs %q names %q: config.json was overwritten", c.dirs, c.names)
+		}
+		if _, err := os.Stat(filepath.Join(w.Root, "projects", "evil")); !os.IsNotExist(err) {
+			t.Fatalf("dirs %q names %q: expected no project created, got %v", c.dirs, c.names, err)
+		}
+		if tasks, err := w.ListTasks(ListFilter{All: true}); err != nil || len(tasks) != 1 {
+			t.Fatalf("dirs %q names %q: expected the workspace untouched, got %d tasks (%v)", c.dirs, c.names, len(tasks), err)
+		}
+	}
+
+	// A well-formed bundle with its own columns still imports.
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.ImportProject(bytes.NewReader(hostileBundle(t, []string{"00-todo"}, "project/columns/c0/tsk_evil__x.md"))); err != nil {
+		t.Fatalf("expected a safe bundle to import, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(w.Root, "projects", "evil", "columns", "00-todo", "tsk_evil__x.md")); err != nil {
+		t.Fatal(err)
+	}
+}
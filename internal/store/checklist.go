@@ -0,0 +1,174 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+const checklistHeader = "## Checklist"
+
+// ChecklistItem is one "- [ ]"/"- [x]" line of a task's "## Checklist"
+// section. Index is 1-based, in file order.
+type ChecklistItem struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
+	Done  bool   `json:"done"`
+}
+
+// checklistSection returns the [start, end) line range of the checklist
+// section (header included), or -1, -1 when the body has none.
+func checklistSection(lines []string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if start < 0 {
+			if strings.EqualFold(trimmed, checklistHeader) {
+				start = i
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "## ") || trimmed == "##" {
+			return start, i
+		}
+	}
+	if start < 0 {
+		return -1, -1
+	}
+	return start, len(lines)
+}
+
+// parseChecklistLine reports whether line is a checklist item.
+func parseChecklistLine(line string) (string, bool, bool) {
+	trimmed := strings.TrimSpace(line)
+	for _, bullet := range []string{"- ", "* "} {
+		rest, ok := strings.CutPrefix(trimmed, bullet)
+		if !ok || len(rest) < 3 || rest[0] != '[' || rest[2] != ']' {
+			continue
+		}
+		switch rest[1] {
+		case ' ':
+			return strings.TrimSpace(rest[3:]), false, true
+		case 'x', 'X':
+			return strings.TrimSpace(rest[3:]), true, true
+		}
+	}
+	return "", false, false
+}
+
+// Checklist returns the items of the task's "## Checklist" section.
+func (t *Task) Checklist() []ChecklistItem {
+	lines := strings.Split(t.Body, "\n")
+	start, end := checklistSection(lines)
+	if start < 0 {
+		return nil
+	}
+	var items []ChecklistItem
+	for _, line := range lines[start+1 : end] {
+		if text, done, ok := parseChecklistLine(line); ok {
+			items = append(items, ChecklistItem{Index: len(items) + 1, Text: text, Done: done})
+		}
+	}
+	return items
+}
+
+// ChecklistProgress returns "done/total" (e.g. "3/5"), or "" when the task
+// has no checklist items.
+func (t *Task) ChecklistProgress() string {
+	items := t.Checklist()
+	if len(items) == 0 {
+		return ""
+	}
+	done := 0
+	for _, it := range items {
+		if it.Done {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d", done, len(items))
+}
+
+// AddChecklistItem appends an unchecked item to the task's checklist,
+// creating the section above the rest of the body when missing.
+func (w *Workspace) AddChecklistItem(prefix string, text string) (*Task, error) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return nil, fmt.Errorf("%w: checklist item text is required", ErrInvalid)
+	}
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	item := "- [ ] " + text
+	lines := strings.Split(strings.Trim(task.Body, "\n"), "\n")
+	start, end := checklistSection(lines)
+	if start < 0 {
+		section := checklistHeader + "\n\n" + item + "\n"
+		if strings.TrimSpace(task.Body) == "" {
+			task.Body = section
+		} else {
+			task.Body = section + "\n" + strings.TrimLeft(task.Body, "\n")
+		}
+	} else {
+		// Insert after the last item (or the header), before trailing blanks.
+		at := start + 1
+		for i := start + 1; i < end; i++ {
+			if strings.TrimSpace(lines[i]) != "" {
+				at = i + 1
+			}
+		}
+		if at == start+1 {
+			item = "\n" + item
+		}
+		out := append([]string{}, lines[:at]...)
+		out = append(out, item)
+		out = append(out, lines[at:]...)
+		task.Body = strings.Join(out, "\n") + "\n"
+	}
+	now := timeNow()
+	task.UpdatedAt = &now
+	if err := w.saveTask("checklist add", task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// SetChecklistItem checks (or unchecks) item n (1-based) of the task's
+// checklist.
+func (w *Workspace) SetChecklistItem(prefix string, n int, done bool) (*Task, error) {
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimLeft(task.Body, "\n"), "\n")
+	start, end := checklistSection(lines)
+	count := 0
+	if start >= 0 {
+		for i := start + 1; i < end; i++ {
+			text, _, ok := parseChecklistLine(lines[i])
+			if !ok {
+				continue
+			}
+			count++
+			if count != n {
+				continue
+			}
+			mark := "[ ]"
+			if done {
+				mark = "[x]"
+			}
+			indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+			lines[i] = indent + "- " + mark + " " + text
+			task.Body = strings.Join(lines, "\n")
+			now := timeNow()
+			task.UpdatedAt = &now
+			if err := w.saveTask("checklist", task); err != nil {
+				return nil, err
+			}
+			return task, nil
+		}
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("%w: task has no checklist items", ErrNotFound)
+	}
+	return nil, fmt.Errorf("%w: checklist item %d (task has %d)", ErrNotFound, n, count)
+}
@@ -0,0 +1,33 @@
+package store
+
+import "testing"
+
+func TestChecklistAddAndCheck(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Launch", Project: "Work", Description: "Context"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"Write copy", "Ship it", "Announce"} {
+		if _, err := w.AddChecklistItem(task.ID, text); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.SetChecklistItem(task.ID, 2, true); err != nil {
+		t.Fatal(err)
+	}
+	got, err := w.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := got.ChecklistProgress(); p != "1/3" {
+		t.Fatalf("expected progress 1/3, got %q\n%s", p, got.Body)
+	}
+	items := got.Checklist()
+	if items[1].Text != "Ship it" || !items[1].Done || items[0].Done {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if _, err := w.SetChecklistItem(task.ID, 4, true); err == nil {
+		t.Fatalf("expected out-of-range item to fail")
+	}
+}
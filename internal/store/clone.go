@@ -0,0 +1,129 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CloneOptions shape the copy CloneTask makes. Project and Column default to
+// the source's (its project's default column when the source is closed);
+// Title defaults to the source's.
+type CloneOptions struct {
+	Project string
+	Column  string
+	Title   string
+	// ResetDue drops the due date, due time and start date.
+	ResetDue bool
+	// DropNotes drops the timestamped `tasker note` entries from the body.
+	DropNotes bool
+}
+
+// CloneTask copies a task under a new ID as a fresh, open task: created now,
+// with no completion or archive time, time entries, links, dependencies,
+// external id or issue, and its checklist unticked. Priority, tags, repeat,
+// attachments and the rest of the body are kept.
+func (w *Workspace) CloneTask(prefix string, opts CloneOptions) (*Task, error) {
+	src, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	project := src.Project
+	if strings.TrimSpace(opts.Project) != "" {
+		p, err := w.GetProject(opts.Project)
+		if err != nil {
+			return nil, err
+		}
+		project = p.Slug
+	}
+	var col ColumnDef
+	var ok bool
+	switch {
+	case strings.TrimSpace(opts.Column) != "":
+		if col, ok = w.projectColumnByID(project, opts.Column); !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalid, opts.Column)
+		}
+	case project == src.Project:
+		if col, ok = w.projectColumnByID(project, src.Column); ok && !w.cfg.IsOpenStatus(col.Status) {
+			ok = false
+		}
+	}
+	if !ok {
+		if col, ok = w.defaultColumn(project); !ok {
+			return nil, fmt.Errorf("%w: project %s has no open column", ErrInvalid, project)
+		}
+	}
+	title := src.Title
+	if strings.TrimSpace(opts.Title) != "" {
+		title = strings.TrimSpace(opts.Title)
+	}
+
+	now := timeNow()
+	id := w.newItemID("tsk_")
+	clone := &Task{TaskMeta: TaskMeta{
+		Schema:      1,
+		ID:          id,
+		Title:       title,
+		Status:      col.Status,
+		Project:     project,
+		Column:      col.ID,
+		Priority:    src.Priority,
+		Tags:        append([]string{}, src.Tags...),
+		Due:         src.Due,
+		DueTime:     src.DueTime,
+		Start:       src.Start,
+		Repeat:      src.Repeat,
+		Attachments: append([]string(nil), src.Attachments...),
+		CreatedAt:   &now,
+		MovedAt:     &now,
+		UpdatedAt:   &now,
+	}, Body: cloneBody(src.Body, opts.DropNotes)}
+	if opts.ResetDue {
+		clone.Due, clone.DueTime, clone.AllDay, clone.Start = "", "", false, ""
+	}
+	clone.Path = filepath.Join(w.projectColumnsDir(project), col.Dir, fmt.Sprintf("%s__%s.md", id, slugify(title)))
+	if err := w.saveTask("clone", clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// cloneBody is body without its time entries (and note entries when
+// dropNotes), checklist items unticked, and sections left empty removed.
+func cloneBody(body string, dropNotes bool) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if _, ok := parseTimeEntry(line); ok {
+			continue
+		}
+		if dropNotes && len(taskNotes(line)) > 0 {
+			continue
+		}
+		if _, done, ok := parseChecklistLine(line); ok && done {
+			if i := strings.IndexAny(line, "xX"); i > 0 && line[i-1] == '[' {
+				line = line[:i] + " " + line[i+1:]
+			}
+		}
+		lines = append(lines, line)
+	}
+	// Drop headings whose section has nothing left in it.
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "## ") {
+			j := i + 1
+			for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+				j++
+			}
+			if j == len(lines) || strings.HasPrefix(strings.TrimSpace(lines[j]), "## ") {
+				i = j - 1
+				continue
+			}
+		}
+		out = append(out, lines[i])
+	}
+	body = strings.Trim(strings.Join(out, "\n"), "\n")
+	if body == "" {
+		return ""
+	}
+	return body + "\n"
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloneTask(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	src, err := w.AddTask(AddTaskInput{Title: "Monthly report", Project: "Work", Due: "2026-10-30", Priority: "high", Tags: []string{"ops"}, Description: "Pull the numbers."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Other", Project: "Home"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddNote(src.ID, "sent to finance"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddChecklistItem(src.ID, "Collect"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.SetChecklistItem(src.ID, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.LogTime(src.ID, 30*time.Minute, timeNow(), ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.MoveTask(src.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := w.CloneTask(src.ID, CloneOptions{DropNotes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.ID == src.ID || clone.Column != "inbox" || clone.CompletedAt != nil || clone.Due != "2026-10-30" || clone.Priority != "high" {
+		t.Fatalf("unexpected clone: %+v", clone.TaskMeta)
+	}
+	if strings.Contains(clone.Body, "finance") || strings.Contains(clone.Body, "## Time") || !strings.Contains(clone.Body, "Pull the numbers.") {
+		t.Fatalf("unexpected clone body:\n%s", clone.Body)
+	}
+	if items := clone.Checklist(); len(items) != 1 || items[0].Done {
+		t.Fatalf("checklist should be unticked: %+v", items)
+	}
+
+	moved, err := w.CloneTask(src.ID, CloneOptions{Project: "Home", Column: "todo", ResetDue: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Project != "home" || moved.Column != "todo" || moved.Due != "" || !strings.Contains(moved.Body, "finance") {
+		t.Fatalf("unexpected clone into home: %+v\n%s", moved.TaskMeta, moved.Body)
+	}
+	if _, err := w.CloneTask(src.ID, CloneOptions{Project: "Nowhere"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected unknown project, got %v", err)
+	}
+}
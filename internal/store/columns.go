@@ -0,0 +1,423 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Columns returns the column set a project (name or slug) uses: the
+// project's own columns when project.json defines them, otherwise the
+// workspace columns.
+func (w *Workspace) Columns(project string) []ColumnDef {
+	if strings.TrimSpace(project) != "" {
+		slug := slugifyOrDefault(project, project)
+		if p, err := readProject(w.projectMetaPath(slug)); err == nil && len(p.Columns) > 0 {
+			return p.Columns
+		}
+	}
+	return w.cfg.Columns
+}
+
+func (w *Workspace) projectMetaPath(projectSlug string) string {
+	return filepath.Join(w.Root, "projects", projectSlug, "project.json")
+}
+
+func (w *Workspace) projectColumnByID(projectSlug string, id string) (ColumnDef, bool) {
+	id = strings.TrimSpace(strings.ToLower(id))
+	for _, c := range w.Columns(projectSlug) {
+		if c.ID == id {
+			return c, true
+		}
+	}
+	return ColumnDef{}, false
+}
+
+func (w *Workspace) projectColumnIDByDir(projectSlug string, dir string) (string, bool) {
+	dir = strings.TrimSpace(dir)
+	for _, c := range w.Columns(projectSlug) {
+		if c.Dir == dir {
+			return c.ID, true
+		}
+	}
+	return "", false
+}
+
+// statusColumn returns the first column of a project with the given status.
+func (w *Workspace) statusColumn(projectSlug string, status string) (ColumnDef, bool) {
+	for _, c := range w.Columns(projectSlug) {
+		if c.Status == status {
+			return c, true
+		}
+	}
+	return ColumnDef{}, false
+}
+
+// defaultColumn is where new tasks land when no column is given: the
+// project's defaults.column, else inbox when the project has one, else its
+// first open column.
+func (w *Workspace) defaultColumn(projectSlug string) (ColumnDef, bool) {
+	if id := w.DefaultsFor(projectSlug).Column; id != "" {
+		if c, ok := w.projectColumnByID(projectSlug, id); ok {
+			return c, true
+		}
+	}
+	if c, ok := w.projectColumnByID(projectSlug, "inbox"); ok {
+		return c, true
+	}
+	return w.statusColumn(projectSlug, "open")
+}
+
+// KnownColumnIDs lists the workspace column ids followed by any extra ids
+// that only appear in project overrides.
+func (w *Workspace) KnownColumnIDs() []string {
+	seen := map[string]bool{}
+	var out []string
+	add := func(cols []ColumnDef) {
+		for _, c := range cols {
+			if !seen[c.ID] {
+				seen[c.ID] = true
+				out = append(out, c.ID)
+			}
+		}
+	}
+	add(w.cfg.Columns)
+	projects, _ := w.ListProjects()
+	for _, p := range projects {
+		add(p.Columns)
+	}
+	return out
+}
+
+// CompleteTask moves a task into its project's done column.
+func (w *Workspace) CompleteTask(prefix string, opts MoveOptions) (*Task, error) {
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	w.reconcileTaskFromPath(task)
+	col, ok := w.statusColumn(task.Project, "done")
+	if !ok {
+		return nil, fmt.Errorf("%w: project %s has no done column", ErrInvalid, task.Project)
+	}
+	return w.MoveTaskWith(task.ID, col.ID, opts)
+}
+
+// CompleteTasks completes every task in ids, all or nothing, like MoveTasks.
+func (w *Workspace) CompleteTasks(ids []string, opts MoveOptions, dryRun bool) ([]Task, error) {
+	return w.runBatch("mv", ids, dryRun, func(id string) (*Task, error) {
+		return w.CompleteTask(id, opts)
+	})
+}
+
+// ColumnChange edits a column set. Project is empty for the workspace
+// columns; otherwise the project's override is edited, starting from a copy
+// of the workspace columns the first time.
+type ColumnChange struct {
+	Project string
+}
+
+func (c ColumnChange) load(w *Workspace) ([]ColumnDef, *Project, error) {
+	if strings.TrimSpace(c.Project) == "" {
+		return append([]ColumnDef{}, w.cfg.Columns...), nil, nil
+	}
+	slug := slugifyOrDefault(c.Project, c.Project)
+	p, err := readProject(w.projectMetaPath(slug))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("%w: project not found: %s", ErrNotFound, c.Project)
+		}
+		return nil, nil, err
+	}
+	cols := p.Columns
+	if len(cols) == 0 {
+		cols = w.cfg.Columns
+	}
+	return append([]ColumnDef{}, cols...), p, nil
+}
+
+func (c ColumnChange) save(w *Workspace, cols []ColumnDef, p *Project) error {
+	if err := validateColumns(cols); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err := validateStatuses(w.cfg.Statuses, cols); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if p == nil {
+		cfg := w.cfg
+		cfg.Columns = cols
+		return w.SaveConfig(cfg)
+	}
+	p.Columns = cols
+	p.UpdatedAt = timeNow()
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	content := string(b)
+	return w.commitChanges("project columns", []fileChange{{Path: w.projectMetaPath(p.Slug), After: &content}})
+}
+
+// projectsUsing lists the projects whose tasks live in the edited column set.
+func (c ColumnChange) projectsUsing(w *Workspace, p *Project) ([]string, error) {
+	if p != nil {
+		return []string{p.Slug}, nil
+	}
+	projects, err := w.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, prj := range projects {
+		if len(prj.Columns) == 0 {
+			out = append(out, prj.Slug)
+		}
+	}
+	return out, nil
+}
+
+// AddColumn appends a column (or inserts it after the column id after) and
+// creates its directory in every affected project. The directory is
+// "NN-<id>", numbered after the highest existing prefix below 99.
+func (w *Workspace) AddColumn(change ColumnChange, col ColumnDef, after string) (*ColumnDef, error) {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	cols, p, err := change.load(w)
+	if err != nil {
+		return nil, err
+	}
+	col.ID = strings.TrimSpace(strings.ToLower(col.ID))
+	if col.ID == "" || slugify(col.ID) != col.ID {
+		return nil, fmt.Errorf("%w: column id must be a lowercase slug, got %q", ErrInvalid, col.ID)
+	}
+	if strings.TrimSpace(col.Status) == "" {
+		col.Status = "open"
+	}
+	if !w.cfg.KnownStatus(col.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q (use %s)", ErrInvalid, col.Status, w.cfg.StatusNames())
+	}
+	if strings.TrimSpace(col.Name) == "" {
+		col.Name = columnName(col.ID)
+	}
+	if col.Dir == "" {
+		next := 0
+		for _, c := range cols {
+			prefix, _, _ := strings.Cut(c.Dir, "-")
+			if n, err := strconv.Atoi(prefix); err == nil && n < 99 && n >= next {
+				next = n + 1
+			}
+		}
+		col.Dir = fmt.Sprintf("%02d-%s", next, col.ID)
+	}
+	at := len(cols)
+	if after = strings.TrimSpace(strings.ToLower(after)); after != "" {
+		at = -1
+		for i, c := range cols {
+			if c.ID == after {
+				at = i + 1
+			}
+		}
+		if at < 0 {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalid, after)
+		}
+	}
+	cols = append(cols[:at], append([]ColumnDef{col}, cols[at:]...)...)
+	if err := change.save(w, cols, p); err != nil {
+		return nil, err
+	}
+	slugs, err := change.projectsUsing(w, p)
+	if err != nil {
+		return nil, err
+	}
+	for _, slug := range slugs {
+		if err := os.MkdirAll(filepath.Join(w.projectColumnsDir(slug), col.Dir), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &col, nil
+}
+
+// columnName is the default display name of a column id: "in-review" is
+// "In Review". Ids are ASCII slugs, so upper-casing each word's first byte
+// is enough.
+func columnName(id string) string {
+	words := strings.Split(id, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// RemoveColumn drops a column. It refuses while any affected project still
+// has tasks in it, and never removes the last column.
+func (w *Workspace) RemoveColumn(change ColumnChange, id string) error {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	cols, p, err := change.load(w)
+	if err != nil {
+		return err
+	}
+	id = strings.TrimSpace(strings.ToLower(id))
+	idx := -1
+	for i, c := range cols {
+		if c.ID == id {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return fmt.Errorf("%w: unknown column %q", ErrNotFound, id)
+	}
+	if len(cols) == 1 {
+		return fmt.Errorf("%w: cannot remove the last column", ErrConflict)
+	}
+	removed := cols[idx]
+	slugs, err := change.projectsUsing(w, p)
+	if err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		dir := filepath.Join(w.projectColumnsDir(slug), removed.Dir)
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(strings.ToLower(e.Name()), ".md") {
+				return fmt.Errorf("%w: column %q still has tasks in project %s; move them first", ErrConflict, id, slug)
+			}
+		}
+	}
+	if err := change.save(w, append(cols[:idx], cols[idx+1:]...), p); err != nil {
+		return err
+	}
+	for _, slug := range slugs {
+		// Only empty directories go; anything else stays for the user.
+		_ = os.Remove(filepath.Join(w.projectColumnsDir(slug), removed.Dir))
+	}
+	return nil
+}
+
+// RenameColumn changes a column's display name and, when newID is set, its
+// id. The directory stays put, so task files do not move, but their column
+// frontmatter is rewritten to the new id in the same journal entry as the
+// column set.
+func (w *Workspace) RenameColumn(change ColumnChange, id string, name string, newID string) (*ColumnDef, error) {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	cols, p, err := change.load(w)
+	if err != nil {
+		return nil, err
+	}
+	id = strings.TrimSpace(strings.ToLower(id))
+	newID = strings.TrimSpace(strings.ToLower(newID))
+	if newID != "" && slugify(newID) != newID {
+		return nil, fmt.Errorf("%w: column id must be a lowercase slug, got %q", ErrInvalid, newID)
+	}
+	for i := range cols {
+		if cols[i].ID != id {
+			continue
+		}
+		if strings.TrimSpace(name) != "" {
+			cols[i].Name = strings.TrimSpace(name)
+		}
+		if newID == "" || newID == id {
+			if err := change.save(w, cols, p); err != nil {
+				return nil, err
+			}
+			return &cols[i], nil
+		}
+		cols[i].ID = newID
+		err := w.Transaction("column rename", w.WriteLockTimeout(), func() error {
+			if err := w.renameTaskColumns(change, p, cols[i].Dir, id, newID); err != nil {
+				return err
+			}
+			// Last, so a failed save rolls the task files back with it.
+			return change.save(w, cols, p)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &cols[i], nil
+	}
+	return nil, fmt.Errorf("%w: unknown column %q", ErrNotFound, id)
+}
+
+// renameTaskColumns rewrites the column frontmatter of the tasks in dir, in
+// every project using the edited column set, from id to newID.
+func (w *Workspace) renameTaskColumns(change ColumnChange, p *Project, dir string, id string, newID string) error {
+	slugs, err := change.projectsUsing(w, p)
+	if err != nil {
+		return err
+	}
+	var changes []fileChange
+	for _, slug := range slugs {
+		colDir := filepath.Join(w.projectColumnsDir(slug), dir)
+		entries, err := os.ReadDir(colDir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(strings.ToLower(e.Name()), ".md") {
+				continue
+			}
+			// Unreadable files are validate's to report, not a reason to
+			// refuse the rename.
+			t, err := readTaskFile(filepath.Join(colDir, e.Name()))
+			if err != nil || t.Column != id {
+				continue
+			}
+			t.Column = newID
+			content, err := renderTaskFile(t)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, fileChange{Path: t.Path, After: &content})
+		}
+	}
+	return w.commitChanges("column rename", changes)
+}
+
+// ReorderColumns sets the column order; ids must list every column once.
+func (w *Workspace) ReorderColumns(change ColumnChange, ids []string) ([]ColumnDef, error) {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	cols, p, err := change.load(w)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(cols) {
+		return nil, fmt.Errorf("%w: reorder needs all %d column ids, got %d", ErrInvalid, len(cols), len(ids))
+	}
+	byID := make(map[string]ColumnDef, len(cols))
+	for _, c := range cols {
+		byID[c.ID] = c
+	}
+	out := make([]ColumnDef, 0, len(cols))
+	for _, id := range ids {
+		id = strings.TrimSpace(strings.ToLower(id))
+		c, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown or repeated column %q", ErrInvalid, id)
+		}
+		delete(byID, id)
+		out = append(out, c)
+	}
+	if err := change.save(w, out, p); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProjectColumnOverride(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	// Column changes re-read config.json, so the projects must exist.
+	for _, name := range []string{"Ops", "Work"} {
+		if _, err := w.CreateProject(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	change := ColumnChange{Project: "ops"}
+	if _, err := w.AddColumn(change, ColumnDef{ID: "icebox"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.RemoveColumn(change, "inbox"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.RenameColumn(change, "done", "Shipped", "shipped"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := w.columnByID("icebox"); ok {
+		t.Fatalf("workspace columns should be untouched")
+	}
+
+	task, err := w.AddTask(AddTaskInput{Title: "Deploy", Project: "Ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Column != "todo" {
+		t.Fatalf("expected first open column without inbox, got %s", task.Column)
+	}
+	if _, err := w.MoveTask(task.ID, "icebox"); err != nil {
+		t.Fatal(err)
+	}
+	done, err := w.CompleteTask(task.ID, MoveOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done.Column != "shipped" || done.Status != "done" {
+		t.Fatalf("expected shipped/done, got %s/%s", done.Column, done.Status)
+	}
+	if err := w.RemoveColumn(change, "shipped"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict removing a column with tasks, got %v", err)
+	}
+
+	if _, err := w.AddTask(AddTaskInput{Title: "Elsewhere", Project: "Work", Column: "icebox"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected icebox to be unknown outside ops, got %v", err)
+	}
+}
+
+func TestReorderColumnsNeedsEveryID(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.ReorderColumns(ColumnChange{}, []string{"done", "inbox"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid for a partial order, got %v", err)
+	}
+	cols, err := w.ReorderColumns(ColumnChange{}, []string{"done", "inbox", "todo", "doing", "blocked", "archive"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cols[0].ID != "done" || w.Config().Columns[0].ID != "done" {
+		t.Fatalf("expected done first, got %+v", cols)
+	}
+}
+
+func TestRenameColumnIDRewritesTasks(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if err := w.SaveConfig(w.cfg); err != nil {
+		t.Fatal(err)
+	}
+	task, err := w.AddTask(AddTaskInput{Title: "Review", Project: "Work", Column: "doing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := w.RenameColumn(ColumnChange{}, "doing", "In progress", "wip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.ID != "wip" || col.Dir != "02-doing" {
+		t.Fatalf("expected wip kept in its directory, got %+v", col)
+	}
+	stored, err := readTaskFile(task.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Column != "wip" {
+		t.Fatalf("expected column frontmatter rewritten, got %s", stored.Column)
+	}
+	report, err := w.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Issues) != 0 {
+		t.Fatalf("expected a clean store after the rename, got %+v", report.Issues)
+	}
+
+	// Config and task files are one journal entry.
+	entry, err := w.Undo(false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Op != "column rename" || len(entry.Changes) != 2 {
+		t.Fatalf("expected one entry for config and task, got %s with %d changes", entry.Op, len(entry.Changes))
+	}
+	if err := w.loadOrDefaultConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := w.columnByID("doing"); !ok {
+		t.Fatalf("expected undo to restore the doing column")
+	}
+	if stored, _ := readTaskFile(task.Path); stored.Column != "doing" {
+		t.Fatalf("expected undo to restore the task column, got %s", stored.Column)
+	}
+}
+
+func TestAddColumnDefaultName(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	col, err := w.AddColumn(ColumnChange{}, ColumnDef{ID: "in-review"}, "doing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Name != "In Review" {
+		t.Fatalf("expected In Review, got %q", col.Name)
+	}
+}
+
+func TestColumnIDsAreCaseInsensitive(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Milk", Project: "Work", Column: " Doing "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Column != "doing" {
+		t.Fatalf("expected the column id as configured, got %q", task.Column)
+	}
+	if task, err = w.MoveTask(task.ID, "BLOCKED"); err != nil || task.Column != "blocked" {
+		t.Fatalf("expected a move to blocked, got %+v (%v)", task, err)
+	}
+	tasks, err := w.ListTasks(ListFilter{Column: "Blocked"})
+	if err != nil || len(tasks) != 1 || tasks[0].Column != "blocked" {
+		t.Fatalf("expected Milk listed in blocked, got %d (%v)", len(tasks), err)
+	}
+}
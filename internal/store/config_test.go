@@ -0,0 +1,77 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveConfigDetectsStaleWrites(t *testing.T) {
+	root := t.TempDir()
+	a, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Init(""); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Open(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := b.Config()
+	if err := a.SetAlias("td", "today --plain"); err != nil {
+		t.Fatal(err)
+	}
+	if v := a.Config().Version; v != 1 {
+		t.Fatalf("expected version 1, got %d", v)
+	}
+	stale.Locale = "de"
+	err = b.SaveConfig(stale)
+	if !errors.Is(err, ErrConfigChanged) || !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected a stale write to fail with ErrConfigChanged, got %v", err)
+	}
+
+	// SetAlias re-reads the config under the lock, so it merges.
+	if err := b.SetAlias("wk", "week"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := b.Config()
+	if cfg.Version != 2 || cfg.Aliases["td"] == "" || cfg.Aliases["wk"] == "" {
+		t.Fatalf("expected both aliases at version 2, got %d %v", cfg.Version, cfg.Aliases)
+	}
+}
+
+// testConfig is the default config with projects.auto_create on, so tests
+// can add tasks to projects they never created.
+func testConfig() Config {
+	cfg := defaultConfig()
+	on := true
+	cfg.Projects = &ProjectsConfig{AutoCreate: &on}
+	return cfg
+}
+
+func TestAutoCreateProjectsIsOptIn(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: defaultConfig()}
+	if _, err := w.CreateProject("Work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Typo", Project: "Wokr"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected an unknown project to fail by default, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(w.Root, "projects", "wokr")); !os.IsNotExist(err) {
+		t.Fatalf("expected no project directory for the typo, got %v", err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Known", Project: "Work"}); err != nil {
+		t.Fatalf("expected an existing project to work: %v", err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Deliberate", Project: "Garden", CreateProject: true}); err != nil {
+		t.Fatalf("expected CreateProject to create the project: %v", err)
+	}
+
+	w.cfg = testConfig()
+	if _, err := w.AddTask(AddTaskInput{Title: "Auto", Project: "Kitchen"}); err != nil {
+		t.Fatalf("expected projects.auto_create to create the project: %v", err)
+	}
+}
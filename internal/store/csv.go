@@ -0,0 +1,190 @@
+package store
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CSVFields are the task columns of a CSV export, in order. An import reads
+// the same names (headers are matched case-insensitively) unless a mapping
+// points a field at another header; id is not imported, external_id is.
+var CSVFields = []string{
+	"id", "title", "project", "column", "status", "priority", "due", "start",
+	"tags", "body", "external_id", "created_at", "completed_at",
+}
+
+// ParseCSVMapping parses "field=Header,field=Header" into a field to header
+// map, e.g. "title=Name,due=Deadline".
+func ParseCSVMapping(s string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, header, ok := strings.Cut(part, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		header = strings.TrimSpace(header)
+		if !ok || field == "" || header == "" {
+			return nil, fmt.Errorf("%w: invalid mapping %q (want field=Header)", ErrInvalid, part)
+		}
+		if !isCSVField(field) {
+			return nil, fmt.Errorf("%w: unknown field %q (want one of %s)", ErrInvalid, field, strings.Join(CSVFields, ", "))
+		}
+		out[field] = header
+	}
+	return out, nil
+}
+
+func isCSVField(field string) bool {
+	for _, f := range CSVFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCSVTags splits a tags cell on commas, semicolons or spaces.
+func splitCSVTags(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == ' ' || r == '\t'
+	})
+}
+
+// ParseCSV reads a CSV file with a header row into import items. mapping
+// renames fields to headers (see ParseCSVMapping); fields without a header
+// in the file are left empty, but a title column is required. project is
+// used for rows without a project.
+func ParseCSV(r io.Reader, mapping map[string]string, project string) ([]ImportItem, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: empty CSV", ErrInvalid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	index := map[string]int{}
+	for _, field := range CSVFields[1:] {
+		name := field
+		if h, ok := mapping[field]; ok {
+			name = h
+		}
+		for i, h := range header {
+			if strings.EqualFold(strings.TrimSpace(h), name) {
+				index[field] = i
+				break
+			}
+		}
+		if _, ok := index[field]; !ok && mapping[field] != "" {
+			return nil, fmt.Errorf("%w: no %q column for %s", ErrInvalid, mapping[field], field)
+		}
+	}
+	if _, ok := index["title"]; !ok {
+		return nil, fmt.Errorf("%w: no title column (map one with title=<header>)", ErrInvalid)
+	}
+	var items []ImportItem
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		line, _ := cr.FieldPos(0)
+		get := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		it := ImportItem{
+			Line: line,
+			Input: AddTaskInput{
+				Title:      get("title"),
+				Project:    get("project"),
+				Column:     get("column"),
+				Priority:   get("priority"),
+				Due:        get("due"),
+				Start:      get("start"),
+				Tags:       splitCSVTags(get("tags")),
+				Body:       get("body"),
+				ExternalID: get("external_id"),
+			},
+			Status:    get("status"),
+			Created:   get("created_at"),
+			Completed: get("completed_at"),
+		}
+		if it.Input.Project == "" {
+			it.Input.Project = project
+		}
+		items = append(items, it)
+	}
+	return items, nil
+}
+
+// RenderCSV renders tasks as CSV with a CSVFields header, renamed through
+// mapping, sorted by project and id so unchanged stores export identical
+// files. Tags are joined with ";" and dates are RFC3339.
+func (w *Workspace) RenderCSV(tasks []Task, mapping map[string]string) ([]byte, error) {
+	sorted := append([]Task{}, tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Project != sorted[j].Project {
+			return sorted[i].Project < sorted[j].Project
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	header := make([]string, len(CSVFields))
+	for i, field := range CSVFields {
+		header[i] = field
+		if h, ok := mapping[field]; ok {
+			header[i] = h
+		}
+	}
+	_ = cw.Write(header)
+	stamp := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	for _, t := range sorted {
+		_ = cw.Write([]string{
+			t.ID,
+			taskTitle(t.Title),
+			t.Project,
+			t.Column,
+			t.Status,
+			t.Priority,
+			t.Due,
+			t.Start,
+			strings.Join(t.Tags, ";"),
+			strings.TrimSpace(t.Body),
+			t.ExternalID,
+			stamp(t.CreatedAt),
+			stamp(t.CompletedAt),
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
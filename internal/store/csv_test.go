@@ -0,0 +1,85 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSVMapping(t *testing.T) {
+	m, err := ParseCSVMapping("title=Name, due=Deadline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["title"] != "Name" || m["due"] != "Deadline" {
+		t.Fatalf("unexpected mapping: %v", m)
+	}
+	for _, bad := range []string{"title", "owner=Who", "due="} {
+		if _, err := ParseCSVMapping(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestImportCSVWithMapping(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	src := "Name,Deadline,Labels,State,Done on\n" +
+		"\"Pay rent, twice\",2026-02-01,home;money,,\n" +
+		"File taxes,,,done,2026-01-10\n"
+	mapping, err := ParseCSVMapping("title=Name,due=Deadline,tags=Labels,status=State,completed_at=Done on")
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := ParseCSV(strings.NewReader(src), mapping, "Home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[1].Line != 3 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	tasks, err := w.ImportTasks(items, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tasks[0].Title != "Pay rent, twice" || tasks[0].Due != "2026-02-01" || len(tasks[0].Tags) != 2 {
+		t.Fatalf("unexpected first task: %+v", tasks[0].TaskMeta)
+	}
+	if tasks[1].Status != "done" || tasks[1].CompletedAt == nil || tasks[1].CompletedAt.Format("2006-01-02") != "2026-01-10" {
+		t.Fatalf("expected a task completed on 2026-01-10, got %+v", tasks[1].TaskMeta)
+	}
+
+	if _, err := ParseCSV(strings.NewReader("Task\nx\n"), nil, "Home"); err == nil {
+		t.Fatalf("expected an error without a title column")
+	}
+}
+
+func TestRenderCSVRoundTrip(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.AddTask(AddTaskInput{Title: "Write \"report\"", Project: "Work", Priority: "high", Tags: []string{"q1", "docs"}, Body: "line one\nline two"}); err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := w.ListTasks(ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := w.RenderCSV(tasks, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), strings.Join(CSVFields, ",")+"\n") {
+		t.Fatalf("unexpected header:\n%s", data)
+	}
+	items, err := ParseCSV(strings.NewReader(string(data)), nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	imported, err := other.ImportTasks(items, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, want := imported[0], tasks[0]
+	if got.Title != want.Title || got.Project != want.Project || got.Column != want.Column || got.Priority != want.Priority ||
+		strings.Join(got.Tags, ",") != strings.Join(want.Tags, ",") || strings.TrimSpace(got.Body) != strings.TrimSpace(want.Body) {
+		t.Fatalf("round trip differs:\n got %+v %q\nwant %+v %q", got.TaskMeta, got.Body, want.TaskMeta, want.Body)
+	}
+}
@@ -0,0 +1,270 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BlockedError is returned when completing a task that is still blocked by
+// open tasks. It matches ErrConflict.
+type BlockedError struct {
+	Task     *Task
+	Blockers []Task
+}
+
+func (e *BlockedError) Error() string {
+	titles := make([]string, 0, len(e.Blockers))
+	for _, b := range e.Blockers {
+		titles = append(titles, fmt.Sprintf("%s (%s)", taskTitle(b.Title), b.ID))
+	}
+	return fmt.Sprintf("conflict: %q is blocked by open task(s): %s", taskTitle(e.Task.Title), strings.Join(titles, ", "))
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// MoveOptions tweaks MoveTask.
+type MoveOptions struct {
+	// Force completes a task even when open tasks still block it.
+	Force bool
+}
+
+func (w *Workspace) tasksByID() (map[string]*Task, error) {
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+	return byID, nil
+}
+
+// OpenBlockers returns the tasks in t.BlockedBy that are not done/archived.
+// IDs that no longer resolve are ignored.
+func (w *Workspace) OpenBlockers(t *Task) ([]Task, error) {
+	if len(t.BlockedBy) == 0 {
+		return nil, nil
+	}
+	byID, err := w.tasksByID()
+	if err != nil {
+		return nil, err
+	}
+	var open []Task
+	for _, id := range t.BlockedBy {
+		if b, ok := byID[id]; ok && w.cfg.IsOpenStatus(b.Status) {
+			open = append(open, *b)
+		}
+	}
+	return open, nil
+}
+
+// AddDependency records that blocker blocks blocked (stored as blocked_by on
+// the blocked task). Self-references and cycles are rejected.
+func (w *Workspace) AddDependency(blockerPrefix string, blockedPrefix string) (*Task, error) {
+	blocker, err := w.GetTaskByPrefix(blockerPrefix)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := w.GetTaskByPrefix(blockedPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if blocker.ID == blocked.ID {
+		return nil, fmt.Errorf("%w: a task cannot block itself", ErrInvalid)
+	}
+	for _, id := range blocked.BlockedBy {
+		if id == blocker.ID {
+			return blocked, nil
+		}
+	}
+	byID, err := w.tasksByID()
+	if err != nil {
+		return nil, err
+	}
+	// blocker must not already (transitively) wait on blocked.
+	seen := map[string]bool{}
+	stack := []string{blocker.ID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == blocked.ID {
+			return nil, fmt.Errorf("%w: %q already depends on %q; adding this would create a cycle", ErrInvalid, taskTitle(blocker.Title), taskTitle(blocked.Title))
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if t, ok := byID[id]; ok {
+			stack = append(stack, t.BlockedBy...)
+		}
+	}
+	now := timeNow()
+	blocked.BlockedBy = append(blocked.BlockedBy, blocker.ID)
+	blocked.UpdatedAt = &now
+	if err := w.saveTask("dep add", blocked); err != nil {
+		return nil, err
+	}
+	return blocked, nil
+}
+
+// RemoveDependency drops blocker from blocked's blocked_by list.
+func (w *Workspace) RemoveDependency(blockerPrefix string, blockedPrefix string) (*Task, error) {
+	blocked, err := w.GetTaskByPrefix(blockedPrefix)
+	if err != nil {
+		return nil, err
+	}
+	blockerID := strings.TrimSpace(blockerPrefix)
+	if blocker, err := w.GetTaskByPrefix(blockerPrefix); err == nil {
+		blockerID = blocker.ID
+	}
+	kept := blocked.BlockedBy[:0]
+	removed := false
+	for _, id := range blocked.BlockedBy {
+		if id == blockerID {
+			removed = true
+			continue
+		}
+		kept = append(kept, id)
+	}
+	if !removed {
+		return nil, fmt.Errorf("%w: %q is not blocked by %s", ErrNotFound, taskTitle(blocked.Title), blockerID)
+	}
+	now := timeNow()
+	blocked.BlockedBy = kept
+	blocked.UpdatedAt = &now
+	if err := w.saveTask("dep rm", blocked); err != nil {
+		return nil, err
+	}
+	return blocked, nil
+}
+
+// DepNode is a task in a dependency graph.
+type DepNode struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Project string `json:"project"`
+	Column  string `json:"column"`
+	Status  string `json:"status"`
+	// Open follows the status's open_like setting.
+	Open bool `json:"open"`
+	// Blocked is true while any blocker is still open.
+	Blocked bool `json:"blocked"`
+}
+
+// DepEdge reads "From blocks To".
+type DepEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type DepGraph struct {
+	Project string    `json:"project,omitempty"`
+	Nodes   []DepNode `json:"nodes"`
+	Edges   []DepEdge `json:"edges"`
+}
+
+// DependencyGraph collects tasks that block or are blocked by another task.
+// With a project, edges are kept when either end is in it.
+func (w *Workspace) DependencyGraph(project string) (*DepGraph, error) {
+	byID, err := w.tasksByID()
+	if err != nil {
+		return nil, err
+	}
+	slug := ""
+	if strings.TrimSpace(project) != "" {
+		slug = slugifyOrDefault(project, project)
+	}
+	g := &DepGraph{Project: slug}
+	inGraph := map[string]bool{}
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		t := byID[id]
+		for _, from := range t.BlockedBy {
+			b, ok := byID[from]
+			if !ok {
+				continue
+			}
+			if slug != "" && t.Project != slug && b.Project != slug {
+				continue
+			}
+			g.Edges = append(g.Edges, DepEdge{From: from, To: id})
+			inGraph[from], inGraph[id] = true, true
+		}
+	}
+	for _, id := range ids {
+		if !inGraph[id] {
+			continue
+		}
+		t := byID[id]
+		node := DepNode{ID: t.ID, Title: t.Title, Project: t.Project, Column: t.Column, Status: t.Status, Open: w.cfg.IsOpenStatus(t.Status)}
+		for _, from := range t.BlockedBy {
+			if b, ok := byID[from]; ok && w.cfg.IsOpenStatus(b.Status) {
+				node.Blocked = true
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+	return g, nil
+}
+
+// Render draws the graph as indented trees: each task is followed by the
+// tasks it blocks. Tasks blocked by several tasks appear under each.
+func (g *DepGraph) Render(ascii bool) string {
+	var b strings.Builder
+	label := "all projects"
+	if g.Project != "" {
+		label = g.Project
+	}
+	b.WriteString(fmt.Sprintf("Dependencies (%s)\n", label))
+	if len(g.Edges) == 0 {
+		b.WriteString("(no dependencies)\n")
+		return b.String()
+	}
+	nodes := map[string]DepNode{}
+	for _, n := range g.Nodes {
+		nodes[n.ID] = n
+	}
+	children := map[string][]string{}
+	hasParent := map[string]bool{}
+	for _, e := range g.Edges {
+		children[e.From] = append(children[e.From], e.To)
+		hasParent[e.To] = true
+	}
+	arrow := "└─ "
+	if ascii {
+		arrow = "`- "
+	}
+	var walk func(id string, depth int)
+	walk = func(id string, depth int) {
+		n := nodes[id]
+		prefix := ""
+		if depth > 0 {
+			prefix = strings.Repeat("   ", depth-1) + arrow
+		}
+		state := ""
+		switch {
+		case !n.Open:
+			state = " [done]"
+		case n.Blocked:
+			state = " [blocked]"
+		}
+		b.WriteString(fmt.Sprintf("%s%s (%s/%s)%s\n", prefix, taskTitle(n.Title), n.Project, n.Column, state))
+		for _, c := range children[id] {
+			walk(c, depth+1)
+		}
+	}
+	for _, n := range g.Nodes {
+		if !hasParent[n.ID] {
+			walk(n.ID, 0)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDependencyBlocksCompletion(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	design, _ := w.AddTask(AddTaskInput{Title: "Design", Project: "Work"})
+	build, _ := w.AddTask(AddTaskInput{Title: "Build", Project: "Work"})
+	if _, err := w.AddDependency(design.ID, build.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddDependency(build.ID, design.ID); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected cycle to be rejected, got %v", err)
+	}
+	_, err := w.MoveTask(build.ID, "done")
+	var blocked *BlockedError
+	if !errors.As(err, &blocked) || !errors.Is(err, ErrConflict) || len(blocked.Blockers) != 1 {
+		t.Fatalf("expected BlockedError, got %v", err)
+	}
+	if _, err := w.MoveTask(design.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.MoveTask(build.ID, "done"); err != nil {
+		t.Fatalf("expected completion once blocker is done: %v", err)
+	}
+	g, err := w.DependencyGraph("Work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Edges) != 1 || g.Edges[0].From != design.ID || g.Edges[0].To != build.ID {
+		t.Fatalf("unexpected edges: %+v", g.Edges)
+	}
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// FieldChange is one field that differs between two versions of an item.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ItemDiff describes how a task or idea differs between a base and a target.
+type ItemDiff struct {
+	Kind   string        `json:"kind"` // task|idea
+	ID     string        `json:"id"`
+	Title  string        `json:"title"`
+	Change string        `json:"change"` // added|removed|changed
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// DiffTasks compares base against target by task ID. Bodies (notes) are only
+// compared when compareBody is set, since JSON exports do not carry them.
+func DiffTasks(base []Task, target []Task, compareBody bool) []ItemDiff {
+	baseByID := map[string]Task{}
+	for _, t := range base {
+		baseByID[t.ID] = t
+	}
+	seen := map[string]bool{}
+	var out []ItemDiff
+	for _, t := range target {
+		seen[t.ID] = true
+		old, ok := baseByID[t.ID]
+		if !ok {
+			out = append(out, ItemDiff{Kind: "task", ID: t.ID, Title: t.Title, Change: DiffAdded})
+			continue
+		}
+		var fields []FieldChange
+		fields = appendFieldChange(fields, "title", old.Title, t.Title)
+		fields = appendFieldChange(fields, "project", old.Project, t.Project)
+		fields = appendFieldChange(fields, "column", old.Column, t.Column)
+		fields = appendFieldChange(fields, "status", old.Status, t.Status)
+		fields = appendFieldChange(fields, "priority", old.Priority, t.Priority)
+		fields = appendFieldChange(fields, "due", old.Due, t.Due)
+		fields = appendFieldChange(fields, "tags", strings.Join(old.Tags, ","), strings.Join(t.Tags, ","))
+		if compareBody {
+			fields = appendFieldChange(fields, "notes", strings.TrimSpace(old.Body), strings.TrimSpace(t.Body))
+		}
+		if len(fields) > 0 {
+			out = append(out, ItemDiff{Kind: "task", ID: t.ID, Title: t.Title, Change: DiffChanged, Fields: fields})
+		}
+	}
+	for _, t := range base {
+		if !seen[t.ID] {
+			out = append(out, ItemDiff{Kind: "task", ID: t.ID, Title: t.Title, Change: DiffRemoved})
+		}
+	}
+	sortItemDiffs(out)
+	return out
+}
+
+// DiffIdeas compares base against target by idea ID.
+func DiffIdeas(base []Idea, target []Idea, compareBody bool) []ItemDiff {
+	baseByID := map[string]Idea{}
+	for _, i := range base {
+		baseByID[i.ID] = i
+	}
+	seen := map[string]bool{}
+	var out []ItemDiff
+	for _, i := range target {
+		seen[i.ID] = true
+		old, ok := baseByID[i.ID]
+		if !ok {
+			out = append(out, ItemDiff{Kind: "idea", ID: i.ID, Title: i.Title, Change: DiffAdded})
+			continue
+		}
+		var fields []FieldChange
+		fields = appendFieldChange(fields, "title", old.Title, i.Title)
+		fields = appendFieldChange(fields, "project", old.Project, i.Project)
+		fields = appendFieldChange(fields, "tags", strings.Join(old.Tags, ","), strings.Join(i.Tags, ","))
+		if compareBody {
+			fields = appendFieldChange(fields, "body", strings.TrimSpace(old.Body), strings.TrimSpace(i.Body))
+		}
+		if len(fields) > 0 {
+			out = append(out, ItemDiff{Kind: "idea", ID: i.ID, Title: i.Title, Change: DiffChanged, Fields: fields})
+		}
+	}
+	for _, i := range base {
+		if !seen[i.ID] {
+			out = append(out, ItemDiff{Kind: "idea", ID: i.ID, Title: i.Title, Change: DiffRemoved})
+		}
+	}
+	sortItemDiffs(out)
+	return out
+}
+
+func appendFieldChange(fields []FieldChange, name string, from string, to string) []FieldChange {
+	if from == to {
+		return fields
+	}
+	return append(fields, FieldChange{Field: name, From: from, To: to})
+}
+
+func sortItemDiffs(diffs []ItemDiff) {
+	order := map[string]int{DiffAdded: 0, DiffRemoved: 1, DiffChanged: 2}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Change != diffs[j].Change {
+			return order[diffs[i].Change] < order[diffs[j].Change]
+		}
+		return diffs[i].ID < diffs[j].ID
+	})
+}
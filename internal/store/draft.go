@@ -0,0 +1,58 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TaskDraft is a task described by a Markdown file: optional frontmatter
+// metadata, a "# " heading for the title, and the rest as the body.
+type TaskDraft struct {
+	Title    string   `yaml:"title"`
+	Project  string   `yaml:"project"`
+	Column   string   `yaml:"column"`
+	Due      string   `yaml:"due"`
+	Priority string   `yaml:"priority"`
+	Repeat   string   `yaml:"repeat"`
+	Tags     []string `yaml:"tags"`
+	Body     string   `yaml:"-"`
+}
+
+// ParseTaskDraft reads a Markdown draft. A frontmatter title wins over the
+// first heading; a heading used as the title is dropped from the body.
+func ParseTaskDraft(content string) (*TaskDraft, error) {
+	s := normalizeText(content)
+	draft := &TaskDraft{}
+	if strings.HasPrefix(s, "---\n") {
+		parts := strings.SplitN(s[len("---\n"):], "\n---", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: unterminated frontmatter", ErrInvalid)
+		}
+		if err := yaml.Unmarshal([]byte(parts[0]), draft); err != nil {
+			return nil, fmt.Errorf("%w: frontmatter: %v", ErrInvalid, err)
+		}
+		s = strings.TrimPrefix(parts[1], "\n")
+	}
+	lines := strings.Split(s, "\n")
+	if strings.TrimSpace(draft.Title) == "" {
+		for i, line := range lines {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if strings.HasPrefix(trimmed, "# ") {
+				draft.Title = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+				lines = lines[i+1:]
+			}
+			break
+		}
+	}
+	draft.Title = strings.TrimSpace(draft.Title)
+	if draft.Title == "" {
+		return nil, fmt.Errorf("%w: no title (add a \"# \" heading or a title in frontmatter)", ErrInvalid)
+	}
+	draft.Body = strings.Trim(strings.Join(lines, "\n"), "\n")
+	return draft, nil
+}
@@ -0,0 +1,16 @@
+package store
+
+import "testing"
+
+func TestParseTaskDraftUsesHeadingAndFrontmatter(t *testing.T) {
+	draft, err := ParseTaskDraft("---\npriority: high\ntags: [spec]\n---\n\n# Payments spec\n\n## Goals\n- retries\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Title != "Payments spec" || draft.Priority != "high" || len(draft.Tags) != 1 {
+		t.Fatalf("unexpected draft: %#v", draft)
+	}
+	if draft.Body != "## Goals\n- retries" {
+		t.Fatalf("unexpected body %q", draft.Body)
+	}
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NormalizeDueTime reads a time of day for due_time: "15:00", "9:30",
+// "3pm", "3:30pm" or "noon", returned as HH:MM. "" stays "" (all day).
+func NormalizeDueTime(value string) (string, error) {
+	v := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(value), " ", ""))
+	switch v {
+	case "":
+		return "", nil
+	case "noon":
+		return "12:00", nil
+	case "midnight":
+		return "00:00", nil
+	}
+	suffix := ""
+	if strings.HasSuffix(v, "am") || strings.HasSuffix(v, "pm") {
+		suffix, v = v[len(v)-2:], v[:len(v)-2]
+	}
+	hs, ms, hasMin := strings.Cut(v, ":")
+	h, err := strconv.Atoi(hs)
+	if err != nil || len(hs) == 0 || len(hs) > 2 {
+		return "", fmt.Errorf("%w: invalid due time %q (use HH:MM or 3pm)", ErrInvalid, value)
+	}
+	m := 0
+	if hasMin {
+		if m, err = strconv.Atoi(ms); err != nil || len(ms) != 2 || m > 59 {
+			return "", fmt.Errorf("%w: invalid due time %q (use HH:MM or 3pm)", ErrInvalid, value)
+		}
+	} else if suffix == "" {
+		// A bare number is too easily a day of the month.
+		return "", fmt.Errorf("%w: invalid due time %q (use HH:MM or 3pm)", ErrInvalid, value)
+	}
+	switch suffix {
+	case "am", "pm":
+		if h < 1 || h > 12 {
+			return "", fmt.Errorf("%w: invalid due time %q (use HH:MM or 3pm)", ErrInvalid, value)
+		}
+		h %= 12
+		if suffix == "pm" {
+			h += 12
+		}
+	default:
+		if h > 23 {
+			return "", fmt.Errorf("%w: invalid due time %q (use HH:MM or 3pm)", ErrInvalid, value)
+		}
+	}
+	return fmt.Sprintf("%02d:%02d", h, m), nil
+}
+
+// DueClock is the time of day the task is due (HH:MM): due_time, or the
+// clock of an RFC3339 due. "" for an all-day or undated task.
+func (m TaskMeta) DueClock() string {
+	if m.DueTime != "" {
+		return m.DueTime
+	}
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(m.Due)); err == nil {
+		return t.UTC().Format("15:04")
+	}
+	return ""
+}
+
+// DueLabel is the due date with its time, "2026-01-20 15:00", or just the
+// date for an all-day task.
+func (m TaskMeta) DueLabel() string {
+	due, ok := parseDueDate(m.Due)
+	if !ok {
+		return strings.TrimSpace(m.Due)
+	}
+	label := due.UTC().Format("2006-01-02")
+	if clock := m.DueClock(); clock != "" {
+		label += " " + clock
+	}
+	return label
+}
+
+// DueAt is the moment the task is due: the start of the due day for an
+// all-day task.
+func (m TaskMeta) DueAt() (time.Time, bool) {
+	due, ok := parseDueDate(m.Due)
+	if !ok {
+		return time.Time{}, false
+	}
+	day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	if clock, err := time.Parse("15:04", m.DueClock()); err == nil {
+		return day.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute), true
+	}
+	return day, true
+}
+
+// normalizeDue keeps due_time and all_day consistent with due: no time
+// without a date, and all_day set exactly when a dated task has no time.
+func (m *TaskMeta) normalizeDue() {
+	if strings.TrimSpace(m.Due) == "" {
+		m.DueTime = ""
+		m.AllDay = false
+		return
+	}
+	m.AllDay = m.DueClock() == ""
+}
+
+// dueSortKey orders tasks by due date, then all-day before timed ones,
+// then by time.
+func (m TaskMeta) dueSortKey() string {
+	due, ok := parseDueDate(m.Due)
+	if !ok {
+		return strings.TrimSpace(m.Due)
+	}
+	key := due.UTC().Format("2006-01-02")
+	if clock := m.DueClock(); clock != "" {
+		key += " " + clock
+	}
+	return key
+}
+
+// sortByDueTime orders tasks due the same day: all-day first, then by time.
+func sortByDueTime(tasks []Task) {
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueClock() < tasks[j].DueClock() })
+}
@@ -0,0 +1,303 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EditIssue is one problem in a file edited outside tasker, at a 1-based
+// line and column of that file (0 when the problem has no one place).
+type EditIssue struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+func (i EditIssue) String() string {
+	switch {
+	case i.Line > 0 && i.Column > 0:
+		return fmt.Sprintf("%d:%d: %s", i.Line, i.Column, i.Message)
+	case i.Line > 0:
+		return fmt.Sprintf("%d: %s", i.Line, i.Message)
+	}
+	return i.Message
+}
+
+// EditError rejects an external edit. Path is the file that was left as it
+// was; Rejected holds the edited content for another try.
+type EditError struct {
+	Path     string
+	Rejected string
+	Issues   []EditIssue
+}
+
+func (e *EditError) Error() string {
+	msgs := make([]string, 0, len(e.Issues))
+	for _, i := range e.Issues {
+		msgs = append(msgs, i.String())
+	}
+	return fmt.Sprintf("invalid edit of %s (kept in %s): %s", filepath.Base(e.Path), e.Rejected, strings.Join(msgs, "; "))
+}
+
+func (e *EditError) Unwrap() error { return ErrInvalid }
+
+// RejectPath is where a rejected edit of path is kept: path + ".rej".
+func RejectPath(path string) string {
+	return path + ".rej"
+}
+
+var yamlLineRe = regexp.MustCompile(`^line (\d+): (.*)$`)
+
+// CheckTaskContent re-parses an edited task file and lists what is wrong
+// with it. was is the task before the edit: its id, project and column are
+// tied to the file's place on disk and cannot change here.
+func CheckTaskContent(content []byte, was *Task) []EditIssue {
+	s := normalizeText(string(content))
+	if !strings.HasPrefix(s, "---\n") {
+		return []EditIssue{{Line: 1, Message: "missing frontmatter (the file must start with a --- line)"}}
+	}
+	parts := strings.SplitN(s, "\n---\n", 2)
+	if len(parts) != 2 {
+		return []EditIssue{{Line: 1, Message: "frontmatter is not closed (no --- line after it)"}}
+	}
+	yamlPart := strings.TrimPrefix(parts[0], "---\n")
+	// The YAML starts on line 2 of the file.
+	const offset = 1
+	var meta TaskMeta
+	if err := yaml.Unmarshal([]byte(yamlPart), &meta); err != nil {
+		return yamlIssues(err, offset)
+	}
+	at := func(key string) int {
+		if line := yamlKeyLine(yamlPart, key); line > 0 {
+			return line + offset
+		}
+		return 0
+	}
+	var issues []EditIssue
+	if strings.TrimSpace(meta.Title) == "" {
+		issues = append(issues, EditIssue{Line: at("title"), Message: "title is required"})
+	}
+	if was != nil {
+		if meta.ID != was.ID {
+			issues = append(issues, EditIssue{Line: at("id"), Message: fmt.Sprintf("id must stay %s", was.ID)})
+		}
+		if meta.Project != was.Project {
+			issues = append(issues, EditIssue{Line: at("project"), Message: fmt.Sprintf("project must stay %s (use tasker mv --to-project)", was.Project)})
+		}
+		if meta.Column != was.Column {
+			issues = append(issues, EditIssue{Line: at("column"), Message: fmt.Sprintf("column must stay %s (use tasker mv)", was.Column)})
+		}
+	}
+	if due := strings.TrimSpace(meta.Due); due != "" {
+		if _, ok := parseDueDate(due); !ok {
+			issues = append(issues, EditIssue{Line: at("due"), Message: fmt.Sprintf("due %q is not YYYY-MM-DD or RFC3339", due)})
+		}
+	}
+	if _, err := NormalizeDueTime(meta.DueTime); err != nil {
+		issues = append(issues, EditIssue{Line: at("due_time"), Message: fmt.Sprintf("due_time %q is not HH:MM", meta.DueTime)})
+	}
+	if start := strings.TrimSpace(meta.Start); start != "" {
+		if _, ok := parseDueDate(start); !ok {
+			issues = append(issues, EditIssue{Line: at("start"), Message: fmt.Sprintf("start %q is not YYYY-MM-DD", start)})
+		}
+	}
+	if _, err := NormalizeRepeat(meta.Repeat); err != nil {
+		issues = append(issues, EditIssue{Line: at("repeat"), Message: strings.TrimPrefix(err.Error(), ErrInvalid.Error()+": ")})
+	}
+	return issues
+}
+
+// yamlParserProblems are the yaml.v3 parser (not scanner) errors. yaml.v3
+// reports those at the 0-based line where the broken construct starts, and
+// leaves the line out when that is the first one.
+var yamlParserProblems = map[string]bool{
+	"did not find expected ',' or ']'":       true,
+	"did not find expected ',' or '}'":       true,
+	"did not find expected '-' indicator":    true,
+	"did not find expected <document start>": true,
+	"did not find expected key":              true,
+	"did not find expected node content":     true,
+}
+
+// yamlIssues turns a yaml.v3 error into 1-based lines of the file, offset
+// being the number of file lines before the YAML.
+func yamlIssues(err error, offset int) []EditIssue {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		issues := make([]EditIssue, 0, len(typeErr.Errors))
+		for _, e := range typeErr.Errors {
+			if m := yamlLineRe.FindStringSubmatch(e); m != nil {
+				line, _ := strconv.Atoi(m[1])
+				issues = append(issues, EditIssue{Line: line + offset, Message: m[2]})
+				continue
+			}
+			issues = append(issues, EditIssue{Message: e})
+		}
+		return issues
+	}
+	msg := strings.TrimPrefix(err.Error(), "yaml: ")
+	line := 0
+	if m := yamlLineRe.FindStringSubmatch(msg); m != nil {
+		line, _ = strconv.Atoi(m[1])
+		msg = m[2]
+	}
+	if yamlParserProblems[msg] {
+		line++
+	}
+	if line == 0 {
+		return []EditIssue{{Message: msg}}
+	}
+	return []EditIssue{{Line: line + offset, Message: msg}}
+}
+
+// yamlKeyLine is the 1-based line of a top-level key in a YAML document.
+func yamlKeyLine(doc, key string) int {
+	for i, line := range strings.Split(doc, "\n") {
+		if strings.HasPrefix(line, key+":") {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// CheckConfigContent re-parses an edited config.json and lists what is
+// wrong with it.
+func CheckConfigContent(content []byte) []EditIssue {
+	var cfg Config
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax):
+			line, col := offsetPosition(content, syntax.Offset)
+			return []EditIssue{{Line: line, Column: col, Message: syntax.Error()}}
+		case errors.As(err, &typ):
+			line, col := offsetPosition(content, typ.Offset)
+			return []EditIssue{{Line: line, Column: col, Message: fmt.Sprintf("%s must be %s, not %s", typ.Field, typ.Type, typ.Value)}}
+		}
+		return []EditIssue{{Message: err.Error()}}
+	}
+	var issues []EditIssue
+	if len(cfg.Columns) > 0 {
+		if err := validateColumns(cfg.Columns); err != nil {
+			issues = append(issues, EditIssue{Line: jsonKeyLine(content, "columns"), Message: err.Error()})
+		}
+	}
+	if err := validateStatuses(cfg.Statuses, cfg.Columns); err != nil {
+		issues = append(issues, EditIssue{Line: jsonKeyLine(content, "statuses"), Message: err.Error()})
+	}
+	return issues
+}
+
+// offsetPosition turns a byte offset into a 1-based line and column.
+func offsetPosition(content []byte, offset int64) (int, int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	before := content[:offset]
+	line := strings.Count(string(before), "\n") + 1
+	col := len(before) - strings.LastIndex(string(before), "\n")
+	return line, col
+}
+
+// jsonKeyLine is the 1-based line of the first "key": in a JSON document.
+func jsonKeyLine(content []byte, key string) int {
+	for i, line := range strings.Split(string(content), "\n") {
+		if strings.Contains(line, `"`+key+`"`) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ApplyTaskEdit replaces the task file of prefix with content edited outside
+// tasker once it re-parses. Otherwise the task is left as it was, content is
+// kept next to it as a .rej file and an *EditError lists the problems. base
+// is the file as the edit started from; when the task changed since, the
+// edit is kept the same way and ErrConflict returned. A successful edit
+// removes a leftover .rej.
+func (w *Workspace) ApplyTaskEdit(prefix string, base, content []byte) (*Task, error) {
+	unlock, err := w.Lock(w.WriteLockTimeout())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	was := task
+	if meta, _, err := parseFrontmatter(base); err == nil && (meta.Project != task.Project || meta.Column != task.Column) {
+		// The file was moved by hand and still names its old place; hold
+		// the edit to what the editor showed (tasker doctor --fix repairs it).
+		stale := *task
+		stale.Project, stale.Column = meta.Project, meta.Column
+		was = &stale
+	}
+	if issues := CheckTaskContent(content, was); len(issues) > 0 {
+		return nil, w.rejectEdit(task.Path, content, issues)
+	}
+	if current, err := os.ReadFile(task.Path); err != nil || string(current) != string(base) {
+		if err := atomicWriteFile(RejectPath(task.Path), content, 0o644); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: the task changed while it was being edited (your edit is kept in %s)", ErrConflict, RejectPath(task.Path))
+	}
+	text := normalizeText(string(content))
+	changes := []fileChange{{Path: task.Path, After: &text}}
+	if err := w.commitChanges("open", changes); err != nil {
+		return nil, err
+	}
+	_ = os.Remove(RejectPath(task.Path))
+	edited, err := readTaskFile(task.Path)
+	if err != nil {
+		return nil, err
+	}
+	w.reconcileTaskFromPath(edited)
+	return edited, nil
+}
+
+// ApplyConfigEdit saves config.json edited outside tasker once it
+// re-parses, like ApplyTaskEdit; etag is ConfigETag as the edit started.
+func (w *Workspace) ApplyConfigEdit(etag string, content []byte) error {
+	path := filepath.Join(w.Root, "config.json")
+	if issues := CheckConfigContent(content); len(issues) > 0 {
+		return w.rejectEdit(path, content, issues)
+	}
+	var cfg Config
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return err
+	}
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if w.cfgETag != etag {
+		if err := atomicWriteFile(RejectPath(path), content, 0o644); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w (your edit is kept in %s)", ErrConfigChanged, RejectPath(path))
+	}
+	if err := w.SaveConfig(cfg); err != nil {
+		return err
+	}
+	_ = os.Remove(RejectPath(path))
+	return nil
+}
+
+func (w *Workspace) rejectEdit(path string, content []byte, issues []EditIssue) error {
+	rej := RejectPath(path)
+	if err := atomicWriteFile(rej, content, 0o644); err != nil {
+		return err
+	}
+	return &EditError{Path: path, Rejected: rej, Issues: issues}
+}
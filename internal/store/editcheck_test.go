@@ -0,0 +1,66 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestApplyTaskEditRejectsBadFrontmatter(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Invoice", Project: "Acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err := os.ReadFile(task.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad := strings.Replace(string(base), "title: Invoice", "title: [Invoice", 1)
+	_, err = w.ApplyTaskEdit(task.ID, base, []byte(bad))
+	var editErr *EditError
+	if !errors.As(err, &editErr) || !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected an EditError, got %v", err)
+	}
+	titleLine := 0
+	for i, line := range strings.Split(bad, "\n") {
+		if strings.HasPrefix(line, "title:") {
+			titleLine = i + 1
+		}
+	}
+	if len(editErr.Issues) == 0 || editErr.Issues[0].Line != titleLine {
+		t.Fatalf("expected an issue on line %d, got %+v", titleLine, editErr.Issues)
+	}
+	if rej, err := os.ReadFile(RejectPath(task.Path)); err != nil || string(rej) != bad {
+		t.Fatalf("expected the edit kept in .rej, got %q %v", rej, err)
+	}
+	if now, _ := os.ReadFile(task.Path); string(now) != string(base) {
+		t.Fatalf("expected the task file untouched")
+	}
+
+	moved := strings.Replace(string(base), "column: inbox", "column: done", 1)
+	if _, err := w.ApplyTaskEdit(task.ID, base, []byte(moved)); !errors.As(err, &editErr) {
+		t.Fatalf("expected a column change to be rejected, got %v", err)
+	}
+
+	good := strings.Replace(string(base), "title: Invoice", "title: Send invoice", 1)
+	saved, err := w.ApplyTaskEdit(task.ID, base, []byte(good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Title != "Send invoice" {
+		t.Fatalf("expected the new title, got %q", saved.Title)
+	}
+	if _, err := os.Stat(RejectPath(task.Path)); !os.IsNotExist(err) {
+		t.Fatalf("expected the .rej removed after a good edit, got %v", err)
+	}
+}
+
+func TestCheckConfigContentPosition(t *testing.T) {
+	issues := CheckConfigContent([]byte("{\n  \"version\": 1,\n  \"columns\": [,]\n}\n"))
+	if len(issues) != 1 || issues[0].Line != 3 || issues[0].Column == 0 {
+		t.Fatalf("expected one issue on line 3, got %+v", issues)
+	}
+}
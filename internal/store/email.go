@@ -0,0 +1,179 @@
+package store
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// EmailDraft is a task taken from a raw RFC 822 message: the subject as
+// title and the plain-text body, without quoted replies or signature.
+type EmailDraft struct {
+	Title     string
+	Body      string
+	From      string // sender address, lowercased
+	Date      string // YYYY-MM-DD, UTC
+	MessageID string
+}
+
+// Tags are the metadata tags recorded for the email: "from:<address>" and
+// "date:<YYYY-MM-DD>".
+func (d *EmailDraft) Tags() []string {
+	var tags []string
+	if d.From != "" {
+		tags = append(tags, "from:"+d.From)
+	}
+	if d.Date != "" {
+		tags = append(tags, "date:"+d.Date)
+	}
+	return tags
+}
+
+var (
+	replyPrefix = regexp.MustCompile(`(?i)^\s*((re|fwd?|aw|wg)\s*(\[\d+\])?\s*:\s*)+`)
+	wroteLine   = regexp.MustCompile(`(?i)^on .+wrote:\s*$`)
+	htmlTag     = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// ParseEmail reads one message. Multipart messages use their first
+// text/plain part (HTML with tags stripped when there is none); quoted-
+// printable and base64 bodies are decoded.
+func ParseEmail(r io.Reader) (*EmailDraft, error) {
+	msg, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("%w: email: %v", ErrInvalid, err)
+	}
+	dec := new(mime.WordDecoder)
+	draft := &EmailDraft{MessageID: strings.Trim(strings.TrimSpace(msg.Header.Get("Message-Id")), "<>")}
+	subject, err := dec.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	draft.Title = strings.TrimSpace(replyPrefix.ReplaceAllString(strings.Join(strings.Fields(subject), " "), ""))
+	if addr, err := msg.Header.AddressList("From"); err == nil && len(addr) > 0 {
+		draft.From = strings.ToLower(addr[0].Address)
+	}
+	if date, err := msg.Header.Date(); err == nil {
+		draft.Date = date.UTC().Format("2006-01-02")
+	}
+	text, html, err := emailText(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: email body: %v", ErrInvalid, err)
+	}
+	if text == "" && html != "" {
+		text = htmlTag.ReplaceAllString(html, "")
+	}
+	draft.Body = stripEmailReply(normalizeText(text))
+	if draft.Title == "" {
+		draft.Title = firstLine(draft.Body)
+	}
+	if draft.Title == "" {
+		draft.Title = "(no subject)"
+	}
+	return draft, nil
+}
+
+// emailText returns the first text/plain and text/html content found in a
+// (possibly nested multipart) body.
+func emailText(contentType string, encoding string, body io.Reader) (string, string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		var text, html string
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return "", "", err
+			}
+			if strings.HasPrefix(strings.ToLower(part.Header.Get("Content-Disposition")), "attachment") {
+				continue
+			}
+			// multipart.Reader already decodes quoted-printable parts.
+			t, h, err := emailText(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", "", err
+			}
+			if text == "" {
+				text = t
+			}
+			if html == "" {
+				html = h
+			}
+		}
+		return text, html, nil
+	}
+	if mediaType != "text/plain" && mediaType != "text/html" {
+		return "", "", nil
+	}
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, &lineJoiner{r: body})
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return "", "", err
+	}
+	if mediaType == "text/html" {
+		return "", string(b), nil
+	}
+	return string(b), "", nil
+}
+
+// lineJoiner drops line breaks so base64 bodies wrapped at 76 columns decode.
+type lineJoiner struct {
+	r io.Reader
+}
+
+func (l *lineJoiner) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	out := p[:0]
+	for _, c := range p[:n] {
+		if c != '\r' && c != '\n' {
+			out = append(out, c)
+		}
+	}
+	return len(out), err
+}
+
+// stripEmailReply drops quoted lines, everything from an "On ... wrote:"
+// or "Original Message" marker, and the signature after "-- ".
+func stripEmailReply(s string) string {
+	var kept []string
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if line == "-- " || trimmed == "--" || wroteLine.MatchString(trimmed) ||
+			strings.Contains(strings.ToLower(trimmed), "original message-----") ||
+			strings.HasPrefix(strings.ToLower(trimmed), "sent from my ") {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+	return strings.Trim(strings.Join(kept, "\n"), "\n")
+}
+
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		if t := strings.TrimSpace(line); t != "" {
+			return t
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseEmailStripsQuotesAndSignature(t *testing.T) {
+	raw := "From: Bob <BOB@example.com>\r\n" +
+		"Subject: RE: Fwd: Invoice =?UTF-8?Q?#42?=\r\n" +
+		"Date: Mon, 19 Jan 2026 23:30:00 -0500\r\n" +
+		"Message-ID: <m1@example.com>\r\n" +
+		"Content-Type: text/plain; charset=utf-8\r\n" +
+		"\r\n" +
+		"Please pay by Friday.\r\n" +
+		"\r\n" +
+		"> old thread\r\n" +
+		"-- \r\n" +
+		"Bob\r\n"
+	draft, err := ParseEmail(strings.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Title != "Invoice #42" {
+		t.Fatalf("expected reply prefixes dropped, got %q", draft.Title)
+	}
+	if draft.Body != "Please pay by Friday." {
+		t.Fatalf("expected quote and signature stripped, got %q", draft.Body)
+	}
+	tags := draft.Tags()
+	if len(tags) != 2 || tags[0] != "from:bob@example.com" || tags[1] != "date:2026-01-20" {
+		t.Fatalf("unexpected tags %v", tags)
+	}
+	if draft.MessageID != "m1@example.com" {
+		t.Fatalf("unexpected message id %q", draft.MessageID)
+	}
+}
+
+func TestParseEmail(t *testing.T) {
+	multipart := "Subject: Launch plan\n" +
+		"Content-Type: multipart/mixed; boundary=outer\n" +
+		"\n" +
+		"--outer\n" +
+		"Content-Type: multipart/alternative; boundary=inner\n" +
+		"\n" +
+		"--inner\n" +
+		"Content-Type: text/html\n" +
+		"\n" +
+		"<p>HTML copy</p>\n" +
+		"--inner\n" +
+		"Content-Type: text/plain\n" +
+		"Content-Transfer-Encoding: base64\n" +
+		"\n" +
+		"U2hpcCBvbiBNb25kYXku\n" +
+		"--inner--\n" +
+		"--outer\n" +
+		"Content-Type: text/plain\n" +
+		"Content-Disposition: attachment; filename=notes.txt\n" +
+		"\n" +
+		"not the body\n" +
+		"--outer--\n"
+	htmlOnly := "Subject: Newsletter\n" +
+		"Content-Type: multipart/alternative; boundary=b\n" +
+		"\n" +
+		"--b\n" +
+		"Content-Type: text/html\n" +
+		"\n" +
+		"<p>Read <b>this</b></p>\n" +
+		"--b--\n"
+	cases := []struct {
+		name  string
+		raw   string
+		title string
+		body  string
+		fail  bool
+	}{
+		{name: "subject and body", raw: "Subject: Book flights\n\nFor the offsite.\nTwo seats.\n", title: "Book flights", body: "For the offsite.\nTwo seats."},
+		{name: "crlf", raw: "Subject: Book flights\r\n\r\nFor the offsite.\r\nTwo seats.\r\n", title: "Book flights", body: "For the offsite.\nTwo seats."},
+		{name: "folded subject", raw: "Subject: Book\r\n  flights\r\n\r\nSoon\r\n", title: "Book flights", body: "Soon"},
+		{name: "multipart prefers text and skips attachments", raw: multipart, title: "Launch plan", body: "Ship on Monday."},
+		{name: "html only", raw: htmlOnly, title: "Newsletter", body: "Read this"},
+		{name: "no subject", raw: "From: a@example.com\n\n\nFirst line\nmore\n", title: "First line", body: "First line\nmore"},
+		{name: "no headers", raw: "\nRemember the milk\n", title: "Remember the milk", body: "Remember the milk"},
+		{name: "headers only", raw: "From: a@example.com\n", title: "(no subject)", body: ""},
+		{name: "not an email", raw: "Remember the milk\n", fail: true},
+		{name: "empty", raw: "", fail: true},
+	}
+	for _, c := range cases {
+		draft, err := ParseEmail(strings.NewReader(c.raw))
+		if c.fail {
+			if !errors.Is(err, ErrInvalid) {
+				t.Fatalf("%s: expected ErrInvalid, got %v", c.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if draft.Title != c.title || strings.TrimSpace(draft.Body) != c.body {
+			t.Fatalf("%s: got title %q body %q, want %q %q", c.name, draft.Title, draft.Body, c.title, c.body)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EscalationConfig raises the priority of neglected tasks.
+type EscalationConfig struct {
+	// AfterDaysOverdue maps a priority to the days overdue after which an
+	// open task gets it, e.g. {"high": 3, "urgent": 7}.
+	AfterDaysOverdue map[string]int `json:"after_days_overdue,omitempty"`
+}
+
+// EscalationStep is one rule of the policy: overdue at least Days days
+// means at least Priority.
+type EscalationStep struct {
+	Days     int    `json:"days"`
+	Priority string `json:"priority"`
+}
+
+// Escalation is one task whose priority the policy raises.
+type Escalation struct {
+	Task        Task   `json:"task"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	DaysOverdue int    `json:"days_overdue"`
+}
+
+// EscalationSteps is escalation.after_days_overdue, fewest days first;
+// entries with an unknown priority or no days are ignored.
+func (c Config) EscalationSteps() []EscalationStep {
+	if c.Escalation == nil {
+		return nil
+	}
+	var steps []EscalationStep
+	for p, days := range c.Escalation.AfterDaysOverdue {
+		if _, ok := priorityRank[strings.ToLower(p)]; ok && days > 0 {
+			steps = append(steps, EscalationStep{Days: days, Priority: strings.ToLower(p)})
+		}
+	}
+	sort.Slice(steps, func(i, j int) bool {
+		if steps[i].Days != steps[j].Days {
+			return steps[i].Days < steps[j].Days
+		}
+		return priorityRank[steps[i].Priority] < priorityRank[steps[j].Priority]
+	})
+	return steps
+}
+
+// ParseEscalationSteps parses a policy such as "3:high,7:urgent" (also
+// "3 -> high" or "3=high") into the map escalation.after_days_overdue holds.
+func ParseEscalationSteps(s string) (map[string]int, error) {
+	out := map[string]int{}
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		var days, priority string
+		var ok bool
+		for _, sep := range []string{"->", ":", "="} {
+			if days, priority, ok = strings.Cut(item, sep); ok {
+				break
+			}
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(days))
+		p := strings.ToLower(strings.TrimSpace(priority))
+		if _, known := priorityRank[p]; !ok || err != nil || n < 1 || !known {
+			return nil, fmt.Errorf("%w: escalation step %q (use <days>:<priority>, e.g. 3:high)", ErrInvalid, item)
+		}
+		out[p] = n
+	}
+	return out, nil
+}
+
+// FormatEscalationSteps renders steps the way ParseEscalationSteps reads
+// them, "off" when there are none.
+func FormatEscalationSteps(steps []EscalationStep) string {
+	if len(steps) == 0 {
+		return "off"
+	}
+	parts := make([]string, 0, len(steps))
+	for _, s := range steps {
+		parts = append(parts, fmt.Sprintf("%d:%s", s.Days, s.Priority))
+	}
+	return strings.Join(parts, ",")
+}
+
+// daysOverdue is how many days past its due date an open task is on
+// today (YYYY-MM-DD); 0 when it is not overdue.
+func (w *Workspace) daysOverdue(t Task, today string) int {
+	if !w.cfg.IsOpenStatus(t.Status) {
+		return 0
+	}
+	due, ok := parseDueDate(t.Due)
+	if !ok {
+		return 0
+	}
+	day, _ := time.Parse("2006-01-02", due.UTC().Format("2006-01-02"))
+	now, err := time.Parse("2006-01-02", today)
+	if err != nil || !day.Before(now) {
+		return 0
+	}
+	return int(now.Sub(day).Hours() / 24)
+}
+
+// EscalatedPriority is the priority the policy gives t today ("" when it
+// leaves t alone) and how many days overdue t is. It never lowers a
+// priority.
+func (w *Workspace) EscalatedPriority(t Task) (string, int) {
+	steps := w.cfg.EscalationSteps()
+	if len(steps) == 0 {
+		return "", 0
+	}
+	days := w.daysOverdue(t, timeNow().Format("2006-01-02"))
+	if days == 0 {
+		return "", 0
+	}
+	current := normalizePriority(t.Priority)
+	target := current
+	for _, s := range steps {
+		if days >= s.Days && priorityRank[s.Priority] > priorityRank[target] {
+			target = s.Priority
+		}
+	}
+	if target == current {
+		return "", days
+	}
+	return target, days
+}
+
+// applyEscalation raises the priority of each task the policy escalates,
+// in memory only, so views rank neglected tasks higher. EscalatedFrom keeps
+// the stored priority.
+func (w *Workspace) applyEscalation(tasks []Task) {
+	for i := range tasks {
+		if to, _ := w.EscalatedPriority(tasks[i]); to != "" {
+			tasks[i].EscalatedFrom = normalizePriority(tasks[i].Priority)
+			tasks[i].Priority = to
+		}
+	}
+}
+
+// Escalations lists the open tasks in project (a project spec; "" for all)
+// the policy would raise, most overdue first.
+func (w *Workspace) Escalations(project string) ([]Escalation, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	var out []Escalation
+	for _, t := range tasks {
+		if to, days := w.EscalatedPriority(t); to != "" {
+			out = append(out, Escalation{Task: t, From: normalizePriority(t.Priority), To: to, DaysOverdue: days})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].DaysOverdue != out[j].DaysOverdue {
+			return out[i].DaysOverdue > out[j].DaysOverdue
+		}
+		return out[i].Task.ID < out[j].Task.ID
+	})
+	return out, nil
+}
+
+// Escalate writes the priorities Escalations reports into the task files,
+// all under one journal entry.
+func (w *Workspace) Escalate(project string) ([]Escalation, error) {
+	escalations, err := w.Escalations(project)
+	if err != nil || len(escalations) == 0 {
+		return escalations, err
+	}
+	now := timeNow()
+	changes := make([]fileChange, 0, len(escalations))
+	for i := range escalations {
+		t := &escalations[i].Task
+		t.Priority = escalations[i].To
+		t.UpdatedAt = &now
+		content, err := renderTaskFile(t)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChange{Path: t.Path, After: &content})
+	}
+	if err := w.commitChanges("escalate", changes); err != nil {
+		return nil, err
+	}
+	return escalations, nil
+}
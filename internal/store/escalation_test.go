@@ -0,0 +1,67 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEscalateOverdueTasks(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	orig := timeNow
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	steps, err := ParseEscalationSteps("3 -> high, 7:urgent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.cfg.Escalation = &EscalationConfig{AfterDaysOverdue: steps}
+	if got := FormatEscalationSteps(w.cfg.EscalationSteps()); got != "3:high,7:urgent" {
+		t.Fatalf("unexpected policy: %s", got)
+	}
+	if _, err := ParseEscalationSteps("3:asap"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid step, got %v", err)
+	}
+
+	week, _ := w.AddTask(AddTaskInput{Title: "Week late", Project: "Work", Due: "2026-03-02"})
+	fewDays, _ := w.AddTask(AddTaskInput{Title: "Few days late", Project: "Work", Due: "2026-03-06"})
+	if _, err := w.AddTask(AddTaskInput{Title: "Already urgent", Project: "Work", Due: "2026-03-01", Priority: "urgent"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Yesterday", Project: "Work", Due: "2026-03-09"}); err != nil {
+		t.Fatal(err)
+	}
+
+	view, err := w.TodayView("", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	escalated := map[string]string{}
+	for _, task := range view.Sections[2].Tasks {
+		if task.EscalatedFrom != "" {
+			escalated[task.Title] = task.EscalatedFrom + "->" + task.Priority
+		}
+	}
+	if len(escalated) != 2 || escalated["Week late"] != "normal->urgent" || escalated["Few days late"] != "normal->high" {
+		t.Fatalf("unexpected escalation in view: %v", escalated)
+	}
+	if stored, _ := w.GetTaskByPrefix(week.ID); stored.Priority != "normal" {
+		t.Fatalf("views must not write priorities, got %s", stored.Priority)
+	}
+
+	done, err := w.Escalate("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != 2 || done[0].Task.ID != week.ID || done[0].DaysOverdue != 8 || done[1].Task.ID != fewDays.ID {
+		t.Fatalf("unexpected escalations: %+v", done)
+	}
+	if stored, _ := w.GetTaskByPrefix(fewDays.ID); stored.Priority != "high" {
+		t.Fatalf("expected high written, got %s", stored.Priority)
+	}
+	if again, err := w.Escalations(""); err != nil || len(again) != 0 {
+		t.Fatalf("expected nothing left to escalate: %+v, %v", again, err)
+	}
+}
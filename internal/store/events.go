@@ -0,0 +1,291 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event is one NDJSON line of the audit log at <root>/events/YYYY-MM.ndjson:
+// a change to one task made by one command. Before is nil for a created task
+// and After is nil for a deleted (trashed) one.
+type Event struct {
+	At      time.Time `json:"at"`
+	Actor   string    `json:"actor,omitempty"`
+	Command string    `json:"command"`
+	TaskID  string    `json:"task_id"`
+	// Action is create, update, move or delete.
+	Action string `json:"action"`
+	// Changed lists the frontmatter fields (yaml names) that changed, plus
+	// "body" when the Markdown body did.
+	Changed []string  `json:"changed,omitempty"`
+	Before  *TaskMeta `json:"before,omitempty"`
+	After   *TaskMeta `json:"after,omitempty"`
+}
+
+// Title is the task title after the change, or before it for a deletion.
+func (e Event) Title() string {
+	if e.After != nil {
+		return e.After.Title
+	}
+	if e.Before != nil {
+		return e.Before.Title
+	}
+	return ""
+}
+
+// Project is the task's project after the change, or before it for a
+// deletion.
+func (e Event) Project() string {
+	if e.After != nil {
+		return e.After.Project
+	}
+	if e.Before != nil {
+		return e.Before.Project
+	}
+	return ""
+}
+
+// EventFilter selects events for ListEvents. Zero fields match everything;
+// TaskID matches ids by prefix (case-insensitive).
+type EventFilter struct {
+	TaskID  string
+	Project string
+	Since   time.Time
+	Until   time.Time
+}
+
+func (w *Workspace) eventsDir() string {
+	return filepath.Join(w.Root, "events")
+}
+
+// taskEvents turns the changes of one commit into events, one per task file
+// touched. A move writes the new file and removes the old one; both sides
+// pair up into a single "move" event.
+func (w *Workspace) taskEvents(op string, changes []JournalChange) []Event {
+	type sides struct {
+		before, after *taskState
+	}
+	var order []string
+	byID := map[string]*sides{}
+	for _, c := range changes {
+		if !isTaskFilePath(c.Path) {
+			continue
+		}
+		before, after := parseTaskState(c.Before), parseTaskState(c.After)
+		id := ""
+		switch {
+		case after != nil:
+			id = after.meta.ID
+		case before != nil:
+			id = before.meta.ID
+		}
+		if id == "" {
+			continue
+		}
+		s := byID[id]
+		if s == nil {
+			s = &sides{}
+			byID[id] = s
+			order = append(order, id)
+		}
+		if before != nil && s.before == nil {
+			s.before = before
+		}
+		if after != nil {
+			s.after = after
+		}
+	}
+	at := timeNow()
+	var out []Event
+	for _, id := range order {
+		s := byID[id]
+		e := Event{At: at, Actor: w.Actor, Command: op, TaskID: id}
+		switch {
+		case s.before == nil && s.after == nil:
+			continue
+		case s.before == nil:
+			e.Action = "create"
+		case s.after == nil:
+			e.Action = "delete"
+		default:
+			e.Changed = changedTaskFields(s.before, s.after)
+			if len(e.Changed) == 0 {
+				continue
+			}
+			e.Action = "update"
+			if s.before.meta.Column != s.after.meta.Column || s.before.meta.Project != s.after.meta.Project {
+				e.Action = "move"
+			}
+		}
+		if s.before != nil {
+			e.Before = &s.before.meta
+		}
+		if s.after != nil {
+			e.After = &s.after.meta
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// taskState is a task file's frontmatter and body.
+type taskState struct {
+	meta TaskMeta
+	body string
+}
+
+func parseTaskState(content *string) *taskState {
+	if content == nil {
+		return nil
+	}
+	meta, body, err := parseFrontmatter([]byte(*content))
+	if err != nil {
+		return nil
+	}
+	return &taskState{meta: *meta, body: body}
+}
+
+// isTaskFilePath reports whether rel (slash-separated, relative to the root)
+// is a task file on a board: projects/<slug>/columns/<dir>/tsk_*.md.
+func isTaskFilePath(rel string) bool {
+	parts := strings.Split(rel, "/")
+	if len(parts) != 5 || parts[0] != "projects" || parts[2] != "columns" {
+		return false
+	}
+	name := path.Base(rel)
+	return strings.HasPrefix(name, "tsk_") && strings.HasSuffix(name, ".md")
+}
+
+// changedTaskFields compares two states field by field (updated_at is
+// ignored since every write bumps it).
+func changedTaskFields(before, after *taskState) []string {
+	var out []string
+	bv, av := reflect.ValueOf(before.meta), reflect.ValueOf(after.meta)
+	typ := bv.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "updated_at" {
+			continue
+		}
+		if !reflect.DeepEqual(bv.Field(i).Interface(), av.Field(i).Interface()) {
+			out = append(out, name)
+		}
+	}
+	if strings.TrimSpace(before.body) != strings.TrimSpace(after.body) {
+		out = append(out, "body")
+	}
+	return out
+}
+
+// appendEvents writes events to the month file of each. The audit log is
+// best effort: the store change it describes has already been committed.
+func (w *Workspace) appendEvents(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	if err := os.MkdirAll(w.eventsDir(), 0o755); err != nil {
+		return
+	}
+	byMonth := map[string][]byte{}
+	var months []string
+	for _, e := range events {
+		b, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		month := e.At.UTC().Format("2006-01")
+		if _, ok := byMonth[month]; !ok {
+			months = append(months, month)
+		}
+		byMonth[month] = append(append(byMonth[month], b...), '\n')
+	}
+	for _, month := range months {
+		f, err := os.OpenFile(filepath.Join(w.eventsDir(), month+".ndjson"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			continue
+		}
+		_, _ = f.Write(byMonth[month])
+		_ = f.Close()
+	}
+}
+
+// ListEvents reads the audit log, oldest first. Month files entirely before
+// filter.Since are skipped without being read.
+func (w *Workspace) ListEvents(filter EventFilter) ([]Event, error) {
+	entries, err := os.ReadDir(w.eventsDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	prefix := strings.ToUpper(strings.TrimSpace(filter.TaskID))
+	project := strings.TrimSpace(filter.Project)
+	var out []Event
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".ndjson") {
+			continue
+		}
+		if month, err := time.Parse("2006-01", strings.TrimSuffix(name, ".ndjson")); err == nil && !filter.Since.IsZero() && month.AddDate(0, 1, 0).Before(filter.Since) {
+			continue
+		}
+		events, err := readEventsFile(filepath.Join(w.eventsDir(), name))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			if !filter.Since.IsZero() && e.At.Before(filter.Since) {
+				continue
+			}
+			if !filter.Until.IsZero() && !e.At.Before(filter.Until) {
+				continue
+			}
+			if prefix != "" && !strings.HasPrefix(strings.ToUpper(e.TaskID), prefix) {
+				continue
+			}
+			if project != "" && !eventInProject(e, project) {
+				continue
+			}
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out, nil
+}
+
+func eventInProject(e Event, project string) bool {
+	return (e.Before != nil && e.Before.Project == project) || (e.After != nil && e.After.Project == project)
+}
+
+func readEventsFile(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var out []Event
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e Event
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			// Skip a line torn by a crash mid-append.
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, sc.Err()
+}
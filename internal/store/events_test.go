@@ -0,0 +1,67 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventsRecordTaskChanges(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig(), Actor: "agent"}
+	task, err := w.AddTask(AddTaskInput{Title: "Pay rent", Project: "Home"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.MoveTask(task.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddNote(task.ID, "paid"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.TrashTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := w.ListEvents(EventFilter{TaskID: task.ID[:10]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"create", "move", "update", "delete"}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d", len(want), len(events))
+	}
+	for i, e := range events {
+		if e.Action != want[i] || e.Actor != "agent" || e.TaskID != task.ID {
+			t.Fatalf("event %d: unexpected %+v", i, e)
+		}
+	}
+	if mv := events[1]; mv.Before.Column != "inbox" || mv.After.Column != "done" {
+		t.Fatalf("expected inbox -> done, got %s -> %s", mv.Before.Column, mv.After.Column)
+	}
+	if note := events[2]; len(note.Changed) != 1 || note.Changed[0] != "body" {
+		t.Fatalf("expected only the body changed by a note, got %v", note.Changed)
+	}
+	if del := events[3]; del.After != nil || del.Title() != "Pay rent" {
+		t.Fatalf("expected a delete with the old title, got %+v", del)
+	}
+	if recent, _ := w.ListEvents(EventFilter{Since: timeNow().Add(time.Hour)}); len(recent) != 0 {
+		t.Fatalf("expected --since to drop older events, got %d", len(recent))
+	}
+}
+
+func TestEventsSkipRolledBackTransaction(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	errStop := errors.New("stop")
+	err := w.Transaction("apply", DefaultLockTimeout, func() error {
+		if _, err := w.AddTask(AddTaskInput{Title: "Temp", Project: "Work"}); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("expected the transaction error, got %v", err)
+	}
+	if events, _ := w.ListEvents(EventFilter{}); len(events) != 0 {
+		t.Fatalf("expected no events from a rolled back transaction, got %d", len(events))
+	}
+}
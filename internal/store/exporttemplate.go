@@ -0,0 +1,167 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ExportTemplatesDir is where `export --using` looks for Go templates,
+// relative to the workspace root.
+const ExportTemplatesDir = "export-templates"
+
+// ExportPayload is the data an export template renders: everything in the
+// workspace, or in one project.
+type ExportPayload struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// Project is the slug the export is scoped to ("" for all projects).
+	Project  string      `json:"project,omitempty"`
+	Columns  []ColumnDef `json:"columns"`
+	Projects []Project   `json:"projects"`
+	Tasks    []Task      `json:"tasks"`
+	Ideas    []Idea      `json:"ideas"`
+}
+
+func (w *Workspace) exportTemplatesDir() string {
+	return filepath.Join(w.Root, ExportTemplatesDir)
+}
+
+// ExportTemplates lists the template files in export-templates/, sorted.
+func (w *Workspace) ExportTemplates() ([]string, error) {
+	entries, err := os.ReadDir(w.exportTemplatesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ExportTemplatePath resolves a template name to its file in
+// export-templates/; the .tmpl extension, and the one before it, may be
+// left off.
+func (w *Workspace) ExportTemplatePath(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w: template %q must be a file name in %s/", ErrInvalid, name, ExportTemplatesDir)
+	}
+	for _, candidate := range []string{name, name + ".tmpl"} {
+		path := filepath.Join(w.exportTemplatesDir(), candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	// "report" also finds a lone report.<ext>.tmpl.
+	names, _ := w.ExportTemplates()
+	var hits []string
+	for _, n := range names {
+		if strings.HasPrefix(n, name+".") && strings.HasSuffix(n, ".tmpl") {
+			hits = append(hits, n)
+		}
+	}
+	if len(hits) == 1 {
+		return filepath.Join(w.exportTemplatesDir(), hits[0]), nil
+	}
+	have := ""
+	if len(names) > 0 {
+		have = " (have: " + strings.Join(names, ", ") + ")"
+	}
+	return "", fmt.Errorf("%w: no template %s in %s%s", ErrNotFound, name, w.exportTemplatesDir(), have)
+}
+
+// BuildExportPayload collects the tasks, ideas and projects of project ("" for
+// all of them). all includes archived tasks.
+func (w *Workspace) BuildExportPayload(project string, all bool) (*ExportPayload, error) {
+	project = strings.TrimSpace(project)
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: all})
+	if err != nil {
+		return nil, err
+	}
+	projects, err := w.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	ideaFilter := IdeaListFilter{Scope: IdeaScopeAll}
+	slug := ""
+	if project != "" {
+		slug = Slugify(project)
+		ideaFilter = IdeaListFilter{Scope: IdeaScopeProject, Project: slug}
+		var scoped []Project
+		for _, p := range projects {
+			if p.Slug == slug {
+				scoped = append(scoped, p)
+			}
+		}
+		projects = scoped
+	}
+	ideas, err := w.ListIdeas(ideaFilter)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportPayload{
+		GeneratedAt: timeNow().UTC(),
+		Project:     slug,
+		Columns:     w.Columns(slug),
+		Projects:    projects,
+		Tasks:       tasks,
+		Ideas:       ideas,
+	}, nil
+}
+
+// exportTemplateFuncs are the helpers templates get on top of text/template's.
+var exportTemplateFuncs = template.FuncMap{
+	"join":    strings.Join,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": strings.ReplaceAll,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// date formats a time (or *time.Time, "" when nil) with a Go layout.
+	"date": func(layout string, v any) string {
+		switch t := v.(type) {
+		case time.Time:
+			return t.Format(layout)
+		case *time.Time:
+			if t != nil {
+				return t.Format(layout)
+			}
+		}
+		return ""
+	},
+}
+
+// RenderExportTemplate executes the template at path on payload. Template
+// errors carry the file, line and column text/template reports.
+func RenderExportTemplate(path string, payload *ExportPayload) ([]byte, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(filepath.Base(path)).Funcs(exportTemplateFuncs).Option("missingkey=error").Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return b.Bytes(), nil
+}
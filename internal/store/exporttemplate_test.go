@@ -0,0 +1,56 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRenderExportTemplate(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	for _, in := range []AddTaskInput{
+		{Title: "Invoice", Project: "Acme", Due: "2026-01-20", Tags: []string{"billing"}},
+		{Title: "Review", Project: "Beta"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Portal", Project: "Acme"}); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(w.Root, ExportTemplatesDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	src := `{{range .Tasks}}{{.Title}};{{join .Tags ","}};{{.Due}}
+{{end}}{{range .Ideas}}idea {{.Title}}
+{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "report.txt.tmpl"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := w.ExportTemplatePath("report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := w.BuildExportPayload("Acme", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := RenderExportTemplate(path, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(out), "Invoice;billing;2026-01-20\nidea Portal\n"; got != want {
+		t.Fatalf("unexpected output %q, want %q", got, want)
+	}
+
+	if _, err := w.ExportTemplatePath("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := w.ExportTemplatePath("../config.json"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected a path outside the directory to be rejected, got %v", err)
+	}
+}
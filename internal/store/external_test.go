@@ -0,0 +1,39 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestAddTaskExternalIDIsIdempotent(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	in := AddTaskInput{Title: "Reply to Ana", Project: "Work", ExternalID: "mail-42"}
+	first, err := w.AddTask(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Existing {
+		t.Fatalf("first add should create the task")
+	}
+	in.Title = "Reply to Ana (retry)"
+	second, err := w.AddTask(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Existing || second.ID != first.ID {
+		t.Fatalf("expected the existing task %s, got %+v", first.ID, second)
+	}
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 {
+		t.Fatalf("expected one task after retry, got %d", len(tasks))
+	}
+	got, err := w.GetTaskBySelectorFiltered("ext:mail-42", SelectorFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != first.ID {
+		t.Fatalf("ext: selector found %s, want %s", got.ID, first.ID)
+	}
+}
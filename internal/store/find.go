@@ -0,0 +1,121 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	FindKindTask = "task"
+	FindKindIdea = "idea"
+)
+
+// FindHit is one result of Find: a task (with its column and status) or an
+// idea (with its scope, root or project).
+type FindHit struct {
+	Kind    string `json:"kind"` // task|idea
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Project string `json:"project,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Scope   string `json:"scope,omitempty"`
+	Path    string `json:"path"`
+	Task    *Task  `json:"task,omitempty"`
+	Idea    *Idea  `json:"idea,omitempty"`
+}
+
+// FindOptions narrows Find. Kind is "" for both subsystems, or task|idea;
+// All includes archived tasks. With a Project, root ideas are left out.
+type FindOptions struct {
+	Project string
+	Match   string
+	Kind    string
+	All     bool
+}
+
+// Find searches tasks and ideas together with the selector match modes. In
+// auto mode the stages (exact, prefix, contains, then full-text search) run
+// across both subsystems at once, so a title that matches exactly is not
+// buried under loose hits from the other kind. A query that reads as an ID
+// matches by ID first. Tasks come before ideas.
+func (w *Workspace) Find(query string, opts FindOptions) ([]FindHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: empty query", ErrInvalid)
+	}
+	kind := strings.ToLower(strings.TrimSpace(opts.Kind))
+	switch kind {
+	case "", "all", "both":
+		kind = ""
+	case FindKindTask, "tasks":
+		kind = FindKindTask
+	case FindKindIdea, "ideas":
+		kind = FindKindIdea
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q (use task|idea)", ErrInvalid, opts.Kind)
+	}
+	taskFilter := normalizeSelectorFilter(SelectorFilter{Project: opts.Project, IncludeArchived: opts.All, Match: opts.Match})
+	ideaScope := IdeaScopeAll
+	if strings.TrimSpace(opts.Project) != "" {
+		ideaScope = IdeaScopeProject
+	}
+	ideaFilter := normalizeIdeaSelectorFilter(IdeaSelectorFilter{Project: opts.Project, Scope: ideaScope, Match: opts.Match})
+
+	var stages []func() ([]Task, []Idea, error)
+	if w.isLikelyIDSelector(query, "tsk_") || w.isLikelyIDSelector(query, "idea_") {
+		stages = append(stages, func() ([]Task, []Idea, error) {
+			tasks, err := w.findTasksByPrefixFiltered(query, taskFilter)
+			if err != nil {
+				return nil, nil, err
+			}
+			ideas, err := w.findIdeasByPrefixFiltered(query, ideaFilter)
+			return tasks, ideas, err
+		})
+	}
+	modes := []string{taskFilter.Match}
+	if taskFilter.Match == MatchAuto {
+		modes = []string{MatchExact, MatchPrefix, MatchContains, MatchSearch}
+	}
+	for _, mode := range modes {
+		tf, idf := taskFilter, ideaFilter
+		tf.Match, idf.Match = mode, mode
+		stages = append(stages, func() ([]Task, []Idea, error) {
+			tasks, err := w.findTasksByMatchMode(query, tf)
+			if err != nil {
+				return nil, nil, err
+			}
+			ideas, err := w.findIdeasByMatchMode(query, idf)
+			return tasks, ideas, err
+		})
+	}
+
+	for _, stage := range stages {
+		tasks, ideas, err := stage()
+		if err != nil {
+			return nil, err
+		}
+		var hits []FindHit
+		if kind != FindKindIdea {
+			for i := range tasks {
+				t := tasks[i]
+				hits = append(hits, FindHit{Kind: FindKindTask, ID: t.ID, Title: t.Title, Project: t.Project, Column: t.Column, Status: t.Status, Path: t.Path, Task: &t})
+			}
+		}
+		if kind != FindKindTask {
+			sortIdeaMatches(ideas)
+			for i := range ideas {
+				idea := ideas[i]
+				scope := IdeaScopeRoot
+				if idea.Project != "" {
+					scope = IdeaScopeProject
+				}
+				hits = append(hits, FindHit{Kind: FindKindIdea, ID: idea.ID, Title: idea.Title, Project: idea.Project, Scope: scope, Path: idea.Path, Idea: &idea})
+			}
+		}
+		if len(hits) > 0 {
+			return hits, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFindAcrossTasksAndIdeas(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Quarterly report", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Report bug upstream", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	idea, err := w.AddIdea(AddIdeaInput{Title: "Automate the quarterly report"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hits, err := w.Find("quarterly report", FindOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].Kind != FindKindTask || hits[0].ID != task.ID || hits[0].Column != "inbox" {
+		t.Fatalf("expected the exact task title to win, got %+v", hits)
+	}
+
+	hits, err = w.Find("quarterly", FindOptions{Match: MatchContains})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 || hits[0].Kind != FindKindTask || hits[1].Kind != FindKindIdea || hits[1].ID != idea.ID || hits[1].Scope != IdeaScopeRoot {
+		t.Fatalf("expected a task then an idea, got %+v", hits)
+	}
+
+	hits, err = w.Find("quarterly", FindOptions{Kind: "idea"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 1 || hits[0].ID != idea.ID {
+		t.Fatalf("expected only the idea, got %+v", hits)
+	}
+
+	if _, err := w.Find("x", FindOptions{Kind: "note"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for an unknown kind, got %v", err)
+	}
+}
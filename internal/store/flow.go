@@ -0,0 +1,140 @@
+package store
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultFlowDays is the window of `report burndown` and `report cfd`.
+const DefaultFlowDays = 14
+
+// FlowPoint is the state of the board at the end of one day (UTC; today's
+// point is the current state). Open counts tasks not yet done or archived;
+// Columns counts tasks per column id.
+type FlowPoint struct {
+	Date    string         `json:"date"`
+	Open    int            `json:"open"`
+	Done    int            `json:"done"`
+	Columns map[string]int `json:"columns"`
+}
+
+// FlowReport is a day-by-day series for burndown and cumulative flow charts.
+type FlowReport struct {
+	Project string `json:"project,omitempty"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+	Days    int    `json:"days"`
+	// Columns lists the column ids in board order.
+	Columns []string    `json:"columns"`
+	Points  []FlowPoint `json:"points"`
+}
+
+// Flow replays the tasks of project (a project spec; "" for all) over the
+// last days days. Where a task was on each day comes from the audit log's
+// move events; a task without them is taken to have been in its current
+// column since moved_at, and in the board's first column before that (an
+// open task) or until completed_at (a done one). Tasks in the trash are
+// left out.
+func (w *Workspace) Flow(project string, days int) (*FlowReport, error) {
+	if days <= 0 {
+		days = DefaultFlowDays
+	}
+	if days > 366 {
+		return nil, fmt.Errorf("%w: days must be at most 366", ErrInvalid)
+	}
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: true})
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	start := today.AddDate(0, 0, -(days - 1))
+	events, err := w.ListEvents(EventFilter{Since: start})
+	if err != nil {
+		return nil, err
+	}
+	moves := map[string][]Event{}
+	for _, e := range events {
+		if e.Action == "move" && e.Before != nil {
+			moves[e.TaskID] = append(moves[e.TaskID], e)
+		}
+	}
+
+	r := &FlowReport{Project: project, Start: start.Format("2006-01-02"), End: today.Format("2006-01-02"), Days: days}
+	statuses := map[string]map[string]string{}
+	firstColumn := map[string]string{}
+	seenColumn := map[string]bool{}
+	columnsOf := func(slug string) map[string]string {
+		if m, ok := statuses[slug]; ok {
+			return m
+		}
+		m := map[string]string{}
+		for i, c := range w.Columns(slug) {
+			m[c.ID] = c.Status
+			if i == 0 {
+				firstColumn[slug] = c.ID
+			}
+			if !seenColumn[c.ID] {
+				seenColumn[c.ID] = true
+				r.Columns = append(r.Columns, c.ID)
+			}
+		}
+		statuses[slug] = m
+		return m
+	}
+	for _, t := range tasks {
+		columnsOf(t.Project)
+	}
+
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		at := day.AddDate(0, 0, 1)
+		if at.After(now) {
+			at = now
+		}
+		p := FlowPoint{Date: day.Format("2006-01-02"), Columns: map[string]int{}}
+		for _, t := range tasks {
+			col, status, ok := w.flowColumnAt(t, moves[t.ID], at, columnsOf, firstColumn)
+			if !ok {
+				continue
+			}
+			if !seenColumn[col] {
+				seenColumn[col] = true
+				r.Columns = append(r.Columns, col)
+			}
+			p.Columns[col]++
+			if status == "done" || status == "archived" {
+				p.Done++
+			} else {
+				p.Open++
+			}
+		}
+		r.Points = append(r.Points, p)
+	}
+	return r, nil
+}
+
+// flowColumnAt is the column (and its status) task t was in just before at,
+// or !ok when it did not exist yet. moves are its move events, oldest first.
+func (w *Workspace) flowColumnAt(t Task, moves []Event, at time.Time, columnsOf func(string) map[string]string, firstColumn map[string]string) (string, string, bool) {
+	if t.CreatedAt != nil && !t.CreatedAt.Before(at) {
+		return "", "", false
+	}
+	if len(moves) > 0 {
+		for _, e := range moves {
+			if e.At.After(at) || e.At.Equal(at) {
+				return e.Before.Column, columnsOf(e.Before.Project)[e.Before.Column], true
+			}
+		}
+		return t.Column, t.Status, true
+	}
+	since := t.MovedAt
+	if since == nil && t.Status != "" && !w.cfg.IsOpenStatus(t.Status) {
+		since = t.CompletedAt
+	}
+	if since != nil && !since.Before(at) {
+		col := firstColumn[t.Project]
+		return col, columnsOf(t.Project)[col], true
+	}
+	return t.Column, t.Status, true
+}
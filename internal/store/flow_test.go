@@ -0,0 +1,48 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFlowReplaysMoves(t *testing.T) {
+	orig := timeNow
+	defer func() { timeNow = orig }()
+	at := func(day int) { timeNow = func() time.Time { return time.Date(2026, 3, day, 9, 0, 0, 0, time.UTC) } }
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+
+	at(1)
+	a, err := w.AddTask(AddTaskInput{Title: "Ship", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	at(2)
+	if _, err := w.AddTask(AddTaskInput{Title: "Plan", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.MoveTask(a.ID, "doing"); err != nil {
+		t.Fatal(err)
+	}
+	at(3)
+	if _, err := w.CompleteTask(a.ID, MoveOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	at(4)
+	r, err := w.Flow("work", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Start != "2026-03-01" || len(r.Points) != 4 {
+		t.Fatalf("unexpected window %s, %d points", r.Start, len(r.Points))
+	}
+	want := []struct {
+		open, done int
+		column     string
+	}{{1, 0, "inbox"}, {2, 0, "doing"}, {1, 1, "done"}, {1, 1, "done"}}
+	for i, p := range r.Points {
+		if p.Open != want[i].open || p.Done != want[i].done || p.Columns[want[i].column] != 1 {
+			t.Fatalf("day %s: expected open %d done %d and one in %s, got %+v", p.Date, want[i].open, want[i].done, want[i].column, p)
+		}
+	}
+}
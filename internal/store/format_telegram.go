@@ -9,57 +9,27 @@ import (
 	"time"
 )
 
-const telegramMaxChars = 3800
-
 func isTelegramFormat(format string) bool {
 	return strings.ToLower(strings.TrimSpace(format)) == "telegram"
 }
 
-func trimTelegramOutput(s string) string {
+// trimTelegramOutput cuts s to formats.telegram.max_chars runes.
+func (w *Workspace) trimTelegramOutput(s string) string {
+	maxChars := w.cfg.TelegramMaxChars()
 	s = strings.TrimRight(s, "\n")
 	runes := []rune(s)
-	if len(runes) <= telegramMaxChars {
+	if len(runes) <= maxChars {
 		return s
 	}
 	suffix := "\n… (truncated)"
 	suffixRunes := []rune(suffix)
-	limit := telegramMaxChars - len(suffixRunes)
+	limit := maxChars - len(suffixRunes)
 	if limit < 1 {
-		return string(runes[:telegramMaxChars])
+		return string(runes[:maxChars])
 	}
 	return string(runes[:limit]) + suffix
 }
 
-func telegramPriorityEmoji(priority string) string {
-	switch normalizePriority(priority) {
-	case "urgent", "high":
-		return "🔴"
-	case "low":
-		return "🟡"
-	default:
-		return ""
-	}
-}
-
-func telegramColumnEmoji(id string) string {
-	switch strings.ToLower(strings.TrimSpace(id)) {
-	case "inbox":
-		return "📥"
-	case "todo":
-		return "📝"
-	case "doing":
-		return "🔨"
-	case "blocked":
-		return "⛔"
-	case "done":
-		return "✅"
-	case "archive", "archived":
-		return "🗄️"
-	default:
-		return ""
-	}
-}
-
 func (w *Workspace) columnDisplayName(id string) string {
 	if col, ok := w.columnByID(id); ok {
 		name := strings.TrimSpace(col.Name)
@@ -73,9 +43,26 @@ func (w *Workspace) columnDisplayName(id string) string {
 	return strings.Title(id)
 }
 
+// columnDefLabel is telegramColumnLabel for a known column; custom ids get
+// the icon of their status.
+func (w *Workspace) columnDefLabel(c ColumnDef) string {
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = strings.Title(c.ID)
+	}
+	emoji := w.cfg.ColumnIcon(c.ID)
+	if emoji == "" {
+		emoji = w.cfg.ColumnIcon(c.Status)
+	}
+	if emoji == "" {
+		return name
+	}
+	return emoji + " " + name
+}
+
 func (w *Workspace) telegramColumnLabel(id string) string {
 	name := w.columnDisplayName(id)
-	emoji := telegramColumnEmoji(id)
+	emoji := w.cfg.ColumnIcon(id)
 	if name == "" {
 		return emoji
 	}
@@ -95,21 +82,6 @@ func cleanTaskTitle(title string) string {
 	return title
 }
 
-func formatDueShort(due string) string {
-	due = strings.TrimSpace(due)
-	if due == "" {
-		return ""
-	}
-	if t, ok := parseDueDate(due); ok {
-		now := timeNow().UTC()
-		if t.Year() == now.Year() {
-			return t.Format("Jan 02")
-		}
-		return t.Format("Jan 02 2006")
-	}
-	return due
-}
-
 func (w *Workspace) telegramContext(groupBy string, t Task) string {
 	switch groupBy {
 	case "project":
@@ -131,23 +103,33 @@ func (w *Workspace) telegramContext(groupBy string, t Task) string {
 func (w *Workspace) telegramTaskLine(t Task, context string, includeDue bool) string {
 	var b strings.Builder
 	b.WriteString("• ")
-	if pri := telegramPriorityEmoji(t.Priority); pri != "" {
+	if pri := w.cfg.PriorityIcon(t.Priority); pri != "" {
 		b.WriteString(pri)
 		b.WriteString(" ")
 	}
 	b.WriteString(cleanTaskTitle(t.Title))
+	if progress := t.ChecklistProgress(); progress != "" {
+		b.WriteString(" ")
+		b.WriteString(w.cfg.iconLabel("checklist", progress))
+	}
 	context = strings.TrimSpace(context)
 	if context != "" {
 		b.WriteString(" — ")
 		b.WriteString(context)
 	}
 	if includeDue {
-		if due := formatDueShort(t.Due); due != "" {
+		if due := w.cfg.FormatDueShort(t.Due); due != "" {
 			b.WriteString(" (due ")
 			b.WriteString(due)
+			if clock := t.DueClock(); clock != "" {
+				b.WriteString(" " + clock)
+			}
 			b.WriteString(")")
 		}
+	} else if clock := t.DueClock(); clock != "" {
+		b.WriteString(" (" + clock + ")")
 	}
+	b.WriteString(w.AgingSuffix(t, w.ASCII))
 	b.WriteString("\n")
 	return b.String()
 }
@@ -163,7 +145,7 @@ func (w *Workspace) telegramGroupHeader(groupBy, key string, count int, showTota
 		if label == "" {
 			label = "(no project)"
 		}
-		label = "📁 " + label
+		label = w.cfg.iconLabel("project", label)
 	case "column":
 		label = w.telegramColumnLabel(key)
 	}
@@ -181,10 +163,12 @@ func (w *Workspace) writeTelegramSection(b *strings.Builder, title string, tasks
 		b.WriteString(title)
 		b.WriteString("\n")
 	}
+	tasks, more := w.ViewPage.section(tasks)
 	if groupBy == "" {
 		for _, t := range tasks {
 			b.WriteString(w.telegramTaskLineForGroup(t, "", includeDue))
 		}
+		w.writeMoreLine(b, "", more)
 		b.WriteString("\n")
 		return true
 	}
@@ -192,15 +176,19 @@ func (w *Workspace) writeTelegramSection(b *strings.Builder, title string, tasks
 	for _, key := range keys {
 		b.WriteString(w.telegramGroupHeader(groupBy, key, len(grouped[key]), showTotals))
 		b.WriteString("\n")
-		for _, t := range grouped[key] {
+		shown, hidden := w.ViewPage.group(grouped[key])
+		for _, t := range shown {
 			b.WriteString(w.telegramTaskLineForGroup(t, groupBy, includeDue))
 		}
+		w.writeMoreLine(b, "", hidden)
 	}
+	w.writeMoreLine(b, "", more)
 	b.WriteString("\n")
 	return true
 }
 
-func (w *Workspace) renderTelegramBoard(project string, openOnly bool) (string, error) {
+func (w *Workspace) renderTelegramBoard(project string, openOnly bool, perColumn int, hideScheduled bool) (string, error) {
+	today := timeNow().UTC().Format("2006-01-02")
 	projectSlug := slugifyOrDefault(project, project)
 	displayName := strings.TrimSpace(project)
 	if displayName == "" {
@@ -208,8 +196,9 @@ func (w *Workspace) renderTelegramBoard(project string, openOnly bool) (string,
 	}
 
 	colTasks := map[string][]Task{}
-	for _, c := range w.cfg.Columns {
-		if openOnly && !isOpenStatus(c.Status) {
+	columns := w.Columns(projectSlug)
+	for _, c := range columns {
+		if openOnly && !w.cfg.IsOpenStatus(c.Status) {
 			continue
 		}
 		dir := filepath.Join(w.projectColumnsDir(projectSlug), c.Dir)
@@ -218,8 +207,8 @@ func (w *Workspace) renderTelegramBoard(project string, openOnly bool) (string,
 			if e.IsDir() || !strings.HasSuffix(strings.ToLower(e.Name()), ".md") {
 				continue
 			}
-			t, err := readTaskFile(filepath.Join(dir, e.Name()))
-			if err != nil {
+			t, err := w.readTaskIndexed(filepath.Join(dir, e.Name()), e)
+			if err != nil || (hideScheduled && t.NotStarted(today)) {
 				continue
 			}
 			colTasks[c.ID] = append(colTasks[c.ID], *t)
@@ -231,12 +220,14 @@ func (w *Workspace) renderTelegramBoard(project string, openOnly bool) (string,
 		}
 	}
 
+	_ = w.saveIndex()
+
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("📋 Tasks — %s\n\n", displayName))
+	b.WriteString(w.cfg.iconLabel("board", "Tasks — "+displayName) + "\n\n")
 
 	wrote := false
-	for _, c := range w.cfg.Columns {
-		if openOnly && !isOpenStatus(c.Status) {
+	for _, c := range columns {
+		if openOnly && !w.cfg.IsOpenStatus(c.Status) {
 			continue
 		}
 		tasks := colTasks[c.ID]
@@ -244,54 +235,66 @@ func (w *Workspace) renderTelegramBoard(project string, openOnly bool) (string,
 			continue
 		}
 		wrote = true
-		b.WriteString(w.telegramColumnLabel(c.ID))
+		b.WriteString(w.columnDefLabel(c))
 		b.WriteString("\n")
-		for _, t := range tasks {
+		shown := tasks
+		if perColumn > 0 && len(shown) > perColumn {
+			shown = shown[:perColumn]
+		}
+		for _, t := range shown {
 			b.WriteString(w.telegramTaskLine(t, "", true))
 		}
+		if hidden := len(tasks) - len(shown); hidden > 0 {
+			b.WriteString(fmt.Sprintf("…and %d more\n", hidden))
+		}
 		b.WriteString("\n")
 	}
 
 	if !wrote {
 		b.WriteString("No open tasks.\n")
 	}
-	return trimTelegramOutput(b.String()), nil
+	return w.trimTelegramOutput(b.String()), nil
 }
 
-func (w *Workspace) renderTelegramToday(project string, today string, dueToday []Task, overdue []Task, groupBy string, showTotals bool) string {
+func (w *Workspace) renderTelegramToday(project string, today string, dueToday []Task, dueSoon []Task, overdue []Task, groupBy string, showTotals bool) string {
 	var b strings.Builder
-	header := fmt.Sprintf("📅 Today — %s", today)
-	if len(dueToday)+len(overdue) > 0 {
-		header = fmt.Sprintf("📅 Today — %s (due %d, overdue %d)", today, len(dueToday), len(overdue))
+	header := fmt.Sprintf("Today — %s", today)
+	if len(dueToday)+len(dueSoon)+len(overdue) > 0 {
+		header = fmt.Sprintf("Today — %s (%s)", today, todayCounts(dueToday, dueSoon, overdue))
 	}
+	header = w.cfg.iconLabel("today", header)
 	b.WriteString(header)
 	b.WriteString("\n\n")
 
 	wrote := false
-	if w.writeTelegramSection(&b, "⏰ Due today", dueToday, groupBy, showTotals, false) {
+	if w.writeTelegramSection(&b, w.cfg.iconLabel("due_today", "Due today"), dueToday, groupBy, showTotals, false) {
 		wrote = true
 	}
-	if w.writeTelegramSection(&b, "⚠️ Overdue", overdue, groupBy, showTotals, true) {
+	if w.writeTelegramSection(&b, w.cfg.iconLabel("due_soon", "Due soon"), dueSoon, groupBy, showTotals, true) {
+		wrote = true
+	}
+	if w.writeTelegramSection(&b, w.cfg.iconLabel("overdue", "Overdue"), overdue, groupBy, showTotals, true) {
 		wrote = true
 	}
 
 	if !wrote {
 		b.WriteString("No tasks due.\n")
 	}
-	return trimTelegramOutput(b.String())
+	return w.trimTelegramOutput(b.String())
 }
 
 func (w *Workspace) renderTelegramAgenda(days int, start time.Time, end time.Time, overdue []Task, byDate map[string][]Task, groupBy string, showTotals bool) string {
 	var b strings.Builder
-	header := fmt.Sprintf("📅 Week — %s → %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	header := fmt.Sprintf("Week — %s → %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
 	if lenByDate(byDate)+len(overdue) > 0 {
-		header = fmt.Sprintf("📅 Week — %s → %s (due %d, overdue %d)", start.Format("2006-01-02"), end.Format("2006-01-02"), lenByDate(byDate), len(overdue))
+		header = fmt.Sprintf("Week — %s → %s (due %d, overdue %d)", start.Format("2006-01-02"), end.Format("2006-01-02"), lenByDate(byDate), len(overdue))
 	}
+	header = w.cfg.iconLabel("week", header)
 	b.WriteString(header)
 	b.WriteString("\n\n")
 
 	wrote := false
-	if w.writeTelegramSection(&b, "⚠️ Overdue", overdue, groupBy, showTotals, true) {
+	if w.writeTelegramSection(&b, w.cfg.iconLabel("overdue", "Overdue"), overdue, groupBy, showTotals, true) {
 		wrote = true
 	}
 
@@ -302,7 +305,7 @@ func (w *Workspace) renderTelegramAgenda(days int, start time.Time, end time.Tim
 		if len(items) == 0 {
 			continue
 		}
-		label := fmt.Sprintf("📆 %s (%s)", key, d.Weekday().String()[:3])
+		label := w.cfg.iconLabel("day", w.cfg.DayLabel(d))
 		if w.writeTelegramSection(&b, label, items, groupBy, showTotals, false) {
 			wrote = true
 		}
@@ -311,5 +314,245 @@ func (w *Workspace) renderTelegramAgenda(days int, start time.Time, end time.Tim
 	if !wrote {
 		b.WriteString("No upcoming tasks.\n")
 	}
-	return trimTelegramOutput(b.String())
+	return w.trimTelegramOutput(b.String())
+}
+
+// renderTelegramAgendaBuckets is the two-level (project,day / column,day)
+// telegram week view.
+func (w *Workspace) renderTelegramAgendaBuckets(days int, start time.Time, end time.Time, overdue []Task, byDate map[string][]Task, outer string, showTotals bool) string {
+	var b strings.Builder
+	header := fmt.Sprintf("Week — %s → %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if lenByDate(byDate)+len(overdue) > 0 {
+		header = fmt.Sprintf("Week — %s → %s (due %d, overdue %d)", start.Format("2006-01-02"), end.Format("2006-01-02"), lenByDate(byDate), len(overdue))
+	}
+	header = w.cfg.iconLabel("week", header)
+	b.WriteString(header)
+	b.WriteString("\n\n")
+
+	buckets := bucketAgenda(overdue, byDate, outer)
+	for _, bucket := range buckets {
+		b.WriteString(w.telegramGroupHeader(outer, bucket.key, bucket.count(), showTotals))
+		b.WriteString("\n")
+		if len(bucket.overdue) > 0 {
+			b.WriteString(w.cfg.iconLabel("overdue", "Overdue") + "\n")
+			shown, more := w.ViewPage.bucketList(bucket.overdue)
+			for _, t := range shown {
+				b.WriteString(w.telegramTaskLineForGroup(t, outer, true))
+			}
+			w.writeMoreLine(&b, "", more)
+		}
+		for i := 0; i < days; i++ {
+			d := start.AddDate(0, 0, i)
+			items := bucket.byDate[d.Format("2006-01-02")]
+			if len(items) == 0 {
+				continue
+			}
+			b.WriteString(w.cfg.iconLabel("day", w.cfg.DayLabel(d)) + "\n")
+			shown, more := w.ViewPage.bucketList(items)
+			for _, t := range shown {
+				b.WriteString(w.telegramTaskLineForGroup(t, outer, false))
+			}
+			w.writeMoreLine(&b, "", more)
+		}
+		b.WriteString("\n")
+	}
+	if len(buckets) == 0 {
+		b.WriteString("No upcoming tasks.\n")
+	}
+	return w.trimTelegramOutput(b.String())
+}
+
+// telegramCardNotes is how many of the latest notes a task card shows.
+const telegramCardNotes = 3
+
+// RenderTaskTelegram renders one task as a chat card: a title line with
+// checklist progress, a column/project/due line, tags, the latest notes and,
+// with withChecklist, the unchecked items by number so follow-ups can refer
+// to them.
+func (w *Workspace) RenderTaskTelegram(t *Task, withChecklist bool) string {
+	var b strings.Builder
+	b.WriteString(strings.TrimPrefix(strings.TrimRight(w.telegramTaskLine(*t, "", false), "\n"), "• "))
+	b.WriteString("\n")
+	label := w.telegramColumnLabel(t.Column)
+	if col, ok := w.projectColumnByID(t.Project, t.Column); ok {
+		label = w.columnDefLabel(col)
+	}
+	meta := []string{label}
+	if t.Project != "" {
+		meta = append(meta, t.Project)
+	}
+	if due := w.cfg.FormatDueShort(t.Due); due != "" {
+		meta = append(meta, "due "+due)
+	}
+	b.WriteString(strings.Join(meta, " · "))
+	b.WriteString("\n")
+	if len(t.Tags) > 0 {
+		b.WriteString("Tags: #" + strings.Join(t.Tags, " #") + "\n")
+	}
+	if notes := taskNotes(t.Body); len(notes) > 0 {
+		shown := notes
+		if len(shown) > telegramCardNotes {
+			shown = shown[len(shown)-telegramCardNotes:]
+		}
+		header := "\nNotes:\n"
+		if hidden := len(notes) - len(shown); hidden > 0 {
+			header = fmt.Sprintf("\nNotes (latest %d of %d):\n", len(shown), len(notes))
+		}
+		b.WriteString(header)
+		width := w.cfg.TelegramDetailWidth()
+		for _, n := range shown {
+			b.WriteString(fmt.Sprintf("- %s %s %s\n", w.cfg.FormatDueShort(n.At.Format("2006-01-02")), w.noteSeparator(), truncate(n.Text, width, w.ASCII)))
+		}
+	}
+	if withChecklist {
+		items := t.Checklist()
+		var open []ChecklistItem
+		for _, it := range items {
+			if !it.Done {
+				open = append(open, it)
+			}
+		}
+		switch {
+		case len(items) == 0:
+			b.WriteString("\nNo checklist.\n")
+		case len(open) == 0:
+			done := "Checklist complete"
+			if icon := w.cfg.SectionIcon("checklist_done"); icon != "" {
+				done += " " + icon
+			}
+			b.WriteString("\n" + done + "\n")
+		default:
+			b.WriteString(fmt.Sprintf("\nRemaining (%d):\n", len(open)))
+			for _, it := range open {
+				b.WriteString(fmt.Sprintf("%d. %s\n", it.Index, cleanTaskTitle(it.Text)))
+			}
+		}
+	}
+	return w.trimTelegramOutput(b.String())
+}
+
+// telegramIdeaLines is how many body lines an idea card shows before pointing
+// at `idea show --full`.
+const telegramIdeaLines = 12
+
+// telegramIdeaHeader is the title and scope/tags lines of an idea card.
+func (w *Workspace) telegramIdeaHeader(i *Idea) string {
+	var b strings.Builder
+	b.WriteString(w.cfg.iconLabel("idea", taskTitle(i.Title)) + "\n")
+	scope := "root"
+	if i.Project != "" {
+		scope = i.Project
+	}
+	b.WriteString(scope)
+	if len(i.Tags) > 0 {
+		b.WriteString(" · #" + strings.Join(i.Tags, " #"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// ideaBodyLines returns the body lines with runs of blank lines collapsed.
+func ideaBodyLines(body string) []string {
+	var out []string
+	blank := true
+	for _, line := range strings.Split(strings.TrimSpace(normalizeText(body)), "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// RenderIdeaTelegram renders an idea as a chat card: the header and the first
+// telegramIdeaLines body lines, with a pointer to the full view when there is
+// more.
+func (w *Workspace) RenderIdeaTelegram(i *Idea) string {
+	var b strings.Builder
+	b.WriteString(w.telegramIdeaHeader(i))
+	lines := ideaBodyLines(i.Body)
+	if len(lines) == 0 {
+		return w.trimTelegramOutput(b.String())
+	}
+	pointer := func(hidden int) string {
+		return fmt.Sprintf("\n… %d more line(s); use idea show --full %s", hidden, i.ID)
+	}
+	// Leave room for the pointer so max_chars never cuts it off.
+	budget := w.cfg.TelegramMaxChars() - len([]rune(b.String())) - 1 - len([]rune(pointer(len(lines))))
+	b.WriteString("\n")
+	shown := 0
+	for _, line := range lines {
+		n := len([]rune(line)) + 1
+		if shown == 0 && n > budget && budget > 1 {
+			// A single huge first line still gets a preview.
+			line = truncate(line, budget-1, w.ASCII)
+			n = len([]rune(line)) + 1
+		}
+		if shown == telegramIdeaLines || n > budget {
+			break
+		}
+		budget -= n
+		b.WriteString(line + "\n")
+		shown++
+	}
+	if shown < len(lines) {
+		b.WriteString(pointer(len(lines) - shown))
+	}
+	return w.trimTelegramOutput(b.String())
+}
+
+// IdeaTelegramPages splits the whole idea into messages that each fit
+// formats.telegram.max_chars, breaking between lines where possible. Every
+// page repeats the header and, when there are several, ends with "(n/N)".
+func (w *Workspace) IdeaTelegramPages(i *Idea) []string {
+	header := w.telegramIdeaHeader(i)
+	lines := ideaBodyLines(i.Body)
+	if len(lines) == 0 {
+		return []string{w.trimTelegramOutput(header)}
+	}
+	// Room for "\n" + body + "\n(99/99) next: idea show --full --page 99 <id>".
+	footer := len([]rune(fmt.Sprintf("\n(99/99) next: idea show --full --page 99 %s", i.ID)))
+	budget := w.cfg.TelegramMaxChars() - len([]rune(header)) - 1 - footer
+	if budget < 20 {
+		budget = 20
+	}
+	var chunks []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			chunks = append(chunks, strings.TrimRight(string(cur), "\n"))
+			cur = nil
+		}
+	}
+	for _, line := range lines {
+		runes := []rune(line + "\n")
+		for len(runes) > budget {
+			flush()
+			chunks = append(chunks, string(runes[:budget]))
+			runes = runes[budget:]
+		}
+		if len(cur)+len(runes) > budget {
+			flush()
+		}
+		cur = append(cur, runes...)
+	}
+	flush()
+	pages := make([]string, len(chunks))
+	for n, chunk := range chunks {
+		page := header + "\n" + chunk + "\n"
+		if len(chunks) > 1 {
+			page += fmt.Sprintf("\n(%d/%d)", n+1, len(chunks))
+			if n+1 < len(chunks) {
+				page += fmt.Sprintf(" next: idea show --full --page %d %s", n+2, i.ID)
+			}
+		}
+		pages[n] = w.trimTelegramOutput(page)
+	}
+	return pages
 }
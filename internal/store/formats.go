@@ -0,0 +1,71 @@
+package store
+
+// Output size defaults, used when the formats block leaves a value unset.
+const (
+	DefaultTelegramMaxChars    = 3800
+	DefaultTelegramDetailWidth = 160
+	DefaultHumanTitleWidth     = 80
+	DefaultHumanSnippetWidth   = 140
+)
+
+// FormatsConfig tunes output truncation per format. Zero values fall back to
+// the defaults above.
+type FormatsConfig struct {
+	Telegram *TelegramFormatConfig `json:"telegram,omitempty"`
+	Human    *HumanFormatConfig    `json:"human,omitempty"`
+}
+
+type TelegramFormatConfig struct {
+	// MaxChars caps a whole telegram message (Telegram's own limit is 4096).
+	MaxChars int `json:"max_chars,omitempty"`
+	// DetailWidth caps the details shown in chat add confirmations.
+	DetailWidth int `json:"detail_width,omitempty"`
+}
+
+type HumanFormatConfig struct {
+	// TitleWidth caps task titles in the board view.
+	TitleWidth int `json:"title_width,omitempty"`
+	// SnippetWidth caps the body snippet in idea listings.
+	SnippetWidth int `json:"snippet_width,omitempty"`
+}
+
+func formatValue(v int, def int) int {
+	if v > 0 {
+		return v
+	}
+	return def
+}
+
+// TelegramMaxChars is formats.telegram.max_chars, or DefaultTelegramMaxChars.
+func (c Config) TelegramMaxChars() int {
+	if c.Formats == nil || c.Formats.Telegram == nil {
+		return DefaultTelegramMaxChars
+	}
+	return formatValue(c.Formats.Telegram.MaxChars, DefaultTelegramMaxChars)
+}
+
+// TelegramDetailWidth is formats.telegram.detail_width, or
+// DefaultTelegramDetailWidth.
+func (c Config) TelegramDetailWidth() int {
+	if c.Formats == nil || c.Formats.Telegram == nil {
+		return DefaultTelegramDetailWidth
+	}
+	return formatValue(c.Formats.Telegram.DetailWidth, DefaultTelegramDetailWidth)
+}
+
+// HumanTitleWidth is formats.human.title_width, or DefaultHumanTitleWidth.
+func (c Config) HumanTitleWidth() int {
+	if c.Formats == nil || c.Formats.Human == nil {
+		return DefaultHumanTitleWidth
+	}
+	return formatValue(c.Formats.Human.TitleWidth, DefaultHumanTitleWidth)
+}
+
+// HumanSnippetWidth is formats.human.snippet_width, or
+// DefaultHumanSnippetWidth.
+func (c Config) HumanSnippetWidth() int {
+	if c.Formats == nil || c.Formats.Human == nil {
+		return DefaultHumanSnippetWidth
+	}
+	return formatValue(c.Formats.Human.SnippetWidth, DefaultHumanSnippetWidth)
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTelegramMaxCharsFromConfig(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	long := strings.Repeat("x", 5000)
+	if got := len([]rune(w.trimTelegramOutput(long))); got != DefaultTelegramMaxChars {
+		t.Fatalf("expected default cap %d, got %d", DefaultTelegramMaxChars, got)
+	}
+	w.cfg.Formats = &FormatsConfig{Telegram: &TelegramFormatConfig{MaxChars: 100}}
+	out := w.trimTelegramOutput(long)
+	if got := len([]rune(out)); got != 100 {
+		t.Fatalf("expected configured cap 100, got %d", got)
+	}
+	if !strings.HasSuffix(out, "(truncated)") {
+		t.Fatalf("expected truncation marker, got %q", out[len(out)-20:])
+	}
+	if w.cfg.HumanTitleWidth() != DefaultHumanTitleWidth {
+		t.Fatalf("expected default title width when human block is unset")
+	}
+}
+
+func TestRenderTaskTelegramCard(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Card", Project: "Work", Column: "doing", Tags: []string{"client"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []string{"first", "second", "third", "fourth"} {
+		if task, err = w.AddNote(task.ID, n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := w.RenderTaskTelegram(task, false)
+	for _, want := range []string{"Doing", "Tags: #client", "Notes (latest 3 of 4):", "fourth"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in card:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "first") {
+		t.Fatalf("expected the oldest note to be dropped:\n%s", out)
+	}
+}
+
+func TestIdeaTelegramPages(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	w.cfg.Formats = &FormatsConfig{Telegram: &TelegramFormatConfig{MaxChars: 300}}
+	var lines []string
+	for i := 0; i < 30; i++ {
+		lines = append(lines, "entry "+strings.Repeat("word ", 6))
+	}
+	idea := &Idea{IdeaMeta: IdeaMeta{ID: "idea_1", Title: "Long"}, Body: strings.Join(lines, "\n")}
+	card := w.RenderIdeaTelegram(idea)
+	if !strings.Contains(card, "more line(s); use idea show --full idea_1") || len([]rune(card)) > 300 {
+		t.Fatalf("expected a summarized card within max_chars, got %q", card)
+	}
+	pages := w.IdeaTelegramPages(idea)
+	if len(pages) < 2 {
+		t.Fatalf("expected several pages, got %d", len(pages))
+	}
+	total := 0
+	for _, p := range pages {
+		if len([]rune(p)) > 300 || !strings.HasPrefix(p, "💡 Long\n") {
+			t.Fatalf("page exceeds max_chars or lacks header: %q", p)
+		}
+		total += strings.Count(p, "entry ")
+	}
+	if total != 30 {
+		t.Fatalf("expected every body line across pages, got %d", total)
+	}
+}
+
+func TestThemeOverridesAndIconsNone(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Ship", Project: "Work", Column: "doing", Priority: "high"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := w.RenderTaskTelegram(task, false); !strings.HasPrefix(out, "🔴 Ship") || !strings.Contains(out, "🔨 Doing") {
+		t.Fatalf("expected default icons, got %q", out)
+	}
+	w.cfg.Theme = &ThemeConfig{Columns: map[string]string{"doing": "🚧"}, Priorities: map[string]string{"high": ""}}
+	if out := w.RenderTaskTelegram(task, false); !strings.HasPrefix(out, "Ship") || !strings.Contains(out, "🚧 Doing") {
+		t.Fatalf("expected theme overrides, got %q", out)
+	}
+	w.cfg.Theme = &ThemeConfig{Icons: IconsNone}
+	if out := w.RenderTaskTelegram(task, false); !strings.HasPrefix(out, "Ship\nDoing · work") {
+		t.Fatalf("expected no icons, got %q", out)
+	}
+	if icon := w.cfg.SectionIcon("today"); icon != "" {
+		t.Fatalf("expected no section icon, got %q", icon)
+	}
+}
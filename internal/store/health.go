@@ -0,0 +1,155 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	HealthOK   = "ok"
+	HealthWarn = "warn"
+	HealthFail = "fail"
+	HealthSkip = "skip"
+)
+
+// HealthCheck is the outcome of one health probe.
+type HealthCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // ok|warn|fail|skip
+	Detail string `json:"detail,omitempty"`
+}
+
+// HealthReport is the result of Health. OK is false when any check failed.
+type HealthReport struct {
+	OK     bool          `json:"ok"`
+	Root   string        `json:"root"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// Health runs cheap read/write probes against the workspace. It never
+// modifies task data; the write probe creates and removes a temp file.
+func (w *Workspace) Health() HealthReport {
+	r := HealthReport{OK: true, Root: w.Root}
+	add := func(name, status, detail string) {
+		r.Checks = append(r.Checks, HealthCheck{Name: name, Status: status, Detail: detail})
+		if status == HealthFail {
+			r.OK = false
+		}
+	}
+
+	info, err := os.Stat(w.Root)
+	switch {
+	case err != nil:
+		add("root", HealthFail, err.Error())
+	case !info.IsDir():
+		add("root", HealthFail, "not a directory")
+	default:
+		add("root", HealthOK, w.Root)
+	}
+
+	cfgPath := filepath.Join(w.Root, "config.json")
+	if b, err := os.ReadFile(cfgPath); err != nil {
+		if os.IsNotExist(err) {
+			add("config", HealthWarn, "config.json missing; defaults in use (run tasker init)")
+		} else {
+			add("config", HealthFail, err.Error())
+		}
+	} else {
+		var cfg Config
+		if err := json.Unmarshal(b, &cfg); err != nil {
+			add("config", HealthFail, "parse: "+err.Error())
+		} else if err := validateColumns(cfg.Columns); err != nil {
+			add("config", HealthFail, err.Error())
+		} else if err := validateStatuses(cfg.Statuses, cfg.Columns); err != nil {
+			add("config", HealthFail, err.Error())
+		} else {
+			add("config", HealthOK, cfgPath)
+		}
+	}
+
+	projectsDir := filepath.Join(w.Root, "projects")
+	if entries, err := os.ReadDir(projectsDir); err != nil {
+		add("projects", HealthFail, "read: "+err.Error())
+	} else if err := probeWritable(projectsDir); err != nil {
+		add("projects", HealthFail, "write: "+err.Error())
+	} else {
+		add("projects", HealthOK, fmt.Sprintf("%d entries, writable", len(entries)))
+	}
+	if projects, err := w.ListProjects(); err == nil {
+		for _, p := range projects {
+			if err := validateColumns(p.Columns); err != nil {
+				add("project "+p.Slug, HealthFail, "columns: "+err.Error())
+			} else if err := validateStatuses(w.cfg.Statuses, p.Columns); err != nil {
+				add("project "+p.Slug, HealthFail, "columns: "+err.Error())
+			}
+		}
+	}
+
+	if pending, err := w.PendingJournal(); err != nil {
+		add("journal", HealthFail, err.Error())
+	} else if len(pending) > 0 {
+		add("journal", HealthWarn, fmt.Sprintf("%d interrupted operation(s); run tasker doctor", len(pending)))
+	} else {
+		add("journal", HealthOK, "clean")
+	}
+
+	if fixes, err := w.LocationMismatches(); err != nil {
+		add("locations", HealthFail, err.Error())
+	} else if len(fixes) > 0 {
+		add("locations", HealthWarn, fmt.Sprintf("%d task(s) moved by hand with stale frontmatter; run tasker doctor --fix", len(fixes)))
+	} else {
+		add("locations", HealthOK, "frontmatter matches file locations")
+	}
+
+	if st, err := w.IndexStatus(); err != nil {
+		add("index", HealthFail, err.Error())
+	} else if !st.Exists {
+		add("index", HealthSkip, "not built yet (built on first listing, or run tasker index rebuild)")
+	} else if st.Stale+st.Missing > 0 {
+		add("index", HealthOK, fmt.Sprintf("%d entries, %d to refresh on next read", st.Entries, st.Stale+st.Missing))
+	} else {
+		add("index", HealthOK, fmt.Sprintf("%d entries, up to date", st.Entries))
+	}
+	if info, locked := w.LockStatus(); !locked {
+		add("lock", HealthOK, "available")
+	} else if info.Stale {
+		add("lock", HealthWarn, fmt.Sprintf("stale lock from pid %d since %s (will be broken)", info.PID, info.Since.Format(time.RFC3339)))
+	} else {
+		add("lock", HealthWarn, fmt.Sprintf("held by pid %d since %s", info.PID, info.Since.Format(time.RFC3339)))
+	}
+	return r
+}
+
+// validateColumns checks an explicit column list for empty or duplicate ids/dirs.
+// An empty list is valid (defaults apply).
+func validateColumns(cols []ColumnDef) error {
+	ids := map[string]bool{}
+	dirs := map[string]bool{}
+	for _, c := range cols {
+		if c.ID == "" || c.Dir == "" {
+			return fmt.Errorf("column with empty id or dir")
+		}
+		if ids[c.ID] {
+			return fmt.Errorf("duplicate column id %q", c.ID)
+		}
+		if dirs[c.Dir] {
+			return fmt.Errorf("duplicate column dir %q", c.Dir)
+		}
+		ids[c.ID] = true
+		dirs[c.Dir] = true
+	}
+	return nil
+}
+
+func probeWritable(dir string) error {
+	f, err := os.CreateTemp(dir, ".health-*")
+	if err != nil {
+		return err
+	}
+	name := f.Name()
+	_ = f.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ICalOptions selects the tasks and components of an iCalendar export.
+type ICalOptions struct {
+	Project string
+	// Days keeps tasks due within that many days from today (overdue open
+	// tasks always stay); 0 exports every due date.
+	Days int
+	// All includes done tasks, as completed to-dos.
+	All bool
+	// Todos and Events pick VTODO and/or all-day VEVENT components.
+	Todos  bool
+	Events bool
+}
+
+// RenderICal renders the tasks with a due date as an iCalendar (RFC 5545)
+// document: a VTODO and/or a VEVENT per task, keyed by task id.
+func (w *Workspace) RenderICal(opts ICalOptions) ([]byte, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: opts.Project, All: opts.All})
+	if err != nil {
+		return nil, err
+	}
+	today := timeNow().UTC().Truncate(24 * time.Hour)
+	var dated []Task
+	for _, t := range tasks {
+		due, ok := parseDueDate(t.Due)
+		if !ok || t.Status == "archived" || (!opts.All && !w.cfg.IsOpenStatus(t.Status)) {
+			continue
+		}
+		if opts.Days > 0 && due.After(today.AddDate(0, 0, opts.Days)) {
+			continue
+		}
+		dated = append(dated, t)
+	}
+	sort.SliceStable(dated, func(i, j int) bool {
+		if dated[i].Due != dated[j].Due {
+			return dated[i].Due < dated[j].Due
+		}
+		return dated[i].ID < dated[j].ID
+	})
+
+	var b strings.Builder
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//tasker//docstore//EN")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	name := "tasker"
+	if opts.Project != "" {
+		name += " " + opts.Project
+	}
+	writeICalLine(&b, "X-WR-CALNAME:"+icalText(name))
+	for _, t := range dated {
+		if opts.Events {
+			w.writeICalComponent(&b, "VEVENT", t)
+		}
+		if opts.Todos {
+			w.writeICalComponent(&b, "VTODO", t)
+		}
+	}
+	writeICalLine(&b, "END:VCALENDAR")
+	return []byte(b.String()), nil
+}
+
+func (w *Workspace) writeICalComponent(b *strings.Builder, kind string, t Task) {
+	due, _ := parseDueDate(t.Due)
+	at, err := time.Parse(time.RFC3339, strings.TrimSpace(t.Due))
+	timed := err == nil
+	if timed {
+		due = at
+	}
+	uid := t.ID + "@tasker"
+	if kind == "VTODO" {
+		uid = t.ID + "-todo@tasker"
+	}
+	writeICalLine(b, "BEGIN:"+kind)
+	writeICalLine(b, "UID:"+uid)
+	// Stamp with the task's own times rather than now, so an unchanged store
+	// exports byte-identical files and subscribed calendars see no churn.
+	stamp := time.Unix(0, 0)
+	if t.CreatedAt != nil {
+		stamp = *t.CreatedAt
+	}
+	if t.UpdatedAt != nil {
+		stamp = *t.UpdatedAt
+	}
+	writeICalLine(b, "DTSTAMP:"+icalTime(stamp))
+	writeICalLine(b, "LAST-MODIFIED:"+icalTime(stamp))
+	writeICalLine(b, "SUMMARY:"+icalText(taskTitle(t.Title)))
+	desc := t.Project + "/" + t.Column
+	if body := strings.TrimSpace(t.Body); body != "" {
+		desc += "\n\n" + body
+	}
+	writeICalLine(b, "DESCRIPTION:"+icalText(desc))
+	if len(t.Tags) > 0 {
+		tags := make([]string, len(t.Tags))
+		for i, tag := range t.Tags {
+			tags[i] = icalText(tag)
+		}
+		writeICalLine(b, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+	writeICalLine(b, fmt.Sprintf("PRIORITY:%d", icalPriority(t.Priority)))
+	switch kind {
+	case "VEVENT":
+		if timed {
+			writeICalLine(b, "DTSTART:"+icalTime(due))
+			writeICalLine(b, "DTEND:"+icalTime(due.Add(30*time.Minute)))
+		} else {
+			writeICalLine(b, "DTSTART;VALUE=DATE:"+due.Format("20060102"))
+			writeICalLine(b, "DTEND;VALUE=DATE:"+due.AddDate(0, 0, 1).Format("20060102"))
+		}
+		writeICalLine(b, "TRANSP:TRANSPARENT")
+	case "VTODO":
+		if timed {
+			writeICalLine(b, "DUE:"+icalTime(due))
+		} else {
+			writeICalLine(b, "DUE;VALUE=DATE:"+due.Format("20060102"))
+		}
+		switch {
+		case t.Status == "done":
+			writeICalLine(b, "STATUS:COMPLETED")
+			if t.CompletedAt != nil {
+				writeICalLine(b, "COMPLETED:"+icalTime(*t.CompletedAt))
+			}
+		case t.Status == "doing":
+			writeICalLine(b, "STATUS:IN-PROCESS")
+		case w.cfg.IsOpenStatus(t.Status):
+			writeICalLine(b, "STATUS:NEEDS-ACTION")
+		default:
+			writeICalLine(b, "STATUS:COMPLETED")
+		}
+	}
+	writeICalLine(b, "END:"+kind)
+}
+
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// icalPriority maps task priorities onto the 1 (highest) to 9 scale.
+func icalPriority(p string) int {
+	switch normalizePriority(p) {
+	case "urgent":
+		return 1
+	case "high":
+		return 3
+	case "low":
+		return 9
+	default:
+		return 5
+	}
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// icalText escapes a TEXT value.
+func icalText(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+// writeICalLine writes one content line, folded at 75 octets without
+// splitting a UTF-8 sequence, with CRLF endings.
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderICal(t *testing.T) {
+	restore := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = restore }()
+
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.AddTask(AddTaskInput{Title: "Pay rent, twice; maybe", Project: "Home", Due: "2026-01-23", Priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Later", Project: "Home", Due: "2026-03-01"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "No due", Project: "Home"}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := w.RenderICal(ICalOptions{Days: 7, Events: true, Todos: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"SUMMARY:Pay rent\\, twice\\; maybe\r\n",
+		"DTSTART;VALUE=DATE:20260123\r\n",
+		"DTEND;VALUE=DATE:20260124\r\n",
+		"DUE;VALUE=DATE:20260123\r\n",
+		"PRIORITY:3\r\n",
+		"STATUS:NEEDS-ACTION\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Later") || strings.Contains(out, "No due") {
+		t.Fatalf("expected only tasks due within 7 days:\n%s", out)
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 1 {
+		t.Fatalf("expected 1 event, got %d", n)
+	}
+	again, _ := w.RenderICal(ICalOptions{Days: 7, Events: true, Todos: true})
+	if string(again) != out {
+		t.Fatalf("expected a stable export")
+	}
+}
+
+func TestWriteICalLineFolds(t *testing.T) {
+	var b strings.Builder
+	writeICalLine(&b, "SUMMARY:"+strings.Repeat("é", 60))
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line longer than 75 octets: %d", len(line))
+		}
+	}
+	if got := strings.ReplaceAll(b.String(), "\r\n ", ""); got != "SUMMARY:"+strings.Repeat("é", 60)+"\r\n" {
+		t.Fatalf("unfolded line differs: %q", got)
+	}
+}
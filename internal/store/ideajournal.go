@@ -0,0 +1,246 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IdeasConfig holds idea capture settings.
+type IdeasConfig struct {
+	// Journal is "daily" to append root-scope ideas to one file per day.
+	Journal string `json:"journal,omitempty"`
+}
+
+// IdeaJournal is ideas.journal: "daily", or "" when every idea gets its own
+// file.
+func (c Config) IdeaJournal() string {
+	if c.Ideas != nil && strings.EqualFold(strings.TrimSpace(c.Ideas.Journal), "daily") {
+		return "daily"
+	}
+	return ""
+}
+
+// journalEntryLine matches the first line of a journal entry:
+// "- 14:05 Title <!-- idea_01J4... -->".
+var journalEntryLine = regexp.MustCompile(`^- (\d{2}:\d{2}) (.*?)\s*<!-- (idea_[0-9A-Za-z-]+) -->\s*$`)
+
+// journalEntry is one idea inside a daily journal file: lines[Start:End]
+// of the file, Time being its "15:04" clock.
+type journalEntry struct {
+	ID    string
+	Time  string
+	Start int
+	End   int
+}
+
+func (w *Workspace) ideaJournalDir() string {
+	return filepath.Join(w.rootIdeasDir(), "journal")
+}
+
+// isIdeaJournalPath reports whether path is a daily journal file.
+func (w *Workspace) isIdeaJournalPath(path string) bool {
+	return filepath.Dir(path) == w.ideaJournalDir()
+}
+
+// readIdeaPath reads the ideas stored at p: one for an idea file, one per
+// entry for a journal file.
+func (w *Workspace) readIdeaPath(p ideaPath) ([]Idea, error) {
+	if p.Project != "" || !w.isIdeaJournalPath(p.Path) {
+		idea, err := readIdeaFile(p.Path, p.Project)
+		if err != nil {
+			return nil, err
+		}
+		idea.Archived = p.Archived
+		return []Idea{*idea}, nil
+	}
+	return readIdeaJournal(p.Path)
+}
+
+// parseJournalEntries finds the entries in a journal file's lines. An entry
+// runs until the next entry line or an unindented, non-blank line.
+func parseJournalEntries(lines []string) []journalEntry {
+	var out []journalEntry
+	for i := 0; i < len(lines); i++ {
+		m := journalEntryLine.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		e := journalEntry{ID: m[3], Time: m[1], Start: i}
+		j := i + 1
+		for j < len(lines) {
+			line := lines[j]
+			if journalEntryLine.MatchString(line) {
+				break
+			}
+			if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				break
+			}
+			j++
+		}
+		// Trailing blank lines separate entries; they are not part of one.
+		for j > i+1 && strings.TrimSpace(lines[j-1]) == "" {
+			j--
+		}
+		e.End = j
+		out = append(out, e)
+		i = j - 1
+	}
+	return out
+}
+
+func readIdeaJournal(path string) ([]Idea, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: journal file name is not a date: %s", ErrInvalid, filepath.Base(path))
+	}
+	lines := strings.Split(normalizeText(string(b)), "\n")
+	var out []Idea
+	for _, e := range parseJournalEntries(lines) {
+		out = append(out, journalIdea(path, day, lines, e))
+	}
+	return out, nil
+}
+
+func journalIdea(path string, day time.Time, lines []string, e journalEntry) Idea {
+	m := journalEntryLine.FindStringSubmatch(lines[e.Start])
+	content := []string{m[2]}
+	for _, line := range lines[e.Start+1 : e.End] {
+		content = append(content, strings.TrimPrefix(line, "  "))
+	}
+	title, tags, body := parseIdeaContent(strings.Join(content, "\n"))
+	at := day
+	if clock, err := time.Parse("15:04", e.Time); err == nil {
+		at = time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, time.Local).UTC()
+	}
+	created, updated := at, at
+	return Idea{
+		IdeaMeta: IdeaMeta{
+			ID:        e.ID,
+			Title:     title,
+			Tags:      dedupeStrings(tags),
+			CreatedAt: &created,
+			UpdatedAt: &updated,
+		},
+		Path:    path,
+		Body:    body,
+		Links:   ideaRelatedIDs(body),
+		Journal: true,
+	}
+}
+
+// formatJournalEntry renders an idea as a journal bullet: the time, title
+// and id on the first line, then the tag line and body indented under it.
+func formatJournalEntry(clock string, id string, title string, tags []string, body string) string {
+	content := strings.Split(strings.TrimRight(formatIdeaContent(title, inferIdeaTags(title, body, tags), body), "\n"), "\n")
+	var b strings.Builder
+	fmt.Fprintf(&b, "- %s %s <!-- %s -->\n", clock, content[0], id)
+	for _, line := range content[1:] {
+		if line != "" {
+			b.WriteString("  ")
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// addJournalIdea appends an idea to today's journal file, creating the file
+// with a date heading on the first capture of the day.
+func (w *Workspace) addJournalIdea(title string, tags []string, body string) (*Idea, error) {
+	now := timeNow()
+	local := now.In(time.Local)
+	day := local.Format("2006-01-02")
+	path := filepath.Join(w.ideaJournalDir(), day+".md")
+	current := "# " + day + "\n"
+	if b, err := os.ReadFile(path); err == nil {
+		current = strings.TrimRight(normalizeText(string(b)), "\n") + "\n"
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	id := w.newItemID("idea_")
+	content := current + "\n" + formatJournalEntry(local.Format("15:04"), id, title, tags, body)
+	if err := w.commitChanges("idea add", []fileChange{{Path: path, After: &content}}); err != nil {
+		return nil, err
+	}
+	created := local.Truncate(time.Minute).UTC()
+	return &Idea{
+		IdeaMeta: IdeaMeta{
+			ID:        id,
+			Title:     title,
+			Tags:      inferIdeaTags(title, body, tags),
+			CreatedAt: &created,
+			UpdatedAt: &created,
+		},
+		Path:    path,
+		Body:    body,
+		Journal: true,
+	}, nil
+}
+
+// rewriteJournalEntry replaces the entry for idea in its journal file with
+// the given title, tags and body, or drops it when remove is set.
+func (w *Workspace) rewriteJournalEntry(op string, idea *Idea, title string, tags []string, body string, remove bool) error {
+	change, err := w.journalEntryChange(idea, title, tags, body, remove)
+	if err != nil {
+		return err
+	}
+	return w.commitChanges(op, []fileChange{change})
+}
+
+// journalEntryChange is the change to idea's journal file that rewrites or
+// removes its entry. A file left without entries is deleted.
+func (w *Workspace) journalEntryChange(idea *Idea, title string, tags []string, body string, remove bool) (fileChange, error) {
+	b, err := os.ReadFile(idea.Path)
+	if err != nil {
+		return fileChange{}, err
+	}
+	content, err := replaceJournalEntry(string(b), idea.ID, title, tags, body, remove)
+	if err != nil {
+		return fileChange{}, err
+	}
+	if content == "" {
+		return fileChange{Path: idea.Path}, nil
+	}
+	return fileChange{Path: idea.Path, After: &content}, nil
+}
+
+// replaceJournalEntry rewrites or removes the entry id in a journal file's
+// content; removing the only entry returns "".
+func replaceJournalEntry(content string, id string, title string, tags []string, body string, remove bool) (string, error) {
+	lines := strings.Split(normalizeText(content), "\n")
+	entries := parseJournalEntries(lines)
+	var target *journalEntry
+	for i := range entries {
+		if entries[i].ID == id {
+			target = &entries[i]
+			break
+		}
+	}
+	if target == nil {
+		return "", ErrNotFound
+	}
+	if remove && len(entries) == 1 {
+		return "", nil
+	}
+	var replacement []string
+	if !remove {
+		replacement = strings.Split(strings.TrimRight(formatJournalEntry(target.Time, id, title, tags, body), "\n"), "\n")
+	}
+	end := target.End
+	if remove {
+		// Take the blank separator line with it.
+		for end < len(lines) && strings.TrimSpace(lines[end]) == "" {
+			end++
+		}
+	}
+	out := append(append(append([]string{}, lines[:target.Start]...), replacement...), lines[end:]...)
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n", nil
+}
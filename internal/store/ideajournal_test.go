@@ -0,0 +1,67 @@
+package store
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdeaJournalDaily(t *testing.T) {
+	cfg := testConfig()
+	cfg.Ideas = &IdeasConfig{Journal: "daily"}
+	w := &Workspace{Root: t.TempDir(), cfg: cfg}
+	SetNow(time.Date(2026, 1, 19, 9, 30, 0, 0, time.Local))
+	defer SetNow(time.Time{})
+
+	first, err := w.AddIdea(AddIdeaInput{Title: "Pricing page", Tags: []string{"web"}, Body: "Compare plans"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Offsite venue"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Own file", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	if !first.Journal || !strings.HasSuffix(first.Path, "2026-01-19.md") {
+		t.Fatalf("expected a journal entry, got %s", first.Path)
+	}
+	b, err := os.ReadFile(first.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(b), "# 2026-01-19\n\n- 09:30 Pricing page <!-- "+first.ID+" -->\n  tags: web\n\n  Compare plans\n") {
+		t.Fatalf("unexpected journal file:\n%s", b)
+	}
+
+	ideas, err := w.ListIdeas(IdeaListFilter{Search: "plans"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ideas) != 1 || ideas[0].ID != first.ID || ideas[0].Body != "Compare plans" || ideas[0].Tags[0] != "web" {
+		t.Fatalf("expected the entry to list on its own, got %+v", ideas)
+	}
+
+	noted, err := w.AddIdeaNote(&ideas[0], "ask sales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(noted.Body, "ask sales") {
+		t.Fatalf("expected the note in the body, got %q", noted.Body)
+	}
+	if err := w.DeleteIdea(noted); err != nil {
+		t.Fatal(err)
+	}
+	all, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 ideas after removing one entry, got %d", len(all))
+	}
+	got, err := w.GetIdeaBySelectorFiltered("offsite", IdeaSelectorFilter{})
+	if err != nil || got.Title != "Offsite venue" {
+		t.Fatalf("expected to select the remaining entry, got %v, %v", got, err)
+	}
+}
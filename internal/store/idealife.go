@@ -0,0 +1,173 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ideaArchiveDir is where archived ideas are filed inside an ideas dir.
+const ideaArchiveDir = "archive"
+
+// IdeaPatch is a partial update for EditIdea. Tags replaces the tag list;
+// AddTags and RemoveTags apply on top of it.
+type IdeaPatch struct {
+	Title      *string
+	Body       *string
+	Tags       *[]string
+	AddTags    []string
+	RemoveTags []string
+}
+
+// allIdeaPaths lists every idea file, live and archived.
+func (w *Workspace) allIdeaPaths() ([]ideaPath, error) {
+	live, err := w.ideaPaths(IdeaScopeAll, "", false)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := w.ideaPaths(IdeaScopeAll, "", true)
+	if err != nil {
+		return nil, err
+	}
+	return append(live, archived...), nil
+}
+
+func (w *Workspace) ideasDirFor(project string) string {
+	if project == "" {
+		return w.rootIdeasDir()
+	}
+	return w.projectIdeasDir(project)
+}
+
+// EditIdea changes an idea's title, body or tags. A tag that is removed
+// also loses its inline #tag tokens, so it does not come back from the text.
+// Retitling an idea file renames it after the new title; its ID stays.
+func (w *Workspace) EditIdea(idea *Idea, patch IdeaPatch) (*Idea, error) {
+	if idea == nil || strings.TrimSpace(idea.Path) == "" {
+		return nil, ErrInvalid
+	}
+	current, err := w.currentIdea(idea)
+	if err != nil {
+		return nil, err
+	}
+	title, body, tags := current.Title, current.Body, current.Tags
+	if patch.Title != nil {
+		if title = normalizeIdeaTitle(strings.TrimSpace(*patch.Title)); title == "" {
+			return nil, fmt.Errorf("%w: title is required", ErrInvalid)
+		}
+	}
+	if patch.Body != nil {
+		body = strings.TrimRight(*patch.Body, "\n")
+	}
+	var removed []string
+	if patch.Tags != nil {
+		next := normalizeIdeaTags(*patch.Tags)
+		for _, t := range tags {
+			if !containsString(next, t) {
+				removed = append(removed, t)
+			}
+		}
+		tags = next
+	}
+	tags = dedupeStrings(append(append([]string{}, tags...), normalizeIdeaTags(patch.AddTags)...))
+	removed = append(removed, normalizeIdeaTags(patch.RemoveTags)...)
+	kept := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if !containsString(removed, t) {
+			kept = append(kept, t)
+		}
+	}
+	for _, t := range removed {
+		title = replaceInlineTag(title, t, "")
+		body = replaceInlineTag(body, t, "")
+	}
+	tags = inferIdeaTags(title, body, kept)
+
+	if current.Journal {
+		err = w.rewriteJournalEntry("idea edit", current, title, tags, body, false)
+	} else {
+		newPath := current.Path
+		if patch.Title != nil && strings.HasPrefix(strings.ToLower(filepath.Base(current.Path)), "idea_") {
+			newPath = filepath.Join(filepath.Dir(current.Path), fmt.Sprintf("%s__%s.md", current.ID, slugify(title)))
+		}
+		content := formatIdeaContent(title, tags, body)
+		changes := []fileChange{{Path: newPath, After: &content}}
+		if newPath != current.Path {
+			changes = append(changes, fileChange{Path: current.Path})
+		}
+		err = w.commitChanges("idea edit", changes)
+		current.Path = newPath
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow()
+	current.Title, current.Body, current.Tags, current.UpdatedAt = title, body, tags, &now
+	current.Links = ideaRelatedIDs(body)
+	return current, nil
+}
+
+// ArchiveIdea files an idea under its ideas dir's archive/, out of listings
+// and selectors until UnarchiveIdea brings it back. A journal entry leaves
+// its journal file and is archived as an idea file of its own.
+func (w *Workspace) ArchiveIdea(idea *Idea) (*Idea, error) {
+	current, err := w.currentIdea(idea)
+	if err != nil {
+		return nil, err
+	}
+	if idea.Archived {
+		return nil, fmt.Errorf("%w: %s is already archived", ErrConflict, current.ID)
+	}
+	name := filepath.Base(current.Path)
+	var content string
+	var changes []fileChange
+	if current.Journal {
+		name = fmt.Sprintf("%s__%s.md", current.ID, slugify(current.Title))
+		content = formatIdeaContent(current.Title, current.Tags, current.Body)
+		change, err := w.journalEntryChange(current, "", nil, "", true)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	} else {
+		raw, err := os.ReadFile(current.Path)
+		if err != nil {
+			return nil, err
+		}
+		content = string(raw)
+		changes = append(changes, fileChange{Path: current.Path})
+	}
+	dest := filepath.Join(w.ideasDirFor(current.Project), ideaArchiveDir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s is already archived", ErrConflict, current.ID)
+	}
+	changes = append([]fileChange{{Path: dest, After: &content}}, changes...)
+	if err := w.commitChanges("idea archive", changes); err != nil {
+		return nil, err
+	}
+	current.Path, current.Journal, current.Archived = dest, false, true
+	return current, nil
+}
+
+// UnarchiveIdea moves an archived idea back into its ideas dir.
+func (w *Workspace) UnarchiveIdea(idea *Idea) (*Idea, error) {
+	if idea == nil || !idea.Archived {
+		return nil, fmt.Errorf("%w: idea is not archived", ErrInvalid)
+	}
+	raw, err := os.ReadFile(idea.Path)
+	if err != nil {
+		return nil, err
+	}
+	dest := filepath.Join(w.ideasDirFor(idea.Project), filepath.Base(idea.Path))
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s already exists", ErrConflict, filepath.Base(dest))
+	}
+	content := string(raw)
+	if err := w.commitChanges("idea unarchive", []fileChange{{Path: dest, After: &content}, {Path: idea.Path}}); err != nil {
+		return nil, err
+	}
+	out := *idea
+	out.Path, out.Archived = dest, false
+	return &out, nil
+}
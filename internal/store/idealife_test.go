@@ -0,0 +1,125 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEditArchiveIdea(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	idea, err := w.AddIdea(AddIdeaInput{Title: "Pricing page", Body: "ask #sales first", Tags: []string{"web"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Pricing tiers"
+	edited, err := w.EditIdea(idea, IdeaPatch{Title: &title, AddTags: []string{"q3"}, RemoveTags: []string{"sales"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(edited.Path, idea.ID+"__pricing-tiers.md") {
+		t.Fatalf("expected file renamed after the title, got %s", edited.Path)
+	}
+	if _, err := os.Stat(idea.Path); !os.IsNotExist(err) {
+		t.Fatalf("old file should be gone: %v", err)
+	}
+	got, err := w.GetIdeaBySelectorFiltered(idea.ID, IdeaSelectorFilter{Scope: IdeaScopeAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Pricing tiers" || got.Body != "ask first" || containsString(got.Tags, "sales") || !containsString(got.Tags, "q3") || !containsString(got.Tags, "web") {
+		t.Fatalf("unexpected edit: %+v", got)
+	}
+
+	archived, err := w.ArchiveIdea(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !archived.Archived || filepath.Base(filepath.Dir(archived.Path)) != ideaArchiveDir {
+		t.Fatalf("expected idea in archive/, got %+v", archived)
+	}
+	live, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil || len(live) != 0 {
+		t.Fatalf("archived idea should be hidden: %+v, %v", live, err)
+	}
+	if _, err := w.GetIdeaBySelectorFiltered(idea.ID, IdeaSelectorFilter{Scope: IdeaScopeAll}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected archived idea unresolvable, got %v", err)
+	}
+	list, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeAll, Archived: true})
+	if err != nil || len(list) != 1 || !list[0].Archived {
+		t.Fatalf("expected one archived idea: %+v, %v", list, err)
+	}
+	if _, err := w.ArchiveIdea(&list[0]); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict archiving twice, got %v", err)
+	}
+
+	back, err := w.UnarchiveIdea(&list[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Archived || back.Path != edited.Path {
+		t.Fatalf("expected idea restored to %s, got %+v", edited.Path, back)
+	}
+	if _, err := w.GetIdeaBySelectorFiltered(idea.ID, IdeaSelectorFilter{Scope: IdeaScopeAll}); err != nil {
+		t.Fatalf("unarchived idea should resolve: %v", err)
+	}
+}
+
+func TestScoreIdeas(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	cheap, err := w.AddIdea(AddIdeaInput{Title: "Cheap win"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	big, err := w.AddIdea(AddIdeaInput{Title: "Big bet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Unreviewed"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ScoreIdea(big, 5, 5); err != nil {
+		t.Fatal(err)
+	}
+	scored, err := w.ScoreIdea(cheap, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scored.Score == nil || scored.Score.Score != 3 {
+		t.Fatalf("unexpected score: %+v", scored.Score)
+	}
+	if _, err := w.ScoreIdea(cheap, 0, 2); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid impact, got %v", err)
+	}
+
+	ideas, err := w.ListIdeas(IdeaListFilter{Sort: "score"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []string
+	for _, idea := range ideas {
+		order = append(order, idea.Title)
+	}
+	if strings.Join(order, ",") != "Cheap win,Big bet,Unreviewed" {
+		t.Fatalf("unexpected score order: %v", order)
+	}
+	unscored, err := w.ListIdeas(IdeaListFilter{Unscored: true})
+	if err != nil || len(unscored) != 1 || unscored[0].Title != "Unreviewed" {
+		t.Fatalf("expected only the unreviewed idea: %+v, %v", unscored, err)
+	}
+	if _, err := w.ListIdeas(IdeaListFilter{Sort: "impact"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected unknown sort key, got %v", err)
+	}
+
+	title := "Cheap win v2"
+	if _, err := w.EditIdea(cheap, IdeaPatch{Title: &title}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := w.GetIdeaBySelectorFiltered(cheap.ID, IdeaSelectorFilter{})
+	if err != nil || got.Score == nil || got.Score.Impact != 3 {
+		t.Fatalf("score should survive a retitle: %+v, %v", got, err)
+	}
+}
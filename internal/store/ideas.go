@@ -29,6 +29,16 @@ type Idea struct {
 	IdeaMeta `json:",inline"`
 	Path     string `json:"path"`
 	Body     string `json:"-"`
+	// Journal marks an entry of a daily journal file (Path) rather than an
+	// idea file of its own.
+	Journal bool `json:"journal,omitempty"`
+	// Archived marks an idea filed under ideas/archive/ (see ArchiveIdea).
+	Archived bool `json:"archived,omitempty"`
+	// Links are the IDs of the tasks the idea is linked to, read from its
+	// Related: lines (see LinkIdea).
+	Links []string `json:"links,omitempty"`
+	// Score is the idea's triage rating, if it has one (see ScoreIdea).
+	Score *IdeaScore `json:"score,omitempty"`
 }
 
 // IdeaMatchConflictError provides details when a selector matches multiple ideas.
@@ -56,17 +66,26 @@ type AddIdeaInput struct {
 	Body    string
 }
 
+// IdeaListFilter narrows ListIdeas. Archived lists the archived ideas
+// instead of the live ones; Unscored keeps only ideas not yet triaged. Sort
+// is one of IdeaSortFields (default updated).
 type IdeaListFilter struct {
-	Project string
-	Scope   string
-	Tag     string
-	Search  string
+	Project  string
+	Scope    string
+	Tags     TagFilter
+	Search   string
+	Archived bool
+	Unscored bool
+	Sort     string
 }
 
+// IdeaSelectorFilter narrows idea selectors. Archived resolves among the
+// archived ideas instead of the live ones.
 type IdeaSelectorFilter struct {
-	Project string
-	Scope   string
-	Match   string
+	Project  string
+	Scope    string
+	Match    string
+	Archived bool
 }
 
 type ideaDir struct {
@@ -75,8 +94,9 @@ type ideaDir struct {
 }
 
 type ideaPath struct {
-	Project string
-	Path    string
+	Project  string
+	Path     string
+	Archived bool
 }
 
 func (w *Workspace) AddIdea(in AddIdeaInput) (*Idea, error) {
@@ -95,14 +115,17 @@ func (w *Workspace) AddIdea(in AddIdeaInput) (*Idea, error) {
 	}
 	body := strings.TrimRight(in.Body, "\n")
 	tags := normalizeIdeaTags(in.Tags)
-	id := "idea_" + newULID()
+	if projectSlug == "" && w.cfg.IdeaJournal() == "daily" {
+		return w.addJournalIdea(title, tags, body)
+	}
+	id := w.newItemID("idea_")
 	filename := fmt.Sprintf("%s__%s.md", id, slugify(title))
 	dir := w.rootIdeasDir()
 	if projectSlug != "" {
 		dir = w.projectIdeasDir(projectSlug)
 	}
 	path := filepath.Join(dir, filename)
-	if err := writeIdeaFile(path, title, tags, body); err != nil {
+	if err := w.writeIdeaFile("idea add", path, title, tags, body); err != nil {
 		return nil, err
 	}
 	tags = inferIdeaTags(title, body, tags)
@@ -153,11 +176,13 @@ func (w *Workspace) ResolveIdeas(selector string, filter IdeaSelectorFilter) ([]
 	return matches, nil
 }
 
+// DeleteIdea moves an idea into the trash (see TrashIdea).
 func (w *Workspace) DeleteIdea(idea *Idea) error {
 	if idea == nil || strings.TrimSpace(idea.Path) == "" {
 		return ErrInvalid
 	}
-	return os.Remove(idea.Path)
+	_, err := w.TrashIdea(idea)
+	return err
 }
 
 func (w *Workspace) AddIdeaNote(idea *Idea, note string) (*Idea, error) {
@@ -168,19 +193,18 @@ func (w *Workspace) AddIdeaNote(idea *Idea, note string) (*Idea, error) {
 	if note == "" {
 		return nil, fmt.Errorf("%w: note is required", ErrInvalid)
 	}
-	current, err := readIdeaFile(idea.Path, idea.Project)
+	current, err := w.currentIdea(idea)
 	if err != nil {
 		return nil, err
 	}
 	now := timeNow()
-	entry := fmt.Sprintf("- %s — %s\n", now.Format(time.RFC3339), note)
-	body := strings.TrimRight(current.Body, "\n")
-	if body == "" {
-		body = entry
+	body := appendNoteEntry(current.Body, "", formatNoteEntry(now, w.noteSeparator(), note))
+	if current.Journal {
+		err = w.rewriteJournalEntry("idea note", current, current.Title, current.Tags, body, false)
 	} else {
-		body = body + "\n" + entry
+		err = w.writeIdeaFile("idea note", current.Path, current.Title, current.Tags, body)
 	}
-	if err := writeIdeaFile(current.Path, current.Title, current.Tags, body); err != nil {
+	if err != nil {
 		return nil, err
 	}
 	current.Body = body
@@ -191,17 +215,20 @@ func (w *Workspace) AddIdeaNote(idea *Idea, note string) (*Idea, error) {
 
 func (w *Workspace) ListIdeas(f IdeaListFilter) ([]Idea, error) {
 	filter := normalizeIdeaListFilter(f)
-	paths, err := w.ideaPaths(filter.Scope, filter.Project)
+	if filter.Sort != "" && !containsString(IdeaSortFields, filter.Sort) {
+		return nil, fmt.Errorf("%w: unknown sort key %q (use %s)", ErrInvalid, filter.Sort, strings.Join(IdeaSortFields, "|"))
+	}
+	paths, err := w.ideaPaths(filter.Scope, filter.Project, filter.Archived)
 	if err != nil {
 		return nil, err
 	}
+	ideas := w.readIdeaPaths(paths)
 	var out []Idea
-	for _, p := range paths {
-		idea, err := readIdeaFile(p.Path, p.Project)
-		if err != nil {
+	for _, idea := range ideas {
+		if filter.Unscored && idea.Score != nil {
 			continue
 		}
-		if filter.Tag != "" && !containsString(idea.Tags, filter.Tag) {
+		if !filter.Tags.matches(idea.Tags) {
 			continue
 		}
 		if filter.Search != "" {
@@ -210,20 +237,9 @@ func (w *Workspace) ListIdeas(f IdeaListFilter) ([]Idea, error) {
 				continue
 			}
 		}
-		out = append(out, *idea)
+		out = append(out, idea)
 	}
-	sort.Slice(out, func(i, j int) bool {
-		if out[i].UpdatedAt != nil && out[j].UpdatedAt != nil && !out[i].UpdatedAt.Equal(*out[j].UpdatedAt) {
-			return out[i].UpdatedAt.After(*out[j].UpdatedAt)
-		}
-		if out[i].Project != out[j].Project {
-			return out[i].Project < out[j].Project
-		}
-		if out[i].Title != out[j].Title {
-			return strings.ToLower(out[i].Title) < strings.ToLower(out[j].Title)
-		}
-		return out[i].ID < out[j].ID
-	})
+	sortIdeas(out, filter.Sort)
 	return out, nil
 }
 
@@ -245,6 +261,9 @@ func (i *Idea) RenderHuman() string {
 	if len(i.Tags) > 0 {
 		b.WriteString("Tags: " + strings.Join(i.Tags, ", ") + "\n")
 	}
+	if i.Score != nil {
+		fmt.Fprintf(&b, "Score: %g (impact %d, effort %d)\n", i.Score.Score, i.Score.Impact, i.Score.Effort)
+	}
 	b.WriteString("\n")
 	if strings.TrimSpace(i.Body) != "" {
 		b.WriteString(strings.TrimRight(i.Body, "\n"))
@@ -255,7 +274,7 @@ func (i *Idea) RenderHuman() string {
 
 func (w *Workspace) resolveIdeaSelectorCandidates(selector string, filter IdeaSelectorFilter) ([]Idea, error) {
 	filter = normalizeIdeaSelectorFilter(filter)
-	if isLikelyIdeaIDSelector(selector) {
+	if w.isLikelyIDSelector(selector, "idea_") {
 		matches, err := w.findIdeasByPrefixFiltered(selector, filter)
 		if err != nil {
 			return nil, err
@@ -293,12 +312,14 @@ func normalizeIdeaListFilter(filter IdeaListFilter) IdeaListFilter {
 		project = slugifyOrDefault(project, project)
 	}
 	scope := normalizeIdeaScope(filter.Scope, project)
-	tag := strings.TrimSpace(filter.Tag)
 	return IdeaListFilter{
-		Project: project,
-		Scope:   scope,
-		Tag:     tag,
-		Search:  strings.TrimSpace(filter.Search),
+		Project:  project,
+		Scope:    scope,
+		Tags:     filter.Tags.normalize(),
+		Search:   strings.TrimSpace(filter.Search),
+		Archived: filter.Archived,
+		Unscored: filter.Unscored,
+		Sort:     strings.ToLower(strings.TrimSpace(filter.Sort)),
 	}
 }
 
@@ -310,30 +331,40 @@ func normalizeIdeaSelectorFilter(filter IdeaSelectorFilter) IdeaSelectorFilter {
 	scope := normalizeIdeaScope(filter.Scope, project)
 	match := normalizeMatchMode(filter.Match)
 	return IdeaSelectorFilter{
-		Project: project,
-		Scope:   scope,
-		Match:   match,
+		Project:  project,
+		Scope:    scope,
+		Match:    match,
+		Archived: filter.Archived,
 	}
 }
 
-func (w *Workspace) ideaPaths(scope string, project string) ([]ideaPath, error) {
+// ideaPaths lists the idea files of scope: the live ones, or with archived
+// the ones under each ideas dir's archive/.
+func (w *Workspace) ideaPaths(scope string, project string, archived bool) ([]ideaPath, error) {
 	dirs, err := w.ideaDirs(scope, project)
 	if err != nil {
 		return nil, err
 	}
 	var out []ideaPath
 	for _, dir := range dirs {
-		_ = filepath.WalkDir(dir.Path, func(path string, d fs.DirEntry, err error) error {
+		root := dir.Path
+		if archived {
+			root = filepath.Join(dir.Path, ideaArchiveDir)
+		}
+		_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 			if err != nil || d == nil {
 				return nil
 			}
 			if d.IsDir() {
+				if !archived && path == filepath.Join(dir.Path, ideaArchiveDir) {
+					return filepath.SkipDir
+				}
 				return nil
 			}
 			if !isIdeaFile(d.Name()) {
 				return nil
 			}
-			out = append(out, ideaPath{Project: dir.Project, Path: path})
+			out = append(out, ideaPath{Project: dir.Project, Path: path, Archived: archived})
 			return nil
 		})
 	}
@@ -477,7 +508,7 @@ func (w *Workspace) findIdeasBySearchFiltered(selector string, filter IdeaSelect
 }
 
 func (w *Workspace) findIdeasByPrefixFiltered(prefix string, filter IdeaSelectorFilter) ([]Idea, error) {
-	paths, err := w.ideaPaths(filter.Scope, filter.Project)
+	paths, err := w.ideaPaths(filter.Scope, filter.Project, filter.Archived)
 	if err != nil {
 		return nil, err
 	}
@@ -487,13 +518,9 @@ func (w *Workspace) findIdeasByPrefixFiltered(prefix string, filter IdeaSelector
 	}
 	needle := strings.ToUpper(prefix)
 	var matches []Idea
-	for _, p := range paths {
-		idea, err := readIdeaFile(p.Path, p.Project)
-		if err != nil {
-			continue
-		}
-		if strings.HasPrefix(strings.ToUpper(idea.ID), needle) {
-			matches = append(matches, *idea)
+	for _, idea := range w.readIdeaPaths(paths) {
+		if idHasPrefix(idea.ID, "idea_", needle) {
+			matches = append(matches, idea)
 		}
 	}
 	sortIdeaMatches(matches)
@@ -501,19 +528,47 @@ func (w *Workspace) findIdeasByPrefixFiltered(prefix string, filter IdeaSelector
 }
 
 func (w *Workspace) loadIdeas(filter IdeaSelectorFilter) ([]Idea, error) {
-	paths, err := w.ideaPaths(filter.Scope, filter.Project)
+	paths, err := w.ideaPaths(filter.Scope, filter.Project, filter.Archived)
 	if err != nil {
 		return nil, err
 	}
+	return w.readIdeaPaths(paths), nil
+}
+
+// readIdeaPaths reads the ideas at paths, skipping unreadable files.
+func (w *Workspace) readIdeaPaths(paths []ideaPath) []Idea {
 	var ideas []Idea
 	for _, p := range paths {
-		idea, err := readIdeaFile(p.Path, p.Project)
+		found, err := w.readIdeaPath(p)
 		if err != nil {
 			continue
 		}
-		ideas = append(ideas, *idea)
+		ideas = append(ideas, found...)
 	}
-	return ideas, nil
+	w.attachIdeaScores(ideas)
+	return ideas
+}
+
+// currentIdea re-reads idea from disk.
+func (w *Workspace) currentIdea(idea *Idea) (*Idea, error) {
+	if !idea.Journal {
+		current, err := readIdeaFile(idea.Path, idea.Project)
+		if err != nil {
+			return nil, err
+		}
+		current.Archived = idea.Archived
+		return current, nil
+	}
+	entries, err := readIdeaJournal(idea.Path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		if entries[i].ID == idea.ID {
+			return &entries[i], nil
+		}
+	}
+	return nil, ErrNotFound
 }
 
 func sortIdeaMatches(matches []Idea) {
@@ -586,10 +641,10 @@ func formatIdeaContent(title string, tags []string, body string) string {
 	return b.String()
 }
 
-func writeIdeaFile(path string, title string, tags []string, body string) error {
+func (w *Workspace) writeIdeaFile(op string, path string, title string, tags []string, body string) error {
 	tags = inferIdeaTags(title, body, tags)
 	content := formatIdeaContent(title, tags, body)
-	return atomicWriteFile(path, []byte(content), 0o644)
+	return w.commitChanges(op, []fileChange{{Path: path, After: &content}})
 }
 
 // ParseIdeaContent exposes the idea parser for CLI stdin capture.
@@ -625,14 +680,14 @@ func readIdeaFile(path string, project string) (*Idea, error) {
 			CreatedAt: &created,
 			UpdatedAt: &updated,
 		},
-		Path: path,
-		Body: body,
+		Path:  path,
+		Body:  body,
+		Links: ideaRelatedIDs(body),
 	}, nil
 }
 
 func parseIdeaContent(text string) (string, []string, string) {
-	s := strings.ReplaceAll(text, "\r\n", "\n")
-	s = strings.ReplaceAll(s, "\r", "\n")
+	s := normalizeText(text)
 	lines := strings.Split(s, "\n")
 	title := ""
 	titleIndex := -1
@@ -754,8 +809,7 @@ func inferIdeaTags(title string, body string, explicit []string) []string {
 
 func extractIdeaInlineTags(text string) []string {
 	var tags []string
-	s := strings.ReplaceAll(text, "\r\n", "\n")
-	s = strings.ReplaceAll(s, "\r", "\n")
+	s := normalizeText(text)
 	lines := strings.Split(s, "\n")
 	inFence := false
 	fence := ""
@@ -839,31 +893,6 @@ func isIdeaTagChar(b byte) bool {
 	return false
 }
 
-func isLikelyIdeaIDSelector(selector string) bool {
-	selector = strings.TrimSpace(selector)
-	if selector == "" {
-		return false
-	}
-	lower := strings.ToLower(selector)
-	if strings.HasPrefix(lower, "idea_") {
-		return true
-	}
-	if len(selector) < 8 {
-		return false
-	}
-	allowed := "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
-	hasDigit := false
-	for _, r := range strings.ToUpper(selector) {
-		if r >= '0' && r <= '9' {
-			hasDigit = true
-		}
-		if !strings.ContainsRune(allowed, r) {
-			return false
-		}
-	}
-	return hasDigit
-}
-
 func (w *Workspace) rootIdeasDir() string {
 	return filepath.Join(w.Root, "ideas")
 }
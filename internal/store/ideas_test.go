@@ -32,3 +32,13 @@ func TestExtractIdeaInlineTagsSkipsCodeFences(t *testing.T) {
 		}
 	}
 }
+
+func TestParseIdeaContentStripsBOMAndCRLF(t *testing.T) {
+	title, _, body := parseIdeaContent("\ufeffWindows idea\r\n\r\n- 2026-01-02T03:04:05Z — note\r\n")
+	if title != "Windows idea" {
+		t.Fatalf("expected BOM-free title, got %q", title)
+	}
+	if body != "- 2026-01-02T03:04:05Z — note" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
@@ -0,0 +1,130 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// IdeaScoreMax is the top of the 1..N impact and effort scales.
+const IdeaScoreMax = 5
+
+// IdeaScore is an idea's triage rating. Score is impact over effort, so
+// cheap, high-impact ideas rank first.
+type IdeaScore struct {
+	Impact   int       `json:"impact"`
+	Effort   int       `json:"effort"`
+	Score    float64   `json:"score"`
+	ScoredAt time.Time `json:"scored_at"`
+}
+
+// IdeaSortFields are the keys `idea ls --sort` accepts.
+var IdeaSortFields = []string{"updated", "score", "title"}
+
+// ideaScoresPath is the file triage scores live in, keyed by idea id, so
+// they survive edits, renames and archiving of the idea files themselves.
+func (w *Workspace) ideaScoresPath() string {
+	return filepath.Join(w.rootIdeasDir(), "scores.json")
+}
+
+func (w *Workspace) ideaScores() (map[string]IdeaScore, error) {
+	b, err := os.ReadFile(w.ideaScoresPath())
+	if os.IsNotExist(err) {
+		return map[string]IdeaScore{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	scores := map[string]IdeaScore{}
+	if err := json.Unmarshal(b, &scores); err != nil {
+		return nil, fmt.Errorf("%s: %w", w.ideaScoresPath(), err)
+	}
+	return scores, nil
+}
+
+// attachIdeaScores fills in the Score of each idea that has one.
+func (w *Workspace) attachIdeaScores(ideas []Idea) {
+	scores, err := w.ideaScores()
+	if err != nil || len(scores) == 0 {
+		return
+	}
+	for i := range ideas {
+		if s, ok := scores[ideas[i].ID]; ok {
+			ideas[i].Score = &s
+		}
+	}
+}
+
+// ScoreIdea rates an idea's impact and effort (1..IdeaScoreMax), replacing
+// any earlier score.
+func (w *Workspace) ScoreIdea(idea *Idea, impact, effort int) (*Idea, error) {
+	if idea == nil || strings.TrimSpace(idea.ID) == "" {
+		return nil, ErrInvalid
+	}
+	for name, v := range map[string]int{"impact": impact, "effort": effort} {
+		if v < 1 || v > IdeaScoreMax {
+			return nil, fmt.Errorf("%w: %s must be 1-%d, got %d", ErrInvalid, name, IdeaScoreMax, v)
+		}
+	}
+	scores, err := w.ideaScores()
+	if err != nil {
+		return nil, err
+	}
+	s := IdeaScore{
+		Impact:   impact,
+		Effort:   effort,
+		Score:    math.Round(float64(impact)/float64(effort)*100) / 100,
+		ScoredAt: timeNow(),
+	}
+	scores[idea.ID] = s
+	b, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	content := string(b) + "\n"
+	if err := w.commitChanges("idea score", []fileChange{{Path: w.ideaScoresPath(), After: &content}}); err != nil {
+		return nil, err
+	}
+	out := *idea
+	out.Score = &s
+	return &out, nil
+}
+
+// sortIdeas orders ideas by key: updated (newest first, the ListIdeas
+// default), score (highest first, unscored last) or title (A-Z).
+func sortIdeas(ideas []Idea, key string) {
+	sort.SliceStable(ideas, func(i, j int) bool {
+		a, b := ideas[i], ideas[j]
+		switch key {
+		case "score":
+			if (a.Score == nil) != (b.Score == nil) {
+				return a.Score != nil
+			}
+			if a.Score != nil && a.Score.Score != b.Score.Score {
+				return a.Score.Score > b.Score.Score
+			}
+			if a.Score != nil && a.Score.Impact != b.Score.Impact {
+				return a.Score.Impact > b.Score.Impact
+			}
+		case "title":
+			if !strings.EqualFold(a.Title, b.Title) {
+				return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+			}
+		}
+		if a.UpdatedAt != nil && b.UpdatedAt != nil && !a.UpdatedAt.Equal(*b.UpdatedAt) {
+			return a.UpdatedAt.After(*b.UpdatedAt)
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Title != b.Title {
+			return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,146 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// IDStyleULID is the default: tsk_01J4ZK8Q3M5V7X9B2C4D6F8G0H.
+	IDStyleULID = "ulid"
+	// IDStyleNanoID is a short random ID: tsk_k3f9x2m1qz.
+	IDStyleNanoID = "nanoid"
+	// IDStyleDate leads with the creation day: tsk_20261016-k3f9x.
+	IDStyleDate = "date"
+)
+
+// IDStyles are the values ids.style accepts.
+var IDStyles = []string{IDStyleULID, IDStyleNanoID, IDStyleDate}
+
+const (
+	// idAlphabet is Crockford base32 in lower case: no i, l, o or u, so IDs
+	// read back without ambiguity and match ULID selectors case-insensitively.
+	idAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+	nanoIDLen  = 10
+	dateIDLen  = 5
+)
+
+// IDsConfig picks how new task and idea IDs look. Existing IDs never change,
+// and every style stays selectable whatever the setting.
+type IDsConfig struct {
+	Style string `json:"style,omitempty"`
+}
+
+// NormalizeIDStyle checks an ids.style value ("" means the default).
+func NormalizeIDStyle(style string) (string, error) {
+	style = strings.ToLower(strings.TrimSpace(style))
+	if style == "" {
+		return IDStyleULID, nil
+	}
+	for _, s := range IDStyles {
+		if s == style {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown id style %q (use %s)", ErrInvalid, style, strings.Join(IDStyles, "|"))
+}
+
+// IDStyle is ids.style, IDStyleULID when unset or unknown.
+func (c Config) IDStyle() string {
+	if c.IDs == nil {
+		return IDStyleULID
+	}
+	style, err := NormalizeIDStyle(c.IDs.Style)
+	if err != nil {
+		return IDStyleULID
+	}
+	return style
+}
+
+// newItemID makes a task ("tsk_") or idea ("idea_") ID in the configured
+// style.
+func (w *Workspace) newItemID(prefix string) string {
+	switch w.cfg.IDStyle() {
+	case IDStyleNanoID:
+		return prefix + randomID(nanoIDLen)
+	case IDStyleDate:
+		return prefix + timeNow().UTC().Format("20060102") + "-" + randomID(dateIDLen)
+	}
+	return prefix + newULID()
+}
+
+func randomID(n int) string {
+	b := make([]byte, n)
+	if _, err := (randReader{}).Read(b); err != nil {
+		id := strings.ToLower(newULID())
+		return id[len(id)-n:]
+	}
+	for i := range b {
+		b[i] = idAlphabet[int(b[i])%len(idAlphabet)]
+	}
+	return string(b)
+}
+
+// isLikelyIDSelector reports whether a selector reads as an ID (or the start
+// of one) of prefix rather than a title: the prefix itself, a ULID-like
+// prefix, and for the configured style a date ("20261016-k3") or a shorter
+// nanoid prefix.
+func (w *Workspace) isLikelyIDSelector(selector string, prefix string) bool {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return false
+	}
+	lower := strings.ToLower(selector)
+	if strings.HasPrefix(lower, prefix) {
+		return true
+	}
+	switch w.cfg.IDStyle() {
+	case IDStyleNanoID:
+		if len(lower) >= 4 && isIDText(lower) && strings.IndexFunc(lower, isDigit) >= 0 {
+			return true
+		}
+	case IDStyleDate:
+		if day, rest, ok := strings.Cut(lower, "-"); ok && len(day) == 8 && strings.IndexFunc(day, notDigit) < 0 && isIDText(rest) {
+			return true
+		}
+	}
+	return isLikelyULIDSelector(selector)
+}
+
+// idHasPrefix matches an upper-cased selector against id with or without
+// its type prefix, so "K3F9" selects tsk_k3f9x2m1qz.
+func idHasPrefix(id string, prefix string, selector string) bool {
+	id = strings.ToUpper(id)
+	return strings.HasPrefix(id, selector) || strings.HasPrefix(strings.TrimPrefix(id, strings.ToUpper(prefix)), selector)
+}
+
+func isIDText(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune(idAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(r rune) bool { return r >= '0' && r <= '9' }
+
+func notDigit(r rune) bool { return !isDigit(r) }
+
+// isLikelyULIDSelector accepts 8+ Crockford base32 characters with a digit.
+func isLikelyULIDSelector(selector string) bool {
+	if len(selector) < 8 {
+		return false
+	}
+	allowed := "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	hasDigit := false
+	for _, r := range strings.ToUpper(selector) {
+		if r >= '0' && r <= '9' {
+			hasDigit = true
+		}
+		if !strings.ContainsRune(allowed, r) {
+			return false
+		}
+	}
+	return hasDigit
+}
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIDStyles(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for style, pattern := range map[string]string{
+		IDStyleULID:   `^tsk_[0-9A-Z]{26}$`,
+		IDStyleNanoID: `^tsk_[0-9a-z]{10}$`,
+		IDStyleDate:   `^tsk_20261016-[0-9a-z]{5}$`,
+	} {
+		w.cfg.IDs = &IDsConfig{Style: style}
+		task, err := w.AddTask(AddTaskInput{Title: "Task " + style, Project: "Work"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !regexp.MustCompile(pattern).MatchString(task.ID) {
+			t.Fatalf("%s: unexpected id %s", style, task.ID)
+		}
+		// The part after tsk_ selects the task, even as a prefix.
+		got, err := w.GetTaskBySelectorFiltered(task.ID[4:len(task.ID)-2], SelectorFilter{})
+		if err != nil || got.ID != task.ID {
+			t.Fatalf("%s: selecting %s: got %+v, %v", style, task.ID, got, err)
+		}
+	}
+
+	w.cfg.IDs = &IDsConfig{Style: IDStyleDate}
+	idea, err := w.AddIdea(AddIdeaInput{Title: "Dated idea"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^idea_20261016-[0-9a-z]{5}$`).MatchString(idea.ID) {
+		t.Fatalf("unexpected idea id %s", idea.ID)
+	}
+	if !w.isLikelyIDSelector("20261016-k3", "tsk_") || w.isLikelyIDSelector("weekly-sync", "tsk_") {
+		t.Fatal("date selectors misread")
+	}
+	if _, err := NormalizeIDStyle("uuid"); err == nil {
+		t.Fatal("expected an unknown style to be rejected")
+	}
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ImportItem is one task read from another tool's file (todo.txt, CSV).
+type ImportItem struct {
+	// Line is where the item came from, for error messages.
+	Line  int
+	Input AddTaskInput
+	// Status, when Input.Column is empty, puts the task in the first column
+	// of its project with that status (e.g. done).
+	Status string
+	// Created and Completed (YYYY-MM-DD or RFC3339) replace the import time.
+	Created   string
+	Completed string
+}
+
+// ImportTasks adds one task per item under a single journal entry, all or
+// nothing, so undo reverts a whole import. Tasks that land in a closed
+// column are completed at their Completed date. With dryRun the tasks are
+// written and rolled back, and the result shows what would be imported.
+func (w *Workspace) ImportTasks(items []ImportItem, dryRun bool) ([]Task, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: no tasks to import", ErrInvalid)
+	}
+	var newDirs []string
+	if dryRun {
+		projects := make([]string, len(items))
+		for i, it := range items {
+			projects[i] = it.Input.Project
+		}
+		newDirs = w.missingProjectDirs(projects...)
+	}
+	out := make([]Task, 0, len(items))
+	err := w.Transaction("import", w.WriteLockTimeout(), func() error {
+		for _, it := range items {
+			task, err := w.importItem(it)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", it.Line, err)
+			}
+			out = append(out, *task)
+		}
+		if dryRun {
+			return errBatchDryRun
+		}
+		return nil
+	})
+	for _, dir := range newDirs {
+		_ = os.RemoveAll(dir)
+	}
+	if err != nil && !(dryRun && errors.Is(err, errBatchDryRun)) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// missingProjectDirs lists the directories of projects that do not exist
+// yet. A dry run rolls back project.json but not the directories
+// CreateProject makes, so it removes these afterwards.
+func (w *Workspace) missingProjectDirs(projects ...string) []string {
+	var dirs []string
+	for _, p := range projects {
+		dir := filepath.Join(w.Root, "projects", slugifyOrDefault(p, "personal"))
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// parseImportTime reads YYYY-MM-DD or RFC3339.
+func parseImportTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid date %q", ErrInvalid, s)
+	}
+	return t, nil
+}
+
+func (w *Workspace) importItem(it ImportItem) (*Task, error) {
+	in := it.Input
+	if status := strings.ToLower(strings.TrimSpace(it.Status)); status != "" && in.Column == "" {
+		col, ok := w.statusColumn(slugifyOrDefault(in.Project, "personal"), status)
+		if !ok {
+			return nil, fmt.Errorf("%w: project %s has no %s column", ErrInvalid, in.Project, status)
+		}
+		in.Column = col.ID
+	}
+	var created, completed *time.Time
+	if it.Created != "" {
+		t, err := parseImportTime(it.Created)
+		if err != nil {
+			return nil, err
+		}
+		created = &t
+	}
+	if it.Completed != "" {
+		t, err := parseImportTime(it.Completed)
+		if err != nil {
+			return nil, err
+		}
+		completed = &t
+	}
+	task, err := w.AddTask(in)
+	if err != nil || task.Existing {
+		return task, err
+	}
+	if created != nil {
+		task.CreatedAt = created
+	}
+	if !w.cfg.IsOpenStatus(task.Status) && task.Status != "archived" {
+		if completed == nil {
+			completed = task.CreatedAt
+		}
+		task.CompletedAt = completed
+		task.MovedAt = completed
+	}
+	if created == nil && task.CompletedAt == nil {
+		return task, nil
+	}
+	if err := w.saveTask("import", task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
@@ -0,0 +1,236 @@
+package store
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const taskIndexSchema = 1
+
+// taskIndex caches parsed task files keyed by path. An entry is reused while
+// the file's size and mtime are unchanged, so listing a large store only
+// re-parses files that changed since the last run. It is a cache: deleting
+// <root>/.index is always safe.
+type taskIndex struct {
+	Schema  int                       `json:"schema"`
+	Entries map[string]taskIndexEntry `json:"entries"`
+	dirty   bool
+}
+
+type taskIndexEntry struct {
+	Size    int64    `json:"size"`
+	ModTime int64    `json:"mtime"`
+	Meta    TaskMeta `json:"meta"`
+	Body    string   `json:"body"`
+}
+
+// IndexStatus summarizes the on-disk index.
+type IndexStatus struct {
+	Path    string `json:"path"`
+	Exists  bool   `json:"exists"`
+	Entries int    `json:"entries"`
+	Stale   int    `json:"stale"`
+	Missing int    `json:"missing"`
+}
+
+func (w *Workspace) indexPath() string {
+	return filepath.Join(w.Root, ".index", "tasks.json")
+}
+
+// taskIndex loads the index once per Workspace; a missing or unreadable
+// index starts empty.
+func (w *Workspace) taskIndex() *taskIndex {
+	if w.index != nil {
+		return w.index
+	}
+	idx := &taskIndex{Schema: taskIndexSchema, Entries: map[string]taskIndexEntry{}}
+	if b, err := os.ReadFile(w.indexPath()); err == nil {
+		var disk taskIndex
+		if json.Unmarshal(b, &disk) == nil && disk.Schema == taskIndexSchema && disk.Entries != nil {
+			idx.Entries = disk.Entries
+		}
+	}
+	w.index = idx
+	return idx
+}
+
+// readTaskIndexed is readTaskFile backed by the index. d is the entry from
+// the directory walk, used to stat without opening the file.
+func (w *Workspace) readTaskIndexed(path string, d fs.DirEntry) (*Task, error) {
+	info, err := d.Info()
+	if err != nil {
+		return nil, err
+	}
+	idx := w.taskIndex()
+	key := w.indexKey(path)
+	if e, ok := idx.Entries[key]; ok && e.Size == info.Size() && e.ModTime == info.ModTime().UnixNano() {
+		return &Task{TaskMeta: e.Meta.clone(), Path: path, Body: e.Body}, nil
+	}
+	t, err := readTaskFile(path)
+	if err != nil {
+		if _, ok := idx.Entries[key]; ok {
+			delete(idx.Entries, key)
+			idx.dirty = true
+		}
+		return nil, err
+	}
+	idx.Entries[key] = taskIndexEntry{Size: info.Size(), ModTime: info.ModTime().UnixNano(), Meta: t.TaskMeta.clone(), Body: t.Body}
+	idx.dirty = true
+	return t, nil
+}
+
+// clone is a deep copy of m, so a caller changing a task it read (a tag, the
+// issue state) never changes the cached entry behind it.
+func (m TaskMeta) clone() TaskMeta {
+	m.Tags = append([]string(nil), m.Tags...)
+	m.BlockedBy = append([]string(nil), m.BlockedBy...)
+	m.Links = append([]TaskLink(nil), m.Links...)
+	m.Attachments = append([]string(nil), m.Attachments...)
+	if m.Issue != nil {
+		issue := *m.Issue
+		m.Issue = &issue
+	}
+	for _, at := range []**time.Time{&m.CreatedAt, &m.MovedAt, &m.UpdatedAt, &m.CompletedAt, &m.ArchivedAt} {
+		if *at != nil {
+			t := **at
+			*at = &t
+		}
+	}
+	return m
+}
+
+// indexKey stores paths relative to the root so a moved store keeps its
+// index valid.
+func (w *Workspace) indexKey(path string) string {
+	if rel, err := filepath.Rel(w.Root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// pruneIndex drops entries for files not in seen (keyed like indexKey).
+// Only call it after walking every task file.
+func (w *Workspace) pruneIndex(seen map[string]bool) {
+	idx := w.taskIndex()
+	for key := range idx.Entries {
+		if !seen[key] {
+			delete(idx.Entries, key)
+			idx.dirty = true
+		}
+	}
+}
+
+// saveIndex writes the index back when it changed. Read paths ignore the
+// error: the index is an optimization and the files stay authoritative.
+func (w *Workspace) saveIndex() error {
+	idx := w.index
+	if idx == nil || !idx.dirty {
+		return nil
+	}
+	b, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	if err := atomicWriteFile(w.indexPath(), b, 0o644); err != nil {
+		return err
+	}
+	idx.dirty = false
+	return nil
+}
+
+// walkTaskFiles calls fn for every task file under projects/, through the
+// index, then prunes entries for deleted files. Callers save the index.
+func (w *Workspace) walkTaskFiles(fn func(t *Task)) error {
+	seen := map[string]bool{}
+	root := filepath.Join(w.Root, "projects")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d == nil {
+			return nil
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") || !isTaskPath(root, path) {
+			return nil
+		}
+		t, err := w.readTaskIndexed(path, d)
+		if err != nil {
+			return nil
+		}
+		seen[w.indexKey(path)] = true
+		fn(t)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.pruneIndex(seen)
+	return nil
+}
+
+// isTaskPath reports whether path is under projects/<slug>/columns/<dir>/.
+func isTaskPath(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	return len(parts) >= 4 && parts[1] == "columns"
+}
+
+// RebuildIndex discards the index and re-parses every task file.
+func (w *Workspace) RebuildIndex() (int, error) {
+	w.index = &taskIndex{Schema: taskIndexSchema, Entries: map[string]taskIndexEntry{}, dirty: true}
+	n := 0
+	if err := w.walkTaskFiles(func(*Task) { n++ }); err != nil {
+		return 0, err
+	}
+	return n, w.saveIndex()
+}
+
+// IndexStatus compares the on-disk index with the task files without
+// updating it.
+func (w *Workspace) IndexStatus() (*IndexStatus, error) {
+	st := &IndexStatus{Path: w.indexPath()}
+	var disk taskIndex
+	if b, err := os.ReadFile(st.Path); err == nil {
+		st.Exists = json.Unmarshal(b, &disk) == nil && disk.Schema == taskIndexSchema
+	}
+	st.Entries = len(disk.Entries)
+	seen := map[string]bool{}
+	root := filepath.Join(w.Root, "projects")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d == nil || d.IsDir() || !strings.HasSuffix(strings.ToLower(d.Name()), ".md") || !isTaskPath(root, path) {
+			return nil
+		}
+		key := w.indexKey(path)
+		seen[key] = true
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		e, ok := disk.Entries[key]
+		if !ok {
+			st.Missing++
+		} else if e.Size != info.Size() || e.ModTime != info.ModTime().UnixNano() {
+			st.Stale++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for key := range disk.Entries {
+		if !seen[key] {
+			st.Stale++
+		}
+	}
+	return st, nil
+}
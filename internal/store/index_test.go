@@ -0,0 +1,82 @@
+package store
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestIndexRefreshesChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	w := &Workspace{Root: root, cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Indexed", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := w.RebuildIndex(); err != nil || n != 1 {
+		t.Fatalf("rebuild: n=%d err=%v", n, err)
+	}
+	// Edit the file behind the index's back, as an editor would.
+	b, err := os.ReadFile(task.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(task.Path, []byte(strings.Replace(string(b), "title: Indexed", "title: Renamed by hand", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fresh := &Workspace{Root: root, cfg: testConfig()}
+	tasks, err := fresh.ListTasks(ListFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Renamed by hand" {
+		t.Fatalf("expected index to pick up the edit, got %+v", tasks)
+	}
+	st, err := fresh.IndexStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Exists || st.Entries != 1 || st.Stale != 0 || st.Missing != 0 {
+		t.Fatalf("unexpected index status: %+v", st)
+	}
+}
+
+func TestIndexedReadsDoNotShareCachedValues(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Indexed", Project: "Work", Tags: []string{"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(task.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	front := "attachments:\n  - notes.txt\nissue:\n  provider: github\n  repo: o/r\n  number: 7\n  state: open\ncreated_at:"
+	if err := os.WriteFile(task.Path, []byte(strings.Replace(string(b), "created_at:", front, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	read := func() *Task {
+		t.Helper()
+		tasks, err := w.ListTasks(ListFilter{})
+		if err != nil || len(tasks) != 1 {
+			t.Fatalf("expected one task, got %d (%v)", len(tasks), err)
+		}
+		return &tasks[0]
+	}
+	// The first read fills the index, the second comes from it; change
+	// both and the cache must not see it.
+	for i := 0; i < 2; i++ {
+		got := read()
+		if got.Issue == nil || len(got.Attachments) != 1 || got.CreatedAt == nil {
+			t.Fatalf("read %d: expected issue, attachment and created_at, got %+v", i, got.TaskMeta)
+		}
+		got.Tags[0] = "changed"
+		got.Attachments[0] = "changed"
+		got.Issue.State = "closed"
+		*got.CreatedAt = got.CreatedAt.AddDate(1, 0, 0)
+	}
+	got := read()
+	if got.Tags[0] != "a" || got.Attachments[0] != "notes.txt" || got.Issue.State != "open" || !got.CreatedAt.Equal(*task.CreatedAt) {
+		t.Fatalf("expected the cached task unchanged, got %+v", got.TaskMeta)
+	}
+}
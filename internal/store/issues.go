@@ -0,0 +1,139 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// IssueRef links a task to an issue in an external tracker.
+type IssueRef struct {
+	Provider string `yaml:"provider" json:"provider"`
+	Repo     string `yaml:"repo" json:"repo"`
+	Number   int    `yaml:"number" json:"number"`
+	URL      string `yaml:"url,omitempty" json:"url,omitempty"`
+	// State is the issue state last seen or set by sync: open or closed.
+	State string `yaml:"state,omitempty" json:"state,omitempty"`
+}
+
+// RemoteIssue is an open issue fetched from a tracker.
+type RemoteIssue struct {
+	Provider string
+	Repo     string
+	Number   int
+	Title    string
+	Body     string
+	URL      string
+	Labels   []string
+}
+
+// IssueExternalID is the external ID of the task pulled from an issue, e.g.
+// github:owner/name#12, so pulling the same issue again is a no-op.
+func IssueExternalID(provider string, repo string, number int) string {
+	return fmt.Sprintf("%s:%s#%d", provider, strings.ToLower(repo), number)
+}
+
+// IssueImport is the result of ImportIssues.
+type IssueImport struct {
+	Added    []Task `json:"added"`
+	Existing []Task `json:"existing"`
+}
+
+// ImportIssues adds a task in project for every issue that has none yet,
+// under one journal entry. Labels become tags (spaces turned into dashes)
+// and the issue body the task body. A missing project is created only with
+// createProject or projects.auto_create, as for AddTask. With dryRun
+// nothing is kept and Added shows what would be created.
+func (w *Workspace) ImportIssues(project string, issues []RemoteIssue, createProject bool, dryRun bool) (IssueImport, error) {
+	var res IssueImport
+	if len(issues) == 0 {
+		return res, nil
+	}
+	var newDirs []string
+	if dryRun {
+		newDirs = w.missingProjectDirs(project)
+	}
+	err := w.Transaction("sync", w.WriteLockTimeout(), func() error {
+		for _, is := range issues {
+			tags := make([]string, 0, len(is.Labels))
+			for _, l := range is.Labels {
+				if tag := strings.Join(strings.Fields(l), "-"); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+			task, err := w.AddTask(AddTaskInput{
+				Title:         is.Title,
+				Project:       project,
+				Tags:          tags,
+				Body:          is.Body,
+				CreateProject: createProject,
+				ExternalID:    IssueExternalID(is.Provider, is.Repo, is.Number),
+			})
+			if err != nil {
+				return fmt.Errorf("%s#%d: %w", is.Repo, is.Number, err)
+			}
+			if task.Existing {
+				res.Existing = append(res.Existing, *task)
+				continue
+			}
+			task.Issue = &IssueRef{Provider: is.Provider, Repo: is.Repo, Number: is.Number, URL: is.URL, State: "open"}
+			if err := w.saveTask("sync", task); err != nil {
+				return err
+			}
+			res.Added = append(res.Added, *task)
+		}
+		if dryRun {
+			return errBatchDryRun
+		}
+		return nil
+	})
+	for _, dir := range newDirs {
+		_ = os.RemoveAll(dir)
+	}
+	if err != nil && !(dryRun && errors.Is(err, errBatchDryRun)) {
+		return IssueImport{}, err
+	}
+	return res, nil
+}
+
+// IssueTasksToClose lists the tasks linked to issues of repo that are done
+// (or archived) locally while their issue number is still in open.
+func (w *Workspace) IssueTasksToClose(provider string, repo string, open map[int]bool) ([]Task, error) {
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	var out []Task
+	for _, t := range tasks {
+		ref := t.Issue
+		if ref == nil || ref.Provider != provider || !strings.EqualFold(ref.Repo, repo) {
+			continue
+		}
+		if open[ref.Number] && !w.cfg.IsOpenStatus(t.Status) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// SetIssueState records the state of a task's linked issue.
+func (w *Workspace) SetIssueState(id string, state string) (*Task, error) {
+	task, err := w.GetTaskByPrefix(id)
+	if err != nil {
+		return nil, err
+	}
+	if task.Issue == nil {
+		return nil, fmt.Errorf("%w: task has no linked issue", ErrInvalid)
+	}
+	if task.Issue.State == state {
+		return task, nil
+	}
+	task.Issue.State = state
+	now := timeNow()
+	task.UpdatedAt = &now
+	if err := w.saveTask("sync", task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
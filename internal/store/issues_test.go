@@ -0,0 +1,62 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestImportIssuesAndClose(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: defaultConfig()}
+	issues := []RemoteIssue{
+		{Provider: "github", Repo: "acme/app", Number: 7, Title: "Fix login", URL: "https://github.com/acme/app/issues/7", Labels: []string{"good first issue"}},
+		{Provider: "github", Repo: "acme/app", Number: 9, Title: "Docs"},
+	}
+	// The repository's project does not exist and projects.auto_create is off.
+	if _, err := w.ImportIssues("App", issues, false, false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a missing project to fail, got %v", err)
+	}
+	dry, err := w.ImportIssues("App", issues, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dry.Added) != 2 {
+		t.Fatalf("expected 2 tasks in the dry run, got %d", len(dry.Added))
+	}
+	if tasks, _ := w.ListTasks(ListFilter{All: true}); len(tasks) != 0 {
+		t.Fatalf("dry run wrote %d task(s)", len(tasks))
+	}
+
+	res, err := w.ImportIssues("App", issues, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := res.Added[0]
+	if first.Issue == nil || first.Issue.Number != 7 || first.ExternalID != "github:acme/app#7" || first.Tags[0] != "good-first-issue" {
+		t.Fatalf("unexpected task: %+v", first.TaskMeta)
+	}
+	again, err := w.ImportIssues("App", issues, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Added) != 0 || len(again.Existing) != 2 {
+		t.Fatalf("expected a second pull to add nothing, got %d added", len(again.Added))
+	}
+
+	if _, err := w.MoveTask(first.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	toClose, err := w.IssueTasksToClose("github", "acme/app", map[int]bool{7: true, 9: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toClose) != 1 || toClose[0].ID != first.ID {
+		t.Fatalf("expected the done task to be closed, got %d", len(toClose))
+	}
+	closed, err := w.SetIssueState(first.ID, "closed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Issue.State != "closed" {
+		t.Fatalf("expected state closed, got %q", closed.Issue.State)
+	}
+}
@@ -0,0 +1,469 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Journal modes for RecoverJournal.
+const (
+	JournalRollback = "rollback"
+	JournalReplay   = "replay"
+)
+
+// journalHistoryLimit is how many completed entries .journal/history keeps
+// for undo.
+const journalHistoryLimit = 50
+
+// journalStaleAfter is how old a pending entry must be before Open treats it
+// as left behind by a crashed process (rather than one still running).
+const journalStaleAfter = time.Minute
+
+// fileChange is one file-level effect of an operation: After is the new
+// content, or nil to remove the file.
+type fileChange struct {
+	Path  string
+	After *string
+}
+
+// JournalChange records one file's content before and after an operation.
+// Path is relative to the workspace root; nil content means "no file".
+type JournalChange struct {
+	Path   string  `json:"path"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
+
+// JournalEntry is a write-ahead intent record. It is written to
+// <root>/.journal/pending before any file is touched and removed once every
+// change has been applied, so a leftover entry always means "interrupted".
+type JournalEntry struct {
+	ID      string          `json:"id"`
+	Op      string          `json:"op"`
+	At      time.Time       `json:"at"`
+	Changes []JournalChange `json:"changes"`
+}
+
+func (w *Workspace) journalPendingDir() string {
+	return filepath.Join(w.Root, ".journal", "pending")
+}
+
+func (w *Workspace) journalHistoryDir() string {
+	return filepath.Join(w.Root, ".journal", "history")
+}
+
+func (w *Workspace) relPath(path string) (string, error) {
+	rel, err := filepath.Rel(w.Root, path)
+	if err != nil {
+		return "", err
+	}
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: path outside workspace: %s", ErrInvalid, path)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func (w *Workspace) absPath(rel string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(rel))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: journal path outside workspace: %s", ErrInvalid, rel)
+	}
+	return filepath.Join(w.Root, clean), nil
+}
+
+func readOptionalFile(path string) (*string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	s := string(b)
+	return &s, nil
+}
+
+func applyFileState(path string, content *string) error {
+	if content == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return atomicWriteFile(path, []byte(*content), 0o644)
+}
+
+// journalTx accumulates the changes of several operations under one pending
+// journal entry so they commit or roll back together.
+type journalTx struct {
+	entry JournalEntry
+	// events are the audit log lines of the transaction's writes, appended
+	// once it commits.
+	events []Event
+}
+
+func (w *Workspace) pendingEntryPath(id string) string {
+	return filepath.Join(w.journalPendingDir(), id+".json")
+}
+
+func (w *Workspace) writePendingEntry(entry JournalEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(w.pendingEntryPath(entry.ID), b, 0o644)
+}
+
+// Transaction runs fn under the workspace lock with every write journaled to a
+// single entry: if fn returns an error (or the process dies) all of its writes
+// are rolled back. Transactions do not nest.
+func (w *Workspace) Transaction(op string, timeout time.Duration, fn func() error) error {
+	if w.tx != nil {
+		return fmt.Errorf("%w: transaction already in progress", ErrConflict)
+	}
+	unlock, err := w.Lock(timeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	tx := &journalTx{entry: JournalEntry{ID: newULID(), Op: op, At: timeNow()}}
+	w.tx = tx
+	fnErr := fn()
+	w.tx = nil
+	if fnErr == nil {
+		if err := w.finishEntry(tx.entry); err != nil {
+			return err
+		}
+		w.appendEvents(tx.events)
+		return nil
+	}
+	if err := w.rollbackChanges(tx.entry.Changes); err != nil {
+		return fmt.Errorf("%w (rollback failed: %v; run tasker doctor)", fnErr, err)
+	}
+	if err := os.Remove(w.pendingEntryPath(tx.entry.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return fnErr
+}
+
+// commitChanges applies changes as one unit. The intent (before/after of every
+// file) is journaled first; if applying fails part-way the files already
+// touched are restored, and if the process dies the entry is left for
+// RecoverJournal. Every commit holds the workspace lock, so two processes
+// never write the store at the same time.
+func (w *Workspace) commitChanges(op string, changes []fileChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	unlock, err := w.Lock(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	entry := JournalEntry{ID: newULID(), Op: op, At: timeNow()}
+	if w.tx != nil {
+		entry = w.tx.entry
+	}
+	first := len(entry.Changes)
+	for _, c := range changes {
+		rel, err := w.relPath(c.Path)
+		if err != nil {
+			return err
+		}
+		before, err := readOptionalFile(c.Path)
+		if err != nil {
+			return err
+		}
+		entry.Changes = append(entry.Changes, JournalChange{Path: rel, Before: before, After: c.After})
+	}
+	if err := w.writePendingEntry(entry); err != nil {
+		return err
+	}
+	if w.tx != nil {
+		// The transaction owns rollback and the pending entry.
+		w.tx.entry = entry
+		for _, c := range changes {
+			if err := applyFileState(c.Path, c.After); err != nil {
+				return err
+			}
+		}
+		w.tx.events = append(w.tx.events, w.taskEvents(op, entry.Changes[first:])...)
+		return nil
+	}
+	pendingPath := w.pendingEntryPath(entry.ID)
+	for i, c := range changes {
+		if err := applyFileState(c.Path, c.After); err != nil {
+			if rbErr := w.rollbackChanges(entry.Changes[first : first+i]); rbErr != nil {
+				// Keep the pending entry so doctor/startup can finish the rollback.
+				return fmt.Errorf("%s: %w (rollback failed: %v)", op, err, rbErr)
+			}
+			_ = os.Remove(pendingPath)
+			return err
+		}
+	}
+	if err := w.finishEntry(entry); err != nil {
+		return err
+	}
+	w.appendEvents(w.taskEvents(op, entry.Changes[first:]))
+	return nil
+}
+
+// finishEntry retires a fully applied pending entry into the undo history
+// (undo's own entries are dropped so repeated undo walks further back).
+func (w *Workspace) finishEntry(entry JournalEntry) error {
+	pendingPath := w.pendingEntryPath(entry.ID)
+	if len(entry.Changes) == 0 || entry.Op == "undo" {
+		if err := os.Remove(pendingPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(w.journalHistoryDir(), 0o755); err != nil {
+		return err
+	}
+	name, err := w.nextHistoryName(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(pendingPath, filepath.Join(w.journalHistoryDir(), name)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	w.pruneJournalHistory()
+	return nil
+}
+
+// nextHistoryName numbers a finished entry one past the newest in the
+// history, so undo follows commit order. Neither the clock (which --now can
+// pin) nor the ULID is monotonic enough for that. Callers hold the workspace
+// lock. Older histories were numbered by start time in nanoseconds; new
+// entries simply continue above them.
+func (w *Workspace) nextHistoryName(entry JournalEntry) (string, error) {
+	names, err := journalEntryNames(w.journalHistoryDir())
+	if err != nil {
+		return "", err
+	}
+	var seq uint64
+	if len(names) > 0 {
+		prefix, _, _ := strings.Cut(names[len(names)-1], "-")
+		if seq, err = strconv.ParseUint(prefix, 10, 64); err != nil {
+			return "", fmt.Errorf("%w: journal history entry %s", ErrInvalid, names[len(names)-1])
+		}
+	}
+	return fmt.Sprintf("%020d-%s.json", seq+1, entry.ID), nil
+}
+
+func (w *Workspace) readHistoryEntry(name string) (JournalEntry, error) {
+	var entry JournalEntry
+	b, err := os.ReadFile(filepath.Join(w.journalHistoryDir(), name))
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(b, &entry); err != nil {
+		return entry, fmt.Errorf("%w: journal entry %s: %v", ErrInvalid, name, err)
+	}
+	return entry, nil
+}
+
+func (w *Workspace) pruneJournalHistory() {
+	names, err := journalEntryNames(w.journalHistoryDir())
+	if err != nil || len(names) <= journalHistoryLimit {
+		return
+	}
+	for _, name := range names[:len(names)-journalHistoryLimit] {
+		_ = os.Remove(filepath.Join(w.journalHistoryDir(), name))
+	}
+}
+
+// journalEntryNames lists *.json names in dir, in name order (oldest first
+// for both pending and history entries).
+func journalEntryNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// JournalHistory lists completed operations that undo can reverse, newest
+// first.
+func (w *Workspace) JournalHistory() ([]JournalEntry, error) {
+	names, err := journalEntryNames(w.journalHistoryDir())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]JournalEntry, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		entry, err := w.readHistoryEntry(names[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+// Undo reverses the most recent completed operation by restoring every file
+// it touched to its "before" content. Files changed since (by hand or by a
+// command that is not journaled) make it fail with ErrConflict unless force.
+// With dryRun nothing is written. The entry is removed from the history once
+// undone.
+func (w *Workspace) Undo(force bool, dryRun bool) (*JournalEntry, error) {
+	names, err := journalEntryNames(w.journalHistoryDir())
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: nothing to undo", ErrNotFound)
+	}
+	name := names[len(names)-1]
+	entry, err := w.readHistoryEntry(name)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]fileChange, 0, len(entry.Changes))
+	for i := len(entry.Changes) - 1; i >= 0; i-- {
+		c := entry.Changes[i]
+		path, err := w.absPath(c.Path)
+		if err != nil {
+			return nil, err
+		}
+		current, err := readOptionalFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if !force && !sameContent(current, c.After) {
+			return nil, fmt.Errorf("%w: %s changed after %s; use --force to undo anyway", ErrConflict, c.Path, entry.Op)
+		}
+		changes = append(changes, fileChange{Path: path, After: c.Before})
+	}
+	if dryRun {
+		return &entry, nil
+	}
+	if err := w.commitChanges("undo", changes); err != nil {
+		return nil, err
+	}
+	if err := os.Remove(filepath.Join(w.journalHistoryDir(), name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func sameContent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (w *Workspace) rollbackChanges(changes []JournalChange) error {
+	for i := len(changes) - 1; i >= 0; i-- {
+		path, err := w.absPath(changes[i].Path)
+		if err != nil {
+			return err
+		}
+		if err := applyFileState(path, changes[i].Before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Workspace) replayChanges(changes []JournalChange) error {
+	for _, c := range changes {
+		path, err := w.absPath(c.Path)
+		if err != nil {
+			return err
+		}
+		if err := applyFileState(path, c.After); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PendingJournal lists interrupted operations, oldest first.
+func (w *Workspace) PendingJournal() ([]JournalEntry, error) {
+	entries, err := os.ReadDir(w.journalPendingDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []JournalEntry
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(w.journalPendingDir(), e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(b, &entry); err != nil {
+			return nil, fmt.Errorf("%w: journal entry %s: %v", ErrInvalid, e.Name(), err)
+		}
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+// RecoverJournal resolves pending entries at least minAge old, newest first,
+// either rolling them back (restore "before") or replaying them (apply
+// "after"). It returns the entries it resolved. Age is measured on the
+// system clock from when the entry file was written, so a clock pinned with
+// SetNow never makes another process's in-flight write look abandoned.
+func (w *Workspace) RecoverJournal(mode string, minAge time.Duration) ([]JournalEntry, error) {
+	if mode != JournalRollback && mode != JournalReplay {
+		return nil, fmt.Errorf("%w: unknown journal mode %q", ErrInvalid, mode)
+	}
+	pending, err := w.PendingJournal()
+	if err != nil {
+		return nil, err
+	}
+	var done []JournalEntry
+	for i := len(pending) - 1; i >= 0; i-- {
+		entry := pending[i]
+		if minAge > 0 {
+			info, err := os.Stat(w.pendingEntryPath(entry.ID))
+			if err != nil || time.Since(info.ModTime()) < minAge {
+				continue
+			}
+		}
+		if mode == JournalReplay {
+			err = w.replayChanges(entry.Changes)
+		} else {
+			err = w.rollbackChanges(entry.Changes)
+		}
+		if err != nil {
+			return done, fmt.Errorf("journal %s (%s): %w", entry.ID, entry.Op, err)
+		}
+		if err := os.Remove(w.pendingEntryPath(entry.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return done, err
+		}
+		done = append(done, entry)
+	}
+	return done, nil
+}
@@ -0,0 +1,195 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecoverJournalRollsBackInterruptedMove(t *testing.T) {
+	root := t.TempDir()
+	w := &Workspace{Root: root, cfg: testConfig()}
+	oldPath := filepath.Join(root, "projects", "p", "columns", "00-inbox", "tsk_1__a.md")
+	newPath := filepath.Join(root, "projects", "p", "columns", "04-done", "tsk_1__a.md")
+	before := "old\n"
+	after := "new\n"
+	// Simulate a crash after the new file was written but before the old one was removed.
+	if err := atomicWriteFile(oldPath, []byte(before), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := atomicWriteFile(newPath, []byte(after), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entry := JournalEntry{ID: newULID(), Op: "mv", At: timeNow(), Changes: []JournalChange{
+		{Path: "projects/p/columns/04-done/tsk_1__a.md", Before: nil, After: &after},
+		{Path: "projects/p/columns/00-inbox/tsk_1__a.md", Before: &before, After: nil},
+	}}
+	b, _ := json.Marshal(entry)
+	if err := atomicWriteFile(filepath.Join(w.journalPendingDir(), entry.ID+".json"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	done, err := w.RecoverJournal(JournalRollback, 0)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if len(done) != 1 {
+		t.Fatalf("expected 1 recovered entry, got %d", len(done))
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Fatalf("expected new path removed, got %v", err)
+	}
+	got, err := os.ReadFile(oldPath)
+	if err != nil || string(got) != before {
+		t.Fatalf("expected old file restored, got %q (%v)", got, err)
+	}
+	pending, _ := w.PendingJournal()
+	if len(pending) != 0 {
+		t.Fatalf("expected journal to be clean, got %d pending", len(pending))
+	}
+}
+
+func TestTransactionRollsBackAllWrites(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	first, err := w.AddTask(AddTaskInput{Title: "Keep me", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var added *Task
+	err = w.Transaction("apply", DefaultLockTimeout, func() error {
+		var err error
+		if added, err = w.AddTask(AddTaskInput{Title: "Temp", Project: "Work"}); err != nil {
+			return err
+		}
+		if _, err := w.MoveTask(first.ID, "done"); err != nil {
+			return err
+		}
+		_, err = w.MoveTask("tsk_missing", "done")
+		return err
+	})
+	if err == nil {
+		t.Fatalf("expected transaction error")
+	}
+	if _, statErr := os.Stat(added.Path); !os.IsNotExist(statErr) {
+		t.Fatalf("expected added task removed, got %v", statErr)
+	}
+	if _, statErr := os.Stat(first.Path); statErr != nil {
+		t.Fatalf("expected original task restored: %v", statErr)
+	}
+	if _, ok := w.LockStatus(); ok {
+		t.Fatalf("expected lock released")
+	}
+	if pending, _ := w.PendingJournal(); len(pending) != 0 {
+		t.Fatalf("expected no pending journal entries, got %d", len(pending))
+	}
+}
+
+func TestWritesWaitForWorkspaceLock(t *testing.T) {
+	root := t.TempDir()
+	holder := &Workspace{Root: root, cfg: testConfig()}
+	unlock, err := holder.Lock(DefaultLockTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	other := &Workspace{Root: root, cfg: testConfig(), LockTimeout: 100 * time.Millisecond}
+	if _, err := other.AddTask(AddTaskInput{Title: "Blocked", Project: "Work"}); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked while another workspace holds the lock, got %v", err)
+	}
+	if tasks, _ := other.ListTasks(ListFilter{All: true}); len(tasks) != 0 {
+		t.Fatalf("expected no task written, got %d", len(tasks))
+	}
+
+	other.LockTimeout = LockNoWait
+	started := time.Now()
+	if _, err := other.AddTask(AddTaskInput{Title: "Blocked", Project: "Work"}); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked without waiting, got %v", err)
+	}
+	if waited := time.Since(started); waited >= lockPollEvery {
+		t.Fatalf("expected LockNoWait to fail at once, waited %s", waited)
+	}
+
+	other.NoLock = true
+	if _, err := other.AddTask(AddTaskInput{Title: "Unlocked", Project: "Work"}); err != nil {
+		t.Fatalf("expected --no-lock write to succeed: %v", err)
+	}
+	if _, ok := holder.LockStatus(); !ok {
+		t.Fatalf("expected the holder's lock to be left in place")
+	}
+}
+
+func TestLockReleaseAndStaleBreakCheckTheHolder(t *testing.T) {
+	root := t.TempDir()
+	w := &Workspace{Root: root, cfg: testConfig()}
+	path := w.lockPath()
+
+	// A lock broken as stale and retaken by someone else survives the
+	// original holder's release.
+	unlock, err := w.Lock(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retaken := "4242\nelsewhere\n2030-01-01T00:00:00Z\nOTHER\n"
+	if err := os.WriteFile(path, []byte(retaken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+	if b, err := os.ReadFile(path); err != nil || string(b) != retaken {
+		t.Fatalf("expected another holder's lock kept on release, got %q, %v", b, err)
+	}
+
+	// A fresh lock is not broken; an abandoned one is, without leftovers.
+	if _, err := w.Lock(0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked on a fresh lock, got %v", err)
+	}
+	old := time.Now().Add(-2 * lockStaleAfter)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = w.Lock(0)
+	if err != nil {
+		t.Fatalf("expected the stale lock broken, got %v", err)
+	}
+	if lockToken(path) == "OTHER" {
+		t.Fatalf("expected a new lock file")
+	}
+	unlock()
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".lock") {
+			t.Fatalf("expected no lock files left after release, found %s", e.Name())
+		}
+	}
+}
+
+func TestRecoverJournalIgnoresPinnedClock(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	after := "new\n"
+	entry := JournalEntry{ID: newULID(), Op: "add", At: timeNow(), Changes: []JournalChange{
+		{Path: "projects/p/columns/00-inbox/tsk_1__a.md", After: &after},
+	}}
+	b, _ := json.Marshal(entry)
+	if err := atomicWriteFile(w.pendingEntryPath(entry.ID), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	SetNow(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer SetNow(time.Time{})
+	if got := Now(); !got.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the pinned clock, got %s", got)
+	}
+	done, err := w.RecoverJournal(JournalRollback, journalStaleAfter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(done) != 0 {
+		t.Fatalf("expected a fresh entry to be left alone under a pinned clock, got %d resolved", len(done))
+	}
+}
@@ -0,0 +1,323 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskLink is one end of a link between two tasks. Links are stored on both
+// tasks; Type is read from the owning task's side ("follows" on one end is
+// "followed-by" on the other).
+type TaskLink struct {
+	ID   string `yaml:"id" json:"id"`
+	Type string `yaml:"type" json:"type"`
+}
+
+// LinkTypes are the link types accepted by LinkTasks.
+var LinkTypes = []string{"relates", "duplicates", "follows"}
+
+var linkInverse = map[string]string{
+	"relates":       "relates",
+	"duplicates":    "duplicated-by",
+	"duplicated-by": "duplicates",
+	"follows":       "followed-by",
+	"followed-by":   "follows",
+}
+
+// NormalizeLinkType validates a link type; empty means "relates".
+func NormalizeLinkType(s string) (string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return "relates", nil
+	}
+	if _, ok := linkInverse[s]; !ok {
+		return "", fmt.Errorf("%w: unknown link type %q (use %s)", ErrInvalid, s, strings.Join(LinkTypes, "|"))
+	}
+	return s, nil
+}
+
+// LinkedTask is a link resolved against the store: Task for a linked task,
+// Idea for a linked idea, neither when the linked ID no longer exists.
+type LinkedTask struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Task *Task  `json:"task,omitempty"`
+	Idea *Idea  `json:"idea,omitempty"`
+}
+
+// setLink records (or retypes) the link to id on t and reports whether t
+// changed.
+func (t *Task) setLink(id string, typ string) bool {
+	for i, l := range t.Links {
+		if l.ID == id {
+			if l.Type == typ {
+				return false
+			}
+			t.Links[i].Type = typ
+			return true
+		}
+	}
+	t.Links = append(t.Links, TaskLink{ID: id, Type: typ})
+	return true
+}
+
+// removeLink drops the link to id from t and reports whether t had one.
+func (t *Task) removeLink(id string) bool {
+	for i, l := range t.Links {
+		if l.ID == id {
+			t.Links = append(t.Links[:i], t.Links[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// LinkTasks links a to b with typ read as "a <typ> b" and writes both task
+// files as one operation. Linking an already linked pair updates its type.
+func (w *Workspace) LinkTasks(aPrefix string, bPrefix string, typ string) (*Task, *Task, error) {
+	typ, err := NormalizeLinkType(typ)
+	if err != nil {
+		return nil, nil, err
+	}
+	a, err := w.GetTaskByPrefix(aPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := w.GetTaskByPrefix(bPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.ID == b.ID {
+		return nil, nil, fmt.Errorf("%w: a task cannot link to itself", ErrInvalid)
+	}
+	now := timeNow()
+	var changes []fileChange
+	for _, side := range []struct {
+		task  *Task
+		other string
+		typ   string
+	}{{a, b.ID, typ}, {b, a.ID, linkInverse[typ]}} {
+		if !side.task.setLink(side.other, side.typ) {
+			continue
+		}
+		side.task.UpdatedAt = &now
+		content, err := renderTaskFile(side.task)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fileChange{Path: side.task.Path, After: &content})
+	}
+	if err := w.commitChanges("link", changes); err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+// UnlinkTasks removes the link between a and b from both task files as one
+// operation. Tasks that are not linked give ErrNotFound.
+func (w *Workspace) UnlinkTasks(aPrefix string, bPrefix string) (*Task, *Task, error) {
+	a, err := w.GetTaskByPrefix(aPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := w.GetTaskByPrefix(bPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := timeNow()
+	var changes []fileChange
+	for _, side := range []struct {
+		task  *Task
+		other string
+	}{{a, b.ID}, {b, a.ID}} {
+		if !side.task.removeLink(side.other) {
+			continue
+		}
+		side.task.UpdatedAt = &now
+		content, err := renderTaskFile(side.task)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fileChange{Path: side.task.Path, After: &content})
+	}
+	if len(changes) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s and %s are not linked", ErrNotFound, a.ID, b.ID)
+	}
+	if err := w.commitChanges("unlink", changes); err != nil {
+		return nil, nil, err
+	}
+	return a, b, nil
+}
+
+// TaskLinks resolves t's links in stored order.
+func (w *Workspace) TaskLinks(t *Task) ([]LinkedTask, error) {
+	if len(t.Links) == 0 {
+		return nil, nil
+	}
+	byID, err := w.tasksByID()
+	if err != nil {
+		return nil, err
+	}
+	var ideas map[string]*Idea
+	out := make([]LinkedTask, 0, len(t.Links))
+	for _, l := range t.Links {
+		link := LinkedTask{Type: l.Type, ID: l.ID, Task: byID[l.ID]}
+		if isIdeaID(l.ID) {
+			if ideas == nil {
+				if ideas, err = w.ideasByID(); err != nil {
+					return nil, err
+				}
+			}
+			link.Idea = ideas[l.ID]
+		}
+		out = append(out, link)
+	}
+	return out, nil
+}
+
+// An idea has no frontmatter, so its side of a link to a task is a
+// "Related: <task-id> (<title>)" line in its body.
+const ideaRelatedPrefix = "Related:"
+
+func isIdeaID(id string) bool {
+	return strings.HasPrefix(strings.ToLower(id), "idea_")
+}
+
+// ideaRelatedIDs lists the task IDs of body's Related: lines.
+func ideaRelatedIDs(body string) []string {
+	var ids []string
+	for _, line := range strings.Split(body, "\n") {
+		if id := relatedLineID(line); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return dedupeStrings(ids)
+}
+
+func relatedLineID(line string) string {
+	line = strings.TrimSpace(line)
+	if len(line) < len(ideaRelatedPrefix) || !strings.EqualFold(line[:len(ideaRelatedPrefix)], ideaRelatedPrefix) {
+		return ""
+	}
+	fields := strings.Fields(line[len(ideaRelatedPrefix):])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+func (w *Workspace) ideasByID() (map[string]*Idea, error) {
+	paths, err := w.allIdeaPaths()
+	if err != nil {
+		return nil, err
+	}
+	ideas := w.readIdeaPaths(paths)
+	byID := make(map[string]*Idea, len(ideas))
+	for i := range ideas {
+		byID[ideas[i].ID] = &ideas[i]
+	}
+	return byID, nil
+}
+
+// ideaBodyChange rewrites idea with a new body, in its own file or its
+// journal entry.
+func (w *Workspace) ideaBodyChange(idea *Idea, body string) (fileChange, error) {
+	tags := inferIdeaTags(idea.Title, body, idea.Tags)
+	if idea.Journal {
+		return w.journalEntryChange(idea, idea.Title, tags, body, false)
+	}
+	content := formatIdeaContent(idea.Title, tags, body)
+	return fileChange{Path: idea.Path, After: &content}, nil
+}
+
+// LinkIdea links an idea and a task ("relates" both ways) as one operation:
+// the task lists the idea under links:, the idea gets a Related: line.
+func (w *Workspace) LinkIdea(idea *Idea, taskPrefix string) (*Idea, *Task, error) {
+	current, err := w.currentIdea(idea)
+	if err != nil {
+		return nil, nil, err
+	}
+	current.Archived = idea.Archived
+	t, err := w.GetTaskByPrefix(taskPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	var changes []fileChange
+	if t.setLink(current.ID, "relates") {
+		now := timeNow()
+		t.UpdatedAt = &now
+		content, err := renderTaskFile(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fileChange{Path: t.Path, After: &content})
+	}
+	if !containsString(ideaRelatedIDs(current.Body), t.ID) {
+		line := fmt.Sprintf("%s %s (%s)", ideaRelatedPrefix, t.ID, strings.TrimSpace(t.Title))
+		body := line
+		if strings.TrimSpace(current.Body) != "" {
+			body = strings.TrimRight(current.Body, "\n") + "\n\n" + line
+		}
+		change, err := w.ideaBodyChange(current, body)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, change)
+		current.Body = body
+	}
+	if len(changes) > 0 {
+		if err := w.commitChanges("link", changes); err != nil {
+			return nil, nil, err
+		}
+	}
+	current.Links = ideaRelatedIDs(current.Body)
+	return current, t, nil
+}
+
+// UnlinkIdea removes the link between an idea and a task from both sides
+// as one operation. An idea and task that are not linked give ErrNotFound.
+func (w *Workspace) UnlinkIdea(idea *Idea, taskPrefix string) (*Idea, *Task, error) {
+	current, err := w.currentIdea(idea)
+	if err != nil {
+		return nil, nil, err
+	}
+	current.Archived = idea.Archived
+	t, err := w.GetTaskByPrefix(taskPrefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	var changes []fileChange
+	if t.removeLink(current.ID) {
+		now := timeNow()
+		t.UpdatedAt = &now
+		content, err := renderTaskFile(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, fileChange{Path: t.Path, After: &content})
+	}
+	if containsString(ideaRelatedIDs(current.Body), t.ID) {
+		lines := strings.Split(current.Body, "\n")
+		kept := lines[:0]
+		for _, line := range lines {
+			if !strings.EqualFold(relatedLineID(line), t.ID) {
+				kept = append(kept, line)
+			}
+		}
+		body := strings.Trim(strings.Join(kept, "\n"), "\n")
+		change, err := w.ideaBodyChange(current, body)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, change)
+		current.Body = body
+	}
+	if len(changes) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s and %s are not linked", ErrNotFound, current.ID, t.ID)
+	}
+	if err := w.commitChanges("unlink", changes); err != nil {
+		return nil, nil, err
+	}
+	current.Links = ideaRelatedIDs(current.Body)
+	return current, t, nil
+}
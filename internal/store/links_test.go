@@ -0,0 +1,90 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLinkTasksIsSymmetric(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	spec, _ := w.AddTask(AddTaskInput{Title: "Spec", Project: "Work"})
+	build, _ := w.AddTask(AddTaskInput{Title: "Build", Project: "Work"})
+	if _, _, err := w.LinkTasks(build.ID, spec.ID, "follows"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := w.LinkTasks(build.ID, build.ID, ""); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected self-link to be rejected, got %v", err)
+	}
+	if _, _, err := w.LinkTasks(build.ID, spec.ID, "blocks"); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected unknown type to be rejected, got %v", err)
+	}
+	got, err := w.GetTaskByPrefix(spec.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	links, err := w.TaskLinks(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 || links[0].Type != "followed-by" || links[0].Task == nil || links[0].Task.ID != build.ID {
+		t.Fatalf("unexpected links on spec: %+v", links)
+	}
+	// Relinking retypes both ends instead of adding a second link.
+	if _, _, err := w.LinkTasks(spec.ID, build.ID, "relates"); err != nil {
+		t.Fatal(err)
+	}
+	got, _ = w.GetTaskByPrefix(build.ID)
+	if len(got.Links) != 1 || got.Links[0].Type != "relates" || got.Links[0].ID != spec.ID {
+		t.Fatalf("unexpected links on build: %+v", got.Links)
+	}
+}
+
+func TestLinkAndUnlinkIdea(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, _ := w.AddTask(AddTaskInput{Title: "Build", Project: "Work"})
+	idea, err := w.AddIdea(AddIdeaInput{Title: "Pricing page", Body: "tiers #web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked, _, err := w.LinkIdea(idea, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(linked.Links) != 1 || linked.Links[0] != task.ID {
+		t.Fatalf("unexpected idea links: %+v", linked.Links)
+	}
+	got, err := w.GetIdeaBySelectorFiltered(idea.ID, IdeaSelectorFilter{})
+	if err != nil || len(got.Links) != 1 || !containsString(got.Tags, "web") {
+		t.Fatalf("link not read back: %+v, %v", got, err)
+	}
+	// Linking again changes nothing.
+	if _, _, err := w.LinkIdea(got, task.ID); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := w.GetIdeaBySelectorFiltered(idea.ID, IdeaSelectorFilter{})
+	if again.Body != got.Body {
+		t.Fatalf("relinking should not add a second line:\n%s", again.Body)
+	}
+	tk, _ := w.GetTaskByPrefix(task.ID)
+	links, err := w.TaskLinks(tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 || links[0].Idea == nil || links[0].Idea.ID != idea.ID {
+		t.Fatalf("unexpected task links: %+v", links)
+	}
+
+	unlinked, _, err := w.UnlinkIdea(got, task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unlinked.Links) != 0 || unlinked.Body != "tiers #web" {
+		t.Fatalf("unexpected idea after unlink: %+v", unlinked)
+	}
+	if tk, _ = w.GetTaskByPrefix(task.ID); len(tk.Links) != 0 {
+		t.Fatalf("task link not removed: %+v", tk.Links)
+	}
+	if _, _, err := w.UnlinkIdea(unlinked, task.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used when config locale is unset or unknown.
+const DefaultLocale = "en"
+
+// localeTable holds the date words of one language. Weekdays start on Sunday
+// to match time.Weekday.
+type localeTable struct {
+	weekdays    [7]string
+	weekdayAbbr [7]string
+	monthAbbr   [12]string
+	// dayFirst renders "21 Jan" rather than "Jan 21".
+	dayFirst bool
+	// nextBefore/nextAfter are the words for "next" in "next friday"
+	// ("nächsten Freitag", "vendredi prochain").
+	nextBefore []string
+	nextAfter  []string
+	// extra are further accepted weekday names ("segunda" for Monday).
+	extra map[string]time.Weekday
+}
+
+var locales = map[string]localeTable{
+	"en": {
+		weekdays:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		weekdayAbbr: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		monthAbbr:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		nextBefore:  []string{"next"},
+	},
+	"de": {
+		weekdays:    [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		weekdayAbbr: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		monthAbbr:   [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+		dayFirst:    true,
+		nextBefore:  []string{"nächsten", "nächster", "nächste", "kommenden"},
+		extra:       map[string]time.Weekday{"sonnabend": time.Saturday},
+	},
+	"fr": {
+		weekdays:    [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		weekdayAbbr: [7]string{"dim", "lun", "mar", "mer", "jeu", "ven", "sam"},
+		monthAbbr:   [12]string{"janv", "févr", "mars", "avr", "mai", "juin", "juil", "août", "sept", "oct", "nov", "déc"},
+		dayFirst:    true,
+		nextAfter:   []string{"prochain"},
+	},
+	"es": {
+		weekdays:    [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		weekdayAbbr: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		monthAbbr:   [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sep", "oct", "nov", "dic"},
+		dayFirst:    true,
+		nextBefore:  []string{"próximo", "el próximo"},
+		nextAfter:   []string{"que viene"},
+	},
+	"it": {
+		weekdays:    [7]string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		weekdayAbbr: [7]string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+		monthAbbr:   [12]string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+		dayFirst:    true,
+		nextBefore:  []string{"prossimo", "prossima"},
+	},
+	"nl": {
+		weekdays:    [7]string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		weekdayAbbr: [7]string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+		monthAbbr:   [12]string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+		dayFirst:    true,
+		nextBefore:  []string{"volgende", "komende"},
+	},
+	"pt": {
+		weekdays:    [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		weekdayAbbr: [7]string{"dom", "seg", "ter", "qua", "qui", "sex", "sáb"},
+		monthAbbr:   [12]string{"jan", "fev", "mar", "abr", "mai", "jun", "jul", "ago", "set", "out", "nov", "dez"},
+		dayFirst:    true,
+		nextBefore:  []string{"próximo", "próxima"},
+		extra: map[string]time.Weekday{
+			"segunda": time.Monday, "terça": time.Tuesday, "quarta": time.Wednesday,
+			"quinta": time.Thursday, "sexta": time.Friday,
+		},
+	},
+}
+
+// Locales lists the supported locale ids.
+func Locales() []string {
+	out := make([]string, 0, len(locales))
+	for id := range locales {
+		out = append(out, id)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// NormalizeLocale maps "de", "de-DE" or "de_AT.UTF-8" to a supported locale
+// id; empty is DefaultLocale.
+func NormalizeLocale(s string) (string, error) {
+	id := strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(id, "-_."); i >= 0 {
+		id = id[:i]
+	}
+	if id == "" || id == "c" || id == "posix" {
+		return DefaultLocale, nil
+	}
+	if _, ok := locales[id]; !ok {
+		return "", fmt.Errorf("%w: unknown locale %q (use %s)", ErrInvalid, s, strings.Join(Locales(), "|"))
+	}
+	return id, nil
+}
+
+// LocaleID is config locale, or DefaultLocale when unset or unknown.
+func (c Config) LocaleID() string {
+	id, err := NormalizeLocale(c.Locale)
+	if err != nil {
+		return DefaultLocale
+	}
+	return id
+}
+
+func (c Config) locale() localeTable {
+	return locales[c.LocaleID()]
+}
+
+// WeekdayAbbrev is the short weekday name in the configured locale.
+func (c Config) WeekdayAbbrev(wd time.Weekday) string {
+	return c.locale().weekdayAbbr[wd]
+}
+
+// DayLabel is "2026-01-21 (Wed)" with the weekday in the configured locale.
+func (c Config) DayLabel(d time.Time) string {
+	return fmt.Sprintf("%s (%s)", d.Format("2006-01-02"), c.WeekdayAbbrev(d.Weekday()))
+}
+
+// FormatDueShort renders a due date as "Jan 02" ("02 Jan" in day-first
+// locales), adding the year when it is not the current one. Values that are
+// not dates are returned as they are.
+func (c Config) FormatDueShort(due string) string {
+	due = strings.TrimSpace(due)
+	if due == "" {
+		return ""
+	}
+	t, ok := parseDueDate(due)
+	if !ok {
+		return due
+	}
+	loc := c.locale()
+	out := fmt.Sprintf("%s %02d", loc.monthAbbr[t.Month()-1], t.Day())
+	if loc.dayFirst {
+		out = fmt.Sprintf("%02d %s", t.Day(), loc.monthAbbr[t.Month()-1])
+	}
+	if t.Year() != timeNow().UTC().Year() {
+		out += fmt.Sprintf(" %d", t.Year())
+	}
+	return out
+}
+
+// WeekdayNames maps the configured locale's weekday names and abbreviations,
+// lowercased and also without accents, to their weekday. English names are
+// not included unless the locale is English.
+func (c Config) WeekdayNames() map[string]time.Weekday {
+	loc := c.locale()
+	out := map[string]time.Weekday{}
+	add := func(name string, wd time.Weekday) {
+		name = strings.ToLower(name)
+		out[name] = wd
+		out[foldAccents(name)] = wd
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		add(loc.weekdays[wd], wd)
+		add(loc.weekdayAbbr[wd], wd)
+	}
+	for name, wd := range loc.extra {
+		add(name, wd)
+	}
+	return out
+}
+
+// NextWords returns the configured locale's words for "next" before and
+// after a weekday, lowercased, with accent-free variants.
+func (c Config) NextWords() (before []string, after []string) {
+	loc := c.locale()
+	fold := func(words []string) []string {
+		var out []string
+		for _, w := range words {
+			w = strings.ToLower(w)
+			out = append(out, w)
+			if f := foldAccents(w); f != w {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+	return fold(loc.nextBefore), fold(loc.nextAfter)
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// foldAccents strips the diacritics of the letters used in weekday names so
+// "sabado" matches "sábado".
+func foldAccents(s string) string {
+	return accentFolder.Replace(s)
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocaleLabels(t *testing.T) {
+	restore := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 21, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = restore }()
+
+	d := time.Date(2026, 1, 23, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		locale string
+		label  string
+		due    string
+	}{
+		{"", "2026-01-23 (Fri)", "Jan 23"},
+		{"de-DE", "2026-01-23 (Fr)", "23 Jan"},
+		{"fr", "2026-01-23 (ven)", "23 janv"},
+	}
+	for _, c := range cases {
+		cfg := Config{Locale: c.locale}
+		if got := cfg.DayLabel(d); got != c.label {
+			t.Fatalf("%q DayLabel = %q, want %q", c.locale, got, c.label)
+		}
+		if got := cfg.FormatDueShort("2026-01-23"); got != c.due {
+			t.Fatalf("%q FormatDueShort = %q, want %q", c.locale, got, c.due)
+		}
+	}
+	if got := (Config{Locale: "de"}).FormatDueShort("2027-03-02"); got != "02 Mär 2027" {
+		t.Fatalf("FormatDueShort other year = %q", got)
+	}
+}
+
+func TestLocaleWeekdayNames(t *testing.T) {
+	names := Config{Locale: "pt"}.WeekdayNames()
+	for name, want := range map[string]time.Weekday{"sexta-feira": time.Friday, "sábado": time.Saturday, "sabado": time.Saturday, "terca": time.Tuesday} {
+		if got, ok := names[name]; !ok || got != want {
+			t.Fatalf("pt %q = %v (%t), want %v", name, got, ok, want)
+		}
+	}
+	if _, ok := names["friday"]; ok {
+		t.Fatalf("pt names should not include English")
+	}
+	if id, err := NormalizeLocale("de_AT.UTF-8"); err != nil || id != "de" {
+		t.Fatalf("NormalizeLocale = %q, %v", id, err)
+	}
+	if _, err := NormalizeLocale("xx"); err == nil {
+		t.Fatalf("expected unknown locale error")
+	}
+}
@@ -0,0 +1,224 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultLockTimeout is how long Lock waits for another holder by default.
+	DefaultLockTimeout = 10 * time.Second
+	// lockStaleAfter is when an unreleased lock file is considered abandoned.
+	lockStaleAfter = 10 * time.Minute
+	lockPollEvery  = 50 * time.Millisecond
+	// LockNoWait as Workspace.LockTimeout makes a write fail at once when
+	// another process holds the lock.
+	LockNoWait time.Duration = -1
+)
+
+// ErrLocked is returned when the workspace lock could not be acquired in time.
+// It satisfies errors.Is(err, ErrConflict).
+var ErrLocked = fmt.Errorf("%w: workspace is locked", ErrConflict)
+
+// ErrReadOnly is returned when the lock file cannot be created because the
+// workspace is on a read-only filesystem or not writable by this user.
+var ErrReadOnly = errors.New("workspace is read-only")
+
+func isReadOnlyErr(err error) bool {
+	return errors.Is(err, fs.ErrPermission) || errors.Is(err, syscall.EROFS)
+}
+
+// LockFailure is the error of the last Lock call that failed with ErrLocked
+// or ErrReadOnly, or nil. The CLI uses it to pick a specific exit code for a
+// command that failed on the lock, whatever error path reported it.
+func (w *Workspace) LockFailure() error {
+	return w.lockErr
+}
+
+// LockInfo describes the current lock holder.
+type LockInfo struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host,omitempty"`
+	Since   time.Time `json:"since"`
+	Stale   bool      `json:"stale"`
+	Present bool      `json:"present"`
+}
+
+func (w *Workspace) lockPath() string {
+	return filepath.Join(w.Root, ".lock")
+}
+
+// Lock acquires the workspace lock (<root>/.lock, created with O_EXCL),
+// waiting up to timeout. Locks older than lockStaleAfter are broken.
+// The lock file holds the pid, host, time and a token unique to the holder.
+// The returned func releases the lock. Lock is reentrant per Workspace: a
+// nested call succeeds at once and only the outermost release removes the
+// lock file. With NoLock set it does nothing.
+func (w *Workspace) Lock(timeout time.Duration) (func(), error) {
+	if w.NoLock {
+		return func() {}, nil
+	}
+	if w.lockDepth > 0 {
+		w.lockDepth++
+		return func() { w.lockDepth-- }, nil
+	}
+	unlock, err := w.lock(timeout)
+	if err != nil {
+		if errors.Is(err, ErrLocked) || errors.Is(err, ErrReadOnly) {
+			w.lockErr = err
+		}
+		return nil, err
+	}
+	w.lockDepth = 1
+	return func() {
+		if w.lockDepth--; w.lockDepth == 0 {
+			unlock()
+		}
+	}, nil
+}
+
+// WriteLockTimeout is LockTimeout, DefaultLockTimeout when unset, or 0
+// (a single attempt) for LockNoWait.
+func (w *Workspace) WriteLockTimeout() time.Duration {
+	switch {
+	case w.LockTimeout > 0:
+		return w.LockTimeout
+	case w.LockTimeout < 0:
+		return 0
+	}
+	return DefaultLockTimeout
+}
+
+// LockConfig takes the workspace lock and re-reads config.json, so a
+// Config/SaveConfig read-modify-write sees every earlier save and no other
+// process can save in between.
+func (w *Workspace) LockConfig(timeout time.Duration) (func(), error) {
+	unlock, err := w.Lock(timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.loadOrDefaultConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		unlock()
+		return nil, err
+	}
+	return unlock, nil
+}
+
+func (w *Workspace) lock(timeout time.Duration) (func(), error) {
+	if err := os.MkdirAll(w.Root, 0o755); err != nil {
+		if isReadOnlyErr(err) {
+			return nil, fmt.Errorf("%w: %v", ErrReadOnly, err)
+		}
+		return nil, err
+	}
+	path := w.lockPath()
+	host, _ := os.Hostname()
+	// token identifies this holder in the lock file, so the release (and a
+	// stale-lock break) never removes a lock someone else has taken since.
+	token := newULID()
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n%s\n%s\n%s\n", os.Getpid(), host, timeNow().Format(time.RFC3339Nano), token)
+			_ = f.Close()
+			return func() { removeLockIfOwned(path, token) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			if isReadOnlyErr(err) {
+				return nil, fmt.Errorf("%w: %v", ErrReadOnly, err)
+			}
+			return nil, err
+		}
+		if breakStaleLock(path, token) {
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			if info, ok := w.LockStatus(); ok {
+				return nil, fmt.Errorf("%w (held by pid %d since %s)", ErrLocked, info.PID, info.Since.Format(time.RFC3339))
+			}
+			return nil, ErrLocked
+		}
+		time.Sleep(lockPollEvery)
+	}
+}
+
+// lockToken is the holder token on the fourth line of a lock file, or "".
+func lockToken(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) < 4 {
+		return ""
+	}
+	return strings.TrimSpace(lines[3])
+}
+
+// removeLockIfOwned releases the lock at path if token still holds it. A
+// lock broken as stale and retaken by another process is left alone.
+func removeLockIfOwned(path string, token string) {
+	if lockToken(path) == token {
+		_ = os.Remove(path)
+	}
+}
+
+// breakStaleLock removes the lock file at path if it is older than
+// lockStaleAfter and reports whether it did. Two processes may find the same
+// stale lock, and one of them may already have taken a fresh lock by the time
+// the other acts, so the file is first renamed to a name unique to this
+// attempt (an atomic step only one of them can win) and checked again there;
+// a lock that turns out to be fresh is put back.
+func breakStaleLock(path string, token string) bool {
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) <= lockStaleAfter {
+		return false
+	}
+	claimed := path + ".stale-" + token
+	if err := os.Rename(path, claimed); err != nil {
+		return false
+	}
+	if info, err := os.Stat(claimed); err == nil && time.Since(info.ModTime()) <= lockStaleAfter {
+		// Link fails rather than replace a lock taken in the meantime.
+		_ = os.Link(claimed, path)
+		_ = os.Remove(claimed)
+		return false
+	}
+	_ = os.Remove(claimed)
+	return true
+}
+
+// LockStatus reports the current lock holder, if any.
+func (w *Workspace) LockStatus() (LockInfo, bool) {
+	path := w.lockPath()
+	stat, err := os.Stat(path)
+	if err != nil {
+		return LockInfo{}, false
+	}
+	info := LockInfo{Present: true, Since: stat.ModTime().UTC()}
+	b, err := os.ReadFile(path)
+	if err == nil {
+		lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+		if len(lines) > 0 {
+			info.PID, _ = strconv.Atoi(strings.TrimSpace(lines[0]))
+		}
+		if len(lines) > 1 {
+			info.Host = strings.TrimSpace(lines[1])
+		}
+		if len(lines) > 2 {
+			if t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(lines[2])); err == nil {
+				info.Since = t
+			}
+		}
+	}
+	info.Stale = time.Since(stat.ModTime()) > lockStaleAfter
+	return info, true
+}
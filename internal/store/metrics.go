@@ -0,0 +1,256 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// StatusCount is the number of tasks in one project with one status.
+type StatusCount struct {
+	Project string `json:"project"`
+	Status  string `json:"status"`
+	Count   int    `json:"count"`
+}
+
+// WorkspaceMetrics is a point-in-time snapshot of task counts.
+type WorkspaceMetrics struct {
+	GeneratedAt time.Time     `json:"generated_at"`
+	Projects    int           `json:"projects"`
+	Tasks       []StatusCount `json:"tasks"`
+	Open        int           `json:"open"`
+	Overdue     int           `json:"overdue"`
+	DueToday    int           `json:"due_today"`
+}
+
+// Metrics counts tasks (including archived) by project and status, plus
+// open tasks that are overdue or due today.
+func (w *Workspace) Metrics() (*WorkspaceMetrics, error) {
+	projects, err := w.ActiveProjects()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow()
+	today := now.Format("2006-01-02")
+	m := &WorkspaceMetrics{GeneratedAt: now, Projects: len(projects)}
+	counts := map[[2]string]int{}
+	for _, t := range tasks {
+		counts[[2]string{t.Project, t.Status}]++
+		if !w.cfg.IsOpenStatus(t.Status) {
+			continue
+		}
+		m.Open++
+		due, ok := parseDueDate(t.Due)
+		if !ok {
+			continue
+		}
+		d := due.In(time.UTC).Format("2006-01-02")
+		if d == today {
+			m.DueToday++
+		} else if d < today {
+			m.Overdue++
+		}
+	}
+	for k, n := range counts {
+		m.Tasks = append(m.Tasks, StatusCount{Project: k[0], Status: k[1], Count: n})
+	}
+	sort.Slice(m.Tasks, func(i, j int) bool {
+		if m.Tasks[i].Project != m.Tasks[j].Project {
+			return m.Tasks[i].Project < m.Tasks[j].Project
+		}
+		return m.Tasks[i].Status < m.Tasks[j].Status
+	})
+	return m, nil
+}
+
+// ProjectStats is one project's row in MetricsReport.
+type ProjectStats struct {
+	Project     string `json:"project"`
+	Open        int    `json:"open"`
+	Overdue     int    `json:"overdue"`
+	DueToday    int    `json:"due_today"`
+	Done        int    `json:"done"`
+	Completed7d int    `json:"completed_7d"`
+	Ideas       int    `json:"ideas"`
+}
+
+// TaskTotals are workspace-wide task counts for MetricsReport.
+type TaskTotals struct {
+	Total    int            `json:"total"`
+	ByStatus map[string]int `json:"by_status"`
+	Open     int            `json:"open"`
+	Overdue  int            `json:"overdue"`
+	DueToday int            `json:"due_today"`
+}
+
+// Throughput counts tasks created and completed over trailing windows.
+type Throughput struct {
+	Completed7d  int `json:"completed_7d"`
+	Completed30d int `json:"completed_30d"`
+	Created7d    int `json:"created_7d"`
+	Created30d   int `json:"created_30d"`
+}
+
+// IdeaTotals counts ideas in the root inbox and per project.
+type IdeaTotals struct {
+	Total     int            `json:"total"`
+	Root      int            `json:"root"`
+	ByProject map[string]int `json:"by_project"`
+}
+
+// MetricsReport is the single-file dashboard export written by
+// `tasker export metrics`; Schema changes when fields are removed or renamed.
+type MetricsReport struct {
+	Schema      int            `json:"schema"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Projects    int            `json:"projects"`
+	Tasks       TaskTotals     `json:"tasks"`
+	Throughput  Throughput     `json:"throughput"`
+	PerProject  []ProjectStats `json:"per_project"`
+	Ideas       IdeaTotals     `json:"ideas"`
+}
+
+// MetricsReport gathers counts, overdue, throughput, per-project stats and
+// idea totals in one pass over the workspace (archived tasks included).
+func (w *Workspace) MetricsReport() (*MetricsReport, error) {
+	projects, err := w.ActiveProjects()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	ideas, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow()
+	today := now.Format("2006-01-02")
+	weekAgo := now.AddDate(0, 0, -7)
+	monthAgo := now.AddDate(0, 0, -30)
+	r := &MetricsReport{
+		Schema:      1,
+		GeneratedAt: now,
+		Projects:    len(projects),
+		Tasks:       TaskTotals{ByStatus: map[string]int{}},
+		Ideas:       IdeaTotals{ByProject: map[string]int{}},
+	}
+	byProject := map[string]*ProjectStats{}
+	stats := func(slug string) *ProjectStats {
+		if byProject[slug] == nil {
+			byProject[slug] = &ProjectStats{Project: slug}
+		}
+		return byProject[slug]
+	}
+	for _, p := range projects {
+		stats(p.Slug)
+	}
+	for _, t := range tasks {
+		ps := stats(t.Project)
+		r.Tasks.Total++
+		r.Tasks.ByStatus[t.Status]++
+		if t.CreatedAt != nil && t.CreatedAt.After(monthAgo) {
+			r.Throughput.Created30d++
+			if t.CreatedAt.After(weekAgo) {
+				r.Throughput.Created7d++
+			}
+		}
+		if t.CompletedAt != nil && t.CompletedAt.After(monthAgo) {
+			r.Throughput.Completed30d++
+			if t.CompletedAt.After(weekAgo) {
+				r.Throughput.Completed7d++
+				ps.Completed7d++
+			}
+		}
+		if t.Status == "done" {
+			ps.Done++
+		}
+		if !w.cfg.IsOpenStatus(t.Status) {
+			continue
+		}
+		r.Tasks.Open++
+		ps.Open++
+		due, ok := parseDueDate(t.Due)
+		if !ok {
+			continue
+		}
+		switch d := due.In(time.UTC).Format("2006-01-02"); {
+		case d == today:
+			r.Tasks.DueToday++
+			ps.DueToday++
+		case d < today:
+			r.Tasks.Overdue++
+			ps.Overdue++
+		}
+	}
+	for _, i := range ideas {
+		r.Ideas.Total++
+		if i.Project == "" {
+			r.Ideas.Root++
+			continue
+		}
+		r.Ideas.ByProject[i.Project]++
+		stats(i.Project).Ideas++
+	}
+	for _, ps := range byProject {
+		r.PerProject = append(r.PerProject, *ps)
+	}
+	sort.Slice(r.PerProject, func(i, j int) bool { return r.PerProject[i].Project < r.PerProject[j].Project })
+	return r, nil
+}
+
+// ReadOpLog returns operations log entries, oldest first, across rotated files.
+// Unparseable lines are skipped.
+func (w *Workspace) ReadOpLog() ([]OpLogEntry, error) {
+	maxFiles := defaultLogMaxFiles
+	if w.cfg.Log != nil && w.cfg.Log.MaxFiles > 0 {
+		maxFiles = w.cfg.Log.MaxFiles
+	}
+	path := w.OpLogPath()
+	files := make([]string, 0, maxFiles+1)
+	for i := maxFiles; i >= 1; i-- {
+		files = append(files, fmt.Sprintf("%s.%d", path, i))
+	}
+	files = append(files, path)
+
+	var out []OpLogEntry
+	for _, name := range files {
+		entries, err := readOpLogFile(name)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, entries...)
+	}
+	return out, nil
+}
+
+// readOpLogFile reads one operations log file; a missing file is empty.
+func readOpLogFile(name string) ([]OpLogEntry, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var out []OpLogEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for sc.Scan() {
+		var e OpLogEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out, sc.Err()
+}
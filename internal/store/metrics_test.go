@@ -0,0 +1,38 @@
+package store
+
+import (
+	"testing"
+)
+
+func TestMetricsReportPerProjectAndIdeas(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.AddTask(AddTaskInput{Title: "Late", Project: "Work", Due: "2000-01-01"}); err != nil {
+		t.Fatal(err)
+	}
+	task, err := w.AddTask(AddTaskInput{Title: "Shipped", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.CompleteTask(task.ID, MoveOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Someday"}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := w.MetricsReport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Tasks.Total != 2 || r.Tasks.Overdue != 1 || r.Tasks.ByStatus["done"] != 1 {
+		t.Fatalf("unexpected task totals %+v", r.Tasks)
+	}
+	if r.Throughput.Completed7d != 1 || r.Throughput.Created7d != 2 {
+		t.Fatalf("unexpected throughput %+v", r.Throughput)
+	}
+	if len(r.PerProject) != 1 || r.PerProject[0].Done != 1 || r.PerProject[0].Overdue != 1 {
+		t.Fatalf("unexpected per-project stats %+v", r.PerProject)
+	}
+	if r.Ideas.Total != 1 || r.Ideas.Root != 1 {
+		t.Fatalf("unexpected idea totals %+v", r.Ideas)
+	}
+}
@@ -0,0 +1,100 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultNoteSeparator sits between a note's timestamp and its text.
+const DefaultNoteSeparator = "—"
+
+type NotesConfig struct {
+	Separator string `json:"separator,omitempty"`
+}
+
+// NoteSeparator is notes.separator, or DefaultNoteSeparator when unset.
+func (c Config) NoteSeparator() string {
+	if c.Notes != nil && strings.TrimSpace(c.Notes.Separator) != "" {
+		return strings.TrimSpace(c.Notes.Separator)
+	}
+	return DefaultNoteSeparator
+}
+
+// noteSeparator is the configured separator, or "-" when the workspace is in
+// ASCII mode and the configured one is not plain ASCII.
+func (w *Workspace) noteSeparator() string {
+	sep := w.cfg.NoteSeparator()
+	if w.ASCII && !isASCII(sep) {
+		return "-"
+	}
+	return sep
+}
+
+// formatNoteEntry renders one note line, shared by task and idea notes.
+func formatNoteEntry(at time.Time, sep string, note string) string {
+	return fmt.Sprintf("- %s %s %s\n", at.Format(time.RFC3339), sep, strings.TrimSpace(note))
+}
+
+// taskNote is one timestamped entry added by `tasker note`.
+type taskNote struct {
+	At   time.Time
+	Text string
+}
+
+// taskNotes returns the note entries in body, oldest first. Lines that are
+// not "- <RFC3339> <sep> <text>" are skipped.
+func taskNotes(body string) []taskNote {
+	var out []taskNote
+	for _, line := range strings.Split(body, "\n") {
+		rest, ok := strings.CutPrefix(strings.TrimSpace(line), "- ")
+		if !ok {
+			continue
+		}
+		stamp, text, ok := strings.Cut(rest, " ")
+		if !ok {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, stamp)
+		if err != nil {
+			continue
+		}
+		// Drop the separator, whatever notes.separator was at the time.
+		if sep, after, ok := strings.Cut(strings.TrimSpace(text), " "); ok && strings.IndexFunc(sep, isWordRune) < 0 {
+			text = after
+		}
+		out = append(out, taskNote{At: at, Text: strings.TrimSpace(text)})
+	}
+	return out
+}
+
+// appendNoteEntry adds entry after body, under header when body is empty.
+func appendNoteEntry(body string, header string, entry string) string {
+	body = strings.TrimRight(body, "\n")
+	if strings.TrimSpace(body) == "" {
+		return header + entry
+	}
+	return body + "\n" + entry
+}
+
+// normalizeText strips a UTF-8 byte order mark and converts CRLF/CR line
+// endings, as left by editors on Windows.
+func normalizeText(s string) string {
+	s = strings.TrimPrefix(s, "\ufeff")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
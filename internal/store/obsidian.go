@@ -0,0 +1,105 @@
+package store
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// obsidianPriority maps priorities onto the Obsidian Tasks plugin signifiers
+// (normal has none).
+func obsidianPriority(p string) string {
+	switch normalizePriority(p) {
+	case "urgent":
+		return "🔺"
+	case "high":
+		return "⏫"
+	case "low":
+		return "🔽"
+	}
+	return ""
+}
+
+// FormatObsidianTask renders a task as an Obsidian Tasks plugin line, e.g.
+// "- [ ] Title [[tsk_...__title|↗]] 🛫 2026-01-20 📅 2026-01-23 ⏫ #tag".
+// Done and archived tasks are checked with their ✅ completion date, doing
+// ones use the [/] in-progress status. The wiki-link names the task file, so
+// it resolves when the store sits inside the vault.
+func (w *Workspace) FormatObsidianTask(t Task) string {
+	box := "[ ]"
+	done := !w.cfg.IsOpenStatus(t.Status)
+	switch {
+	case done:
+		box = "[x]"
+	case t.Status == "doing":
+		box = "[/]"
+	}
+	parts := []string{"-", box, strings.Join(strings.Fields(taskTitle(t.Title)), " ")}
+	if t.Path != "" {
+		parts = append(parts, "[["+strings.TrimSuffix(filepath.Base(t.Path), ".md")+"|↗]]")
+	}
+	if start, ok := parseDueDate(t.Start); ok {
+		parts = append(parts, "🛫 "+start.Format("2006-01-02"))
+	}
+	if due, ok := parseDueDate(t.Due); ok {
+		parts = append(parts, "📅 "+due.Format("2006-01-02"))
+	}
+	if pri := obsidianPriority(t.Priority); pri != "" {
+		parts = append(parts, pri)
+	}
+	if done {
+		if t.CompletedAt != nil {
+			parts = append(parts, "✅ "+t.CompletedAt.UTC().Format("2006-01-02"))
+		} else if t.UpdatedAt != nil {
+			parts = append(parts, "✅ "+t.UpdatedAt.UTC().Format("2006-01-02"))
+		}
+	}
+	for _, tag := range t.Tags {
+		if tag = strings.Join(strings.Fields(tag), "-"); tag != "" {
+			parts = append(parts, "#"+tag)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// RenderObsidian renders tasks as a Markdown note for the Obsidian Tasks
+// plugin: one "## <project>" heading per project, tasks sorted by due date
+// (undated last) then id.
+func (w *Workspace) RenderObsidian(tasks []Task) []byte {
+	sorted := append([]Task{}, tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if (a.Due == "") != (b.Due == "") {
+			return a.Due != ""
+		}
+		if a.Due != b.Due {
+			return a.Due < b.Due
+		}
+		return a.ID < b.ID
+	})
+	names := map[string]string{}
+	if projects, err := w.ListProjects(); err == nil {
+		for _, p := range projects {
+			names[p.Slug] = p.Name
+		}
+	}
+	var b strings.Builder
+	b.WriteString("# Tasks\n")
+	project := ""
+	for i, t := range sorted {
+		if i == 0 || t.Project != project {
+			project = t.Project
+			name := names[project]
+			if name == "" {
+				name = project
+			}
+			b.WriteString("\n## " + name + "\n\n")
+		}
+		b.WriteString(w.FormatObsidianTask(t))
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
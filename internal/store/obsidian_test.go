@@ -0,0 +1,33 @@
+package store
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderObsidian(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	ship, err := w.AddTask(AddTaskInput{Project: "Work", Title: "Ship it", Due: "2026-01-23", Priority: "high", Tags: []string{"release"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := w.FormatObsidianTask(*ship)
+	want := "- [ ] Ship it [[" + strings.TrimSuffix(filepath.Base(ship.Path), ".md") + "|↗]] 📅 2026-01-23 ⏫ #release"
+	if line != want {
+		t.Fatalf("unexpected line:\n got %q\nwant %q", line, want)
+	}
+
+	done := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	ship.Status = "done"
+	ship.CompletedAt = &done
+	if line := w.FormatObsidianTask(*ship); !strings.HasPrefix(line, "- [x] Ship it") || !strings.Contains(line, "✅ 2026-01-20") {
+		t.Fatalf("expected a checked line with its completion date, got %q", line)
+	}
+
+	out := string(w.RenderObsidian([]Task{*ship}))
+	if !strings.HasPrefix(out, "# Tasks\n\n## Work\n\n- [x] Ship it") {
+		t.Fatalf("unexpected note:\n%s", out)
+	}
+}
@@ -0,0 +1,185 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultLogMaxBytes = 1 << 20
+	defaultLogMaxFiles = 3
+)
+
+// LogConfig controls the operations log at <root>/logs/tasker.log.
+type LogConfig struct {
+	Enabled  bool  `json:"enabled"`
+	MaxBytes int64 `json:"max_bytes,omitempty"` // rotate when the active file would exceed this (default 1 MiB)
+	MaxFiles int   `json:"max_files,omitempty"` // rotated files to keep (default 3)
+}
+
+// OpLogEntry is one NDJSON line in the operations log.
+type OpLogEntry struct {
+	At         time.Time `json:"at"`
+	Command    string    `json:"command"`
+	Args       []string  `json:"args"`
+	DurationMS int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Result     string    `json:"result"`
+	Mutating   bool      `json:"mutating,omitempty"`
+	User       string    `json:"user,omitempty"`
+	Host       string    `json:"host,omitempty"`
+	PID        int       `json:"pid"`
+}
+
+func (w *Workspace) OpLogPath() string {
+	return filepath.Join(w.Root, "logs", "tasker.log")
+}
+
+// OpLogEnabled reports whether the operations log is turned on in config.
+func (w *Workspace) OpLogEnabled() bool {
+	return w.cfg.Log != nil && w.cfg.Log.Enabled
+}
+
+// AppendOpLog appends entry to the operations log, rotating first when the
+// active file would grow past the configured size.
+func (w *Workspace) AppendOpLog(entry OpLogEntry) error {
+	maxBytes := int64(defaultLogMaxBytes)
+	maxFiles := defaultLogMaxFiles
+	if w.cfg.Log != nil {
+		if w.cfg.Log.MaxBytes > 0 {
+			maxBytes = w.cfg.Log.MaxBytes
+		}
+		if w.cfg.Log.MaxFiles > 0 {
+			maxFiles = w.cfg.Log.MaxFiles
+		}
+	}
+	if entry.At.IsZero() {
+		entry.At = timeNow()
+	}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	path := w.OpLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && info.Size()+int64(len(b)) > maxBytes {
+		if err := w.rotateOpLog(path, maxFiles); err != nil {
+			return err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rotateOpLog shifts path -> path.1 -> path.2 ... and drops anything past
+// keep, folding the dropped entries into the running totals first.
+func (w *Workspace) rotateOpLog(path string, keep int) error {
+	dropped := fmt.Sprintf("%s.%d", path, keep)
+	if entries, err := readOpLogFile(dropped); err == nil && len(entries) > 0 {
+		totals, err := w.readOpLogTotals()
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			totals.add(e)
+		}
+		b, err := json.MarshalIndent(totals, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := atomicWriteFile(w.opLogTotalsPath(), append(b, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+	_ = os.Remove(dropped)
+	for i := keep - 1; i >= 1; i-- {
+		from := fmt.Sprintf("%s.%d", path, i)
+		if _, err := os.Stat(from); err == nil {
+			if err := os.Rename(from, fmt.Sprintf("%s.%d", path, i+1)); err != nil {
+				return err
+			}
+		}
+	}
+	return os.Rename(path, path+".1")
+}
+
+// OpLogTotals counts operations log entries by command: those still in the
+// log plus those that rotated out, which are kept in <root>/logs/totals.json
+// so the counts only ever grow.
+type OpLogTotals struct {
+	Commands  map[string]*OpCommandTotals `json:"commands"`
+	Mutations int64                       `json:"mutations"` // successful mutating commands
+}
+
+// OpCommandTotals counts the invocations of one command.
+type OpCommandTotals struct {
+	Results    map[string]int64 `json:"results"` // by exit result name
+	Count      int64            `json:"count"`
+	DurationMS int64            `json:"duration_ms"`
+}
+
+func (t *OpLogTotals) add(e OpLogEntry) {
+	if t.Commands == nil {
+		t.Commands = map[string]*OpCommandTotals{}
+	}
+	c := t.Commands[e.Command]
+	if c == nil {
+		c = &OpCommandTotals{Results: map[string]int64{}}
+		t.Commands[e.Command] = c
+	}
+	c.Results[e.Result]++
+	c.Count++
+	c.DurationMS += e.DurationMS
+	if e.Mutating && e.ExitCode == 0 {
+		t.Mutations++
+	}
+}
+
+func (w *Workspace) opLogTotalsPath() string {
+	return filepath.Join(w.Root, "logs", "totals.json")
+}
+
+func (w *Workspace) readOpLogTotals() (OpLogTotals, error) {
+	var t OpLogTotals
+	b, err := os.ReadFile(w.opLogTotalsPath())
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, fmt.Errorf("%s: %w", w.opLogTotalsPath(), err)
+	}
+	return t, nil
+}
+
+// OpLogTotals returns the running command totals of the operations log.
+func (w *Workspace) OpLogTotals() (OpLogTotals, error) {
+	totals, err := w.readOpLogTotals()
+	if err != nil {
+		return totals, err
+	}
+	entries, err := w.ReadOpLog()
+	if err != nil {
+		return totals, err
+	}
+	for _, e := range entries {
+		totals.add(e)
+	}
+	return totals, nil
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppendOpLogRotates(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: Config{Log: &LogConfig{Enabled: true, MaxBytes: 200, MaxFiles: 2}}}
+	for i := 0; i < 10; i++ {
+		if err := w.AppendOpLog(OpLogEntry{Command: "ls", Args: []string{"ls", "--all"}}); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	for _, suffix := range []string{"", ".1", ".2"} {
+		info, err := os.Stat(w.OpLogPath() + suffix)
+		if err != nil {
+			t.Fatalf("expected log file %q: %v", suffix, err)
+		}
+		if info.Size() > 200 {
+			t.Fatalf("log file %q exceeds max bytes: %d", suffix, info.Size())
+		}
+	}
+	if _, err := os.Stat(w.OpLogPath() + ".3"); err == nil {
+		t.Fatalf("expected at most 2 rotated files")
+	}
+}
+
+func TestOpLogTotalsSurviveRotation(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: Config{Log: &LogConfig{Enabled: true, MaxBytes: 200, MaxFiles: 1}}}
+	for i := 0; i < 10; i++ {
+		e := OpLogEntry{Command: "add", Result: "ok", DurationMS: 5, Mutating: true}
+		if i%2 == 1 {
+			e = OpLogEntry{Command: "ls", Result: "not_found", ExitCode: 3, DurationMS: 1}
+		}
+		if err := w.AppendOpLog(e); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if entries, err := w.ReadOpLog(); err != nil || len(entries) >= 10 {
+		t.Fatalf("expected rotation to drop entries, kept %d (%v)", len(entries), err)
+	}
+	totals, err := w.OpLogTotals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	add, ls := totals.Commands["add"], totals.Commands["ls"]
+	if add == nil || ls == nil || add.Count != 5 || add.Results["ok"] != 5 || add.DurationMS != 25 || ls.Results["not_found"] != 5 || totals.Mutations != 5 {
+		t.Fatalf("expected every entry counted, got %+v add=%+v ls=%+v", totals, add, ls)
+	}
+}
@@ -0,0 +1,76 @@
+package store
+
+import "fmt"
+
+// ViewPage caps how many tasks the today and week views list: Offset and
+// Limit page through each section, MaxPerGroup caps each project or column
+// group inside it. Zero values mean no cap. Section counts in headers and
+// totals stay whole; what is cut shows as "…and N more".
+type ViewPage struct {
+	Limit       int
+	Offset      int
+	MaxPerGroup int
+}
+
+// PageBounds returns the slice bounds of the page at offset holding at most
+// limit of total items (limit <= 0: all the rest).
+func PageBounds(total, offset, limit int) (int, int) {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit > 0 && offset+limit < total {
+		end = offset + limit
+	}
+	return offset, end
+}
+
+// MoreLine is the note that n more items were left out.
+func MoreLine(n int, ascii bool) string {
+	if ascii {
+		return fmt.Sprintf("...and %d more", n)
+	}
+	return fmt.Sprintf("…and %d more", n)
+}
+
+// section pages a section's tasks and reports how many follow the page.
+func (p ViewPage) section(tasks []Task) ([]Task, int) {
+	start, end := PageBounds(len(tasks), p.Offset, p.Limit)
+	return tasks[start:end], len(tasks) - end
+}
+
+// group caps one group's tasks at MaxPerGroup.
+func (p ViewPage) group(tasks []Task) ([]Task, int) {
+	if p.MaxPerGroup <= 0 || len(tasks) <= p.MaxPerGroup {
+		return tasks, 0
+	}
+	return tasks[:p.MaxPerGroup], len(tasks) - p.MaxPerGroup
+}
+
+// bucketList pages one section of a project or column block in the
+// two-level week view, where the block is the group.
+func (p ViewPage) bucketList(tasks []Task) ([]Task, int) {
+	shown, more := p.section(tasks)
+	shown, hidden := p.group(shown)
+	return shown, more + hidden
+}
+
+// capGroups keeps tasks in order but at most MaxPerGroup per groupBy key.
+func (p ViewPage) capGroups(tasks []Task, groupBy string) ([]Task, int) {
+	if p.MaxPerGroup <= 0 || groupBy == "" {
+		return tasks, 0
+	}
+	seen := map[string]int{}
+	out := make([]Task, 0, len(tasks))
+	for _, t := range tasks {
+		keys, _ := groupTasks([]Task{t}, groupBy)
+		if seen[keys[0]] < p.MaxPerGroup {
+			out = append(out, t)
+		}
+		seen[keys[0]]++
+	}
+	return out, len(tasks) - len(out)
+}
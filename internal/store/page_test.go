@@ -0,0 +1,58 @@
+package store
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestViewPageCapsSections(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "One", Project: "Work", Due: "2026-01-20"},
+		{Title: "Two", Project: "Work", Due: "2026-01-20"},
+		{Title: "Three", Project: "Work", Due: "2026-01-20"},
+		{Title: "Four", Project: "Home", Due: "2026-01-20"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w.ViewPage = ViewPage{Limit: 2, Offset: 1}
+	view, err := w.TodayView("", true, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	today := view.Sections[0]
+	if today.Count != 4 || len(today.Tasks) != 2 || today.More != 1 {
+		t.Fatalf("expected 2 of 4 tasks after skipping 1, 1 more: %+v", today)
+	}
+
+	w.ViewPage = ViewPage{MaxPerGroup: 1}
+	view, err = w.TodayView("", true, "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if today := view.Sections[0]; len(today.Tasks) != 2 || today.More != 2 {
+		t.Fatalf("expected one task per project and 2 more: %+v", today)
+	}
+	out, err := w.RenderToday("", true, "project", false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Today (2026-01-20) - due 4") || !strings.Contains(out, "…and 2 more") {
+		t.Fatalf("expected full counts and a more line, got:\n%s", out)
+	}
+
+	if start, end := PageBounds(5, 4, 3); start != 4 || end != 5 {
+		t.Fatalf("unexpected bounds %d..%d", start, end)
+	}
+	if start, end := PageBounds(5, 9, 0); start != 5 || end != 5 {
+		t.Fatalf("expected an empty page past the end, got %d..%d", start, end)
+	}
+}
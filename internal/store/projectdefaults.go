@@ -0,0 +1,72 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProjectDefaults are per-project settings in project.json that take
+// precedence over the agent config whenever a command is scoped to that one
+// project. Zero values defer to the config; OpenOnly is a pointer so a
+// project can turn off an open_only the config turns on.
+type ProjectDefaults struct {
+	Column       string `json:"column,omitempty"` // where new tasks land
+	OpenOnly     *bool  `json:"open_only,omitempty"`
+	WeekDays     int    `json:"week_days,omitempty"`
+	SummaryGroup string `json:"summary_group,omitempty"` // none|project|column
+}
+
+func (d ProjectDefaults) empty() bool {
+	return d.Column == "" && d.OpenOnly == nil && d.WeekDays == 0 && d.SummaryGroup == ""
+}
+
+// DefaultsFor returns the defaults of the project spec names, when it names
+// exactly one existing project; globs, lists and unknown names get none.
+func (w *Workspace) DefaultsFor(spec string) ProjectDefaults {
+	parts := ProjectSpecParts(spec)
+	if len(parts) != 1 || IsProjectGlob(parts[0]) {
+		return ProjectDefaults{}
+	}
+	p, err := readProject(w.projectMetaPath(slugifyOrDefault(parts[0], parts[0])))
+	if err != nil || p.Defaults == nil {
+		return ProjectDefaults{}
+	}
+	return *p.Defaults
+}
+
+// SetProjectDefaults replaces a project's defaults. The column must be one of
+// the project's columns.
+func (w *Workspace) SetProjectDefaults(selector string, d ProjectDefaults) (*Project, error) {
+	p, err := w.GetProject(selector)
+	if err != nil {
+		return nil, err
+	}
+	d.Column = strings.TrimSpace(strings.ToLower(d.Column))
+	if d.Column != "" {
+		if _, ok := w.projectColumnByID(p.Slug, d.Column); !ok {
+			return nil, fmt.Errorf("%w: project %s has no column %q", ErrInvalid, p.Slug, d.Column)
+		}
+	}
+	if d.WeekDays < 0 {
+		return nil, fmt.Errorf("%w: week_days must be positive", ErrInvalid)
+	}
+	d.SummaryGroup = strings.TrimSpace(strings.ToLower(d.SummaryGroup))
+	switch d.SummaryGroup {
+	case "", "none", "project", "column":
+	default:
+		return nil, fmt.Errorf("%w: summary_group must be none|project|column", ErrInvalid)
+	}
+	p.Defaults = nil
+	if !d.empty() {
+		p.Defaults = &d
+	}
+	p.UpdatedAt = timeNow()
+	change, err := w.projectChange(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.commitChanges("project set", []fileChange{change}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
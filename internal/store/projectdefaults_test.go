@@ -0,0 +1,40 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProjectDefaults(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	if _, err := w.CreateProject("Work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.SetProjectDefaults("work", ProjectDefaults{Column: "nope"}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid column, got %v", err)
+	}
+	off := false
+	if _, err := w.SetProjectDefaults("work", ProjectDefaults{Column: "todo", OpenOnly: &off, WeekDays: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if d := w.DefaultsFor("Work"); d.Column != "todo" || d.OpenOnly == nil || *d.OpenOnly || d.WeekDays != 3 {
+		t.Fatalf("unexpected defaults: %+v", d)
+	}
+	if d := w.DefaultsFor("work,personal"); d.Column != "" {
+		t.Fatalf("a project list should not pick up defaults: %+v", d)
+	}
+	task, err := w.AddTask(AddTaskInput{Title: "Draft", Project: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Column != "todo" {
+		t.Fatalf("expected the project's default column, got %s", task.Column)
+	}
+	p, err := w.SetProjectDefaults("work", ProjectDefaults{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Defaults != nil {
+		t.Fatalf("empty defaults should be dropped: %+v", p.Defaults)
+	}
+}
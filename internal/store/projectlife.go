@@ -0,0 +1,265 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectRemoval is what RemoveProject took away: the tasks and ideas it
+// moved to the trash (with --force), and the day directory they went to.
+type ProjectRemoval struct {
+	Project   Project `json:"project"`
+	Tasks     int     `json:"tasks"`
+	Ideas     int     `json:"ideas"`
+	TrashedOn string  `json:"trashed_on,omitempty"`
+}
+
+func (w *Workspace) projectDir(slug string) string {
+	return filepath.Join(w.Root, "projects", slug)
+}
+
+// GetProject reads a project by slug or name.
+func (w *Workspace) GetProject(selector string) (*Project, error) {
+	slug := slugifyOrDefault(selector, selector)
+	if slug == "" {
+		return nil, fmt.Errorf("%w: project is required", ErrInvalid)
+	}
+	p, err := readProject(w.projectMetaPath(slug))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: project not found: %s", ErrNotFound, selector)
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// ActiveProjects is ListProjects without the archived ones: what views,
+// globs and listings cover unless a project is named.
+func (w *Workspace) ActiveProjects() ([]Project, error) {
+	projects, err := w.ListProjects()
+	if err != nil {
+		return nil, err
+	}
+	out := projects[:0]
+	for _, p := range projects {
+		if !p.IsArchived() {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (w *Workspace) projectChange(p *Project) (fileChange, error) {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fileChange{}, err
+	}
+	content := string(b)
+	return fileChange{Path: w.projectMetaPath(p.Slug), After: &content}, nil
+}
+
+// SetProjectArchived archives a project (hidden from default listings, its
+// files untouched) or brings it back.
+func (w *Workspace) SetProjectArchived(selector string, archived bool) (*Project, error) {
+	p, err := w.GetProject(selector)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsArchived() == archived {
+		return p, nil
+	}
+	now := timeNow()
+	p.ArchivedAt = nil
+	if archived {
+		p.ArchivedAt = &now
+	}
+	p.UpdatedAt = now
+	change, err := w.projectChange(p)
+	if err != nil {
+		return nil, err
+	}
+	op := "project archive"
+	if !archived {
+		op = "project unarchive"
+	}
+	if err := w.commitChanges(op, []fileChange{change}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RenameProject gives a project a new name. When the slug changes the
+// project directory moves with it and every task's project frontmatter is
+// rewritten, all as one journal entry; a project already using the new slug
+// is a conflict.
+func (w *Workspace) RenameProject(selector string, newName string) (*Project, error) {
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, fmt.Errorf("%w: new project name is required", ErrInvalid)
+	}
+	p, err := w.GetProject(selector)
+	if err != nil {
+		return nil, err
+	}
+	oldSlug, newSlug := p.Slug, slugify(newName)
+	p.Name = newName
+	p.UpdatedAt = timeNow()
+	if newSlug == oldSlug {
+		change, err := w.projectChange(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.commitChanges("project rename", []fileChange{change}); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+	if _, err := os.Stat(w.projectDir(newSlug)); err == nil {
+		return nil, fmt.Errorf("%w: project %s already exists", ErrConflict, newSlug)
+	}
+	p.Slug = newSlug
+
+	oldDir, newDir := w.projectDir(oldSlug), w.projectDir(newSlug)
+	projectsRoot := filepath.Join(w.Root, "projects")
+	var changes []fileChange
+	err = filepath.WalkDir(oldDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(oldDir, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(newDir, rel)
+		var content string
+		switch {
+		case path == w.projectMetaPath(oldSlug):
+			b, err := json.MarshalIndent(p, "", "  ")
+			if err != nil {
+				return err
+			}
+			content = string(b)
+		case strings.HasSuffix(strings.ToLower(d.Name()), ".md") && isTaskPath(projectsRoot, path):
+			t, err := readTaskFile(path)
+			if err != nil {
+				// Not a task we can parse: move it as it is.
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					return err
+				}
+				content = string(raw)
+				break
+			}
+			t.Project = newSlug
+			if content, err = renderTaskFile(t); err != nil {
+				return err
+			}
+		default:
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			content = string(raw)
+		}
+		changes = append(changes, fileChange{Path: dest, After: &content}, fileChange{Path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.commitChanges("project rename", changes); err != nil {
+		return nil, err
+	}
+	for _, c := range w.Columns(newSlug) {
+		_ = os.MkdirAll(filepath.Join(w.projectColumnsDir(newSlug), c.Dir), 0o755)
+	}
+	_ = os.MkdirAll(w.projectIdeasDir(newSlug), 0o755)
+	removeEmptyTree(oldDir)
+	return p, nil
+}
+
+// RemoveProject deletes a project. A project that still holds tasks or
+// ideas is a conflict unless force is set; then its tasks and ideas go to
+// the trash (as `rm` and `idea rm` would put them, so `trash restore` brings
+// any of them back and recreates the project) and the rest is deleted, all
+// as one journal entry.
+func (w *Workspace) RemoveProject(selector string, force bool) (*ProjectRemoval, error) {
+	p, err := w.GetProject(selector)
+	if err != nil {
+		return nil, err
+	}
+	dir := w.projectDir(p.Slug)
+	projectsRoot := filepath.Join(w.Root, "projects")
+	ideasDir := w.projectIdeasDir(p.Slug)
+	day := timeNow().Format("2006-01-02")
+	r := &ProjectRemoval{Project: *p}
+	var changes []fileChange
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		var dest string
+		switch {
+		case strings.HasSuffix(strings.ToLower(d.Name()), ".md") && isTaskPath(projectsRoot, path):
+			r.Tasks++
+			dest = filepath.Join(w.trashDir(), day, p.Slug, d.Name())
+		case strings.HasPrefix(path, ideasDir+string(filepath.Separator)) && isIdeaFile(d.Name()):
+			r.Ideas++
+			dest = filepath.Join(w.trashDir(), day, trashIdeasDir, p.Slug, d.Name())
+		}
+		if dest != "" {
+			if _, err := os.Stat(dest); err == nil {
+				return fmt.Errorf("%w: %s is already in the trash", ErrConflict, d.Name())
+			}
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			content := string(raw)
+			changes = append(changes, fileChange{Path: dest, After: &content})
+		}
+		changes = append(changes, fileChange{Path: path})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if (r.Tasks > 0 || r.Ideas > 0) && !force {
+		return nil, fmt.Errorf("%w: project %s still has %d task(s) and %d idea(s) (use --force to move them to the trash)", ErrConflict, p.Slug, r.Tasks, r.Ideas)
+	}
+	if r.Tasks > 0 || r.Ideas > 0 {
+		r.TrashedOn = day
+	}
+	if err := w.commitChanges("project rm", changes); err != nil {
+		return nil, err
+	}
+	removeEmptyTree(dir)
+	return r, nil
+}
+
+// removeEmptyTree removes dir if nothing but empty directories is left in
+// it.
+func removeEmptyTree(dir string) {
+	files := false
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if !files {
+		_ = os.RemoveAll(dir)
+	}
+}
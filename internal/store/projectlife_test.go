@@ -0,0 +1,82 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestProjectLifecycle(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Draft", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Later", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.CreateProject("Home"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.RenameProject("work", "Home"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict renaming onto home, got %v", err)
+	}
+	p, err := w.RenameProject("work", "Client Work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Slug != "client-work" || p.Name != "Client Work" {
+		t.Fatalf("unexpected project: %+v", p)
+	}
+	if _, err := os.Stat(w.projectDir("work")); !os.IsNotExist(err) {
+		t.Fatalf("old project dir should be gone: %v", err)
+	}
+	moved, err := w.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Project != "client-work" || moved.Column != "inbox" {
+		t.Fatalf("task not moved with the project: %+v", moved)
+	}
+	ideas, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeProject, Project: "client-work"})
+	if err != nil || len(ideas) != 1 {
+		t.Fatalf("expected the idea to move, got %v, %v", ideas, err)
+	}
+
+	if _, err := w.SetProjectArchived("client-work", true); err != nil {
+		t.Fatal(err)
+	}
+	if tasks, err := w.ListTasks(ListFilter{}); err != nil || len(tasks) != 0 {
+		t.Fatalf("archived project tasks should be hidden, got %v, %v", tasks, err)
+	}
+	if tasks, err := w.ListTasks(ListFilter{Project: "client-work"}); err != nil || len(tasks) != 1 {
+		t.Fatalf("named archived project should list, got %v, %v", tasks, err)
+	}
+	if projects, err := w.ProjectOverviews("", false); err != nil || len(projects) != 1 || projects[0].Slug != "home" {
+		t.Fatalf("expected only home, got %+v, %v", projects, err)
+	}
+	if projects, err := w.ProjectOverviews("", true); err != nil || len(projects) != 2 || projects[0].Open != 1 {
+		t.Fatalf("expected both with --all, got %+v, %v", projects, err)
+	}
+
+	if _, err := w.RemoveProject("client-work", false); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict removing a non-empty project, got %v", err)
+	}
+	r, err := w.RemoveProject("client-work", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Tasks != 1 || r.Ideas != 1 {
+		t.Fatalf("unexpected removal: %+v", r)
+	}
+	if _, err := os.Stat(w.projectDir("client-work")); !os.IsNotExist(err) {
+		t.Fatalf("project dir should be gone: %v", err)
+	}
+	if trashed, err := w.ListTrash(); err != nil || len(trashed) != 1 || trashed[0].Task.ID != task.ID {
+		t.Fatalf("expected the task in the trash, got %+v, %v", trashed, err)
+	}
+	if _, err := w.RemoveProject("home", false); err != nil {
+		t.Fatalf("empty project should remove without --force: %v", err)
+	}
+}
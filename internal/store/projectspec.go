@@ -0,0 +1,131 @@
+package store
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// A project spec names the projects a listing covers: one project (name or
+// slug), a glob such as "clients/*" or "clients-*", or several of either
+// separated by commas. The CLI joins repeated --project flags into one spec.
+
+// JoinProjectSpec joins several --project values into one spec.
+func JoinProjectSpec(values []string) string {
+	var parts []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ProjectSpecParts splits a spec into its names and globs.
+func ProjectSpecParts(spec string) []string {
+	var parts []string
+	for _, p := range strings.Split(spec, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return parts
+}
+
+// IsProjectGlob reports whether part is a glob rather than a project name.
+func IsProjectGlob(part string) bool {
+	return strings.ContainsAny(part, "*?[")
+}
+
+// matchProjectGlob matches a glob against a project's slug and name,
+// case-insensitively. A "/" in the glob also matches the "-" it becomes in
+// the slug, so "clients/*" covers a project named "clients/acme".
+func matchProjectGlob(glob, slug, name string) (bool, error) {
+	glob = strings.ToLower(strings.TrimSpace(glob))
+	candidates := [][2]string{
+		{glob, slug},
+		{glob, strings.ToLower(name)},
+		{strings.ReplaceAll(glob, "/", "-"), slug},
+	}
+	for _, c := range candidates {
+		ok, err := path.Match(c[0], c[1])
+		if err != nil {
+			return false, fmt.Errorf("%w: bad project pattern %q", ErrInvalid, glob)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ExpandProjectSpec resolves spec to project slugs in spec order, each once.
+// Names are slugified whether or not the project exists; globs expand to
+// the existing projects they match, possibly none. Archived projects only
+// come in by name.
+func (w *Workspace) ExpandProjectSpec(spec string) ([]string, error) {
+	var projects []Project
+	loaded := false
+	seen := map[string]bool{}
+	var out []string
+	add := func(slug string) {
+		if !seen[slug] {
+			seen[slug] = true
+			out = append(out, slug)
+		}
+	}
+	for _, part := range ProjectSpecParts(spec) {
+		if !IsProjectGlob(part) {
+			add(slugifyOrDefault(part, part))
+			continue
+		}
+		if !loaded {
+			var err error
+			if projects, err = w.ActiveProjects(); err != nil {
+				return nil, err
+			}
+			loaded = true
+		}
+		for _, p := range projects {
+			ok, err := matchProjectGlob(part, p.Slug, p.Name)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				add(p.Slug)
+			}
+		}
+	}
+	return out, nil
+}
+
+// projectSpecMatcher returns a test for project slugs against spec, for
+// tasks whose project may no longer exist (the trash). An empty spec
+// matches everything.
+func projectSpecMatcher(spec string) (func(slug string) bool, error) {
+	parts := ProjectSpecParts(spec)
+	if len(parts) == 0 {
+		return func(string) bool { return true }, nil
+	}
+	for _, part := range parts {
+		if IsProjectGlob(part) {
+			if _, err := matchProjectGlob(part, "", ""); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return func(slug string) bool {
+		for _, part := range parts {
+			if !IsProjectGlob(part) {
+				if slugifyOrDefault(part, part) == slug {
+					return true
+				}
+				continue
+			}
+			if ok, _ := matchProjectGlob(part, slug, slug); ok {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListTasksProjectSpec(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	for _, in := range []AddTaskInput{
+		{Title: "Acme invoice", Project: "clients/acme"},
+		{Title: "Globex call", Project: "clients/globex"},
+		{Title: "Laundry", Project: "Home"},
+		{Title: "Standup", Project: "Work"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	count := func(spec string) int {
+		t.Helper()
+		tasks, err := w.ListTasks(ListFilter{Project: spec})
+		if err != nil {
+			t.Fatalf("%q: %v", spec, err)
+		}
+		return len(tasks)
+	}
+	if n := count("clients/*"); n != 2 {
+		t.Fatalf("expected clients/* to cover 2 tasks, got %d", n)
+	}
+	if n := count("home,work"); n != 2 {
+		t.Fatalf("expected home,work to cover 2 tasks, got %d", n)
+	}
+	if n := count("clients-a*,Work,work"); n != 2 {
+		t.Fatalf("expected a glob plus a name, each once, got %d", n)
+	}
+	if n := count("nomatch*"); n != 0 {
+		t.Fatalf("expected an unmatched glob to list nothing, got %d", n)
+	}
+	if _, err := w.ListTasks(ListFilter{Project: "clients/["}); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid for a bad pattern, got %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProjectOverview is a project with live task counts, for `project ls`.
+// Open counts open-like tasks that are neither doing nor blocked; Done
+// counts done and other closed statuses short of archived.
+type ProjectOverview struct {
+	Project
+	Open     int `json:"open"`
+	Doing    int `json:"doing"`
+	Blocked  int `json:"blocked"`
+	Done     int `json:"done"`
+	Archived int `json:"archived"`
+	// LastActivity is the latest change to the project or any of its tasks.
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// ProjectSorts are the orders ProjectOverviews accepts.
+var ProjectSorts = []string{"name", "activity"}
+
+// ProjectOverviews lists the projects with their task counts, by slug or,
+// with sortBy "activity", most recently active first. Tasks are read
+// through the index, so this stays cheap on large stores. Archived projects
+// are left out unless all is set.
+func (w *Workspace) ProjectOverviews(sortBy string, all bool) ([]ProjectOverview, error) {
+	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+	if sortBy != "" && sortBy != "name" && sortBy != "activity" {
+		return nil, fmt.Errorf("%w: unknown project sort %q (use %s)", ErrInvalid, sortBy, strings.Join(ProjectSorts, "|"))
+	}
+	list := w.ActiveProjects
+	if all {
+		list = w.ListProjects
+	}
+	projects, err := list()
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	if len(projects) > 0 {
+		slugs := make([]string, len(projects))
+		for i, p := range projects {
+			slugs[i] = p.Slug
+		}
+		if tasks, err = w.ListTasks(ListFilter{Project: strings.Join(slugs, ","), All: true}); err != nil {
+			return nil, err
+		}
+	}
+	bySlug := map[string]*ProjectOverview{}
+	out := make([]ProjectOverview, len(projects))
+	for i, p := range projects {
+		out[i] = ProjectOverview{Project: p, LastActivity: p.UpdatedAt}
+		bySlug[p.Slug] = &out[i]
+	}
+	for _, t := range tasks {
+		o, ok := bySlug[t.Project]
+		if !ok {
+			continue
+		}
+		switch {
+		case t.Status == "doing":
+			o.Doing++
+		case t.Status == "blocked":
+			o.Blocked++
+		case t.Status == "archived":
+			o.Archived++
+		case w.cfg.IsOpenStatus(t.Status):
+			o.Open++
+		default:
+			o.Done++
+		}
+		for _, at := range []*time.Time{t.UpdatedAt, t.MovedAt, t.CompletedAt} {
+			if at != nil && at.After(o.LastActivity) {
+				o.LastActivity = *at
+			}
+		}
+	}
+	if sortBy == "activity" {
+		sort.SliceStable(out, func(i, j int) bool { return out[i].LastActivity.After(out[j].LastActivity) })
+	}
+	return out, nil
+}
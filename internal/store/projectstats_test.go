@@ -0,0 +1,47 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectOverviews(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Draft", Project: "Work"},
+		{Title: "Build", Project: "Work", Column: "doing"},
+		{Title: "Wait", Project: "Work", Column: "blocked"},
+		{Title: "Paint", Project: "Home"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now = now.Add(time.Hour)
+	if _, err := w.AddTask(AddTaskInput{Title: "Ship", Project: "Work", Column: "done"}); err != nil {
+		t.Fatal(err)
+	}
+
+	projects, err := w.ProjectOverviews("activity", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 2 || projects[0].Slug != "work" {
+		t.Fatalf("expected work first by activity, got %+v", projects)
+	}
+	work := projects[0]
+	if work.Open != 1 || work.Doing != 1 || work.Blocked != 1 || work.Done != 1 || !work.LastActivity.Equal(now) {
+		t.Fatalf("unexpected counts: %+v", work)
+	}
+	if projects, err := w.ProjectOverviews("", false); err != nil || projects[0].Slug != "home" {
+		t.Fatalf("expected projects by slug, got %+v, %v", projects, err)
+	}
+	if _, err := w.ProjectOverviews("size", false); err == nil {
+		t.Fatal("expected an unknown sort to be rejected")
+	}
+}
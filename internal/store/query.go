@@ -0,0 +1,444 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed filter expression such as
+//
+//	project=work and (priority>=high or tag=client) and due<2026-02-01
+//
+// Comparisons are field op value with op one of = != < <= > >= and ~
+// (contains); they combine with and, or, not and parentheses (and binds
+// tighter than or). Values are words or quoted strings.
+type Query struct {
+	root queryNode
+	text string
+}
+
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+	return q.text
+}
+
+// Match reports whether t satisfies the query; a nil query matches
+// everything.
+func (q *Query) Match(t Task) bool {
+	return q == nil || q.root.match(t)
+}
+
+type queryNode interface {
+	match(t Task) bool
+}
+
+type queryAnd struct{ left, right queryNode }
+type queryOr struct{ left, right queryNode }
+type queryNot struct{ node queryNode }
+
+func (n queryAnd) match(t Task) bool { return n.left.match(t) && n.right.match(t) }
+func (n queryOr) match(t Task) bool  { return n.left.match(t) || n.right.match(t) }
+func (n queryNot) match(t Task) bool { return !n.node.match(t) }
+
+// queryFieldKind says how a field compares.
+type queryFieldKind int
+
+const (
+	queryText queryFieldKind = iota
+	queryDate
+	queryPriority
+	queryTags
+)
+
+// queryFields are the task fields a query can name.
+var queryFields = map[string]queryFieldKind{
+	"id":        queryText,
+	"title":     queryText,
+	"project":   queryText,
+	"column":    queryText,
+	"status":    queryText,
+	"repeat":    queryText,
+	"body":      queryText,
+	"due_time":  queryText,
+	"priority":  queryPriority,
+	"tag":       queryTags,
+	"due":       queryDate,
+	"start":     queryDate,
+	"created":   queryDate,
+	"updated":   queryDate,
+	"completed": queryDate,
+}
+
+var priorityRank = map[string]int{"low": 0, "normal": 1, "high": 2, "urgent": 3}
+
+type queryCompare struct {
+	field string
+	kind  queryFieldKind
+	op    string
+	value string
+}
+
+func (c queryCompare) match(t Task) bool {
+	switch c.kind {
+	case queryTags:
+		has := false
+		for _, tag := range t.Tags {
+			if strings.EqualFold(tag, c.value) || (c.op == "~" && strings.Contains(strings.ToLower(tag), c.value)) {
+				has = true
+				break
+			}
+		}
+		if c.op == "!=" {
+			return !has
+		}
+		return has
+	case queryPriority:
+		return compareOrdered(priorityRank[normalizePriority(t.Priority)], priorityRank[c.value], c.op)
+	case queryDate:
+		got := queryTaskDate(t, c.field)
+		if c.value == "" {
+			// field=none / field!=none
+			return (c.op == "=") == (got == "")
+		}
+		if got == "" {
+			return c.op == "!="
+		}
+		return compareStrings(got, c.value, c.op)
+	}
+	got := strings.ToLower(queryTaskText(t, c.field))
+	if c.op == "~" {
+		return strings.Contains(got, c.value)
+	}
+	return compareStrings(got, c.value, c.op)
+}
+
+func queryTaskText(t Task, field string) string {
+	switch field {
+	case "id":
+		return t.ID
+	case "title":
+		return t.Title
+	case "project":
+		return t.Project
+	case "column":
+		return t.Column
+	case "status":
+		return t.Status
+	case "repeat":
+		return t.Repeat
+	case "body":
+		return t.Body
+	case "due_time":
+		return t.DueClock()
+	}
+	return ""
+}
+
+// queryTaskDate is the YYYY-MM-DD of a date field, "" when unset.
+func queryTaskDate(t Task, field string) string {
+	day := func(ts *time.Time) string {
+		if ts == nil {
+			return ""
+		}
+		return ts.UTC().Format("2006-01-02")
+	}
+	switch field {
+	case "due":
+		if d, ok := parseDueDate(t.Due); ok {
+			return d.UTC().Format("2006-01-02")
+		}
+	case "start":
+		if d, ok := parseDueDate(t.Start); ok {
+			return d.UTC().Format("2006-01-02")
+		}
+	case "created":
+		return day(t.CreatedAt)
+	case "updated":
+		return day(t.UpdatedAt)
+	case "completed":
+		return day(t.CompletedAt)
+	}
+	return ""
+}
+
+func compareStrings(a, b, op string) bool {
+	return compareOrdered(strings.Compare(a, b), 0, op)
+}
+
+func compareOrdered(a, b int, op string) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// ParseQuery parses a filter expression (see Query). resolveDate turns date
+// values such as "today" or "fri" into YYYY-MM-DD; without it only
+// YYYY-MM-DD, today, tomorrow and yesterday are read. A date field compared
+// with "none" matches tasks where it is unset.
+func ParseQuery(text string, resolveDate func(string) (string, error)) (*Query, error) {
+	p := &queryParser{text: text, resolveDate: resolveDate}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty query", ErrInvalid)
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != nil {
+		return nil, p.errorAt(*tok, "unexpected %q", tok.text)
+	}
+	return &Query{root: node, text: strings.TrimSpace(text)}, nil
+}
+
+type queryToken struct {
+	kind string // word, string, op, ( or )
+	text string
+	pos  int // 1-based column
+}
+
+type queryParser struct {
+	text        string
+	tokens      []queryToken
+	next        int
+	resolveDate func(string) (string, error)
+}
+
+func (p *queryParser) errorAt(tok queryToken, format string, args ...any) error {
+	return fmt.Errorf("%w: query: %s at column %d", ErrInvalid, fmt.Sprintf(format, args...), tok.pos)
+}
+
+func (p *queryParser) lex() error {
+	r := []rune(p.text)
+	for i := 0; i < len(r); {
+		c := r[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			p.tokens = append(p.tokens, queryToken{kind: string(c), text: string(c), pos: i + 1})
+			i++
+		case strings.ContainsRune("=!<>~", c):
+			op := string(c)
+			if i+1 < len(r) && r[i+1] == '=' && c != '=' && c != '~' {
+				op += "="
+			}
+			if op == "!" {
+				return fmt.Errorf("%w: query: \"!\" must be \"!=\" at column %d", ErrInvalid, i+1)
+			}
+			p.tokens = append(p.tokens, queryToken{kind: "op", text: op, pos: i + 1})
+			i += len(op)
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(r) && r[end] != c {
+				end++
+			}
+			if end == len(r) {
+				return fmt.Errorf("%w: query: unterminated %c at column %d", ErrInvalid, c, i+1)
+			}
+			p.tokens = append(p.tokens, queryToken{kind: "string", text: string(r[i+1 : end]), pos: i + 1})
+			i = end + 1
+		default:
+			start := i
+			for i < len(r) && !unicode.IsSpace(r[i]) && !strings.ContainsRune("()=!<>~\"'", r[i]) {
+				i++
+			}
+			p.tokens = append(p.tokens, queryToken{kind: "word", text: string(r[start:i]), pos: start + 1})
+		}
+	}
+	return nil
+}
+
+func (p *queryParser) peek() *queryToken {
+	if p.next >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.next]
+}
+
+func (p *queryParser) keyword(word string) bool {
+	tok := p.peek()
+	if tok != nil && tok.kind == "word" && strings.EqualFold(tok.text, word) {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) endError() error {
+	return fmt.Errorf("%w: query: unexpected end at column %d", ErrInvalid, len([]rune(p.text))+1)
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = queryOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = queryAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.keyword("not") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return queryNot{node}, nil
+	}
+	tok := p.peek()
+	if tok == nil {
+		return nil, p.endError()
+	}
+	if tok.kind == "(" {
+		p.next++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		closing := p.peek()
+		if closing == nil {
+			return nil, fmt.Errorf("%w: query: missing ) for ( at column %d", ErrInvalid, tok.pos)
+		}
+		if closing.kind != ")" {
+			return nil, p.errorAt(*closing, "expected ) but found %q", closing.text)
+		}
+		p.next++
+		return node, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *queryParser) parseCompare() (queryNode, error) {
+	fieldTok := p.tokens[p.next]
+	if fieldTok.kind != "word" {
+		return nil, p.errorAt(fieldTok, "expected a field but found %q", fieldTok.text)
+	}
+	field := strings.ToLower(fieldTok.text)
+	switch field {
+	case "tags":
+		field = "tag"
+	case "text", "notes":
+		field = "body"
+	}
+	kind, ok := queryFields[field]
+	if !ok {
+		return nil, p.errorAt(fieldTok, "unknown field %q (use %s)", fieldTok.text, strings.Join(queryFieldNames(), ", "))
+	}
+	p.next++
+	opTok := p.peek()
+	if opTok == nil {
+		return nil, p.endError()
+	}
+	if opTok.kind != "op" {
+		return nil, p.errorAt(*opTok, "expected an operator after %s but found %q", field, opTok.text)
+	}
+	p.next++
+	valueTok := p.peek()
+	if valueTok == nil {
+		return nil, p.endError()
+	}
+	if valueTok.kind != "word" && valueTok.kind != "string" {
+		return nil, p.errorAt(*valueTok, "expected a value but found %q", valueTok.text)
+	}
+	p.next++
+	c := queryCompare{field: field, kind: kind, op: opTok.text, value: strings.ToLower(strings.TrimSpace(valueTok.text))}
+	switch kind {
+	case queryText:
+		if field == "project" && c.op != "~" {
+			c.value = Slugify(c.value)
+		}
+	case queryTags:
+		if c.op != "=" && c.op != "!=" && c.op != "~" {
+			return nil, p.errorAt(*opTok, "tag only takes =, != or ~")
+		}
+		c.value = strings.TrimPrefix(c.value, "#")
+	case queryPriority:
+		if c.op == "~" {
+			return nil, p.errorAt(*opTok, "priority does not take ~")
+		}
+		c.value = normalizePriority(c.value)
+		if _, ok := priorityRank[c.value]; !ok {
+			return nil, p.errorAt(*valueTok, "unknown priority %q (use low|normal|high|urgent)", valueTok.text)
+		}
+	case queryDate:
+		if c.op == "~" {
+			return nil, p.errorAt(*opTok, "%s does not take ~", field)
+		}
+		if c.value == "none" {
+			if c.op != "=" && c.op != "!=" {
+				return nil, p.errorAt(*opTok, "%s=none and %s!=none only", field, field)
+			}
+			c.value = ""
+			break
+		}
+		date, err := p.date(valueTok.text)
+		if err != nil {
+			return nil, p.errorAt(*valueTok, "invalid date %q (use YYYY-MM-DD)", valueTok.text)
+		}
+		c.value = date
+	}
+	return c, nil
+}
+
+func (p *queryParser) date(value string) (string, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.Format("2006-01-02"), nil
+	}
+	if p.resolveDate != nil {
+		return p.resolveDate(value)
+	}
+	now := timeNow()
+	switch strings.ToLower(value) {
+	case "today":
+		return now.Format("2006-01-02"), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1).Format("2006-01-02"), nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1).Format("2006-01-02"), nil
+	}
+	return "", ErrInvalid
+}
+
+func queryFieldNames() []string {
+	return []string{"id", "title", "project", "column", "status", "priority", "tag", "due", "due_time", "start", "created", "updated", "completed", "repeat", "body"}
+}
@@ -0,0 +1,62 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListTasksQuery(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	timeNow = func() time.Time { return time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = orig }()
+
+	for _, in := range []AddTaskInput{
+		{Title: "Invoice", Project: "Work", Priority: "urgent", Due: "2026-01-25"},
+		{Title: "Call client", Project: "Work", Tags: []string{"client"}, Due: "2026-03-01"},
+		{Title: "Refactor", Project: "Work", Priority: "low", Due: "2026-01-22"},
+		{Title: "Plan trip", Project: "Home", Priority: "high"},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	titles := func(expr string) string {
+		q, err := ParseQuery(expr, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", expr, err)
+		}
+		tasks, err := w.ListTasks(ListFilter{Query: q})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, task := range tasks {
+			out = append(out, task.Title)
+		}
+		return strings.Join(out, ",")
+	}
+	for expr, want := range map[string]string{
+		"project=work and (priority>=high or tag=client) and due<2026-02-01": "Invoice",
+		"project=work and (priority>=high or tag=client)":                    "Invoice,Call client",
+		"due=none":                      "Plan trip",
+		"not project=work":              "Plan trip",
+		"title~'call' OR priority=low":  "Refactor,Call client",
+		"due>today and due<=2026-01-25": "Refactor,Invoice",
+	} {
+		if got := titles(expr); got != want {
+			t.Fatalf("%s: got %q, want %q", expr, got, want)
+		}
+	}
+
+	for _, bad := range []string{"", "project=", "(project=work", "size>3", "due~2026", "priority>=huge", "tag<a"} {
+		if _, err := ParseQuery(bad, nil); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("%q: expected ErrInvalid, got %v", bad, err)
+		}
+	}
+	if _, err := ParseQuery("project=work and (tag=a", nil); err == nil || !strings.Contains(err.Error(), "column 18") {
+		t.Fatalf("expected the unclosed ( to be located, got %v", err)
+	}
+}
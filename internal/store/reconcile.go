@@ -0,0 +1,77 @@
+package store
+
+import (
+	"sort"
+)
+
+// LocationFix is a task whose frontmatter disagrees with the directory it
+// sits in, usually after the file was moved by hand. Fields lists what the
+// location says instead.
+type LocationFix struct {
+	ID     string        `json:"id"`
+	Title  string        `json:"title"`
+	Path   string        `json:"path"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// LocationMismatches lists the tasks whose project, column or status
+// frontmatter differs from their location. Reads already trust the path;
+// this finds the files that would still tell grep, editors and sync tools
+// otherwise.
+func (w *Workspace) LocationMismatches() ([]LocationFix, error) {
+	var out []LocationFix
+	err := w.walkTaskFiles(func(t *Task) {
+		if fix, ok := w.locationFix(t); ok {
+			out = append(out, fix)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// FixLocations rewrites the frontmatter of every mismatched task to match
+// its location, as one undoable operation, and returns what it corrected.
+func (w *Workspace) FixLocations() ([]LocationFix, error) {
+	fixes, err := w.LocationMismatches()
+	if err != nil || len(fixes) == 0 {
+		return fixes, err
+	}
+	changes := make([]fileChange, 0, len(fixes))
+	for _, fix := range fixes {
+		t, err := readTaskFile(fix.Path)
+		if err != nil {
+			return nil, err
+		}
+		w.reconcileTaskFromPath(t)
+		content, err := renderTaskFile(t)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChange{Path: fix.Path, After: &content})
+	}
+	if err := w.commitChanges("doctor-fix", changes); err != nil {
+		return nil, err
+	}
+	return fixes, nil
+}
+
+// locationFix compares t, as read from its frontmatter, with what its path
+// says.
+func (w *Workspace) locationFix(t *Task) (LocationFix, bool) {
+	want := *t
+	w.reconcileTaskFromPath(&want)
+	fix := LocationFix{ID: t.ID, Title: t.Title, Path: t.Path}
+	for _, f := range []struct{ name, from, to string }{
+		{"project", t.Project, want.Project},
+		{"column", t.Column, want.Column},
+		{"status", t.Status, want.Status},
+	} {
+		if f.from != f.to {
+			fix.Fields = append(fix.Fields, FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return fix, len(fix.Fields) > 0
+}
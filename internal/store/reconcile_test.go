@@ -0,0 +1,48 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFixLocationsAfterManualMove(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Moved by hand", Project: "Work", Column: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Left alone", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	col, _ := w.projectColumnByID("work", "doing")
+	dest := filepath.Join(w.projectColumnsDir("work"), col.Dir, filepath.Base(task.Path))
+	if err := os.Rename(task.Path, dest); err != nil {
+		t.Fatal(err)
+	}
+
+	fixes, err := w.LocationMismatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 1 || fixes[0].ID != task.ID || len(fixes[0].Fields) != 2 {
+		t.Fatalf("expected column and status of one task to be stale, got %+v", fixes)
+	}
+	if c := fixes[0].Fields[0]; c.Field != "column" || c.From != "todo" || c.To != "doing" {
+		t.Fatalf("unexpected change %+v", c)
+	}
+
+	if fixed, err := w.FixLocations(); err != nil || len(fixed) != 1 {
+		t.Fatalf("expected one fix, got %+v, %v", fixed, err)
+	}
+	raw, err := readTaskFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.Column != "doing" || raw.Status != "doing" || raw.Title != "Moved by hand" {
+		t.Fatalf("frontmatter not rewritten: %+v", raw.TaskMeta)
+	}
+	if fixes, err := w.LocationMismatches(); err != nil || len(fixes) != 0 {
+		t.Fatalf("expected no mismatches after fix, got %+v, %v", fixes, err)
+	}
+}
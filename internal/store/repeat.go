@@ -0,0 +1,327 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// repeatRule is a parsed Repeat value: every N units, or on given weekdays.
+type repeatRule struct {
+	N        int
+	Unit     string // day|week|month|year
+	Weekdays []time.Weekday
+}
+
+var repeatWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "weds": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseWeekday matches an English weekday name or abbreviation ("fri",
+// "thurs", "sunday"), lower case.
+func ParseWeekday(s string) (time.Weekday, bool) {
+	wd, ok := repeatWeekdays[s]
+	return wd, ok
+}
+
+var rruleFreqs = map[string]string{"DAILY": "day", "WEEKLY": "week", "MONTHLY": "month", "YEARLY": "year"}
+
+var rruleDays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// parseRepeat accepts daily|weekly|monthly|yearly|weekdays, "every N
+// days|weeks|months|years", "every mon,thu" and RRULE-style
+// "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO".
+func parseRepeat(s string) (repeatRule, error) {
+	raw := strings.TrimSpace(s)
+	lower := strings.ToLower(raw)
+	bad := fmt.Errorf("%w: invalid repeat %q (use daily|weekly|monthly|yearly|weekdays, \"every 2 weeks\", \"every mon,thu\" or FREQ=...)", ErrInvalid, raw)
+	switch lower {
+	case "daily":
+		return repeatRule{N: 1, Unit: "day"}, nil
+	case "weekly":
+		return repeatRule{N: 1, Unit: "week"}, nil
+	case "monthly":
+		return repeatRule{N: 1, Unit: "month"}, nil
+	case "yearly", "annually":
+		return repeatRule{N: 1, Unit: "year"}, nil
+	case "weekdays":
+		return repeatRule{Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}, nil
+	}
+	if strings.HasPrefix(strings.ToUpper(raw), "FREQ=") || strings.HasPrefix(strings.ToUpper(raw), "RRULE:") {
+		return parseRRule(strings.TrimPrefix(strings.ToUpper(raw), "RRULE:"), bad)
+	}
+	rest, ok := strings.CutPrefix(lower, "every ")
+	if !ok {
+		return repeatRule{}, bad
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return repeatRule{}, bad
+	}
+	n := 1
+	if v, err := strconv.Atoi(fields[0]); err == nil {
+		if v < 1 || len(fields) != 2 {
+			return repeatRule{}, bad
+		}
+		n = v
+		fields = fields[1:]
+	}
+	if len(fields) == 1 {
+		unit := strings.TrimSuffix(fields[0], "s")
+		switch unit {
+		case "day", "week", "month", "year":
+			return repeatRule{N: n, Unit: unit}, nil
+		}
+	}
+	if n != 1 {
+		return repeatRule{}, bad
+	}
+	var days []time.Weekday
+	for _, part := range strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' }) {
+		d, ok := repeatWeekdays[part]
+		if !ok {
+			return repeatRule{}, bad
+		}
+		days = append(days, d)
+	}
+	return repeatRule{Weekdays: days}, nil
+}
+
+func parseRRule(s string, bad error) (repeatRule, error) {
+	rule := repeatRule{N: 1}
+	for _, part := range strings.Split(s, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "FREQ":
+			unit, ok := rruleFreqs[value]
+			if !ok {
+				return repeatRule{}, bad
+			}
+			rule.Unit = unit
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return repeatRule{}, bad
+			}
+			rule.N = n
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := rruleDays[d]
+				if !ok {
+					return repeatRule{}, bad
+				}
+				rule.Weekdays = append(rule.Weekdays, wd)
+			}
+		case "":
+		default:
+			return repeatRule{}, bad
+		}
+	}
+	if rule.Unit == "" {
+		return repeatRule{}, bad
+	}
+	if len(rule.Weekdays) > 0 {
+		if rule.Unit != "week" || rule.N != 1 {
+			return repeatRule{}, bad
+		}
+		rule.Unit = ""
+	}
+	return rule, nil
+}
+
+// String is the canonical form stored in the task's repeat field.
+func (r repeatRule) String() string {
+	if len(r.Weekdays) > 0 {
+		days := append([]time.Weekday{}, r.Weekdays...)
+		sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+		names := make([]string, 0, len(days))
+		for i, d := range days {
+			if i > 0 && d == days[i-1] {
+				continue
+			}
+			names = append(names, strings.ToLower(d.String()[:3]))
+		}
+		if strings.Join(names, ",") == "mon,tue,wed,thu,fri" {
+			return "weekdays"
+		}
+		return "every " + strings.Join(names, ",")
+	}
+	if r.N == 1 {
+		switch r.Unit {
+		case "day":
+			return "daily"
+		case "week":
+			return "weekly"
+		case "month":
+			return "monthly"
+		case "year":
+			return "yearly"
+		}
+	}
+	return fmt.Sprintf("every %d %ss", r.N, r.Unit)
+}
+
+// NormalizeRepeat validates a repeat value and returns its canonical form;
+// "" and "none" clear it.
+func NormalizeRepeat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "none", "never", "off":
+		return "", nil
+	}
+	rule, err := parseRepeat(s)
+	if err != nil {
+		return "", err
+	}
+	return rule.String(), nil
+}
+
+// advance returns the next occurrence strictly after d. Monthly and yearly
+// rules land on day of the month, or the month's last day when it is
+// shorter, so a due date clamped to Feb 28 goes on to Mar 31.
+func (r repeatRule) advance(d time.Time, day int) time.Time {
+	if len(r.Weekdays) > 0 {
+		for i := 1; i <= 7; i++ {
+			next := d.AddDate(0, 0, i)
+			for _, wd := range r.Weekdays {
+				if next.Weekday() == wd {
+					return next
+				}
+			}
+		}
+		return d.AddDate(0, 0, 7)
+	}
+	switch r.Unit {
+	case "week":
+		return d.AddDate(0, 0, 7*r.N)
+	case "month":
+		return addMonthsOnDay(d, r.N, day)
+	case "year":
+		return addMonthsOnDay(d, 12*r.N, day)
+	default:
+		return d.AddDate(0, 0, r.N)
+	}
+}
+
+// AddMonthsClamped adds months, clamping the day to the target month's last
+// day (Jan 31 + 1 month is Feb 28/29, not Mar 3).
+func AddMonthsClamped(d time.Time, months int) time.Time {
+	return addMonthsOnDay(d, months, d.Day())
+}
+
+// addMonthsOnDay adds months and lands on day of the target month, clamped
+// to its last day.
+func addMonthsOnDay(d time.Time, months int, day int) time.Time {
+	first := time.Date(d.Year(), d.Month(), 1, d.Hour(), d.Minute(), d.Second(), 0, d.Location())
+	target := first.AddDate(0, months, 0)
+	if last := target.AddDate(0, 1, -1).Day(); day > last {
+		day = last
+	}
+	return time.Date(target.Year(), target.Month(), day, d.Hour(), d.Minute(), d.Second(), 0, d.Location())
+}
+
+// repeatAnchor is the day of the month a monthly or yearly repeat falls on:
+// the task's repeat_day while its due date sits on a shorter month's last
+// day, otherwise the due date's own day (so a hand-edited due date moves the
+// anchor).
+func repeatAnchor(due time.Time, repeatDay int) int {
+	if last := due.AddDate(0, 1, -due.Day()).Day(); repeatDay > due.Day() && due.Day() == last {
+		return repeatDay
+	}
+	return due.Day()
+}
+
+// nextDue advances due by the rule until it is after today, so a late
+// completion does not spawn occurrences that are already overdue. A task
+// without a due date repeats from today. Monthly and yearly repeats keep
+// their anchor day (see repeatAnchor) instead of drifting to the end of a
+// short month; the second result is the repeat_day the next occurrence
+// carries, 0 unless its due date was clamped.
+func nextDue(rule repeatRule, due string, repeatDay int, now time.Time) (string, int) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	base, ok := parseDueDate(due)
+	withTime := ok && len(strings.TrimSpace(due)) > 10
+	if !ok {
+		base = today
+	}
+	anchor := repeatAnchor(base, repeatDay)
+	next := rule.advance(base, anchor)
+	for i := 0; i < 1000; i++ {
+		day := time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)
+		if day.After(today) {
+			break
+		}
+		next = rule.advance(next, anchor)
+	}
+	keep := 0
+	if (rule.Unit == "month" || rule.Unit == "year") && len(rule.Weekdays) == 0 && next.Day() < anchor {
+		keep = anchor
+	}
+	if withTime {
+		return next.Format(time.RFC3339), keep
+	}
+	return next.Format("2006-01-02"), keep
+}
+
+// shiftStart keeps a repeating task's start the same number of days before
+// its due date. Without both dates the next occurrence has no start.
+func shiftStart(start, due, nextDue string) string {
+	s, ok1 := parseDueDate(start)
+	d, ok2 := parseDueDate(due)
+	n, ok3 := parseDueDate(nextDue)
+	if !ok1 || !ok2 || !ok3 {
+		return ""
+	}
+	return n.Add(s.Sub(d)).Format("2006-01-02")
+}
+
+// nextOccurrence builds (without writing) the task that follows a completed
+// recurring task. It goes back to the column the task was completed from,
+// or inbox when that was already a done/archived column.
+func (w *Workspace) nextOccurrence(t *Task, fromColumn string) (*Task, error) {
+	rule, err := parseRepeat(t.Repeat)
+	if err != nil {
+		return nil, err
+	}
+	col, ok := w.projectColumnByID(t.Project, fromColumn)
+	if !ok || !w.cfg.IsOpenStatus(col.Status) {
+		col, ok = w.defaultColumn(t.Project)
+		if !ok {
+			return nil, fmt.Errorf("%w: no open column for the next occurrence", ErrInvalid)
+		}
+	}
+	now := timeNow()
+	due, repeatDay := nextDue(rule, t.Due, t.RepeatDay, now)
+	id := w.newItemID("tsk_")
+	next := &Task{TaskMeta: TaskMeta{
+		Schema:    1,
+		ID:        id,
+		Title:     t.Title,
+		Status:    col.Status,
+		Project:   t.Project,
+		Column:    col.ID,
+		Priority:  t.Priority,
+		Tags:      append([]string{}, t.Tags...),
+		Due:       due,
+		DueTime:   t.DueTime,
+		Start:     shiftStart(t.Start, t.Due, due),
+		Repeat:    t.Repeat,
+		RepeatDay: repeatDay,
+		CreatedAt: &now,
+		MovedAt:   &now,
+		UpdatedAt: &now,
+	}}
+	next.Path = filepath.Join(w.projectColumnsDir(t.Project), col.Dir, fmt.Sprintf("%s__%s.md", id, slugify(t.Title)))
+	return next, nil
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextDue(t *testing.T) {
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		repeat, due, want string
+	}{
+		{"monthly", "2026-01-31", "2026-02-28"},
+		{"every 2 weeks", "2026-01-19", "2026-02-02"},
+		{"daily", "2026-01-10", "2026-01-21"},
+		{"FREQ=WEEKLY;BYDAY=MO,TH", "2026-01-19", "2026-01-22"},
+		{"weekly", "", "2026-01-27"},
+	}
+	for _, c := range cases {
+		rule, err := parseRepeat(c.repeat)
+		if err != nil {
+			t.Fatalf("%s: %v", c.repeat, err)
+		}
+		if got, _ := nextDue(rule, c.due, 0, now); got != c.want {
+			t.Fatalf("%s from %q: expected %s, got %s", c.repeat, c.due, c.want, got)
+		}
+	}
+	if _, err := NormalizeRepeat("fortnightly-ish"); err == nil {
+		t.Fatalf("expected invalid repeat to fail")
+	}
+}
+
+func TestMoveToDoneSpawnsNextOccurrence(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Pay rent", Project: "Home", Column: "todo", Due: "2099-01-01", Repeat: "Monthly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Repeat != "monthly" {
+		t.Fatalf("expected normalized repeat, got %q", task.Repeat)
+	}
+	done, err := w.MoveTask(task.ID, "done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := done.NextOccurrence
+	if next == nil {
+		t.Fatalf("expected next occurrence")
+	}
+	got, err := w.GetTaskByPrefix(next.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Column != "todo" || got.Due != "2099-02-01" || got.Repeat != "monthly" {
+		t.Fatalf("unexpected next occurrence: column=%s due=%s repeat=%s", got.Column, got.Due, got.Repeat)
+	}
+	if again, err := w.MoveTask(task.ID, "done"); err != nil || again.NextOccurrence != nil {
+		t.Fatalf("expected no second spawn when already done (err=%v)", err)
+	}
+}
+
+func TestMonthlyRepeatKeepsMonthEndAnchor(t *testing.T) {
+	now := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	cases := []struct {
+		repeat string
+		due    string
+		chain  []string
+	}{
+		{"monthly", "2026-01-31", []string{"2026-02-28", "2026-03-31", "2026-04-30", "2026-05-31"}},
+		{"monthly", "2026-01-30", []string{"2026-02-28", "2026-03-30", "2026-04-30"}},
+		{"every 3 months", "2026-11-30", []string{"2027-02-28", "2027-05-30"}},
+		{"yearly", "2028-02-29", []string{"2029-02-28", "2030-02-28", "2031-02-28", "2032-02-29"}},
+	}
+	for _, c := range cases {
+		rule, err := parseRepeat(c.repeat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		due, day := c.due, 0
+		for _, want := range c.chain {
+			if due, day = nextDue(rule, due, day, now); due != want {
+				t.Fatalf("%s from %s: expected %v, got %s", c.repeat, c.due, c.chain, due)
+			}
+		}
+	}
+
+	// A hand-edited due date moves the anchor.
+	rule, _ := parseRepeat("monthly")
+	if got, day := nextDue(rule, "2026-02-15", 31, now); got != "2026-03-15" || day != 0 {
+		t.Fatalf("expected the edited day to win, got %s (repeat_day %d)", got, day)
+	}
+}
+
+func TestMonthEndRepeatAcrossCompletions(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Close books", Project: "Home", Due: "2099-01-31", Repeat: "monthly"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"2099-02-28", "2099-03-31", "2099-04-30"} {
+		done, err := w.MoveTask(task.ID, "done")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if done.NextOccurrence == nil {
+			t.Fatalf("expected next occurrence")
+		}
+		next, err := w.GetTaskByPrefix(done.NextOccurrence.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Due != want {
+			t.Fatalf("expected %s, got %s", want, next.Due)
+		}
+		task = next
+	}
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NotStarted reports whether t has a start date after today (YYYY-MM-DD).
+// Such tasks stay out of today/week until then.
+func (t *Task) NotStarted(today string) bool {
+	start, ok := parseDueDate(t.Start)
+	return ok && start.Format("2006-01-02") > today
+}
+
+// ScheduledTasks lists open tasks that have not started yet, by start date.
+// days > 0 limits them to tasks starting within that many days.
+func (w *Workspace) ScheduledTasks(project string, days int) ([]Task, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow().UTC()
+	today := now.Format("2006-01-02")
+	limit := ""
+	if days > 0 {
+		limit = now.AddDate(0, 0, days).Format("2006-01-02")
+	}
+	var out []Task
+	for _, t := range tasks {
+		if !w.cfg.IsOpenStatus(t.Status) || !t.NotStarted(today) {
+			continue
+		}
+		if limit != "" && t.Start[:10] > limit {
+			continue
+		}
+		out = append(out, t)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+	return out, nil
+}
+
+// RenderScheduled renders ScheduledTasks grouped by start date.
+func (w *Workspace) RenderScheduled(project string, days int, format string) (string, error) {
+	tasks, err := w.ScheduledTasks(project, days)
+	if err != nil {
+		return "", err
+	}
+	var dates []string
+	byDate := map[string][]Task{}
+	for _, t := range tasks {
+		d := t.Start[:10]
+		if _, ok := byDate[d]; !ok {
+			dates = append(dates, d)
+		}
+		byDate[d] = append(byDate[d], t)
+	}
+	label := func(d string) string {
+		if day, err := time.Parse("2006-01-02", d); err == nil {
+			return w.cfg.DayLabel(day)
+		}
+		return d
+	}
+	var b strings.Builder
+	if isTelegramFormat(format) {
+		b.WriteString(w.cfg.iconLabel("scheduled", fmt.Sprintf("Scheduled (%d)", len(tasks))) + "\n\n")
+		if len(tasks) == 0 {
+			b.WriteString("Nothing scheduled.\n")
+		}
+		for _, d := range dates {
+			w.writeTelegramSection(&b, "Starts "+label(d), byDate[d], "", false, true)
+		}
+		return w.trimTelegramOutput(b.String()), nil
+	}
+	if len(tasks) == 0 {
+		return "Scheduled - nothing waiting to start", nil
+	}
+	b.WriteString(fmt.Sprintf("Scheduled - %d not started\n\n", len(tasks)))
+	for _, d := range dates {
+		w.writeTaskSection(&b, "Starts "+label(d), byDate[d], "", false, true)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,234 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotEntries are the workspace paths captured by a snapshot. Exports,
+// logs, the journal and other snapshots are deliberately left out.
+var snapshotEntries = []string{"config.json", "projects", "ideas"}
+
+// Snapshot is a named point-in-time copy of the workspace under
+// <root>/.snapshots/<slug>/.
+type Snapshot struct {
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	Files     int       `json:"files"`
+	Path      string    `json:"path"`
+}
+
+func (w *Workspace) snapshotsDir() string {
+	return filepath.Join(w.Root, ".snapshots")
+}
+
+// CreateSnapshot captures config, projects and ideas. Files are hard-linked
+// where the filesystem allows it (every write in the store replaces files via
+// rename, so links never see later edits) and copied otherwise.
+func (w *Workspace) CreateSnapshot(name string) (*Snapshot, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: snapshot name is required", ErrInvalid)
+	}
+	slug := slugify(name)
+	dir := filepath.Join(w.snapshotsDir(), slug)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%w: snapshot %q already exists", ErrConflict, slug)
+	}
+	tmp := dir + ".partial"
+	_ = os.RemoveAll(tmp)
+	files := 0
+	for _, entry := range snapshotEntries {
+		n, err := linkTree(filepath.Join(w.Root, entry), filepath.Join(tmp, entry))
+		if err != nil {
+			_ = os.RemoveAll(tmp)
+			return nil, err
+		}
+		files += n
+	}
+	snap := &Snapshot{Name: name, Slug: slug, CreatedAt: timeNow(), Files: files, Path: dir}
+	b, _ := json.MarshalIndent(snap, "", "  ")
+	if err := atomicWriteFile(filepath.Join(tmp, "snapshot.json"), b, 0o644); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+	if err := os.Rename(tmp, dir); err != nil {
+		_ = os.RemoveAll(tmp)
+		return nil, err
+	}
+	return snap, nil
+}
+
+// ListSnapshots returns snapshots, newest first.
+func (w *Workspace) ListSnapshots() ([]Snapshot, error) {
+	entries, err := os.ReadDir(w.snapshotsDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []Snapshot{}, nil
+		}
+		return nil, err
+	}
+	out := []Snapshot{}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasSuffix(e.Name(), ".partial") {
+			continue
+		}
+		snap, err := w.readSnapshot(e.Name())
+		if err != nil {
+			continue
+		}
+		out = append(out, *snap)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (w *Workspace) readSnapshot(slug string) (*Snapshot, error) {
+	dir := filepath.Join(w.snapshotsDir(), slug)
+	b, err := os.ReadFile(filepath.Join(dir, "snapshot.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return nil, err
+	}
+	snap.Path = dir
+	return &snap, nil
+}
+
+// GetSnapshot finds a snapshot by name or slug.
+func (w *Workspace) GetSnapshot(name string) (*Snapshot, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalid
+	}
+	return w.readSnapshot(slugify(name))
+}
+
+// RestoreSnapshot replaces config, projects and ideas with the snapshot's
+// copy, under the workspace lock. The current state is moved aside first and
+// only deleted once the restore has succeeded.
+func (w *Workspace) RestoreSnapshot(name string, timeout time.Duration) (*Snapshot, error) {
+	snap, err := w.GetSnapshot(name)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := w.Lock(timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	aside := filepath.Join(w.Root, fmt.Sprintf(".restore-%d", timeNow().UnixNano()))
+	if err := os.MkdirAll(aside, 0o755); err != nil {
+		return nil, err
+	}
+	moved := []string{}
+	undo := func() {
+		for _, entry := range moved {
+			_ = os.RemoveAll(filepath.Join(w.Root, entry))
+			_ = os.Rename(filepath.Join(aside, entry), filepath.Join(w.Root, entry))
+		}
+		_ = os.RemoveAll(aside)
+	}
+	for _, entry := range snapshotEntries {
+		src := filepath.Join(w.Root, entry)
+		if _, err := os.Lstat(src); err != nil {
+			continue
+		}
+		if err := os.Rename(src, filepath.Join(aside, entry)); err != nil {
+			undo()
+			return nil, err
+		}
+		moved = append(moved, entry)
+	}
+	for _, entry := range snapshotEntries {
+		if _, err := linkTree(filepath.Join(snap.Path, entry), filepath.Join(w.Root, entry)); err != nil {
+			undo()
+			return nil, err
+		}
+	}
+	_ = os.RemoveAll(aside)
+	_ = w.loadOrDefaultConfig()
+	return snap, nil
+}
+
+// DeleteSnapshot removes a snapshot directory.
+func (w *Workspace) DeleteSnapshot(name string) error {
+	snap, err := w.GetSnapshot(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(snap.Path)
+}
+
+// linkTree mirrors src into dst using hard links (falling back to copies) and
+// returns the number of files. A missing src is not an error.
+func linkTree(src string, dst string) (int, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if !info.IsDir() {
+		return 1, linkOrCopy(src, dst)
+	}
+	files := 0
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if strings.HasPrefix(d.Name(), ".tmp-") || !d.Type().IsRegular() {
+			return nil
+		}
+		files++
+		return linkOrCopy(path, target)
+	})
+	return files, err
+}
+
+func linkOrCopy(src string, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if err := os.Link(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
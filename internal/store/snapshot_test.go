@@ -0,0 +1,33 @@
+package store
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSnapshotRestoreAfterMove(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Snap me", Project: "Work", Column: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.CreateSnapshot("before"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := w.MoveTask(task.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.RestoreSnapshot("before", DefaultLockTimeout); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	got, err := w.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Column != "todo" {
+		t.Fatalf("expected column todo after restore, got %q", got.Column)
+	}
+	if _, err := os.Stat(task.Path); err != nil {
+		t.Fatalf("expected original path restored: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StatusDef declares a status beyond the built-in five. Columns may use it
+// like any other status; OpenLike decides whether its tasks count as open
+// (overdue, open-only boards, agenda, aging, dependency blockers).
+type StatusDef struct {
+	ID       string `json:"id"`
+	OpenLike bool   `json:"open_like"`
+	// Abbrev is the one-letter code in `ls` output; defaults to the first
+	// letter of the id.
+	Abbrev string `json:"abbrev,omitempty"`
+}
+
+// builtinStatuses are always defined and cannot be redeclared.
+var builtinStatuses = []StatusDef{
+	{ID: "open", OpenLike: true, Abbrev: "o"},
+	{ID: "doing", OpenLike: true, Abbrev: "d"},
+	{ID: "blocked", OpenLike: true, Abbrev: "b"},
+	{ID: "done", Abbrev: "✓"},
+	{ID: "archived", Abbrev: "a"},
+}
+
+// StatusDefs lists the built-in statuses followed by the configured ones.
+func (c Config) StatusDefs() []StatusDef {
+	return append(append([]StatusDef{}, builtinStatuses...), c.Statuses...)
+}
+
+func (c Config) statusDef(status string) (StatusDef, bool) {
+	status = strings.TrimSpace(strings.ToLower(status))
+	for _, s := range c.StatusDefs() {
+		if s.ID == status {
+			return s, true
+		}
+	}
+	return StatusDef{}, false
+}
+
+// KnownStatus reports whether status is built in or declared in config.
+func (c Config) KnownStatus(status string) bool {
+	_, ok := c.statusDef(status)
+	return ok
+}
+
+// IsOpenStatus reports whether tasks with status count as open. Unknown
+// statuses are not open.
+func (c Config) IsOpenStatus(status string) bool {
+	s, ok := c.statusDef(status)
+	return ok && s.OpenLike
+}
+
+// StatusAbbrev is the one-letter code for status, "?" when unknown.
+func (c Config) StatusAbbrev(status string) string {
+	s, ok := c.statusDef(status)
+	switch {
+	case !ok:
+		return "?"
+	case s.Abbrev != "":
+		return s.Abbrev
+	default:
+		return string([]rune(s.ID)[:1])
+	}
+}
+
+// StatusNames is the "open|doing|..." list used in usage and error text.
+func (c Config) StatusNames() string {
+	var ids []string
+	for _, s := range c.StatusDefs() {
+		ids = append(ids, s.ID)
+	}
+	return strings.Join(ids, "|")
+}
+
+// validateStatuses checks configured statuses for bad, duplicate or
+// built-in ids, and that every column uses a known status.
+func validateStatuses(statuses []StatusDef, cols []ColumnDef) error {
+	cfg := Config{Statuses: statuses}
+	seen := map[string]bool{}
+	for _, s := range builtinStatuses {
+		seen[s.ID] = true
+	}
+	for _, s := range statuses {
+		if s.ID == "" || slugify(s.ID) != s.ID {
+			return fmt.Errorf("status id must be a lowercase slug, got %q", s.ID)
+		}
+		if seen[s.ID] {
+			return fmt.Errorf("duplicate or built-in status %q", s.ID)
+		}
+		if len([]rune(s.Abbrev)) > 1 {
+			return fmt.Errorf("status %q abbrev must be one character", s.ID)
+		}
+		seen[s.ID] = true
+	}
+	for _, c := range cols {
+		if c.Status != "" && !cfg.KnownStatus(c.Status) {
+			return fmt.Errorf("column %q uses unknown status %q (use %s)", c.ID, c.Status, cfg.StatusNames())
+		}
+	}
+	return nil
+}
+
+// SetStatus declares or updates a configured status, or drops it when remove
+// is set. Removing refuses while a workspace or project column uses it.
+func (w *Workspace) SetStatus(def StatusDef, remove bool) error {
+	unlock, err := w.LockConfig(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	def.ID = strings.TrimSpace(strings.ToLower(def.ID))
+	cfg := w.cfg
+	statuses := []StatusDef{}
+	found := false
+	for _, s := range cfg.Statuses {
+		if s.ID != def.ID {
+			statuses = append(statuses, s)
+			continue
+		}
+		found = true
+		if !remove {
+			if def.Abbrev == "" {
+				def.Abbrev = s.Abbrev
+			}
+			statuses = append(statuses, def)
+		}
+	}
+	if remove && !found {
+		return fmt.Errorf("%w: status %q is not configured", ErrNotFound, def.ID)
+	}
+	if !found && !remove {
+		statuses = append(statuses, def)
+	}
+	if err := validateStatuses(statuses, cfg.Columns); err != nil {
+		return fmt.Errorf("%w: %v", statusErrKind(remove), err)
+	}
+	projects, err := w.ListProjects()
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if err := validateStatuses(statuses, p.Columns); err != nil {
+			return fmt.Errorf("%w: project %s: %v", statusErrKind(remove), p.Slug, err)
+		}
+	}
+	cfg.Statuses = statuses
+	return w.SaveConfig(cfg)
+}
+
+// statusErrKind is ErrConflict when a removal trips over a column still
+// using the status, ErrInvalid otherwise.
+func statusErrKind(remove bool) error {
+	if remove {
+		return ErrConflict
+	}
+	return ErrInvalid
+}
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfiguredStatusesHonorOpenLike(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	// Status and column changes re-read config.json, so Work must exist.
+	if _, err := w.CreateProject("Work"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddColumn(ColumnChange{}, ColumnDef{ID: "review", Status: "review"}, ""); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected undeclared status to be rejected, got %v", err)
+	}
+	if err := w.SetStatus(StatusDef{ID: "review", OpenLike: true}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetStatus(StatusDef{ID: "waiting"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetStatus(StatusDef{ID: "done"}, false); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected built-in status to be rejected, got %v", err)
+	}
+	for _, id := range []string{"review", "waiting"} {
+		if _, err := w.AddColumn(ColumnChange{}, ColumnDef{ID: id, Status: id}, ""); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.AddTask(AddTaskInput{Title: id, Project: "Work", Column: id, Due: "2000-01-01"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	overdue, err := w.ListTasks(ListFilter{Project: "work", Due: DueFilter{Overdue: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overdue) != 1 || overdue[0].Status != "review" {
+		t.Fatalf("expected only the open-like review task overdue, got %+v", overdue)
+	}
+	if got := w.cfg.StatusAbbrev("waiting"); got != "w" {
+		t.Fatalf("expected abbrev w, got %q", got)
+	}
+	if err := w.SetStatus(StatusDef{ID: "review"}, true); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict removing a status in use, got %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package store
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +30,24 @@ var (
 	timeNow     = func() time.Time { return time.Now().UTC() }
 )
 
+// SetNow pins the clock the store reads (what "today" is, timestamps it
+// writes) to t for the rest of the process; the zero time restores the
+// system clock.
+func SetNow(t time.Time) {
+	if t.IsZero() {
+		timeNow = func() time.Time { return time.Now().UTC() }
+		return
+	}
+	t = t.UTC()
+	timeNow = func() time.Time { return t }
+}
+
+// Now is the store's clock: the system time in UTC, or the time pinned with
+// SetNow.
+func Now() time.Time {
+	return timeNow()
+}
+
 // MatchConflictError provides details when a selector matches multiple tasks.
 // It still satisfies errors.Is(err, ErrConflict).
 type MatchConflictError struct {
@@ -46,9 +66,65 @@ func (e *MatchConflictError) Is(target error) bool {
 	return target == ErrConflict
 }
 
+// MatchCandidate summarizes one conflicting task for machine consumers, with
+// enough context to pick one without another lookup.
+type MatchCandidate struct {
+	ID        string     `json:"id"`
+	Title     string     `json:"title"`
+	Path      string     `json:"path"`
+	Project   string     `json:"project"`
+	Column    string     `json:"column"`
+	Status    string     `json:"status"`
+	Priority  string     `json:"priority"`
+	Due       string     `json:"due"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// Candidates returns the conflicting tasks as MatchCandidates.
+func (e *MatchConflictError) Candidates() []MatchCandidate {
+	out := make([]MatchCandidate, 0, len(e.Matches))
+	for _, t := range e.Matches {
+		out = append(out, MatchCandidate{
+			ID:        t.ID,
+			Title:     t.Title,
+			Path:      t.Path,
+			Project:   t.Project,
+			Column:    t.Column,
+			Status:    t.Status,
+			Priority:  t.Priority,
+			Due:       t.Due,
+			UpdatedAt: t.UpdatedAt,
+		})
+	}
+	return out
+}
+
 type Workspace struct {
 	Root string
-	cfg  Config
+	// ASCII keeps generated text (such as note separators) plain ASCII.
+	ASCII bool
+	// NoLock skips the workspace lock entirely (see Lock). It is an escape
+	// hatch for a single writer on a filesystem where the lock file misbehaves.
+	NoLock bool
+	// LockTimeout is how long writes wait for the workspace lock; zero means
+	// DefaultLockTimeout and LockNoWait not waiting at all.
+	LockTimeout time.Duration
+	// Actor names who is making changes in the audit log (see Event).
+	Actor string
+	// ViewSort orders the tasks within each section of the today and week
+	// views; the zero value keeps them by due time.
+	ViewSort TaskSort
+	// ViewPage caps the tasks those views list (see ViewPage).
+	ViewPage ViewPage
+	cfg      Config
+	tx       *journalTx
+	index    *taskIndex
+	// lockDepth counts nested Lock calls holding the workspace lock.
+	lockDepth int
+	// lockErr is the last ErrLocked/ErrReadOnly failure (see LockFailure).
+	lockErr error
+	// cfgETag identifies the config.json cfg was read from (see ConfigETag).
+	cfgETag string
 }
 
 type SelectorFilter struct {
@@ -57,6 +133,20 @@ type SelectorFilter struct {
 	Status          string
 	IncludeArchived bool
 	Match           string
+	Tags            TagFilter
+	Due             DueFilter
+}
+
+// listFilter is the ListFilter equivalent of a selector filter.
+func (f SelectorFilter) listFilter() ListFilter {
+	return ListFilter{
+		Project: f.Project,
+		Column:  f.Column,
+		Status:  f.Status,
+		Tags:    f.Tags,
+		All:     f.IncludeArchived,
+		Due:     f.Due,
+	}
 }
 
 const (
@@ -68,9 +158,62 @@ const (
 )
 
 type Config struct {
-	Schema  int          `json:"schema"`
-	Columns []ColumnDef  `json:"columns"`
-	Agent   *AgentConfig `json:"agent,omitempty"`
+	Schema int `json:"schema"`
+	// Version counts saves; SaveConfig bumps it.
+	Version  int             `json:"version,omitempty"`
+	Columns  []ColumnDef     `json:"columns"`
+	Agent    *AgentConfig    `json:"agent,omitempty"`
+	Log      *LogConfig      `json:"log,omitempty"`
+	Projects *ProjectsConfig `json:"projects,omitempty"`
+	Notes    *NotesConfig    `json:"notes,omitempty"`
+	Ideas    *IdeasConfig    `json:"ideas,omitempty"`
+	Formats  *FormatsConfig  `json:"formats,omitempty"`
+	Aging    *AgingConfig    `json:"aging,omitempty"`
+	Exports  *ExportsConfig  `json:"exports,omitempty"`
+	Sync     *SyncConfig     `json:"sync,omitempty"`
+	Statuses []StatusDef     `json:"statuses,omitempty"`
+	Agenda   *AgendaConfig   `json:"agenda,omitempty"`
+	Theme    *ThemeConfig    `json:"theme,omitempty"`
+	IDs      *IDsConfig      `json:"ids,omitempty"`
+	// Escalation raises the priority of overdue tasks (see EscalationSteps).
+	Escalation *EscalationConfig `json:"escalation,omitempty"`
+	// Locale picks the language of weekday/month labels and the weekday
+	// names accepted in due dates (en, de, fr, ...).
+	Locale string `json:"locale,omitempty"`
+	// Aliases map a name to the command line it expands to.
+	Aliases map[string]string `json:"aliases,omitempty"`
+}
+
+// ExportsConfig lists views re-rendered into the exports directory after
+// every successful mutating command.
+type ExportsConfig struct {
+	// Auto entries are "<view>[:<project>]" with view today|week|board.
+	Auto []string `json:"auto,omitempty"`
+	// Format is human (default), telegram or json.
+	Format string `json:"format,omitempty"`
+}
+
+// SyncConfig holds the settings of `tasker sync git`.
+type SyncConfig struct {
+	// AutoCommit commits the root after every successful mutating command
+	// when it is a git repository.
+	AutoCommit bool `json:"auto_commit,omitempty"`
+}
+
+// SyncAutoCommit reports whether sync.auto_commit is on.
+func (c Config) SyncAutoCommit() bool {
+	return c.Sync != nil && c.Sync.AutoCommit
+}
+
+type ProjectsConfig struct {
+	// AutoCreate lets add/capture create a project on first use. Unset means
+	// false, so a mistyped --project fails instead of starting a project.
+	AutoCreate *bool `json:"auto_create,omitempty"`
+}
+
+// AutoCreateProjects reports whether unknown projects are created on add.
+func (c Config) AutoCreateProjects() bool {
+	return c.Projects != nil && c.Projects.AutoCreate != nil && *c.Projects.AutoCreate
 }
 
 type ColumnDef struct {
@@ -88,6 +231,9 @@ type AgentConfig struct {
 	OpenOnly        bool   `json:"open_only"`
 	SummaryGroup    string `json:"summary_group"`  // none|project|column
 	SummaryTotals   bool   `json:"summary_totals"` // show per-group counts
+	// AutoArchiveDays archives done tasks older than this many days after
+	// every mutating command; 0 is off.
+	AutoArchiveDays int `json:"auto_archive_days,omitempty"`
 }
 
 type Project struct {
@@ -97,19 +243,53 @@ type Project struct {
 	Slug      string    `json:"slug"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Columns overrides the workspace columns for this project.
+	Columns []ColumnDef `json:"columns,omitempty"`
+	// Defaults override the agent config for commands scoped to this
+	// project (see ProjectDefaults).
+	Defaults *ProjectDefaults `json:"defaults,omitempty"`
+	// ArchivedAt is set on archived projects, which stay on disk but drop
+	// out of default listings (see ActiveProjects).
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
 }
 
+// IsArchived reports whether the project has been archived.
+func (p Project) IsArchived() bool { return p.ArchivedAt != nil }
+
 type TaskMeta struct {
-	Schema      int        `yaml:"schema" json:"schema"`
-	ID          string     `yaml:"id" json:"id"`
-	Title       string     `yaml:"title" json:"title"`
-	Status      string     `yaml:"status" json:"status"`
-	Project     string     `yaml:"project" json:"project"`
-	Column      string     `yaml:"column" json:"column"`
-	Priority    string     `yaml:"priority" json:"priority"`
-	Tags        []string   `yaml:"tags" json:"tags"`
-	Due         string     `yaml:"due" json:"due"`
-	CreatedAt   *time.Time `yaml:"created_at" json:"created_at"`
+	Schema   int      `yaml:"schema" json:"schema"`
+	ID       string   `yaml:"id" json:"id"`
+	Title    string   `yaml:"title" json:"title"`
+	Status   string   `yaml:"status" json:"status"`
+	Project  string   `yaml:"project" json:"project"`
+	Column   string   `yaml:"column" json:"column"`
+	Priority string   `yaml:"priority" json:"priority"`
+	Tags     []string `yaml:"tags" json:"tags"`
+	Due      string   `yaml:"due" json:"due"`
+	// DueTime is the time of day (HH:MM) the task is due; AllDay is set when
+	// a dated task has none. Both follow Due (see normalizeDue).
+	DueTime string `yaml:"due_time,omitempty" json:"due_time,omitempty"`
+	AllDay  bool   `yaml:"all_day,omitempty" json:"all_day,omitempty"`
+	// Start hides the task from today/week until that date.
+	Start  string `yaml:"start,omitempty" json:"start,omitempty"`
+	Repeat string `yaml:"repeat,omitempty" json:"repeat,omitempty"`
+	// RepeatDay is the day of the month a monthly or yearly repeat falls on
+	// when Due had to be clamped to a shorter month (31 for a task due on
+	// the 31st that is now due Feb 28).
+	RepeatDay int      `yaml:"repeat_day,omitempty" json:"repeat_day,omitempty"`
+	BlockedBy []string `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"`
+	// Links are non-blocking relations to other tasks, mirrored on both ends.
+	Links []TaskLink `yaml:"links,omitempty" json:"links,omitempty"`
+	// Attachments are the task's attached files, as paths relative to the
+	// root (attachments/<task-id>/<name>; see AttachFile).
+	Attachments []string `yaml:"attachments,omitempty" json:"attachments,omitempty"`
+	// ExternalID is a client-supplied key that makes add idempotent.
+	ExternalID string `yaml:"external_id,omitempty" json:"external_id,omitempty"`
+	// Issue is the tracker issue the task was pulled from (sync github).
+	Issue     *IssueRef  `yaml:"issue,omitempty" json:"issue,omitempty"`
+	CreatedAt *time.Time `yaml:"created_at" json:"created_at"`
+	// MovedAt is when the task entered its current column.
+	MovedAt     *time.Time `yaml:"moved_at,omitempty" json:"moved_at,omitempty"`
 	UpdatedAt   *time.Time `yaml:"updated_at" json:"updated_at"`
 	CompletedAt *time.Time `yaml:"completed_at" json:"completed_at"`
 	ArchivedAt  *time.Time `yaml:"archived_at" json:"archived_at"`
@@ -119,25 +299,111 @@ type Task struct {
 	TaskMeta `json:",inline"`
 	Path     string `json:"path"`
 	Body     string `json:"-"`
+	// NextOccurrence is set by MoveTask when completing a recurring task
+	// spawned its next occurrence.
+	NextOccurrence *Task `json:"next_occurrence,omitempty"`
+	// Existing is set by AddTask when the external ID matched a task that
+	// was already there; nothing was written.
+	Existing bool `json:"existing,omitempty"`
+	// Bundle is the archive bundle (archive/<year>.ndjson) a compacted task
+	// was read from; Path is then where its file used to live.
+	Bundle string `json:"bundle,omitempty"`
+	// EscalatedFrom is the stored priority of a task a view shows with the
+	// higher one the escalation policy gives it.
+	EscalatedFrom string `json:"escalated_from,omitempty"`
 }
 
 type AddTaskInput struct {
-	Title       string
-	Project     string
-	Column      string
-	Due         string
+	Title   string
+	Project string
+	Column  string
+	Due     string
+	// DueTime is a time of day for Due (see NormalizeDueTime).
+	DueTime     string
+	Start       string
 	Priority    string
 	Tags        []string
 	Description string
+	// Repeat is a recurrence rule (see NormalizeRepeat).
+	Repeat string
+	// Body is raw Markdown used as the task body; it wins over Description.
+	Body string
+	// CreateProject creates a missing project even when
+	// projects.auto_create is off.
+	CreateProject bool
+	// ExternalID, when set, makes the add idempotent: a task that already
+	// carries it is returned (with Existing set) instead of a new one.
+	ExternalID string
 }
 
 type ListFilter struct {
+	// Project is a project spec: a name, a glob or a comma-separated list
+	// (see ExpandProjectSpec).
 	Project string
 	Column  string
 	Status  string
-	Tag     string
+	Tags    TagFilter
 	Search  string
 	All     bool
+	Due     DueFilter
+	// Query is a filter expression on top of the fields above (see
+	// ParseQuery).
+	Query *Query
+	// Sort replaces the default due-then-updated order when active.
+	Sort TaskSort
+	// ArchiveBundles also lists the tasks compacted into archive/<year>.ndjson
+	// bundles (see CompactArchive).
+	ArchiveBundles bool
+}
+
+// DueFilter narrows tasks by due date. Before/After are YYYY-MM-DD and
+// exclusive; any active predicate drops tasks without a due date.
+type DueFilter struct {
+	Before  string
+	After   string
+	Overdue bool // open and due before today
+	Today   bool // due today
+}
+
+func (d DueFilter) active() bool {
+	return d.Before != "" || d.After != "" || d.Overdue || d.Today
+}
+
+// Validate checks that Before/After are dates.
+func (d DueFilter) Validate() error {
+	for _, v := range []string{d.Before, d.After} {
+		if v == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", v); err != nil {
+			return fmt.Errorf("%w: due date %q (use YYYY-MM-DD)", ErrInvalid, v)
+		}
+	}
+	return nil
+}
+
+func (d DueFilter) matches(t Task, today string, open bool) bool {
+	if !d.active() {
+		return true
+	}
+	due, ok := parseDueDate(t.Due)
+	if !ok {
+		return false
+	}
+	day := due.In(time.UTC).Format("2006-01-02")
+	if d.Before != "" && day >= d.Before {
+		return false
+	}
+	if d.After != "" && day <= d.After {
+		return false
+	}
+	if d.Overdue && (day >= today || !open) {
+		return false
+	}
+	if d.Today && day != today {
+		return false
+	}
+	return true
 }
 
 // Open opens a workspace rooted at root. It does not create files until Init is called.
@@ -146,6 +412,11 @@ func Open(root string) (*Workspace, error) {
 	if err := ws.loadOrDefaultConfig(); err != nil {
 		// If config doesn't exist, that's ok until Init.
 	}
+	// Roll back operations interrupted by a crash (unless a live lock holder
+	// may still be working); doctor reports failures.
+	if info, locked := ws.LockStatus(); !locked || info.Stale {
+		_, _ = ws.RecoverJournal(JournalRollback, journalStaleAfter)
+	}
 	return ws, nil
 }
 
@@ -180,7 +451,11 @@ func (w *Workspace) ensureConfig() error {
 	}
 	w.cfg = defaultConfig()
 	b, _ := json.MarshalIndent(w.cfg, "", "  ")
-	return atomicWriteFile(cfgPath, b, 0o644)
+	if err := atomicWriteFile(cfgPath, b, 0o644); err != nil {
+		return err
+	}
+	w.cfgETag = configETag(b)
+	return nil
 }
 
 func defaultConfig() Config {
@@ -202,8 +477,10 @@ func (w *Workspace) loadOrDefaultConfig() error {
 	b, err := os.ReadFile(cfgPath)
 	if err != nil {
 		w.cfg = defaultConfig()
+		w.cfgETag = ""
 		return err
 	}
+	w.cfgETag = configETag(b)
 	var cfg Config
 	if err := json.Unmarshal(b, &cfg); err != nil {
 		return err
@@ -222,6 +499,48 @@ func (w *Workspace) Config() Config {
 	return w.cfg
 }
 
+// ErrConfigChanged is returned by SaveConfig when config.json was written by
+// someone else after this workspace read it. It satisfies
+// errors.Is(err, ErrConflict).
+var ErrConfigChanged = fmt.Errorf("%w: config.json changed since it was read; re-run the command", ErrConflict)
+
+// configETag is the content hash of a config.json, "" when it is missing.
+func configETag(b []byte) string {
+	if b == nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8])
+}
+
+// ConfigETag identifies the config.json the workspace config was read from,
+// "" when there was none.
+func (w *Workspace) ConfigETag() string {
+	return w.cfgETag
+}
+
+// ReloadConfig re-reads config.json when it changed on disk since it was
+// read, so long-running commands (serve, watch, mcp) follow edits made by
+// other tasker processes. It reports whether the config was re-read.
+func (w *Workspace) ReloadConfig() (bool, error) {
+	b, err := os.ReadFile(filepath.Join(w.Root, "config.json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if configETag(b) == w.cfgETag {
+		return false, nil
+	}
+	if err := w.loadOrDefaultConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	return true, nil
+}
+
+// SaveConfig writes cfg under the workspace lock and bumps its version. A
+// config.json that changed on disk since it was read (another tasker
+// process, an editor) is not overwritten: the save fails with
+// ErrConfigChanged instead of losing that change. Hold LockConfig across
+// the read and the save to avoid that.
 func (w *Workspace) SaveConfig(cfg Config) error {
 	if cfg.Schema == 0 {
 		cfg.Schema = 1
@@ -229,10 +548,33 @@ func (w *Workspace) SaveConfig(cfg Config) error {
 	if len(cfg.Columns) == 0 {
 		cfg.Columns = defaultConfig().Columns
 	}
-	w.cfg = cfg
-	b, _ := json.MarshalIndent(cfg, "", "  ")
+	unlock, err := w.Lock(w.WriteLockTimeout())
+	if err != nil {
+		return err
+	}
+	defer unlock()
 	cfgPath := filepath.Join(w.Root, "config.json")
-	return atomicWriteFile(cfgPath, b, 0o644)
+	current, err := os.ReadFile(cfgPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if configETag(current) != w.cfgETag {
+		return ErrConfigChanged
+	}
+	cfg.Version = w.cfg.Version + 1
+	b, _ := json.MarshalIndent(cfg, "", "  ")
+	if w.tx != nil {
+		// Inside a transaction the config rolls back with everything else.
+		content := string(b)
+		if err := w.commitChanges("config", []fileChange{{Path: cfgPath, After: &content}}); err != nil {
+			return err
+		}
+	} else if err := atomicWriteFile(cfgPath, b, 0o644); err != nil {
+		return err
+	}
+	w.cfg = cfg
+	w.cfgETag = configETag(b)
+	return nil
 }
 
 func (w *Workspace) CreateProject(name string) (*Project, error) {
@@ -277,7 +619,8 @@ func (w *Workspace) CreateProject(name string) (*Project, error) {
 		}
 	}
 	b, _ := json.MarshalIndent(p, "", "  ")
-	if err := atomicWriteFile(metaPath, b, 0o644); err != nil {
+	content := string(b)
+	if err := w.commitChanges("project add", []fileChange{{Path: metaPath, After: &content}}); err != nil {
 		return nil, err
 	}
 	return p, nil
@@ -325,11 +668,28 @@ func (w *Workspace) AddTask(in AddTaskInput) (*Task, error) {
 	if strings.TrimSpace(in.Title) == "" {
 		return nil, fmt.Errorf("%w: title is required", ErrInvalid)
 	}
+	externalID := strings.TrimSpace(in.ExternalID)
+	if externalID != "" {
+		existing, err := w.findTasksByExternalID(externalID, SelectorFilter{IncludeArchived: true})
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			task := existing[0]
+			task.Existing = true
+			return &task, nil
+		}
+	}
 	projectName := strings.TrimSpace(in.Project)
 	if projectName == "" {
 		projectName = "Personal"
 	}
 	projectSlug := slugify(projectName)
+	if !in.CreateProject && !w.cfg.AutoCreateProjects() {
+		if _, err := os.Stat(filepath.Join(w.Root, "projects", projectSlug, "project.json")); err != nil {
+			return nil, fmt.Errorf("%w: project not found: %s", ErrNotFound, projectName)
+		}
+	}
 	_, err := w.CreateProject(projectName) // idempotent create (name preserved)
 	if err != nil {
 		return nil, err
@@ -337,30 +697,52 @@ func (w *Workspace) AddTask(in AddTaskInput) (*Task, error) {
 
 	colID := strings.TrimSpace(in.Column)
 	if colID == "" {
-		colID = "inbox"
+		def, ok := w.defaultColumn(projectSlug)
+		if !ok {
+			return nil, fmt.Errorf("%w: project %s has no open column", ErrInvalid, projectSlug)
+		}
+		colID = def.ID
 	}
-	col, ok := w.columnByID(colID)
+	dueTime, err := NormalizeDueTime(in.DueTime)
+	if err != nil {
+		return nil, err
+	}
+	if dueTime != "" && strings.TrimSpace(in.Due) == "" {
+		return nil, fmt.Errorf("%w: a due time needs a due date", ErrInvalid)
+	}
+	repeat, err := NormalizeRepeat(in.Repeat)
+	if err != nil {
+		return nil, err
+	}
+	col, ok := w.projectColumnByID(projectSlug, colID)
 	if !ok {
 		return nil, fmt.Errorf("%w: unknown column %q", ErrInvalid, colID)
 	}
 
 	now := timeNow()
-	id := "tsk_" + newULID()
+	id := w.newItemID("tsk_")
 	meta := TaskMeta{
-		Schema:    1,
-		ID:        id,
-		Title:     strings.TrimSpace(in.Title),
-		Status:    col.Status,
-		Project:   projectSlug,
-		Column:    colID,
-		Priority:  normalizePriority(in.Priority),
-		Tags:      dedupeStrings(in.Tags),
-		Due:       strings.TrimSpace(in.Due),
-		CreatedAt: &now,
-		UpdatedAt: &now,
+		Schema:     1,
+		ID:         id,
+		Title:      strings.TrimSpace(in.Title),
+		Status:     col.Status,
+		Project:    projectSlug,
+		Column:     col.ID,
+		Priority:   normalizePriority(in.Priority),
+		Tags:       dedupeStrings(in.Tags),
+		Due:        strings.TrimSpace(in.Due),
+		DueTime:    dueTime,
+		Start:      strings.TrimSpace(in.Start),
+		Repeat:     repeat,
+		ExternalID: externalID,
+		CreatedAt:  &now,
+		MovedAt:    &now,
+		UpdatedAt:  &now,
 	}
 	body := ""
-	if strings.TrimSpace(in.Description) != "" {
+	if strings.TrimSpace(in.Body) != "" {
+		body = strings.Trim(in.Body, "\n") + "\n"
+	} else if strings.TrimSpace(in.Description) != "" {
 		body = "## Notes\n\n" + strings.TrimSpace(in.Description) + "\n"
 	}
 
@@ -368,12 +750,52 @@ func (w *Workspace) AddTask(in AddTaskInput) (*Task, error) {
 	path := filepath.Join(w.projectColumnsDir(projectSlug), col.Dir, filename)
 
 	task := &Task{TaskMeta: meta, Path: path, Body: body}
-	if err := writeTaskFile(task); err != nil {
+	if err := w.saveTask("add", task); err != nil {
 		return nil, err
 	}
 	return task, nil
 }
 
+// PreviewTask returns the task AddTask would create for in, project and
+// column resolved and body rendered, without writing anything: the add runs
+// in a transaction that is rolled back. The id is a fresh one; the real add
+// gets another.
+func (w *Workspace) PreviewTask(in AddTaskInput) (*Task, error) {
+	projectDir := filepath.Join(w.Root, "projects", slugifyOrDefault(in.Project, "Personal"))
+	if _, err := os.Stat(projectDir); errors.Is(err, os.ErrNotExist) {
+		// A new project's directories are created outside the journal.
+		defer removeEmptyDirs(projectDir)
+	}
+	var task *Task
+	err := w.Transaction("add", w.WriteLockTimeout(), func() error {
+		t, err := w.AddTask(in)
+		if err != nil {
+			return err
+		}
+		task = t
+		return errBatchDryRun
+	})
+	if err != nil && !errors.Is(err, errBatchDryRun) {
+		return nil, err
+	}
+	return task, nil
+}
+
+// removeEmptyDirs removes dir when the tree under it holds directories only.
+func removeEmptyDirs(dir string) {
+	empty := true
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			empty = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if empty {
+		_ = os.RemoveAll(dir)
+	}
+}
+
 func (w *Workspace) GetTaskByPrefix(prefix string) (*Task, error) {
 	candidates, err := w.findTasksByPrefix(prefix)
 	if err != nil {
@@ -429,9 +851,15 @@ func (w *Workspace) ResolveTasks(selector string, filter SelectorFilter) ([]Task
 	return matches, nil
 }
 
+// ExternalIDPrefix marks a selector as an external ID ("ext:<key>").
+const ExternalIDPrefix = "ext:"
+
 func (w *Workspace) resolveSelectorCandidates(selector string, filter SelectorFilter) ([]Task, error) {
 	filter = normalizeSelectorFilter(filter)
-	if isLikelyIDSelector(selector) {
+	if key, ok := strings.CutPrefix(selector, ExternalIDPrefix); ok {
+		return w.findTasksByExternalID(strings.TrimSpace(key), filter)
+	}
+	if w.isLikelyIDSelector(selector, "tsk_") {
 		matches, err := w.findTasksByPrefixFiltered(selector, filter)
 		if err != nil {
 			return nil, err
@@ -469,6 +897,8 @@ func normalizeSelectorFilter(filter SelectorFilter) SelectorFilter {
 		Status:          status,
 		IncludeArchived: includeArchived,
 		Match:           match,
+		Tags:            filter.Tags.normalize(),
+		Due:             filter.Due,
 	}
 }
 
@@ -529,13 +959,7 @@ func (w *Workspace) findTasksByMatchMode(selector string, filter SelectorFilter)
 }
 
 func (w *Workspace) findTasksByTitleExactFiltered(selector string, filter SelectorFilter) ([]Task, error) {
-	listFilter := ListFilter{
-		Project: filter.Project,
-		Column:  filter.Column,
-		Status:  filter.Status,
-		All:     filter.IncludeArchived,
-	}
-	tasks, err := w.ListTasks(listFilter)
+	tasks, err := w.ListTasks(filter.listFilter())
 	if err != nil {
 		return nil, err
 	}
@@ -556,13 +980,7 @@ func (w *Workspace) findTasksByTitleExactFiltered(selector string, filter Select
 }
 
 func (w *Workspace) findTasksByTitlePrefixFiltered(selector string, filter SelectorFilter) ([]Task, error) {
-	listFilter := ListFilter{
-		Project: filter.Project,
-		Column:  filter.Column,
-		Status:  filter.Status,
-		All:     filter.IncludeArchived,
-	}
-	tasks, err := w.ListTasks(listFilter)
+	tasks, err := w.ListTasks(filter.listFilter())
 	if err != nil {
 		return nil, err
 	}
@@ -585,13 +1003,7 @@ func (w *Workspace) findTasksByTitlePrefixFiltered(selector string, filter Selec
 }
 
 func (w *Workspace) findTasksByTitleContainsFiltered(selector string, filter SelectorFilter) ([]Task, error) {
-	listFilter := ListFilter{
-		Project: filter.Project,
-		Column:  filter.Column,
-		Status:  filter.Status,
-		All:     filter.IncludeArchived,
-	}
-	tasks, err := w.ListTasks(listFilter)
+	tasks, err := w.ListTasks(filter.listFilter())
 	if err != nil {
 		return nil, err
 	}
@@ -614,13 +1026,8 @@ func (w *Workspace) findTasksByTitleContainsFiltered(selector string, filter Sel
 }
 
 func (w *Workspace) findTasksBySearchFiltered(selector string, filter SelectorFilter) ([]Task, error) {
-	listFilter := ListFilter{
-		Project: filter.Project,
-		Column:  filter.Column,
-		Status:  filter.Status,
-		All:     filter.IncludeArchived,
-		Search:  strings.TrimSpace(selector),
-	}
+	listFilter := filter.listFilter()
+	listFilter.Search = strings.TrimSpace(selector)
 	tasks, err := w.ListTasks(listFilter)
 	if err != nil {
 		return nil, err
@@ -643,7 +1050,7 @@ func (w *Workspace) findTasksByPrefixFiltered(prefix string, filter SelectorFilt
 			continue
 		}
 		w.reconcileTaskFromPath(t)
-		if !matchesSelectorFilter(*t, filter) {
+		if !matchesSelectorFilter(*t, filter, w.cfg.IsOpenStatus(t.Status)) {
 			continue
 		}
 		matches = append(matches, *t)
@@ -651,7 +1058,24 @@ func (w *Workspace) findTasksByPrefixFiltered(prefix string, filter SelectorFilt
 	return sortSelectorMatches(matches), nil
 }
 
-func matchesSelectorFilter(t Task, filter SelectorFilter) bool {
+func (w *Workspace) findTasksByExternalID(key string, filter SelectorFilter) ([]Task, error) {
+	if key == "" {
+		return nil, nil
+	}
+	tasks, err := w.ListTasks(filter.listFilter())
+	if err != nil {
+		return nil, err
+	}
+	var matches []Task
+	for _, t := range tasks {
+		if t.ExternalID == key {
+			matches = append(matches, t)
+		}
+	}
+	return sortSelectorMatches(matches), nil
+}
+
+func matchesSelectorFilter(t Task, filter SelectorFilter, open bool) bool {
 	if filter.Project != "" && t.Project != filter.Project {
 		return false
 	}
@@ -664,7 +1088,10 @@ func matchesSelectorFilter(t Task, filter SelectorFilter) bool {
 	if !filter.IncludeArchived && t.Status == "archived" {
 		return false
 	}
-	return true
+	if !filter.Tags.matches(t.Tags) {
+		return false
+	}
+	return filter.Due.matches(t, timeNow().Format("2006-01-02"), open)
 }
 
 func sortSelectorMatches(matches []Task) []Task {
@@ -702,65 +1129,53 @@ func (w *Workspace) tasksFromPaths(paths []string) []Task {
 	return out
 }
 
-func isLikelyIDSelector(selector string) bool {
-	selector = strings.TrimSpace(selector)
-	if selector == "" {
-		return false
-	}
-	lower := strings.ToLower(selector)
-	if strings.HasPrefix(lower, "tsk_") {
-		return true
-	}
-	if len(selector) < 8 {
-		return false
-	}
-	allowed := "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
-	hasDigit := false
-	for _, r := range strings.ToUpper(selector) {
-		if r >= '0' && r <= '9' {
-			hasDigit = true
-		}
-		if !strings.ContainsRune(allowed, r) {
-			return false
-		}
-	}
-	return hasDigit
+func (w *Workspace) MoveTask(prefix string, toColumnID string) (*Task, error) {
+	return w.MoveTaskWith(prefix, toColumnID, MoveOptions{})
 }
 
-func (w *Workspace) MoveTask(prefix string, toColumnID string) (*Task, error) {
+// MoveTaskWith is MoveTask with options. Moving a task into a done column
+// fails with a *BlockedError while any of its blockers is still open,
+// unless opts.Force is set.
+func (w *Workspace) MoveTaskWith(prefix string, toColumnID string, opts MoveOptions) (*Task, error) {
 	task, err := w.GetTaskByPrefix(prefix)
 	if err != nil {
 		return nil, err
 	}
 	w.reconcileTaskFromPath(task)
-	col, ok := w.columnByID(toColumnID)
+	col, ok := w.projectColumnByID(task.Project, toColumnID)
 	if !ok {
 		return nil, fmt.Errorf("%w: unknown column %q", ErrInvalid, toColumnID)
 	}
+	if col.Status == "done" && !opts.Force && task.Status != "done" {
+		blockers, err := w.OpenBlockers(task)
+		if err != nil {
+			return nil, err
+		}
+		if len(blockers) > 0 {
+			return nil, &BlockedError{Task: task, Blockers: blockers}
+		}
+	}
 	projectSlug := strings.TrimSpace(task.Project)
 	if projectSlug == "" {
 		return nil, fmt.Errorf("%w: task project unknown", ErrInvalid)
 	}
 
-	// Move file to new directory
 	oldPath := task.Path
-	newDir := filepath.Join(w.projectColumnsDir(projectSlug), col.Dir)
-	if err := os.MkdirAll(newDir, 0o755); err != nil {
-		return nil, err
-	}
-	newPath := filepath.Join(newDir, filepath.Base(oldPath))
-	if err := os.Rename(oldPath, newPath); err != nil {
-		return nil, err
-	}
+	newPath := filepath.Join(w.projectColumnsDir(projectSlug), col.Dir, filepath.Base(oldPath))
+	fromColumn, fromStatus := task.Column, task.Status
 
 	now := timeNow()
 	task.Path = newPath
-	task.Column = toColumnID
+	if task.Column != col.ID {
+		task.MovedAt = &now
+	}
+	task.Column = col.ID
 	task.Status = col.Status
 	task.UpdatedAt = &now
 	if col.Status == "done" {
 		task.CompletedAt = &now
-	} else {
+	} else if col.Status != "archived" || fromStatus != "done" {
+		// Archiving a done task keeps when it was completed.
 		task.CompletedAt = nil
 	}
 	if col.Status == "archived" {
@@ -768,9 +1183,33 @@ func (w *Workspace) MoveTask(prefix string, toColumnID string) (*Task, error) {
 	} else {
 		task.ArchivedAt = nil
 	}
-	if err := writeTaskFile(task); err != nil {
+	// Write the new file before removing the old one, under one journal
+	// entry, so a crash never leaves the task half-moved.
+	content, err := renderTaskFile(task)
+	if err != nil {
+		return nil, err
+	}
+	changes := []fileChange{{Path: newPath, After: &content}}
+	if newPath != oldPath {
+		changes = append(changes, fileChange{Path: oldPath})
+	}
+	// Completing a recurring task spawns its next occurrence in the same
+	// journal entry.
+	var next *Task
+	if col.Status == "done" && fromStatus != "done" && strings.TrimSpace(task.Repeat) != "" {
+		if next, err = w.nextOccurrence(task, fromColumn); err != nil {
+			return nil, err
+		}
+		nextContent, err := renderTaskFile(next)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fileChange{Path: next.Path, After: &nextContent})
+	}
+	if err := w.commitChanges("mv", changes); err != nil {
 		return nil, err
 	}
+	task.NextOccurrence = next
 	return task, nil
 }
 
@@ -781,24 +1220,116 @@ func (w *Workspace) AddNote(prefix string, note string) (*Task, error) {
 	}
 	now := timeNow()
 	task.UpdatedAt = &now
-	entry := fmt.Sprintf("- %s — %s\n", now.Format(time.RFC3339), strings.TrimSpace(note))
-	if task.Body == "" {
-		task.Body = "## Notes\n\n" + entry
-	} else {
-		task.Body = strings.TrimRight(task.Body, "\n") + "\n" + entry
+	entry := formatNoteEntry(now, w.noteSeparator(), note)
+	task.Body = appendNoteEntry(task.Body, "## Notes\n\n", entry)
+	if err := w.saveTask("note", task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// TaskPatch lists task fields to change; nil fields are left as they are.
+type TaskPatch struct {
+	Title *string
+	Due   *string
+	// DueTime sets the time of day of the due date; "" makes it all day.
+	DueTime    *string
+	Start      *string
+	Priority   *string
+	Repeat     *string
+	Tags       *[]string
+	AddTags    []string
+	RemoveTags []string
+}
+
+// EditTask applies patch to the task with the given ID prefix. A title change
+// also renames the file (same column) so the slug stays in sync.
+func (w *Workspace) EditTask(prefix string, patch TaskPatch) (*Task, error) {
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	oldPath := task.Path
+	if patch.Title != nil {
+		title := strings.TrimSpace(*patch.Title)
+		if title == "" {
+			return nil, fmt.Errorf("%w: title is required", ErrInvalid)
+		}
+		task.Title = title
+	}
+	if patch.Due != nil {
+		task.Due = strings.TrimSpace(*patch.Due)
+	}
+	if patch.DueTime != nil {
+		dueTime, err := NormalizeDueTime(*patch.DueTime)
+		if err != nil {
+			return nil, err
+		}
+		if dueTime != "" && task.Due == "" {
+			return nil, fmt.Errorf("%w: a due time needs a due date", ErrInvalid)
+		}
+		task.DueTime = dueTime
+	}
+	if patch.Start != nil {
+		task.Start = strings.TrimSpace(*patch.Start)
+	}
+	if patch.Priority != nil {
+		task.Priority = normalizePriority(*patch.Priority)
 	}
-	if err := writeTaskFile(task); err != nil {
+	if patch.Repeat != nil {
+		repeat, err := NormalizeRepeat(*patch.Repeat)
+		if err != nil {
+			return nil, err
+		}
+		task.Repeat = repeat
+	}
+	if patch.Tags != nil {
+		task.Tags = dedupeStrings(*patch.Tags)
+	}
+	if len(patch.AddTags) > 0 {
+		task.Tags = dedupeStrings(append(append([]string{}, task.Tags...), patch.AddTags...))
+	}
+	if len(patch.RemoveTags) > 0 {
+		kept := make([]string, 0, len(task.Tags))
+		for _, t := range task.Tags {
+			if !containsString(patch.RemoveTags, t) {
+				kept = append(kept, t)
+			}
+		}
+		task.Tags = kept
+	}
+	now := timeNow()
+	task.UpdatedAt = &now
+
+	newPath := oldPath
+	if patch.Title != nil {
+		newPath = filepath.Join(filepath.Dir(oldPath), fmt.Sprintf("%s__%s.md", task.ID, slugify(task.Title)))
+	}
+	content, err := renderTaskFile(task)
+	if err != nil {
+		return nil, err
+	}
+	changes := []fileChange{{Path: newPath, After: &content}}
+	if newPath != oldPath {
+		changes = append(changes, fileChange{Path: oldPath})
+	}
+	if err := w.commitChanges("edit", changes); err != nil {
 		return nil, err
 	}
+	task.Path = newPath
 	return task, nil
 }
 
 func (w *Workspace) ListTasks(f ListFilter) ([]Task, error) {
+	f.Column = strings.ToLower(strings.TrimSpace(f.Column))
 	var projects []string
 	if strings.TrimSpace(f.Project) != "" {
-		projects = []string{slugifyOrDefault(f.Project, f.Project)}
+		var err error
+		if projects, err = w.ExpandProjectSpec(f.Project); err != nil {
+			return nil, err
+		}
 	} else {
-		ps, err := w.ListProjects()
+		ps, err := w.ActiveProjects()
 		if err != nil {
 			return nil, err
 		}
@@ -806,11 +1337,37 @@ func (w *Workspace) ListTasks(f ListFilter) ([]Task, error) {
 			projects = append(projects, p.Slug)
 		}
 	}
+	if err := f.Due.Validate(); err != nil {
+		return nil, err
+	}
+	f.Tags = f.Tags.normalize()
+	today := timeNow().Format("2006-01-02")
+	keep := func(t *Task) bool {
+		if f.Status != "" && t.Status != f.Status {
+			return false
+		}
+		if !f.Tags.matches(t.Tags) {
+			return false
+		}
+		if !f.Due.matches(*t, today, w.cfg.IsOpenStatus(t.Status)) {
+			return false
+		}
+		if !f.Query.Match(*t) {
+			return false
+		}
+		if f.Search != "" {
+			q := strings.ToLower(f.Search)
+			if !strings.Contains(strings.ToLower(t.Title), q) && !strings.Contains(strings.ToLower(t.descriptionText()), q) {
+				return false
+			}
+		}
+		return true
+	}
 	var out []Task
 	for _, prj := range projects {
-		cols := w.cfg.Columns
+		cols := w.Columns(prj)
 		for _, c := range cols {
-			if !f.All && c.ID == "archive" {
+			if !f.All && c.Status == "archived" && f.Column != c.ID {
 				continue
 			}
 			if f.Column != "" && c.ID != f.Column {
@@ -827,7 +1384,7 @@ func (w *Workspace) ListTasks(f ListFilter) ([]Task, error) {
 				if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
 					return nil
 				}
-				t, err := readTaskFile(path)
+				t, err := w.readTaskIndexed(path, d)
 				if err != nil {
 					return nil
 				}
@@ -836,27 +1393,40 @@ func (w *Workspace) ListTasks(f ListFilter) ([]Task, error) {
 				t.Column = c.ID
 				t.Status = c.Status
 
-				if f.Status != "" && t.Status != f.Status {
-					return nil
-				}
-				if f.Tag != "" && !containsString(t.Tags, f.Tag) {
-					return nil
+				if keep(t) {
+					out = append(out, *t)
 				}
-				if f.Search != "" {
-					q := strings.ToLower(f.Search)
-					if !strings.Contains(strings.ToLower(t.Title), q) && !strings.Contains(strings.ToLower(t.descriptionText()), q) {
-						return nil
-					}
-				}
-				out = append(out, *t)
 				return nil
 			})
 		}
 	}
-	// simple sort: due then updated
+	_ = w.saveIndex()
+	if f.ArchiveBundles {
+		bundled, err := w.BundledTasks()
+		if err != nil {
+			return nil, err
+		}
+		inSpec := map[string]bool{}
+		for _, prj := range projects {
+			inSpec[prj] = true
+		}
+		for i := range bundled {
+			t := &bundled[i]
+			if strings.TrimSpace(f.Project) != "" && !inSpec[t.Project] {
+				continue
+			}
+			if f.Column != "" && t.Column != f.Column {
+				continue
+			}
+			if keep(t) {
+				out = append(out, *t)
+			}
+		}
+	}
+	// simple sort: due (and due time) then updated
 	sort.Slice(out, func(i, j int) bool {
-		di := out[i].Due
-		dj := out[j].Due
+		di := out[i].dueSortKey()
+		dj := out[j].dueSortKey()
 		if di != "" && dj != "" && di != dj {
 			return di < dj
 		}
@@ -865,19 +1435,26 @@ func (w *Workspace) ListTasks(f ListFilter) ([]Task, error) {
 		}
 		return out[i].ID < out[j].ID
 	})
+	f.Sort.Apply(out)
 	return out, nil
 }
 
-func (w *Workspace) RenderBoard(project string, ascii bool, format string, openOnly bool) (string, error) {
+// RenderBoard renders a project board. perColumn caps the tasks listed per
+// column in the telegram format (0 means no cap).
+// RenderBoard draws a project board. hideScheduled leaves out tasks whose
+// start date is still ahead.
+func (w *Workspace) RenderBoard(project string, ascii bool, format string, openOnly bool, perColumn int, hideScheduled bool) (string, error) {
 	if isTelegramFormat(format) {
-		return w.renderTelegramBoard(project, openOnly)
+		return w.renderTelegramBoard(project, openOnly, perColumn, hideScheduled)
 	}
+	today := timeNow().UTC().Format("2006-01-02")
 	projectSlug := slugifyOrDefault(project, project)
 	// Collect tasks per column.
-	type card struct{ Title, Pri string }
+	type card struct{ Title, Pri, Progress, Aging string }
 	colCards := map[string][]card{}
-	for _, c := range w.cfg.Columns {
-		if openOnly && !isOpenStatus(c.Status) {
+	columns := w.Columns(projectSlug)
+	for _, c := range columns {
+		if openOnly && !w.cfg.IsOpenStatus(c.Status) {
 			continue
 		}
 		dir := filepath.Join(w.projectColumnsDir(projectSlug), c.Dir)
@@ -886,22 +1463,24 @@ func (w *Workspace) RenderBoard(project string, ascii bool, format string, openO
 			if e.IsDir() || !strings.HasSuffix(strings.ToLower(e.Name()), ".md") {
 				continue
 			}
-			t, err := readTaskFile(filepath.Join(dir, e.Name()))
-			if err != nil {
+			t, err := w.readTaskIndexed(filepath.Join(dir, e.Name()), e)
+			if err != nil || (hideScheduled && t.NotStarted(today)) {
 				continue
 			}
 			title := taskTitle(t.Title)
-			title = truncate(title, 80, ascii)
-			colCards[c.ID] = append(colCards[c.ID], card{Title: title, Pri: t.PriorityAbbrev()})
+			title = truncate(title, w.cfg.HumanTitleWidth(), ascii)
+			colCards[c.ID] = append(colCards[c.ID], card{Title: title, Pri: t.PriorityAbbrev(), Progress: t.ChecklistProgress(), Aging: w.AgingSuffix(*t, ascii)})
 		}
 	}
 
+	_ = w.saveIndex()
+
 	// Simple board rendering (no box drawing; keep it lean).
 	var b strings.Builder
 	b.WriteString(projectSlug + "\n\n")
 	wroteAny := false
-	for _, c := range w.cfg.Columns {
-		if openOnly && !isOpenStatus(c.Status) {
+	for _, c := range columns {
+		if openOnly && !w.cfg.IsOpenStatus(c.Status) {
 			continue
 		}
 		cards := colCards[c.ID]
@@ -914,7 +1493,11 @@ func (w *Workspace) RenderBoard(project string, ascii bool, format string, openO
 		b.WriteString(c.Name + "\n")
 		for _, cd := range cards {
 			pri := priorityLabel(cd.Pri)
-			b.WriteString(fmt.Sprintf("  - %s%s\n", pri, cd.Title))
+			progress := ""
+			if cd.Progress != "" {
+				progress = " [" + cd.Progress + "]"
+			}
+			b.WriteString(fmt.Sprintf("  - %s%s%s%s\n", pri, cd.Title, progress, cd.Aging))
 		}
 		wroteAny = true
 	}
@@ -925,79 +1508,51 @@ func (w *Workspace) RenderBoard(project string, ascii bool, format string, openO
 }
 
 func (w *Workspace) RenderToday(project string, openOnly bool, groupBy string, showTotals bool, format string) (string, error) {
-	filter := ListFilter{Project: project, All: false}
-	tasks, err := w.ListTasks(filter)
+	today, dueToday, dueSoon, overdue, err := w.collectToday(project, openOnly)
 	if err != nil {
 		return "", err
 	}
-	today := timeNow().Format("2006-01-02")
-	var dueToday []Task
-	var overdue []Task
-	for _, t := range tasks {
-		if openOnly && !isOpenStatus(t.Status) {
-			continue
-		}
-		dueDate, ok := parseDueDate(t.Due)
-		if !ok {
-			continue
-		}
-		d := dueDate.In(time.UTC).Format("2006-01-02")
-		if d == today {
-			dueToday = append(dueToday, t)
-		} else if d < today {
-			overdue = append(overdue, t)
-		}
-	}
 	if isTelegramFormat(format) {
-		return w.renderTelegramToday(project, today, dueToday, overdue, groupBy, showTotals), nil
+		return w.renderTelegramToday(project, today, dueToday, dueSoon, overdue, groupBy, showTotals), nil
 	}
-	if len(dueToday) == 0 && len(overdue) == 0 {
+	if len(dueToday)+len(dueSoon)+len(overdue) == 0 {
 		return fmt.Sprintf("Today (%s) - nothing due, nothing overdue", today), nil
 	}
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("Today (%s) - due %d, overdue %d\n\n", today, len(dueToday), len(overdue)))
-	writeTaskSection(&b, "Due today", dueToday, groupBy, showTotals, false)
-	writeTaskSection(&b, "Overdue", overdue, groupBy, showTotals, true)
+	b.WriteString(fmt.Sprintf("Today (%s) - %s\n\n", today, todayCounts(dueToday, dueSoon, overdue)))
+	w.writeTaskSection(&b, "Due today", dueToday, groupBy, showTotals, false)
+	w.writeTaskSection(&b, "Due soon", dueSoon, groupBy, showTotals, true)
+	w.writeTaskSection(&b, "Overdue", overdue, groupBy, showTotals, true)
 	return b.String(), nil
 }
 
-func (w *Workspace) RenderAgenda(project string, days int, openOnly bool, groupBy string, showTotals bool, format string) (string, error) {
-	filter := ListFilter{Project: project, All: false}
-	tasks, err := w.ListTasks(filter)
-	if err != nil {
-		return "", err
+// todayCounts is "due 2, overdue 1", naming due soon only when there is any.
+func todayCounts(dueToday, dueSoon, overdue []Task) string {
+	if len(dueSoon) == 0 {
+		return fmt.Sprintf("due %d, overdue %d", len(dueToday), len(overdue))
 	}
+	return fmt.Sprintf("due %d, due soon %d, overdue %d", len(dueToday), len(dueSoon), len(overdue))
+}
+
+func (w *Workspace) RenderAgenda(project string, days int, openOnly bool, groupBy string, showTotals bool, format string) (string, error) {
 	if days <= 0 {
 		days = 7
 	}
-	start := timeNow().UTC()
-	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
-	end := start.AddDate(0, 0, days-1)
-
-	var overdue []Task
-	byDate := map[string][]Task{}
-	for _, t := range tasks {
-		if openOnly && !isOpenStatus(t.Status) {
-			continue
-		}
-		dueDate, ok := parseDueDate(t.Due)
-		if !ok {
-			continue
-		}
-		d := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
-		if d.Before(start) {
-			overdue = append(overdue, t)
-			continue
-		}
-		if d.After(end) {
-			continue
-		}
-		key := d.Format("2006-01-02")
-		byDate[key] = append(byDate[key], t)
+	outer, inner, err := SplitAgendaGroup(groupBy)
+	if err != nil {
+		return "", err
+	}
+	start, end, overdue, byDate, err := w.collectAgenda(project, days, openOnly)
+	if err != nil {
+		return "", err
 	}
 	if isTelegramFormat(format) {
-		return w.renderTelegramAgenda(days, start, end, overdue, byDate, groupBy, showTotals), nil
+		if outer != "" {
+			return w.renderTelegramAgendaBuckets(days, start, end, overdue, byDate, outer, showTotals), nil
+		}
+		return w.renderTelegramAgenda(days, start, end, overdue, byDate, inner, showTotals), nil
 	}
+	groupBy = inner
 
 	var b strings.Builder
 	rangeLabel := fmt.Sprintf("%s -> %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
@@ -1006,18 +1561,55 @@ func (w *Workspace) RenderAgenda(project string, days int, openOnly bool, groupB
 	}
 	b.WriteString(fmt.Sprintf("Week (%d days) - %s - due %d, overdue %d\n\n", days, rangeLabel, lenByDate(byDate), len(overdue)))
 
-	writeTaskSection(&b, "Overdue", overdue, groupBy, showTotals, true)
+	if outer != "" {
+		w.writeAgendaBuckets(&b, days, start, bucketAgenda(overdue, byDate, outer), outer, showTotals)
+		return b.String(), nil
+	}
+
+	w.writeTaskSection(&b, "Overdue", overdue, groupBy, showTotals, true)
 
 	for i := 0; i < days; i++ {
 		d := start.AddDate(0, 0, i)
-		key := d.Format("2006-01-02")
-		label := fmt.Sprintf("%s (%s)", key, d.Weekday().String()[:3])
-		items := byDate[key]
-		writeTaskSection(&b, label, items, groupBy, showTotals, false)
+		items := byDate[d.Format("2006-01-02")]
+		w.writeTaskSection(&b, w.cfg.DayLabel(d), items, groupBy, showTotals, false)
 	}
 	return b.String(), nil
 }
 
+// writeAgendaBuckets renders a two-level agenda: a header per project or
+// column, then its overdue and per-day tasks.
+func (w *Workspace) writeAgendaBuckets(b *strings.Builder, days int, start time.Time, buckets []agendaBucket, outer string, showTotals bool) {
+	for _, bucket := range buckets {
+		header := fmt.Sprintf("%s: %s", strings.Title(outer), bucket.key)
+		if showTotals {
+			header = fmt.Sprintf("%s (%d)", header, bucket.count())
+		}
+		b.WriteString(header + "\n")
+		if len(bucket.overdue) > 0 {
+			b.WriteString("  Overdue\n")
+			shown, more := w.ViewPage.bucketList(bucket.overdue)
+			for _, t := range shown {
+				b.WriteString(formatTaskLine(t, outer, true))
+			}
+			w.writeMoreLine(b, "    ", more)
+		}
+		for i := 0; i < days; i++ {
+			d := start.AddDate(0, 0, i)
+			items := bucket.byDate[d.Format("2006-01-02")]
+			if len(items) == 0 {
+				continue
+			}
+			b.WriteString("  " + w.cfg.DayLabel(d) + "\n")
+			shown, more := w.ViewPage.bucketList(items)
+			for _, t := range shown {
+				b.WriteString(formatTaskLine(t, outer, false))
+			}
+			w.writeMoreLine(b, "    ", more)
+		}
+		b.WriteString("\n")
+	}
+}
+
 func lenByDate(byDate map[string][]Task) int {
 	n := 0
 	for _, list := range byDate {
@@ -1026,25 +1618,18 @@ func lenByDate(byDate map[string][]Task) int {
 	return n
 }
 
-func isOpenStatus(status string) bool {
-	switch status {
-	case "open", "doing", "blocked":
-		return true
-	default:
-		return false
-	}
-}
-
-func writeTaskSection(b *strings.Builder, title string, tasks []Task, groupBy string, showTotals bool, includeDue bool) {
+func (w *Workspace) writeTaskSection(b *strings.Builder, title string, tasks []Task, groupBy string, showTotals bool, includeDue bool) {
 	if len(tasks) == 0 {
 		return
 	}
 	b.WriteString(title + "\n")
+	tasks, more := w.ViewPage.section(tasks)
 	groupBy = normalizeGroupBy(groupBy)
 	if groupBy == "" {
 		for _, t := range tasks {
 			b.WriteString(formatTaskLine(t, "", includeDue))
 		}
+		w.writeMoreLine(b, "  ", more)
 		b.WriteString("\n")
 		return
 	}
@@ -1055,11 +1640,21 @@ func writeTaskSection(b *strings.Builder, title string, tasks []Task, groupBy st
 			header = fmt.Sprintf("%s (%d)", header, len(grouped[key]))
 		}
 		b.WriteString("  " + header + "\n")
-		for _, t := range grouped[key] {
+		shown, hidden := w.ViewPage.group(grouped[key])
+		for _, t := range shown {
 			b.WriteString(formatTaskLine(t, groupBy, includeDue))
 		}
+		w.writeMoreLine(b, "    ", hidden)
 		b.WriteString("\n")
 	}
+	w.writeMoreLine(b, "  ", more)
+}
+
+// writeMoreLine notes n tasks left out by the view page, if any.
+func (w *Workspace) writeMoreLine(b *strings.Builder, indent string, n int) {
+	if n > 0 {
+		b.WriteString(indent + MoreLine(n, w.ASCII) + "\n")
+	}
 }
 
 func normalizeGroupBy(groupBy string) string {
@@ -1095,8 +1690,11 @@ func groupTasks(tasks []Task, groupBy string) ([]string, map[string][]Task) {
 }
 
 func formatTaskLine(t Task, groupBy string, includeDue bool) string {
-	due := formatDueSuffix(t.Due, includeDue)
+	due := formatDueSuffix(t, includeDue)
 	title := taskTitle(t.Title)
+	if progress := t.ChecklistProgress(); progress != "" {
+		title += " [" + progress + "]"
+	}
 	pri := priorityLabel(t.PriorityAbbrev())
 	indent := "  "
 	if groupBy != "" {
@@ -1120,15 +1718,19 @@ func taskTitle(title string) string {
 	return title
 }
 
-func formatDueSuffix(due string, includeDue bool) string {
-	if !includeDue {
+// formatDueSuffix is " (due 2026-01-20 15:00)", or just " (15:00)" in
+// sections where the day is already known.
+func formatDueSuffix(t Task, includeDue bool) string {
+	if strings.TrimSpace(t.Due) == "" {
 		return ""
 	}
-	due = strings.TrimSpace(due)
-	if due == "" {
+	if !includeDue {
+		if clock := t.DueClock(); clock != "" {
+			return fmt.Sprintf(" (%s)", clock)
+		}
 		return ""
 	}
-	return fmt.Sprintf(" (due %s)", due)
+	return fmt.Sprintf(" (due %s)", t.DueLabel())
 }
 
 func priorityLabel(abbrev string) string {
@@ -1169,21 +1771,10 @@ func (t *Task) IDShort(n int) string {
 	return s[:n]
 }
 
+// StatusAbbrev is the one-letter status code for the built-in statuses;
+// Config.StatusAbbrev also knows configured ones.
 func (t *Task) StatusAbbrev() string {
-	switch t.Status {
-	case "open":
-		return "o"
-	case "doing":
-		return "d"
-	case "blocked":
-		return "b"
-	case "done":
-		return "✓"
-	case "archived":
-		return "a"
-	default:
-		return "?"
-	}
+	return Config{}.StatusAbbrev(t.Status)
 }
 
 func (t *Task) PriorityAbbrev() string {
@@ -1209,7 +1800,26 @@ func (t *Task) RenderHuman() string {
 	b.WriteString(fmt.Sprintf("Status: %s\n", t.Status))
 	b.WriteString(fmt.Sprintf("Priority: %s\n", t.Priority))
 	if t.Due != "" {
-		b.WriteString(fmt.Sprintf("Due: %s\n", t.Due))
+		b.WriteString(fmt.Sprintf("Due: %s\n", t.DueLabel()))
+	}
+	if t.Start != "" {
+		b.WriteString(fmt.Sprintf("Start: %s\n", t.Start))
+	}
+	if t.Repeat != "" {
+		b.WriteString(fmt.Sprintf("Repeat: %s\n", t.Repeat))
+	}
+	if len(t.BlockedBy) > 0 {
+		b.WriteString(fmt.Sprintf("Blocked by: %s\n", strings.Join(t.BlockedBy, ", ")))
+	}
+	if len(t.Links) > 0 {
+		links := make([]string, 0, len(t.Links))
+		for _, l := range t.Links {
+			links = append(links, l.Type+" "+l.ID)
+		}
+		b.WriteString(fmt.Sprintf("Links: %s\n", strings.Join(links, ", ")))
+	}
+	if len(t.Attachments) > 0 {
+		b.WriteString(fmt.Sprintf("Attachments: %s\n", strings.Join(t.Attachments, ", ")))
 	}
 	if len(t.Tags) > 0 {
 		b.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(t.Tags, ", ")))
@@ -1222,11 +1832,21 @@ func (t *Task) RenderHuman() string {
 	return b.String()
 }
 
-func writeTaskFile(t *Task) error {
-	yamlBytes, err := yaml.Marshal(&t.TaskMeta)
+// saveTask writes t to t.Path through the journal.
+func (w *Workspace) saveTask(op string, t *Task) error {
+	content, err := renderTaskFile(t)
 	if err != nil {
 		return err
 	}
+	return w.commitChanges(op, []fileChange{{Path: t.Path, After: &content}})
+}
+
+func renderTaskFile(t *Task) (string, error) {
+	t.normalizeDue()
+	yamlBytes, err := yaml.Marshal(&t.TaskMeta)
+	if err != nil {
+		return "", err
+	}
 	var buf bytes.Buffer
 	buf.WriteString("---\n")
 	buf.Write(yamlBytes)
@@ -1237,7 +1857,7 @@ func writeTaskFile(t *Task) error {
 			buf.WriteString("\n")
 		}
 	}
-	return atomicWriteFile(t.Path, buf.Bytes(), 0o644)
+	return buf.String(), nil
 }
 
 func readTaskFile(path string) (*Task, error) {
@@ -1254,8 +1874,7 @@ func readTaskFile(path string) (*Task, error) {
 }
 
 func parseFrontmatter(b []byte) (*TaskMeta, string, error) {
-	s := strings.ReplaceAll(string(b), "\r\n", "\n")
-	s = strings.ReplaceAll(s, "\r", "\n")
+	s := normalizeText(string(b))
 	if !strings.HasPrefix(s, "---\n") {
 		// No frontmatter; treat as invalid for v0.1.
 		return nil, "", fmt.Errorf("%w: missing frontmatter", ErrInvalid)
@@ -1274,6 +1893,7 @@ func parseFrontmatter(b []byte) (*TaskMeta, string, error) {
 	if meta.Schema == 0 {
 		meta.Schema = 1
 	}
+	meta.normalizeDue()
 	return &meta, body, nil
 }
 
@@ -1284,27 +1904,15 @@ func (w *Workspace) findTasksByPrefix(prefix string) ([]string, error) {
 	}
 	prefixNorm := strings.ToUpper(prefix)
 	var hits []string
-	root := filepath.Join(w.Root, "projects")
-	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
-		if err != nil || d == nil {
-			return nil
-		}
-		if d.IsDir() {
-			return nil
-		}
-		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") {
-			return nil
-		}
-		// Prefer parsing meta for correctness
-		t, err := readTaskFile(path)
-		if err != nil {
-			return nil
-		}
-		if strings.HasPrefix(strings.ToUpper(t.ID), prefixNorm) {
-			hits = append(hits, path)
+	err := w.walkTaskFiles(func(t *Task) {
+		if idHasPrefix(t.ID, "tsk_", prefixNorm) {
+			hits = append(hits, t.Path)
 		}
-		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+	_ = w.saveIndex()
 	sort.Strings(hits)
 	return hits, nil
 }
@@ -1323,16 +1931,6 @@ func (w *Workspace) columnByID(id string) (ColumnDef, bool) {
 	return ColumnDef{}, false
 }
 
-func (w *Workspace) columnIDByDir(dir string) (string, bool) {
-	dir = strings.TrimSpace(dir)
-	for _, c := range w.cfg.Columns {
-		if c.Dir == dir {
-			return c.ID, true
-		}
-	}
-	return "", false
-}
-
 func normalizePriority(p string) string {
 	p = strings.TrimSpace(strings.ToLower(p))
 	switch p {
@@ -1363,6 +1961,11 @@ func newULID() string {
 	return strings.ToUpper(id.String())
 }
 
+// Slugify returns the slug used for project directories and task filenames.
+func Slugify(s string) string {
+	return slugify(s)
+}
+
 func slugifyOrDefault(s, def string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -1464,7 +2067,7 @@ func (w *Workspace) projectAndColumnFromPath(path string) (string, string) {
 		return project, ""
 	}
 	colDir := parts[2]
-	colID, _ := w.columnIDByDir(colDir)
+	colID, _ := w.projectColumnIDByDir(project, colDir)
 	return project, colID
 }
 
@@ -1478,12 +2081,17 @@ func (w *Workspace) reconcileTaskFromPath(t *Task) {
 	}
 	if colID != "" {
 		t.Column = colID
-		if col, ok := w.columnByID(colID); ok {
+		if col, ok := w.projectColumnByID(t.Project, colID); ok {
 			t.Status = col.Status
 		}
 	}
 }
 
+// ExpandHome expands a leading "~/" to the user's home directory.
+func ExpandHome(path string) string {
+	return expandHome(path)
+}
+
 func expandHome(path string) string {
 	if strings.HasPrefix(path, "~"+string(os.PathSeparator)) || path == "~" {
 		home, _ := os.UserHomeDir()
@@ -1499,15 +2107,25 @@ func atomicWriteFile(path string, data []byte, perm fs.FileMode) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
 	}
-	tmp := filepath.Join(dir, fmt.Sprintf(".tmp-%d", timeNow().UnixNano()))
-	if err := os.WriteFile(tmp, data, perm); err != nil {
-		_ = os.Remove(tmp)
+	// A uniquely named temp file, so concurrent writers (or a pinned clock)
+	// never share one.
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
 		return err
 	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), perm)
+	}
 	// Rename is atomic on same filesystem.
-	if err := os.Rename(tmp, path); err != nil {
-		_ = os.Remove(tmp)
-		return err
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
 	}
-	return nil
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
 }
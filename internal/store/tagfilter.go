@@ -0,0 +1,68 @@
+package store
+
+import "strings"
+
+// TagFilter narrows tasks and ideas by tag: an item needs every tag in All,
+// at least one in Any (when Any is set) and none in Not. Tags compare
+// case-insensitively.
+type TagFilter struct {
+	All []string `json:"all,omitempty"`
+	Any []string `json:"any,omitempty"`
+	Not []string `json:"not,omitempty"`
+}
+
+// OneTag is the filter for a single required tag ("" for none).
+func OneTag(tag string) TagFilter {
+	if tag = trimTag(tag); tag == "" {
+		return TagFilter{}
+	}
+	return TagFilter{All: []string{tag}}
+}
+
+// Active reports whether the filter narrows anything.
+func (f TagFilter) Active() bool {
+	return len(f.All) > 0 || len(f.Any) > 0 || len(f.Not) > 0
+}
+
+func (f TagFilter) matches(tags []string) bool {
+	for _, tag := range f.All {
+		if !containsString(tags, tag) {
+			return false
+		}
+	}
+	for _, tag := range f.Not {
+		if containsString(tags, tag) {
+			return false
+		}
+	}
+	if len(f.Any) == 0 {
+		return true
+	}
+	for _, tag := range f.Any {
+		if containsString(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize splits comma lists, trims the tags (and a leading #) and drops
+// empty ones.
+func (f TagFilter) normalize() TagFilter {
+	clean := func(tags []string) []string {
+		var out []string
+		for _, value := range tags {
+			for _, tag := range strings.Split(value, ",") {
+				if tag = trimTag(tag); tag != "" {
+					out = append(out, tag)
+				}
+			}
+		}
+		return out
+	}
+	return TagFilter{All: clean(f.All), Any: clean(f.Any), Not: clean(f.Not)}
+}
+
+func trimTag(tag string) string {
+	return strings.TrimPrefix(strings.TrimSpace(tag), "#")
+}
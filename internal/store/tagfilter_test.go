@@ -0,0 +1,37 @@
+package store
+
+import "testing"
+
+func TestListTasksTagFilter(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	for _, in := range []AddTaskInput{
+		{Title: "Invoice", Project: "Acme", Tags: []string{"client", "urgent"}},
+		{Title: "Call", Project: "Acme", Tags: []string{"client", "waiting"}},
+		{Title: "Brief", Project: "Acme", Tags: []string{"client", "today"}},
+		{Title: "Gym", Project: "Acme", Tags: []string{"today"}},
+	} {
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	titles := func(f TagFilter) map[string]bool {
+		tasks, err := w.ListTasks(ListFilter{Project: "Acme", Tags: f})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := map[string]bool{}
+		for _, task := range tasks {
+			out[task.Title] = true
+		}
+		return out
+	}
+	if got := titles(TagFilter{All: []string{"client", "#today"}}); len(got) != 1 || !got["Brief"] {
+		t.Fatalf("expected AND to keep Brief, got %v", got)
+	}
+	if got := titles(TagFilter{Any: []string{"urgent,today"}}); len(got) != 3 || got["Call"] {
+		t.Fatalf("expected OR to keep Invoice, Brief and Gym, got %v", got)
+	}
+	if got := titles(TagFilter{All: []string{"client"}, Not: []string{"Waiting"}}); len(got) != 2 || got["Call"] {
+		t.Fatalf("expected NOT to drop Call, got %v", got)
+	}
+}
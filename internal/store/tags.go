@@ -0,0 +1,281 @@
+package store
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TagCount is one tag with how many tasks (archived included) and ideas
+// carry it.
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Tasks int    `json:"tasks"`
+	Ideas int    `json:"ideas"`
+}
+
+// TagChange is what RenameTag or RemoveTag rewrote, or would rewrite on a
+// dry run.
+type TagChange struct {
+	Tag   string `json:"tag"`
+	To    string `json:"to,omitempty"`
+	Tasks []Task `json:"tasks"`
+	Ideas []Idea `json:"ideas"`
+}
+
+// TagCounts counts the tags of every task and idea, or of one project's,
+// most used first. Tags differing only in case count as one, under their
+// most used spelling.
+func (w *Workspace) TagCounts(project string) ([]TagCount, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: true})
+	if err != nil {
+		return nil, err
+	}
+	ideaFilter := IdeaListFilter{Scope: IdeaScopeAll}
+	if strings.TrimSpace(project) != "" {
+		ideaFilter = IdeaListFilter{Scope: IdeaScopeProject, Project: project}
+	}
+	ideas, err := w.ListIdeas(ideaFilter)
+	if err != nil {
+		return nil, err
+	}
+	byKey := map[string]*TagCount{}
+	spellings := map[string]int{}
+	var out []*TagCount
+	count := func(tags []string, idea bool) {
+		for _, tag := range dedupeStrings(tags) {
+			key := strings.ToLower(tag)
+			c := byKey[key]
+			if c == nil {
+				c = &TagCount{Tag: tag}
+				byKey[key] = c
+				out = append(out, c)
+			}
+			spellings[tag]++
+			if n, best := spellings[tag], spellings[c.Tag]; n > best || (n == best && tag == key) {
+				c.Tag = tag
+			}
+			if idea {
+				c.Ideas++
+			} else {
+				c.Tasks++
+			}
+		}
+	}
+	for _, t := range tasks {
+		count(t.Tags, false)
+	}
+	for _, idea := range ideas {
+		count(idea.Tags, true)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].Tasks+out[i].Ideas, out[j].Tasks+out[j].Ideas
+		if a != b {
+			return a > b
+		}
+		return strings.ToLower(out[i].Tag) < strings.ToLower(out[j].Tag)
+	})
+	counts := make([]TagCount, len(out))
+	for i, c := range out {
+		counts[i] = *c
+	}
+	return counts, nil
+}
+
+func cleanTagArg(tag string) (string, error) {
+	tag = cleanIdeaTag(tag)
+	if tag == "" || strings.ContainsAny(tag, " \t,") {
+		return "", fmt.Errorf("%w: invalid tag %q", ErrInvalid, tag)
+	}
+	return tag, nil
+}
+
+// RenameTag renames a tag on every task (in any project, archived or not)
+// and idea, as one journal entry. Inline #tags in idea titles and bodies are
+// rewritten too, so the old tag does not come back on the next read.
+func (w *Workspace) RenameTag(from, to string, dryRun bool) (*TagChange, error) {
+	from, err := cleanTagArg(from)
+	if err != nil {
+		return nil, err
+	}
+	if to, err = cleanTagArg(to); err != nil {
+		return nil, err
+	}
+	rename := func(tags []string) []string {
+		out := make([]string, 0, len(tags))
+		for _, t := range tags {
+			if strings.EqualFold(t, from) {
+				t = to
+			}
+			out = append(out, t)
+		}
+		return dedupeStrings(out)
+	}
+	c := &TagChange{Tag: from, To: to}
+	return c, w.rewriteTags("tag rename", c, false, rename, to, dryRun)
+}
+
+// RemoveTag drops a tag from every task and idea that carries it, or with
+// doneOnly from closed tasks alone (ideas are left as they are), as one
+// journal entry.
+func (w *Workspace) RemoveTag(tag string, doneOnly bool, dryRun bool) (*TagChange, error) {
+	tag, err := cleanTagArg(tag)
+	if err != nil {
+		return nil, err
+	}
+	remove := func(tags []string) []string {
+		out := make([]string, 0, len(tags))
+		for _, t := range tags {
+			if !strings.EqualFold(t, tag) {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+	c := &TagChange{Tag: tag}
+	return c, w.rewriteTags("tag rm", c, doneOnly, remove, "", dryRun)
+}
+
+// rewriteTags applies edit to the tags of the tasks and ideas carrying
+// c.Tag, replacing inline idea tags with inline (or dropping them when it is
+// empty), and records what changed in c.
+func (w *Workspace) rewriteTags(op string, c *TagChange, doneOnly bool, edit func([]string) []string, inline string, dryRun bool) error {
+	c.Tasks, c.Ideas = []Task{}, []Idea{}
+	var changes []fileChange
+	root := filepath.Join(w.Root, "projects")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d == nil {
+			return nil
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") || !isTaskPath(root, path) {
+			return nil
+		}
+		t, err := readTaskFile(path)
+		if err != nil || !containsString(t.Tags, c.Tag) {
+			return nil
+		}
+		if doneOnly {
+			located := *t
+			w.reconcileTaskFromPath(&located)
+			if w.cfg.IsOpenStatus(located.Status) {
+				return nil
+			}
+		}
+		t.Tags = edit(t.Tags)
+		content, err := renderTaskFile(t)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, fileChange{Path: path, After: &content})
+		w.reconcileTaskFromPath(t)
+		c.Tasks = append(c.Tasks, *t)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !doneOnly {
+		paths, err := w.allIdeaPaths()
+		if err != nil {
+			return err
+		}
+		for _, p := range paths {
+			ideas, err := w.readIdeaPath(p)
+			if err != nil {
+				continue
+			}
+			var journal *string
+			for _, idea := range ideas {
+				if !containsString(idea.Tags, c.Tag) {
+					continue
+				}
+				title := replaceInlineTag(idea.Title, c.Tag, inline)
+				body := replaceInlineTag(idea.Body, c.Tag, inline)
+				tags := inferIdeaTags(title, body, edit(idea.Tags))
+				if idea.Journal {
+					if journal == nil {
+						b, err := os.ReadFile(p.Path)
+						if err != nil {
+							return err
+						}
+						content := string(b)
+						journal = &content
+					}
+					content, err := replaceJournalEntry(*journal, idea.ID, title, tags, body, false)
+					if err != nil {
+						return err
+					}
+					journal = &content
+				} else {
+					content := formatIdeaContent(title, tags, body)
+					changes = append(changes, fileChange{Path: p.Path, After: &content})
+				}
+				idea.Title, idea.Tags, idea.Body = title, tags, body
+				c.Ideas = append(c.Ideas, idea)
+			}
+			if journal != nil {
+				changes = append(changes, fileChange{Path: p.Path, After: journal})
+			}
+		}
+	}
+	if dryRun || len(changes) == 0 {
+		return nil
+	}
+	return w.commitChanges(op, changes)
+}
+
+// replaceInlineTag renames the inline #tag (or @tag) tokens for tag in text
+// to with, keeping the sigil, or drops them when with is empty. Headings and fenced code are
+// left alone, as extractIdeaInlineTags skips them.
+func replaceInlineTag(text string, tag string, with string) string {
+	lines := strings.Split(text, "\n")
+	fence := ""
+	for n, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			if fence == "" {
+				fence = trimmed[:3]
+			} else if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" || isIdeaHeadingLine(line) {
+			continue
+		}
+		var b strings.Builder
+		for i := 0; i < len(line); i++ {
+			ch := line[i]
+			j := i + 1
+			for j < len(line) && isIdeaTagChar(line[j]) {
+				j++
+			}
+			if (ch == '#' || ch == '@') && (i == 0 || !isIdeaTagChar(line[i-1])) && strings.EqualFold(line[i+1:j], tag) {
+				if with != "" {
+					b.WriteByte(ch)
+					b.WriteString(with)
+				} else if i > 0 && line[i-1] == ' ' && (j == len(line) || line[j] == ' ') {
+					// Drop the space before a removed tag too.
+					s := strings.TrimSuffix(b.String(), " ")
+					b.Reset()
+					b.WriteString(s)
+				}
+				i = j - 1
+				continue
+			}
+			b.WriteByte(ch)
+		}
+		lines[n] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRenameAndRemoveTag(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	open, err := w.AddTask(AddTaskInput{Title: "Open", Project: "Work", Tags: []string{"urgent", "home"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Shipped", Project: "Work", Column: "done", Tags: []string{"Urgent"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Later", Body: "see #urgent"}); err != nil {
+		t.Fatal(err)
+	}
+
+	counts, err := w.TagCounts("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(counts) != 2 || counts[0].Tag != "urgent" || counts[0].Tasks != 2 || counts[0].Ideas != 1 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+
+	c, err := w.RenameTag("#urgent", "asap", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Tasks) != 2 || len(c.Ideas) != 1 || c.Ideas[0].Body != "see #asap" {
+		t.Fatalf("unexpected rename: %+v", c)
+	}
+	ideas, err := w.ListIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil || len(ideas) != 1 || containsString(ideas[0].Tags, "urgent") || !containsString(ideas[0].Tags, "asap") {
+		t.Fatalf("idea not retagged: %+v, %v", ideas, err)
+	}
+
+	c, err = w.RemoveTag("asap", true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Tasks) != 1 || c.Tasks[0].Title != "Shipped" || len(c.Ideas) != 0 {
+		t.Fatalf("expected only the done task untagged: %+v", c)
+	}
+	got, err := w.GetTaskByPrefix(open.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !containsString(got.Tags, "asap") || !containsString(got.Tags, "home") {
+		t.Fatalf("open task should keep its tags: %v", got.Tags)
+	}
+	if _, err := w.RenameTag("asap", "two words", false); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected invalid tag, got %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortFields are the keys --sort accepts, in the order help lists them.
+var SortFields = []string{"due", "priority", "created", "updated", "title"}
+
+// TaskSort orders tasks by one or more keys, each in its natural direction:
+// due soonest first (undated last), priority most urgent first, created and
+// updated newest first, title A-Z. Reverse flips the whole order. The zero
+// value leaves tasks as they are.
+type TaskSort struct {
+	Keys    []string
+	Reverse bool
+}
+
+// ParseSort reads a comma-separated list of SortFields ("priority,due").
+func ParseSort(spec string, reverse bool) (TaskSort, error) {
+	s := TaskSort{Reverse: reverse}
+	for _, part := range strings.Split(spec, ",") {
+		key := strings.ToLower(strings.TrimSpace(part))
+		if key == "" {
+			continue
+		}
+		known := false
+		for _, f := range SortFields {
+			known = known || f == key
+		}
+		if !known {
+			return TaskSort{}, fmt.Errorf("%w: unknown sort key %q (use %s)", ErrInvalid, key, strings.Join(SortFields, "|"))
+		}
+		s.Keys = append(s.Keys, key)
+	}
+	if len(s.Keys) == 0 && reverse {
+		return TaskSort{}, fmt.Errorf("%w: --reverse needs --sort", ErrInvalid)
+	}
+	return s, nil
+}
+
+// Active reports whether s orders anything.
+func (s TaskSort) Active() bool {
+	return len(s.Keys) > 0
+}
+
+// Apply sorts tasks in place; ties keep their order, then fall back to ID.
+func (s TaskSort) Apply(tasks []Task) {
+	if !s.Active() {
+		return
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		c := s.compare(tasks[i], tasks[j])
+		if c == 0 {
+			c = strings.Compare(tasks[i].ID, tasks[j].ID)
+		}
+		if s.Reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func (s TaskSort) compare(a, b Task) int {
+	for _, key := range s.Keys {
+		var c int
+		switch key {
+		case "due":
+			da, db := a.dueSortKey(), b.dueSortKey()
+			switch {
+			case da == db:
+			case da == "":
+				c = 1
+			case db == "":
+				c = -1
+			default:
+				c = strings.Compare(da, db)
+			}
+		case "priority":
+			c = priorityRank[normalizePriority(b.Priority)] - priorityRank[normalizePriority(a.Priority)]
+		case "created":
+			c = compareNewest(a.CreatedAt, b.CreatedAt)
+		case "updated":
+			c = compareNewest(a.UpdatedAt, b.UpdatedAt)
+		case "title":
+			c = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareNewest puts the later time first and missing times last.
+func compareNewest(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case a.After(*b):
+		return -1
+	case b.After(*a):
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListTasksSort(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	day := time.Date(2026, 1, 20, 9, 0, 0, 0, time.UTC)
+	defer func() { timeNow = orig }()
+
+	for i, in := range []AddTaskInput{
+		{Title: "Bravo", Project: "Work", Priority: "high", Due: "2026-01-25"},
+		{Title: "alpha", Project: "Work", Priority: "urgent"},
+		{Title: "Charlie", Project: "Work", Priority: "high", Due: "2026-01-22"},
+		{Title: "Delta", Project: "Work", Priority: "low", Due: "2026-01-21"},
+	} {
+		at := day.Add(time.Duration(i) * time.Minute)
+		timeNow = func() time.Time { return at }
+		if _, err := w.AddTask(in); err != nil {
+			t.Fatal(err)
+		}
+	}
+	titles := func(spec string, reverse bool) string {
+		s, err := ParseSort(spec, reverse)
+		if err != nil {
+			t.Fatalf("%s: %v", spec, err)
+		}
+		tasks, err := w.ListTasks(ListFilter{Sort: s})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []string
+		for _, task := range tasks {
+			out = append(out, task.Title)
+		}
+		return strings.Join(out, ",")
+	}
+	for _, c := range []struct {
+		spec    string
+		reverse bool
+		want    string
+	}{
+		{"priority,due", false, "alpha,Charlie,Bravo,Delta"},
+		{"title", false, "alpha,Bravo,Charlie,Delta"},
+		{"created", false, "Delta,Charlie,alpha,Bravo"},
+		{"created", true, "Bravo,alpha,Charlie,Delta"},
+		{"due", true, "alpha,Bravo,Charlie,Delta"},
+	} {
+		if got := titles(c.spec, c.reverse); got != c.want {
+			t.Fatalf("--sort %q reverse=%v: got %s, want %s", c.spec, c.reverse, got, c.want)
+		}
+	}
+	for _, bad := range []string{"size", "due,color"} {
+		if _, err := ParseSort(bad, false); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("%q: expected ErrInvalid, got %v", bad, err)
+		}
+	}
+	if _, err := ParseSort("", true); !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected --reverse alone to be rejected, got %v", err)
+	}
+}
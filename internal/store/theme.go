@@ -0,0 +1,116 @@
+package store
+
+import (
+	"sort"
+	"strings"
+)
+
+// IconsNone is the theme.icons value that drops every icon from telegram and
+// human output.
+const IconsNone = "none"
+
+// ThemeConfig overrides the icons of columns, priorities and section headers.
+// A key mapped to "" removes that one icon.
+type ThemeConfig struct {
+	// Icons is "none" to render without any icons, empty for the defaults.
+	Icons      string            `json:"icons,omitempty"`
+	Columns    map[string]string `json:"columns,omitempty"`
+	Priorities map[string]string `json:"priorities,omitempty"`
+	Sections   map[string]string `json:"sections,omitempty"`
+}
+
+var defaultColumnIcons = map[string]string{
+	"inbox":    "📥",
+	"todo":     "📝",
+	"doing":    "🔨",
+	"blocked":  "⛔",
+	"done":     "✅",
+	"archive":  "🗄️",
+	"archived": "🗄️",
+}
+
+var defaultPriorityIcons = map[string]string{
+	"urgent": "🔴",
+	"high":   "🔴",
+	"low":    "🟡",
+}
+
+// defaultSectionIcons are the icons of headers and markers, by theme key.
+var defaultSectionIcons = map[string]string{
+	"board":          "📋",
+	"today":          "📅",
+	"week":           "📅",
+	"day":            "📆",
+	"due_today":      "⏰",
+	"due_soon":       "🟠",
+	"overdue":        "⚠️",
+	"project":        "📁",
+	"scheduled":      "🗓️",
+	"idea":           "💡",
+	"checklist":      "☑️",
+	"checklist_done": "✅",
+	"stale":          "⚠",
+	"added":          "✅",
+}
+
+// SectionIconKeys lists the keys theme.sections accepts.
+func SectionIconKeys() []string {
+	keys := make([]string, 0, len(defaultSectionIcons))
+	for k := range defaultSectionIcons {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IconsOff reports whether theme.icons is "none".
+func (c Config) IconsOff() bool {
+	return c.Theme != nil && strings.EqualFold(strings.TrimSpace(c.Theme.Icons), IconsNone)
+}
+
+func (c Config) themeIcon(overrides map[string]string, defaults map[string]string, key string) string {
+	if c.IconsOff() {
+		return ""
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	if icon, ok := overrides[key]; ok {
+		return strings.TrimSpace(icon)
+	}
+	return defaults[key]
+}
+
+// ColumnIcon is the icon of a column id (or status), "" when it has none.
+func (c Config) ColumnIcon(id string) string {
+	var overrides map[string]string
+	if c.Theme != nil {
+		overrides = c.Theme.Columns
+	}
+	return c.themeIcon(overrides, defaultColumnIcons, id)
+}
+
+// PriorityIcon is the icon shown before a task title for its priority.
+func (c Config) PriorityIcon(priority string) string {
+	var overrides map[string]string
+	if c.Theme != nil {
+		overrides = c.Theme.Priorities
+	}
+	return c.themeIcon(overrides, defaultPriorityIcons, normalizePriority(priority))
+}
+
+// SectionIcon is the icon of a header or marker, keyed as in
+// defaultSectionIcons.
+func (c Config) SectionIcon(key string) string {
+	var overrides map[string]string
+	if c.Theme != nil {
+		overrides = c.Theme.Sections
+	}
+	return c.themeIcon(overrides, defaultSectionIcons, key)
+}
+
+// iconLabel prefixes label with the section icon for key, if any.
+func (c Config) iconLabel(key string, label string) string {
+	if icon := c.SectionIcon(key); icon != "" {
+		return icon + " " + label
+	}
+	return label
+}
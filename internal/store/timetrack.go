@@ -0,0 +1,327 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimeEntry is one "- time <start> <duration|running> [<sep> text]" line in
+// a task body. Start/stop timers and manual log entries share the format.
+type TimeEntry struct {
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"-"`
+	Minutes  int           `json:"minutes"`
+	Running  bool          `json:"running,omitempty"`
+	Text     string        `json:"text,omitempty"`
+}
+
+const timeEntryPrefix = "- time "
+
+// formatTimeEntry renders one time line; d is ignored when running.
+func formatTimeEntry(start time.Time, d time.Duration, running bool, sep string, text string) string {
+	span := "running"
+	if !running {
+		span = FormatSpent(d)
+	}
+	line := timeEntryPrefix + start.Format(time.RFC3339) + " " + span
+	if text = strings.TrimSpace(text); text != "" {
+		line += " " + sep + " " + text
+	}
+	return line + "\n"
+}
+
+// parseTimeEntry reads one time line; ok is false for any other line.
+func parseTimeEntry(line string) (TimeEntry, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), timeEntryPrefix)
+	if !ok {
+		return TimeEntry{}, false
+	}
+	fields := strings.SplitN(strings.TrimSpace(rest), " ", 3)
+	if len(fields) < 2 {
+		return TimeEntry{}, false
+	}
+	start, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return TimeEntry{}, false
+	}
+	e := TimeEntry{Start: start}
+	if fields[1] == "running" {
+		e.Running = true
+	} else if e.Duration, err = time.ParseDuration(fields[1]); err != nil || e.Duration < 0 {
+		return TimeEntry{}, false
+	}
+	if len(fields) == 3 {
+		text := strings.TrimSpace(fields[2])
+		if sep, after, ok := strings.Cut(text, " "); ok && strings.IndexFunc(sep, isWordRune) < 0 {
+			text = after
+		} else if strings.IndexFunc(text, isWordRune) < 0 {
+			text = ""
+		}
+		e.Text = strings.TrimSpace(text)
+	}
+	e.Minutes = int(e.Duration / time.Minute)
+	return e, true
+}
+
+// TimeEntries returns the task's time entries, oldest first as written.
+func (t *Task) TimeEntries() []TimeEntry {
+	var out []TimeEntry
+	for _, line := range strings.Split(t.Body, "\n") {
+		if e, ok := parseTimeEntry(line); ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// runningEntry returns the task's running timer, if any.
+func (t *Task) runningEntry() (TimeEntry, bool) {
+	for _, e := range t.TimeEntries() {
+		if e.Running {
+			return e, true
+		}
+	}
+	return TimeEntry{}, false
+}
+
+// FormatSpent renders a duration rounded to the minute: "1h30m", "2h", "45m".
+func FormatSpent(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh%dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
+
+// appendTimeEntry adds entry at the end of body, opening a "## Time" section
+// the first time.
+func appendTimeEntry(body string, entry string) string {
+	body = strings.TrimRight(body, "\n")
+	if strings.TrimSpace(body) == "" {
+		return "## Time\n\n" + entry
+	}
+	if !strings.HasPrefix(body, "## Time\n") && !strings.Contains(body, "\n## Time\n") {
+		return body + "\n\n## Time\n\n" + entry
+	}
+	return body + "\n" + entry
+}
+
+// RunningTimers returns the tasks with a running timer.
+func (w *Workspace) RunningTimers() ([]Task, error) {
+	tasks, err := w.ListTasks(ListFilter{All: true})
+	if err != nil {
+		return nil, err
+	}
+	var out []Task
+	for _, t := range tasks {
+		if _, ok := t.runningEntry(); ok {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// StartTimer starts a timer on the task. Timers running on other tasks are
+// stopped first and returned; a timer already running on this task is a
+// conflict.
+func (w *Workspace) StartTimer(prefix string) (*Task, []Task, error) {
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e, ok := task.runningEntry(); ok {
+		return nil, nil, fmt.Errorf("%w: timer already running on %q since %s", ErrConflict, taskTitle(task.Title), e.Start.Format(time.RFC3339))
+	}
+	running, err := w.RunningTimers()
+	if err != nil {
+		return nil, nil, err
+	}
+	var stopped []Task
+	err = w.Transaction("start", w.WriteLockTimeout(), func() error {
+		for i := range running {
+			t, _, err := w.stopTimer(&running[i])
+			if err != nil {
+				return err
+			}
+			stopped = append(stopped, *t)
+		}
+		now := timeNow()
+		task.UpdatedAt = &now
+		task.Body = appendTimeEntry(task.Body, formatTimeEntry(now, 0, true, w.noteSeparator(), ""))
+		return w.saveTask("start", task)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return task, stopped, nil
+}
+
+// StopTimer stops the running timer on the task, or on the only task with
+// one when prefix is empty, and returns the time recorded.
+func (w *Workspace) StopTimer(prefix string) (*Task, time.Duration, error) {
+	if strings.TrimSpace(prefix) == "" {
+		running, err := w.RunningTimers()
+		if err != nil {
+			return nil, 0, err
+		}
+		switch len(running) {
+		case 0:
+			return nil, 0, fmt.Errorf("%w: no timer running", ErrNotFound)
+		case 1:
+			return w.stopTimer(&running[0])
+		default:
+			return nil, 0, fmt.Errorf("%w: %d timers running; name the task to stop", ErrConflict, len(running))
+		}
+	}
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, 0, err
+	}
+	return w.stopTimer(task)
+}
+
+func (w *Workspace) stopTimer(task *Task) (*Task, time.Duration, error) {
+	now := timeNow()
+	lines := strings.Split(task.Body, "\n")
+	var spent time.Duration
+	found := false
+	for i, line := range lines {
+		e, ok := parseTimeEntry(line)
+		if !ok || !e.Running {
+			continue
+		}
+		spent = now.Sub(e.Start).Round(time.Minute)
+		if spent < 0 {
+			spent = 0
+		}
+		lines[i] = strings.TrimSuffix(formatTimeEntry(e.Start, spent, false, w.noteSeparator(), e.Text), "\n")
+		found = true
+		break
+	}
+	if !found {
+		return nil, 0, fmt.Errorf("%w: no timer running on %q", ErrNotFound, taskTitle(task.Title))
+	}
+	task.Body = strings.Join(lines, "\n")
+	task.UpdatedAt = &now
+	if err := w.saveTask("stop", task); err != nil {
+		return nil, 0, err
+	}
+	return task, spent, nil
+}
+
+// LogTime records d of manual effort on the task, dated at (now when zero).
+func (w *Workspace) LogTime(prefix string, d time.Duration, at time.Time, text string) (*Task, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("%w: logged time must be positive", ErrInvalid)
+	}
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow()
+	if at.IsZero() {
+		at = now
+	}
+	task.UpdatedAt = &now
+	task.Body = appendTimeEntry(task.Body, formatTimeEntry(at, d.Round(time.Minute), false, w.noteSeparator(), text))
+	if err := w.saveTask("log", task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// TimesheetRow is the time booked against one project, tag or task.
+type TimesheetRow struct {
+	Key     string  `json:"key"`
+	Title   string  `json:"title,omitempty"`
+	Project string  `json:"project,omitempty"`
+	Minutes int     `json:"minutes"`
+	Hours   float64 `json:"hours"`
+}
+
+// Timesheet totals time entries that started in [From, To].
+type Timesheet struct {
+	From     string         `json:"from"`
+	To       string         `json:"to"`
+	Minutes  int            `json:"minutes"`
+	Hours    float64        `json:"hours"`
+	Running  int            `json:"running,omitempty"`
+	Projects []TimesheetRow `json:"projects"`
+	Tags     []TimesheetRow `json:"tags"`
+	Tasks    []TimesheetRow `json:"tasks"`
+}
+
+// Timesheet aggregates time entries dated from..to (inclusive, YYYY-MM-DD)
+// per project, tag and task. Running timers count up to now.
+func (w *Workspace) Timesheet(project string, from string, to string) (*Timesheet, error) {
+	tasks, err := w.ListTasks(ListFilter{Project: project, All: true})
+	if err != nil {
+		return nil, err
+	}
+	now := timeNow()
+	sheet := &Timesheet{From: from, To: to}
+	projects := map[string]int{}
+	tags := map[string]int{}
+	for _, t := range tasks {
+		minutes := 0
+		for _, e := range t.TimeEntries() {
+			day := e.Start.UTC().Format("2006-01-02")
+			if day < from || day > to {
+				continue
+			}
+			d := e.Duration
+			if e.Running {
+				d = now.Sub(e.Start)
+				sheet.Running++
+			}
+			minutes += int(d.Round(time.Minute) / time.Minute)
+		}
+		if minutes == 0 {
+			continue
+		}
+		sheet.Minutes += minutes
+		projects[t.Project] += minutes
+		for _, tag := range t.Tags {
+			tags[tag] += minutes
+		}
+		sheet.Tasks = append(sheet.Tasks, TimesheetRow{Key: t.ID, Title: t.Title, Project: t.Project, Minutes: minutes})
+	}
+	sheet.Hours = minutesToHours(sheet.Minutes)
+	sheet.Projects = timesheetRows(projects)
+	sheet.Tags = timesheetRows(tags)
+	sortTimesheetRows(sheet.Tasks)
+	for i := range sheet.Tasks {
+		sheet.Tasks[i].Hours = minutesToHours(sheet.Tasks[i].Minutes)
+	}
+	return sheet, nil
+}
+
+func timesheetRows(totals map[string]int) []TimesheetRow {
+	rows := make([]TimesheetRow, 0, len(totals))
+	for key, minutes := range totals {
+		rows = append(rows, TimesheetRow{Key: key, Minutes: minutes, Hours: minutesToHours(minutes)})
+	}
+	sortTimesheetRows(rows)
+	return rows
+}
+
+// sortTimesheetRows orders rows by time spent, most first, then by key.
+func sortTimesheetRows(rows []TimesheetRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Minutes != rows[j].Minutes {
+			return rows[i].Minutes > rows[j].Minutes
+		}
+		return rows[i].Key < rows[j].Key
+	})
+}
+
+func minutesToHours(minutes int) float64 {
+	return float64(minutes*100/60) / 100
+}
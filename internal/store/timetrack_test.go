@@ -0,0 +1,51 @@
+package store
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimersAndTimesheet(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	now := time.Date(2026, 1, 19, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	spec, _ := w.AddTask(AddTaskInput{Title: "Spec", Project: "Work", Tags: []string{"client"}})
+	build, _ := w.AddTask(AddTaskInput{Title: "Build", Project: "Home"})
+	if _, _, err := w.StartTimer(spec.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := w.StartTimer(spec.ID); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict for a running timer, got %v", err)
+	}
+	now = now.Add(90 * time.Minute)
+	// Starting another task stops the running one.
+	_, stopped, err := w.StartTimer(build.ID)
+	if err != nil || len(stopped) != 1 || stopped[0].ID != spec.ID {
+		t.Fatalf("expected spec to be stopped, got %+v (%v)", stopped, err)
+	}
+	now = now.Add(20 * time.Minute)
+	if _, spent, err := w.StopTimer(""); err != nil || spent != 20*time.Minute {
+		t.Fatalf("expected 20m stopped, got %v (%v)", spent, err)
+	}
+	if _, err := w.LogTime(spec.ID, 45*time.Minute, now.AddDate(0, 0, -10), "old"); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := w.GetTaskByPrefix(spec.ID)
+	if entries := got.TimeEntries(); len(entries) != 2 || entries[0].Minutes != 90 || entries[1].Text != "old" {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	sheet, err := w.Timesheet("", "2026-01-19", "2026-01-25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sheet.Minutes != 110 || len(sheet.Projects) != 2 || sheet.Projects[0].Key != "work" || sheet.Projects[0].Minutes != 90 {
+		t.Fatalf("unexpected timesheet: %+v", sheet)
+	}
+	if len(sheet.Tags) != 1 || sheet.Tags[0].Key != "client" || sheet.Tags[0].Hours != 1.5 {
+		t.Fatalf("unexpected tag totals: %+v", sheet.Tags)
+	}
+}
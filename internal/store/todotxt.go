@@ -0,0 +1,196 @@
+package store
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// TodoTxtItem is one task line of a todo.txt file
+// (https://github.com/todotxt/todo.txt).
+type TodoTxtItem struct {
+	Line      int    `json:"line"`
+	Done      bool   `json:"done,omitempty"`
+	Completed string `json:"completed,omitempty"`
+	Created   string `json:"created,omitempty"`
+	// Priority is the tasker priority the (A)-(Z) letter maps to.
+	Priority string   `json:"priority,omitempty"`
+	Title    string   `json:"title"`
+	Projects []string `json:"projects,omitempty"`
+	Contexts []string `json:"contexts,omitempty"`
+	Due      string   `json:"due,omitempty"`
+	// Start is the t: threshold date.
+	Start string `json:"start,omitempty"`
+}
+
+func isTodoTxtDate(s string) bool {
+	_, err := time.Parse("2006-01-02", s)
+	return err == nil
+}
+
+// todoTxtPriority maps (A) to urgent, (B) to high, (C) and lower to low.
+func todoTxtPriority(letter byte) string {
+	switch letter {
+	case 'A':
+		return "urgent"
+	case 'B':
+		return "high"
+	default:
+		return "low"
+	}
+}
+
+// ParseTodoTxtLine parses one todo.txt line; ok is false for blank lines.
+// +project and @context words are lifted out of the title, as are the due:
+// and t: extensions. Other key:value words stay in the title.
+func ParseTodoTxtLine(line string) (TodoTxtItem, bool) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return TodoTxtItem{}, false
+	}
+	var it TodoTxtItem
+	if words[0] == "x" {
+		it.Done = true
+		words = words[1:]
+		if len(words) > 0 && isTodoTxtDate(words[0]) {
+			it.Completed = words[0]
+			words = words[1:]
+		}
+	}
+	if len(words) > 0 {
+		if w := words[0]; len(w) == 3 && w[0] == '(' && w[2] == ')' && w[1] >= 'A' && w[1] <= 'Z' {
+			it.Priority = todoTxtPriority(w[1])
+			words = words[1:]
+		}
+	}
+	if len(words) > 0 && isTodoTxtDate(words[0]) {
+		it.Created = words[0]
+		words = words[1:]
+	}
+	var title []string
+	for _, w := range words {
+		switch {
+		case len(w) > 1 && w[0] == '+':
+			it.Projects = append(it.Projects, w[1:])
+		case len(w) > 1 && w[0] == '@':
+			it.Contexts = append(it.Contexts, w[1:])
+		case strings.HasPrefix(w, "due:") && isTodoTxtDate(w[4:]):
+			it.Due = w[4:]
+		case strings.HasPrefix(w, "t:") && isTodoTxtDate(w[2:]):
+			it.Start = w[2:]
+		case strings.HasPrefix(w, "pri:") && len(w) == 5 && w[4] >= 'A' && w[4] <= 'Z':
+			// Completed tasks keep their priority as pri:X.
+			it.Priority = todoTxtPriority(w[4])
+		default:
+			title = append(title, w)
+		}
+	}
+	it.Title = strings.Join(title, " ")
+	return it, true
+}
+
+// ParseTodoTxt parses every non-blank line of a todo.txt file.
+func ParseTodoTxt(r io.Reader) ([]TodoTxtItem, error) {
+	var items []TodoTxtItem
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for n := 1; sc.Scan(); n++ {
+		if it, ok := ParseTodoTxtLine(strings.TrimPrefix(sc.Text(), "\ufeff")); ok {
+			it.Line = n
+			items = append(items, it)
+		}
+	}
+	return items, sc.Err()
+}
+
+// ImportItem converts the item for ImportTasks. Its first +project picks
+// the project (project otherwise); further projects and contexts become tags.
+func (it TodoTxtItem) ImportItem(project string) ImportItem {
+	in := AddTaskInput{
+		Title:    it.Title,
+		Project:  project,
+		Priority: it.Priority,
+		Due:      it.Due,
+		Start:    it.Start,
+		Tags:     append([]string{}, it.Contexts...),
+	}
+	if len(it.Projects) > 0 {
+		in.Project = it.Projects[0]
+		in.Tags = append(in.Tags, it.Projects[1:]...)
+	}
+	item := ImportItem{Line: it.Line, Input: in, Created: it.Created, Completed: it.Completed}
+	if it.Done {
+		item.Status = "done"
+	}
+	return item
+}
+
+// ImportTodoTxt imports todo.txt items with ImportTasks.
+func (w *Workspace) ImportTodoTxt(items []TodoTxtItem, project string, dryRun bool) ([]Task, error) {
+	converted := make([]ImportItem, len(items))
+	for i, it := range items {
+		converted[i] = it.ImportItem(project)
+	}
+	return w.ImportTasks(converted, dryRun)
+}
+
+// FormatTodoTxt renders a task as one todo.txt line: urgent/high/low become
+// (A)/(B)/(C), the project a +project, tags @contexts, and the due and start
+// dates due: and t:. Done tasks start with x and their completion date and
+// keep the priority as pri:X, as the format recommends.
+func (w *Workspace) FormatTodoTxt(t Task) string {
+	var parts []string
+	done := !w.cfg.IsOpenStatus(t.Status)
+	pri := ""
+	switch normalizePriority(t.Priority) {
+	case "urgent":
+		pri = "A"
+	case "high":
+		pri = "B"
+	case "low":
+		pri = "C"
+	}
+	if done {
+		parts = append(parts, "x")
+		if t.CompletedAt != nil {
+			parts = append(parts, t.CompletedAt.UTC().Format("2006-01-02"))
+		} else if t.UpdatedAt != nil {
+			parts = append(parts, t.UpdatedAt.UTC().Format("2006-01-02"))
+		}
+	} else if pri != "" {
+		parts = append(parts, "("+pri+")")
+	}
+	if t.CreatedAt != nil && (!done || len(parts) > 1) {
+		parts = append(parts, t.CreatedAt.UTC().Format("2006-01-02"))
+	}
+	parts = append(parts, strings.Join(strings.Fields(taskTitle(t.Title)), " "))
+	if t.Project != "" {
+		parts = append(parts, "+"+t.Project)
+	}
+	for _, tag := range t.Tags {
+		if tag = strings.Join(strings.Fields(tag), "-"); tag != "" {
+			parts = append(parts, "@"+tag)
+		}
+	}
+	if due, ok := parseDueDate(t.Due); ok {
+		parts = append(parts, "due:"+due.Format("2006-01-02"))
+	}
+	if start, ok := parseDueDate(t.Start); ok {
+		parts = append(parts, "t:"+start.Format("2006-01-02"))
+	}
+	if done && pri != "" {
+		parts = append(parts, "pri:"+pri)
+	}
+	return strings.Join(parts, " ")
+}
+
+// RenderTodoTxt renders tasks as a todo.txt file, one line per task.
+func (w *Workspace) RenderTodoTxt(tasks []Task) []byte {
+	var b strings.Builder
+	for _, t := range tasks {
+		b.WriteString(w.FormatTodoTxt(t))
+		b.WriteString("\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,56 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTodoTxtLine(t *testing.T) {
+	it, ok := ParseTodoTxtLine("x 2026-01-05 2026-01-01 Pay bills +Home @desk due:2026-01-04 note:keep pri:B")
+	if !ok {
+		t.Fatal("expected a task")
+	}
+	if !it.Done || it.Completed != "2026-01-05" || it.Created != "2026-01-01" || it.Priority != "high" {
+		t.Fatalf("unexpected item: %+v", it)
+	}
+	if it.Title != "Pay bills note:keep" || it.Due != "2026-01-04" || it.Projects[0] != "Home" || it.Contexts[0] != "desk" {
+		t.Fatalf("unexpected item: %+v", it)
+	}
+	if it, _ := ParseTodoTxtLine("(A) Call Mom t:2026-02-01"); it.Priority != "urgent" || it.Start != "2026-02-01" || it.Title != "Call Mom" {
+		t.Fatalf("unexpected item: %+v", it)
+	}
+	if _, ok := ParseTodoTxtLine("   "); ok {
+		t.Fatal("expected blank line to be skipped")
+	}
+}
+
+func TestImportExportTodoTxtRoundTrip(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	items, err := ParseTodoTxt(strings.NewReader("(B) 2026-01-02 Write report +Work @office due:2026-01-09\nx 2026-01-03 Old chore\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dry, err := w.ImportTodoTxt(items, "", true)
+	if err != nil || len(dry) != 2 {
+		t.Fatalf("dry run: %v, %d", err, len(dry))
+	}
+	if tasks, _ := w.ListTasks(ListFilter{All: true}); len(tasks) != 0 {
+		t.Fatalf("dry run wrote %d tasks", len(tasks))
+	}
+	tasks, err := w.ImportTodoTxt(items, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tasks[0].Project != "work" || tasks[0].Priority != "high" || tasks[0].Tags[0] != "office" {
+		t.Fatalf("unexpected first task: %+v", tasks[0].TaskMeta)
+	}
+	if tasks[1].Status != "done" || tasks[1].CompletedAt == nil || tasks[1].CompletedAt.Format("2006-01-02") != "2026-01-03" {
+		t.Fatalf("unexpected done task: %+v", tasks[1].TaskMeta)
+	}
+	if got := w.FormatTodoTxt(tasks[0]); got != "(B) 2026-01-02 Write report +work @office due:2026-01-09" {
+		t.Fatalf("FormatTodoTxt = %q", got)
+	}
+	if got := w.FormatTodoTxt(tasks[1]); !strings.HasPrefix(got, "x 2026-01-03 ") || !strings.HasSuffix(got, "Old chore +personal") {
+		t.Fatalf("FormatTodoTxt done = %q", got)
+	}
+}
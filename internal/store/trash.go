@@ -0,0 +1,386 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TrashedTask is a task file parked under <root>/.trash/<date>/<project>/.
+type TrashedTask struct {
+	Task
+	// TrashedOn is the YYYY-MM-DD directory the file was moved into.
+	TrashedOn string `json:"trashed_on"`
+}
+
+// TrashedIdea is an idea file parked under <root>/.trash/<date>/_ideas/
+// (root ideas) or <root>/.trash/<date>/_ideas/<project>/.
+type TrashedIdea struct {
+	Idea
+	TrashedOn string `json:"trashed_on"`
+}
+
+// trashIdeasDir names the per-day directory of trashed ideas. Project slugs
+// never start with "_", so it cannot clash with a project's trashed tasks.
+const trashIdeasDir = "_ideas"
+
+func (w *Workspace) trashDir() string {
+	return filepath.Join(w.Root, ".trash")
+}
+
+// TrashTask moves a task file into the trash instead of deleting it. The file
+// content is kept byte for byte so RestoreTrash can put it back unchanged.
+func (w *Workspace) TrashTask(prefix string) (*TrashedTask, error) {
+	task, err := w.GetTaskByPrefix(prefix)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(task.Path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(raw)
+	day := timeNow().Format("2006-01-02")
+	dest := filepath.Join(w.trashDir(), day, task.Project, filepath.Base(task.Path))
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s is already in the trash", ErrConflict, task.ID)
+	}
+	if err := w.commitChanges("rm", []fileChange{
+		{Path: dest, After: &content},
+		{Path: task.Path},
+	}); err != nil {
+		return nil, err
+	}
+	task.Path = dest
+	return &TrashedTask{Task: *task, TrashedOn: day}, nil
+}
+
+// ListTrash returns trashed tasks, most recently trashed first.
+func (w *Workspace) ListTrash() ([]TrashedTask, error) {
+	days, err := os.ReadDir(w.trashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []TrashedTask
+	for _, day := range days {
+		if !day.IsDir() {
+			continue
+		}
+		dayDir := filepath.Join(w.trashDir(), day.Name())
+		projects, err := os.ReadDir(dayDir)
+		if err != nil {
+			continue
+		}
+		for _, p := range projects {
+			if !p.IsDir() || p.Name() == trashIdeasDir {
+				continue
+			}
+			files, err := os.ReadDir(filepath.Join(dayDir, p.Name()))
+			if err != nil {
+				continue
+			}
+			for _, f := range files {
+				if f.IsDir() || !strings.HasSuffix(strings.ToLower(f.Name()), ".md") {
+					continue
+				}
+				t, err := readTaskFile(filepath.Join(dayDir, p.Name(), f.Name()))
+				if err != nil {
+					continue
+				}
+				if t.Project == "" {
+					t.Project = p.Name()
+				}
+				out = append(out, TrashedTask{Task: *t, TrashedOn: day.Name()})
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TrashedOn != out[j].TrashedOn {
+			return out[i].TrashedOn > out[j].TrashedOn
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// RestoreTrash moves a trashed task (by ID prefix) back into its project and
+// column. A column that no longer exists restores into inbox.
+func (w *Workspace) RestoreTrash(prefix string) (*Task, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalid)
+	}
+	trashed, err := w.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+	var matches []TrashedTask
+	for _, t := range trashed {
+		if strings.HasPrefix(t.ID, prefix) {
+			matches = append(matches, t)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, ErrNotFound
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%w: %d trashed tasks match %q", ErrConflict, len(matches), prefix)
+	}
+	t := matches[0].Task
+	if _, err := os.Stat(filepath.Join(w.Root, "projects", t.Project, "project.json")); err != nil {
+		if _, err := w.CreateProject(t.Project); err != nil {
+			return nil, err
+		}
+	}
+	col, ok := w.projectColumnByID(t.Project, t.Column)
+	if !ok {
+		if col, ok = w.defaultColumn(t.Project); !ok {
+			return nil, fmt.Errorf("%w: project %s has no open column", ErrInvalid, t.Project)
+		}
+	}
+	dest := filepath.Join(w.projectColumnsDir(t.Project), col.Dir, filepath.Base(t.Path))
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s already exists", ErrConflict, dest)
+	}
+	raw, err := os.ReadFile(t.Path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(raw)
+	if t.Column != col.ID || t.Status != col.Status {
+		// Restored into a fallback column: keep the frontmatter in step.
+		t.Column, t.Status = col.ID, col.Status
+		if content, err = renderTaskFile(&t); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.commitChanges("trash restore", []fileChange{
+		{Path: dest, After: &content},
+		{Path: t.Path},
+	}); err != nil {
+		return nil, err
+	}
+	// Drop the emptied day/project directories so trash ls stays tidy.
+	_ = os.Remove(filepath.Dir(t.Path))
+	_ = os.Remove(filepath.Dir(filepath.Dir(t.Path)))
+	restored, err := readTaskFile(dest)
+	if err != nil {
+		return nil, err
+	}
+	w.reconcileTaskFromPath(restored)
+	return restored, nil
+}
+
+// ListDeletedTasks is ListTrash narrowed by the project, column, status,
+// tag, due and search parts of f.
+func (w *Workspace) ListDeletedTasks(f ListFilter) ([]TrashedTask, error) {
+	if err := f.Due.Validate(); err != nil {
+		return nil, err
+	}
+	trashed, err := w.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+	inProject, err := projectSpecMatcher(f.Project)
+	if err != nil {
+		return nil, err
+	}
+	f.Tags = f.Tags.normalize()
+	today := timeNow().Format("2006-01-02")
+	var out []TrashedTask
+	for _, t := range trashed {
+		switch {
+		case !inProject(t.Project),
+			f.Column != "" && t.Column != f.Column,
+			f.Status != "" && t.Status != f.Status,
+			!f.Tags.matches(t.Tags),
+			!f.Query.Match(t.Task),
+			!f.Due.matches(t.Task, today, w.cfg.IsOpenStatus(t.Status)):
+			continue
+		}
+		if f.Search != "" {
+			q := strings.ToLower(f.Search)
+			if !strings.Contains(strings.ToLower(t.Title), q) && !strings.Contains(strings.ToLower(t.descriptionText()), q) {
+				continue
+			}
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// TrashIdea moves an idea into the trash instead of deleting it. A journal
+// entry leaves its journal file and is trashed as an idea file of its own.
+func (w *Workspace) TrashIdea(idea *Idea) (*TrashedIdea, error) {
+	current, err := w.currentIdea(idea)
+	if err != nil {
+		return nil, err
+	}
+	day := timeNow().Format("2006-01-02")
+	dir := filepath.Join(w.trashDir(), day, trashIdeasDir, current.Project)
+	var content string
+	var changes []fileChange
+	name := filepath.Base(current.Path)
+	if current.Journal {
+		name = fmt.Sprintf("%s__%s.md", current.ID, slugify(current.Title))
+		content = formatIdeaContent(current.Title, current.Tags, current.Body)
+		change, err := w.journalEntryChange(current, "", nil, "", true)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	} else {
+		raw, err := os.ReadFile(current.Path)
+		if err != nil {
+			return nil, err
+		}
+		content = string(raw)
+		changes = append(changes, fileChange{Path: current.Path})
+	}
+	dest := filepath.Join(dir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s is already in the trash", ErrConflict, current.ID)
+	}
+	changes = append([]fileChange{{Path: dest, After: &content}}, changes...)
+	if err := w.commitChanges("idea rm", changes); err != nil {
+		return nil, err
+	}
+	current.Path = dest
+	current.Journal = false
+	return &TrashedIdea{Idea: *current, TrashedOn: day}, nil
+}
+
+// ListDeletedIdeas returns trashed ideas matching the project, scope, tag
+// and search of f, most recently trashed first.
+func (w *Workspace) ListDeletedIdeas(f IdeaListFilter) ([]TrashedIdea, error) {
+	filter := normalizeIdeaListFilter(f)
+	days, err := os.ReadDir(w.trashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var out []TrashedIdea
+	for _, day := range days {
+		if !day.IsDir() {
+			continue
+		}
+		dir := filepath.Join(w.trashDir(), day.Name(), trashIdeasDir)
+		var paths []ideaPath
+		switch filter.Scope {
+		case IdeaScopeRoot:
+			paths = trashedIdeaPaths(dir, "")
+		case IdeaScopeProject:
+			paths = trashedIdeaPaths(filepath.Join(dir, filter.Project), filter.Project)
+		default:
+			paths = trashedIdeaPaths(dir, "")
+			projects, _ := os.ReadDir(dir)
+			for _, p := range projects {
+				if p.IsDir() && (filter.Project == "" || p.Name() == filter.Project) {
+					paths = append(paths, trashedIdeaPaths(filepath.Join(dir, p.Name()), p.Name())...)
+				}
+			}
+		}
+		for _, p := range paths {
+			idea, err := readIdeaFile(p.Path, p.Project)
+			if err != nil {
+				continue
+			}
+			if !filter.Tags.matches(idea.Tags) {
+				continue
+			}
+			if filter.Search != "" {
+				q := strings.ToLower(filter.Search)
+				if !strings.Contains(strings.ToLower(idea.Title), q) && !strings.Contains(strings.ToLower(idea.Body), q) {
+					continue
+				}
+			}
+			out = append(out, TrashedIdea{Idea: *idea, TrashedOn: day.Name()})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TrashedOn != out[j].TrashedOn {
+			return out[i].TrashedOn > out[j].TrashedOn
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// trashedIdeaPaths lists the idea files directly inside dir.
+func trashedIdeaPaths(dir string, project string) []ideaPath {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []ideaPath
+	for _, e := range entries {
+		if !e.IsDir() && isIdeaFile(e.Name()) {
+			out = append(out, ideaPath{Project: project, Path: filepath.Join(dir, e.Name())})
+		}
+	}
+	return out
+}
+
+// RestoreTrashedIdea moves a trashed idea (by ID prefix) back to the root or
+// its project's ideas, as an idea file of its own.
+func (w *Workspace) RestoreTrashedIdea(prefix string) (*Idea, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalid)
+	}
+	trashed, err := w.ListDeletedIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil {
+		return nil, err
+	}
+	var matches []TrashedIdea
+	for _, t := range trashed {
+		if strings.HasPrefix(strings.ToUpper(t.ID), strings.ToUpper(prefix)) {
+			matches = append(matches, t)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, ErrNotFound
+	case len(matches) > 1:
+		return nil, fmt.Errorf("%w: %d trashed ideas match %q", ErrConflict, len(matches), prefix)
+	}
+	idea := matches[0].Idea
+	dir := w.rootIdeasDir()
+	if idea.Project != "" {
+		if _, err := os.Stat(w.projectMetaPath(idea.Project)); err != nil {
+			if _, err := w.CreateProject(idea.Project); err != nil {
+				return nil, err
+			}
+		}
+		dir = w.projectIdeasDir(idea.Project)
+	}
+	dest := filepath.Join(dir, filepath.Base(idea.Path))
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("%w: %s already exists", ErrConflict, dest)
+	}
+	raw, err := os.ReadFile(idea.Path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(raw)
+	if err := w.commitChanges("trash restore", []fileChange{
+		{Path: dest, After: &content},
+		{Path: idea.Path},
+	}); err != nil {
+		return nil, err
+	}
+	// Drop the emptied directories up to the day so trash stays tidy.
+	for dir := filepath.Dir(idea.Path); dir != w.trashDir(); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return readIdeaFile(dest, idea.Project)
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTrashAndRestore(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Old draft", Project: "Work", Column: "todo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.TrashTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetTaskByPrefix(task.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected trashed task to be gone, got %v", err)
+	}
+	trashed, err := w.ListTrash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trashed) != 1 || trashed[0].ID != task.ID {
+		t.Fatalf("expected one trashed task, got %+v", trashed)
+	}
+	restored, err := w.RestoreTrash(task.ID[:10])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Column != "todo" || restored.Project != "work" {
+		t.Fatalf("expected restore into work/todo, got %s/%s", restored.Project, restored.Column)
+	}
+	if _, err := w.GetTaskByPrefix(task.ID); err != nil {
+		t.Fatalf("expected restored task to resolve: %v", err)
+	}
+	if _, err := w.RestoreTrash(task.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected empty trash, got %v", err)
+	}
+}
+
+func TestTrashIdeaAndListDeleted(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	idea, err := w.AddIdea(AddIdeaInput{Title: "Side project", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.DeleteIdea(idea); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetIdeaBySelectorFiltered("Side project", IdeaSelectorFilter{Project: "Work"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected the trashed idea to be unselectable, got %v", err)
+	}
+	if trashed, _ := w.ListTrash(); len(trashed) != 0 {
+		t.Fatalf("expected trashed ideas to stay out of the task trash, got %d", len(trashed))
+	}
+	deleted, err := w.ListDeletedIdeas(IdeaListFilter{Scope: IdeaScopeAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != idea.ID || deleted[0].Project != "work" {
+		t.Fatalf("expected the idea in the trash, got %+v", deleted)
+	}
+	if _, err := w.RestoreTrashedIdea(idea.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.GetIdeaBySelectorFiltered("Side project", IdeaSelectorFilter{Project: "Work"}); err != nil {
+		t.Fatalf("expected the restored idea, got %v", err)
+	}
+
+	task, err := w.AddTask(AddTaskInput{Title: "Gone", Project: "Home", Tags: []string{"x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.TrashTask(task.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := w.ListDeletedTasks(ListFilter{Project: "Work"}); len(got) != 0 {
+		t.Fatalf("expected no deleted tasks in Work, got %d", len(got))
+	}
+	if got, _ := w.ListDeletedTasks(ListFilter{Tags: OneTag("x")}); len(got) != 1 {
+		t.Fatalf("expected the deleted task by tag, got %d", len(got))
+	}
+}
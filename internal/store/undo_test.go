@@ -0,0 +1,87 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestUndoReversesLatestOperation(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Misfire", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.MoveTask(task.ID, "done"); err != nil {
+		t.Fatal(err)
+	}
+	entry, err := w.Undo(false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Op != "mv" {
+		t.Fatalf("expected to undo mv, got %s", entry.Op)
+	}
+	back, err := w.GetTaskByPrefix(task.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.Column != "inbox" {
+		t.Fatalf("expected task back in inbox, got %s", back.Column)
+	}
+
+	// A hand edit after the add blocks undo unless forced.
+	if err := os.WriteFile(back.Path, []byte("edited"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Undo(false, false); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected conflict after hand edit, got %v", err)
+	}
+	if _, err := w.Undo(true, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(back.Path); !os.IsNotExist(err) {
+		t.Fatalf("expected undone add to remove the file")
+	}
+	// AddTask created the project first; that is the last entry left.
+	if entry, err := w.Undo(false, false); err != nil || entry.Op != "project add" {
+		t.Fatalf("expected to undo project add, got %+v %v", entry, err)
+	}
+	if _, err := w.Undo(false, false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected empty history, got %v", err)
+	}
+}
+
+func TestUndoFollowsCommitOrderWithPinnedClock(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	orig := timeNow
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = orig }()
+
+	task, err := w.AddTask(AddTaskInput{Title: "Pinned", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, col := range []string{"doing", "blocked", "done"} {
+		if _, err := w.MoveTask(task.ID, col); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []string{"blocked", "doing", "inbox"} {
+		if _, err := w.Undo(false, false); err != nil {
+			t.Fatal(err)
+		}
+		back, err := w.GetTaskByPrefix(task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back.Column != want {
+			t.Fatalf("expected undo to reach %s, got %s", want, back.Column)
+		}
+	}
+	if entry, err := w.Undo(false, false); err != nil || entry.Op != "add" {
+		t.Fatalf("expected to undo add last, got %+v %v", entry, err)
+	}
+}
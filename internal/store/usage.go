@@ -0,0 +1,219 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultLargeFile is the size above which DiskUsage flags a file.
+const DefaultLargeFile = 64 << 10
+
+// UsageArea is the file count and size of one part of the workspace: a
+// project column ("work/inbox"), a project's ideas ("work/ideas"), the rest
+// of a project ("work"), or a store-wide area ("ideas", "exports", "trash",
+// "snapshots", "journal", "events", "index", "logs", "git", "other").
+type UsageArea struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"` // column|ideas|project|exports|trash|...
+	Project string `json:"project,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Files   int    `json:"files"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// LargeFile is a file over the DiskUsage threshold. Kind is "task" or
+// "idea" for a document whose body is large, "file" for anything else
+// (attachments, exports, snapshots).
+type LargeFile struct {
+	Path  string `json:"path"`
+	Area  string `json:"area"`
+	Kind  string `json:"kind"`
+	Bytes int64  `json:"bytes"`
+}
+
+// UsageReport is the result of DiskUsage.
+type UsageReport struct {
+	Root       string      `json:"root"`
+	Files      int         `json:"files"`
+	Bytes      int64       `json:"bytes"`
+	Areas      []UsageArea `json:"areas"`
+	LargeAfter int64       `json:"large_after"`
+	Large      []LargeFile `json:"large"`
+}
+
+// usageAreaOrder sorts the store-wide areas after the projects.
+var usageAreaOrder = map[string]int{
+	"ideas": 1, "exports": 2, "trash": 3, "snapshots": 4, "journal": 5, "events": 6, "index": 7, "logs": 8, "git": 9, "other": 10,
+}
+
+// DiskUsage counts the files and bytes under the root per area, and lists
+// files larger than largeAfter bytes (DefaultLargeFile when <= 0), biggest
+// first. exportDir is counted as "exports" even when it lies outside the
+// root; "" means <root>/exports.
+func (w *Workspace) DiskUsage(largeAfter int64, exportDir string) (*UsageReport, error) {
+	if largeAfter <= 0 {
+		largeAfter = DefaultLargeFile
+	}
+	if strings.TrimSpace(exportDir) == "" {
+		exportDir = filepath.Join(w.Root, "exports")
+	}
+	exportDir = filepath.Clean(exportDir)
+	r := &UsageReport{Root: w.Root, LargeAfter: largeAfter}
+	areas := map[string]*UsageArea{}
+	columnIDs := map[string]map[string]string{}
+	columnIndex := map[string]int{}
+
+	add := func(area UsageArea, rel string, size int64) {
+		a := areas[area.Name]
+		if a == nil {
+			a = &area
+			areas[area.Name] = a
+		}
+		a.Files++
+		a.Bytes += size
+		r.Files++
+		r.Bytes += size
+		if size > largeAfter {
+			kind := "file"
+			base := filepath.Base(rel)
+			switch {
+			case area.Kind == "column" && strings.HasPrefix(base, "tsk_") && strings.HasSuffix(base, ".md"):
+				kind = "task"
+			case area.Kind == "ideas" && strings.HasSuffix(base, ".md"):
+				kind = "idea"
+			}
+			r.Large = append(r.Large, LargeFile{Path: filepath.ToSlash(rel), Area: area.Name, Kind: kind, Bytes: size})
+		}
+	}
+	columnOf := func(slug, dir string) string {
+		ids, ok := columnIDs[slug]
+		if !ok {
+			ids = map[string]string{}
+			for i, c := range w.Columns(slug) {
+				ids[c.Dir] = c.ID
+				columnIndex[slug+"/"+c.ID] = i
+			}
+			columnIDs[slug] = ids
+		}
+		if id, ok := ids[dir]; ok {
+			return id
+		}
+		return dir
+	}
+
+	walk := func(dir string, area func(rel, path string) UsageArea) error {
+		return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					return nil
+				}
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			rel, err := filepath.Rel(w.Root, path)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				rel = path
+			}
+			add(area(rel, path), rel, info.Size())
+			return nil
+		})
+	}
+	if err := walk(w.Root, func(rel, path string) UsageArea {
+		return usageArea(rel, path, exportDir, columnOf)
+	}); err != nil {
+		return nil, err
+	}
+	if rel, err := filepath.Rel(w.Root, exportDir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		if err := walk(exportDir, func(string, string) UsageArea {
+			return UsageArea{Name: "exports", Kind: "exports"}
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range areas {
+		r.Areas = append(r.Areas, *a)
+	}
+	sort.Slice(r.Areas, func(i, j int) bool {
+		a, b := r.Areas[i], r.Areas[j]
+		if (a.Project != "") != (b.Project != "") {
+			return a.Project != ""
+		}
+		if a.Project != b.Project {
+			return a.Project < b.Project
+		}
+		if a.Project == "" {
+			return usageAreaOrder[a.Kind] < usageAreaOrder[b.Kind]
+		}
+		if ra, rb := usageProjectRank(a, columnIndex), usageProjectRank(b, columnIndex); ra != rb {
+			return ra < rb
+		}
+		return a.Name < b.Name
+	})
+	sort.SliceStable(r.Large, func(i, j int) bool { return r.Large[i].Bytes > r.Large[j].Bytes })
+	return r, nil
+}
+
+// usageProjectRank orders a project's areas: columns in board order, then
+// its ideas, then the rest of the project.
+func usageProjectRank(a UsageArea, columnIndex map[string]int) int {
+	switch a.Kind {
+	case "column":
+		if i, ok := columnIndex[a.Project+"/"+a.Column]; ok {
+			return i
+		}
+		return 1000
+	case "ideas":
+		return 1001
+	}
+	return 1002
+}
+
+// usageArea classifies the file at rel (relative to the root).
+func usageArea(rel, path, exportDir string, columnOf func(slug, dir string) string) UsageArea {
+	if strings.HasPrefix(path, exportDir+string(filepath.Separator)) {
+		return UsageArea{Name: "exports", Kind: "exports"}
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	switch parts[0] {
+	case "projects":
+		if len(parts) < 3 {
+			return UsageArea{Name: "other", Kind: "other"}
+		}
+		slug := parts[1]
+		if parts[2] == "columns" && len(parts) > 4 {
+			col := columnOf(slug, parts[3])
+			return UsageArea{Name: slug + "/" + col, Kind: "column", Project: slug, Column: col}
+		}
+		if parts[2] == "ideas" {
+			return UsageArea{Name: slug + "/ideas", Kind: "ideas", Project: slug}
+		}
+		return UsageArea{Name: slug, Kind: "project", Project: slug}
+	case "ideas":
+		return UsageArea{Name: "ideas", Kind: "ideas"}
+	case ".trash":
+		return UsageArea{Name: "trash", Kind: "trash"}
+	case ".snapshots":
+		return UsageArea{Name: "snapshots", Kind: "snapshots"}
+	case ".journal":
+		return UsageArea{Name: "journal", Kind: "journal"}
+	case "events":
+		return UsageArea{Name: "events", Kind: "events"}
+	case ".index":
+		return UsageArea{Name: "index", Kind: "index"}
+	case "logs":
+		return UsageArea{Name: "logs", Kind: "logs"}
+	case ".git":
+		return UsageArea{Name: "git", Kind: "git"}
+	}
+	return UsageArea{Name: "other", Kind: "other"}
+}
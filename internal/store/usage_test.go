@@ -0,0 +1,61 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiskUsageAreasAndLargeFiles(t *testing.T) {
+	root := t.TempDir()
+	w := &Workspace{Root: root, cfg: testConfig()}
+	big, err := w.AddTask(AddTaskInput{Title: "Big", Project: "Work", Body: strings.Repeat("x", 2048)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddTask(AddTaskInput{Title: "Small", Project: "Work"}); err != nil {
+		t.Fatal(err)
+	}
+	outside := filepath.Join(t.TempDir(), "exports")
+	if err := os.MkdirAll(outside, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "tasks.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := w.DiskUsage(1024, outside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]UsageArea{}
+	for _, a := range r.Areas {
+		byName[a.Name] = a
+	}
+	if a := byName["work/inbox"]; a.Kind != "column" || a.Files != 2 {
+		t.Fatalf("expected 2 files in work/inbox, got %+v", a)
+	}
+	if a := byName["exports"]; a.Files != 1 || a.Bytes != 2 {
+		t.Fatalf("expected the outside export dir counted, got %+v", a)
+	}
+	if r.Areas[0].Name != "work/inbox" {
+		t.Fatalf("expected project columns first, got %q", r.Areas[0].Name)
+	}
+	var sum int64
+	for _, a := range r.Areas {
+		sum += a.Bytes
+	}
+	if sum != r.Bytes {
+		t.Fatalf("expected areas to add up to %d, got %d", r.Bytes, sum)
+	}
+	var found bool
+	for _, f := range r.Large {
+		if f.Kind == "task" && filepath.Base(f.Path) == filepath.Base(big.Path) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the big task flagged, got %+v", r.Large)
+	}
+}
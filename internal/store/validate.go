@@ -0,0 +1,142 @@
+package store
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	IssueParse     = "parse"
+	IssueDuplicate = "duplicate_id"
+	IssueFilename  = "filename"
+	IssueLocation  = "location"
+)
+
+// ValidationIssue is one problem Validate found in a task or idea file.
+// Errors (files that do not parse, duplicated IDs) break reads; the rest is
+// drift that only `validate --strict` fails on.
+type ValidationIssue struct {
+	Kind   string `json:"kind"` // parse|duplicate_id|filename|location
+	Path   string `json:"path"` // relative to the root
+	ID     string `json:"id,omitempty"`
+	Detail string `json:"detail"`
+	Error  bool   `json:"error"`
+}
+
+// ValidationReport is the result of Validate.
+type ValidationReport struct {
+	Root   string            `json:"root"`
+	Tasks  int               `json:"tasks"`
+	Ideas  int               `json:"ideas"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// Errors counts the issues that are errors.
+func (r ValidationReport) Errors() int {
+	n := 0
+	for _, i := range r.Issues {
+		if i.Error {
+			n++
+		}
+	}
+	return n
+}
+
+// Validate reads every task and idea file straight from disk (never the
+// index, so it checks what a fresh checkout holds) and reports files that do
+// not parse, IDs used more than once, task file names that do not start with
+// their ID, idea file names without the idea_ prefix, and task frontmatter
+// that disagrees with its location. It never writes.
+func (w *Workspace) Validate() (ValidationReport, error) {
+	r := ValidationReport{Root: w.Root, Issues: []ValidationIssue{}}
+	rel := func(path string) string {
+		if p, err := w.relPath(path); err == nil {
+			return filepath.ToSlash(p)
+		}
+		return path
+	}
+	add := func(kind, path, id, detail string) {
+		r.Issues = append(r.Issues, ValidationIssue{Kind: kind, Path: rel(path), ID: id, Detail: detail, Error: kind == IssueParse || kind == IssueDuplicate})
+	}
+	seen := map[string][]string{}
+
+	root := filepath.Join(w.Root, "projects")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d == nil {
+			return nil
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(strings.ToLower(d.Name()), ".md") || !isTaskPath(root, path) {
+			return nil
+		}
+		r.Tasks++
+		t, err := readTaskFile(path)
+		if err != nil {
+			add(IssueParse, path, "", err.Error())
+			return nil
+		}
+		if strings.TrimSpace(t.ID) == "" {
+			add(IssueParse, path, "", "frontmatter has no id")
+			return nil
+		}
+		seen[t.ID] = append(seen[t.ID], path)
+		if name := d.Name(); name != t.ID+".md" && !strings.HasPrefix(name, t.ID+"__") {
+			add(IssueFilename, path, t.ID, fmt.Sprintf("file name does not start with its id %s", t.ID))
+		}
+		if fix, ok := w.locationFix(t); ok {
+			var fields []string
+			for _, f := range fix.Fields {
+				fields = append(fields, fmt.Sprintf("%s %q, location says %q", f.Field, f.From, f.To))
+			}
+			add(IssueLocation, path, t.ID, strings.Join(fields, "; "))
+		}
+		return nil
+	})
+	if err != nil {
+		return r, err
+	}
+
+	paths, err := w.allIdeaPaths()
+	if err != nil {
+		return r, err
+	}
+	for _, p := range paths {
+		ideas, err := w.readIdeaPath(p)
+		if err != nil {
+			r.Ideas++
+			add(IssueParse, p.Path, "", err.Error())
+			continue
+		}
+		r.Ideas += len(ideas)
+		for _, idea := range ideas {
+			seen[idea.ID] = append(seen[idea.ID], idea.Path)
+			if !idea.Journal && !strings.HasPrefix(strings.ToLower(filepath.Base(idea.Path)), "idea_") {
+				add(IssueFilename, idea.Path, idea.ID, "idea file name does not start with idea_ (its id is the file name)")
+			}
+		}
+	}
+
+	for id, files := range seen {
+		if len(files) < 2 {
+			continue
+		}
+		for _, path := range files {
+			add(IssueDuplicate, path, id, fmt.Sprintf("id used by %d files", len(files)))
+		}
+	}
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		if r.Issues[i].Path != r.Issues[j].Path {
+			return r.Issues[i].Path < r.Issues[j].Path
+		}
+		return r.Issues[i].Kind < r.Issues[j].Kind
+	})
+	return r, nil
+}
@@ -0,0 +1,48 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	task, err := w.AddTask(AddTaskInput{Title: "Ship it", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AddIdea(AddIdeaInput{Title: "Later"}); err != nil {
+		t.Fatal(err)
+	}
+	report, err := w.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Tasks != 1 || report.Ideas != 1 || len(report.Issues) != 0 {
+		t.Fatalf("expected a clean store, got %+v", report)
+	}
+
+	b, err := os.ReadFile(task.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Dir(task.Path)
+	if err := os.WriteFile(filepath.Join(dir, "copy.md"), b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.md"), []byte("no frontmatter\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err = w.Validate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	kinds := map[string]int{}
+	for _, i := range report.Issues {
+		kinds[i.Kind]++
+	}
+	if kinds[IssueParse] != 1 || kinds[IssueDuplicate] != 2 || kinds[IssueFilename] != 1 || report.Errors() != 3 {
+		t.Fatalf("expected a parse error, two duplicate-id errors and a filename warning, got %+v", report.Issues)
+	}
+}
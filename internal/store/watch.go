@@ -0,0 +1,198 @@
+package store
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchedRoots are the parts of the root that hold workspace content;
+// internal dirs (journal, snapshots, exports) and temp files are ignored.
+var watchedRoots = []string{"config.json", "projects", "ideas"}
+
+// ChangeStamp fingerprints the workspace content (config, projects and
+// ideas) from file names, sizes and mtimes. It changes whenever a task or
+// idea is written, moved or removed. Watch modes fall back to polling it
+// where file-system notifications are unavailable.
+func (w *Workspace) ChangeStamp() (string, error) {
+	h := sha256.New()
+	for _, name := range watchedRoots {
+		root := filepath.Join(w.Root, name)
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if strings.HasPrefix(d.Name(), ".") && p != root {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			fmt.Fprintf(h, "%s\x00%d\x00%d\n", p, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ChangeWatcher reports changes to the workspace content as file-system
+// notifications arrive. Directories created later (a new project, a new
+// column) are watched as they appear.
+type ChangeWatcher struct {
+	// Changes receives a value after one or more changes; a burst of writes
+	// (a move, a transaction) may arrive as a single value.
+	Changes <-chan struct{}
+	// Errors receives watcher failures; the watcher keeps running.
+	Errors <-chan error
+
+	root      string
+	fsw       *fsnotify.Watcher
+	changes   chan struct{}
+	errors    chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// WatchChanges starts watching the workspace content: config.json and
+// every directory under projects/ and ideas/, skipping dot-directories as
+// ChangeStamp does. Close stops it.
+func (w *Workspace) WatchChanges() (*ChangeWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	c := &ChangeWatcher{
+		root:    w.Root,
+		fsw:     fsw,
+		changes: make(chan struct{}, 1),
+		errors:  make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+	c.Changes, c.Errors = c.changes, c.errors
+	// The root itself is watched for config.json and for projects/ or
+	// ideas/ appearing.
+	if err := fsw.Add(w.Root); err != nil {
+		_ = fsw.Close()
+		return nil, err
+	}
+	for _, name := range watchedRoots[1:] {
+		if err := c.addTree(filepath.Join(w.Root, name)); err != nil {
+			_ = fsw.Close()
+			return nil, err
+		}
+	}
+	go c.run()
+	return c, nil
+}
+
+// Close stops the watcher and closes Changes.
+func (c *ChangeWatcher) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+		err = c.fsw.Close()
+	})
+	return err
+}
+
+// addTree watches dir and every directory below it that is not hidden.
+func (c *ChangeWatcher) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") && p != dir {
+			return filepath.SkipDir
+		}
+		if err := c.fsw.Add(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// relevant reports whether an event touches workspace content.
+func (c *ChangeWatcher) relevant(path string) bool {
+	rel, err := filepath.Rel(c.root, path)
+	if err != nil || rel == "." {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	for _, part := range parts {
+		if strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	for _, name := range watchedRoots {
+		if parts[0] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ChangeWatcher) run() {
+	defer close(c.changes)
+	for {
+		select {
+		case <-c.done:
+			return
+		case ev, ok := <-c.fsw.Events:
+			if !ok {
+				return
+			}
+			if !c.relevant(ev.Name) {
+				continue
+			}
+			if ev.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					if err := c.addTree(ev.Name); err != nil {
+						c.report(err)
+					}
+				}
+			}
+			select {
+			case c.changes <- struct{}{}:
+			default:
+			}
+		case err, ok := <-c.fsw.Errors:
+			if !ok {
+				return
+			}
+			c.report(err)
+		}
+	}
+}
+
+func (c *ChangeWatcher) report(err error) {
+	select {
+	case c.errors <- err:
+	default:
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestChangeStampTracksTaskWrites(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	before, err := w.ChangeStamp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := w.AddTask(AddTaskInput{Title: "Watch me", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	added, _ := w.ChangeStamp()
+	if added == before {
+		t.Fatalf("expected stamp to change after add")
+	}
+	if same, _ := w.ChangeStamp(); same != added {
+		t.Fatalf("expected stable stamp without writes")
+	}
+	if _, err := w.MoveTask(task.ID, "doing"); err != nil {
+		t.Fatal(err)
+	}
+	if moved, _ := w.ChangeStamp(); moved == added {
+		t.Fatalf("expected stamp to change after move")
+	}
+}
+
+func TestWatchChangesSeesTaskWrites(t *testing.T) {
+	w := &Workspace{Root: t.TempDir(), cfg: testConfig()}
+	cw, err := w.WatchChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cw.Close()
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-cw.Changes:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no change reported after %s", what)
+		}
+		// Let the rest of the burst arrive, then start clean.
+		time.Sleep(50 * time.Millisecond)
+		select {
+		case <-cw.Changes:
+		default:
+		}
+	}
+
+	// The project and its column dirs are created after the watch starts.
+	task, err := w.AddTask(AddTaskInput{Title: "Watch me", Project: "Work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wait("add")
+	if _, err := w.MoveTask(task.ID, "doing"); err != nil {
+		t.Fatal(err)
+	}
+	wait("move into a column dir created after the watch started")
+	if err := w.SaveConfig(w.cfg); err != nil {
+		t.Fatal(err)
+	}
+	wait("config save")
+
+	if err := os.WriteFile(filepath.Join(w.Root, "projects", ".scratch"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-cw.Changes:
+		t.Fatalf("hidden files are not workspace content")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-cw.Changes; ok {
+		t.Fatalf("expected Changes closed after Close")
+	}
+}